@@ -273,3 +273,13 @@ func (c *Call) CallerProcedure() string {
 	}
 	return c.md.CallerProcedure()
 }
+
+// PeerIdentity returns the identity of the remote peer, as verified by a
+// mutual TLS handshake, or nil if the peer did not present a verified client
+// certificate.
+func (c *Call) PeerIdentity() *transport.PeerIdentity {
+	if c == nil {
+		return nil
+	}
+	return c.md.PeerIdentity()
+}
@@ -45,6 +45,7 @@ func TestNilCall(t *testing.T) {
 	assert.Equal(t, "", call.RoutingKey())
 	assert.Equal(t, "", call.RoutingDelegate())
 	assert.Equal(t, "", call.CallerProcedure())
+	assert.Nil(t, call.PeerIdentity())
 	assert.Equal(t, "", call.Header("foo"))
 	assert.Equal(t, "", call.OriginalHeader("foo"))
 	assert.Empty(t, call.HeaderNames())
@@ -70,6 +71,7 @@ func TestReadFromRequest(t *testing.T) {
 		RoutingKey:      "rk",
 		RoutingDelegate: "rd",
 		CallerProcedure: "cp",
+		PeerIdentity:    &transport.PeerIdentity{SPIFFEID: "spiffe://example.org/caller"},
 		// later header's key/value takes precedence
 		Headers: transport.NewHeaders().With("Foo", "Bar").With("foo", "bar"),
 	})
@@ -93,6 +95,7 @@ func TestReadFromRequest(t *testing.T) {
 	assert.Equal(t, call.Headers(), maps.Collect(call.HeadersAll()), "HeadersAll should match Headers")
 
 	assert.Equal(t, "cp", call.CallerProcedure())
+	assert.Equal(t, "spiffe://example.org/caller", call.PeerIdentity().SPIFFEID)
 	assert.Len(t, call.HeaderNames(), 1)
 	assert.Equal(t, 1, call.HeadersLen())
 	assert.Equal(t, 2, call.OriginalHeadersLen())
@@ -157,6 +157,10 @@ func (ic *inboundCallMetadata) CallerProcedure() string {
 	return ic.req.CallerProcedure
 }
 
+func (ic *inboundCallMetadata) PeerIdentity() *transport.PeerIdentity {
+	return ic.req.PeerIdentity
+}
+
 func (ic *inboundCallMetadata) WriteResponseHeader(k, v string) error {
 	if ic.disableResponseHeaders {
 		return yarpcerrors.InvalidArgumentErrorf("call does not support setting response headers")
@@ -36,3 +36,14 @@ type Inbound interface {
 	// An inbound may submit zero or more transports.
 	Transports() []Transport
 }
+
+// DrainableInbound is an Inbound that can stop accepting new connections while
+// it continues to serve existing ones. A Dispatcher drains such inbounds
+// before it stops them.
+type DrainableInbound interface {
+	Inbound
+
+	// Drain asks clients to move to new connections, typically on other
+	// instances, without interrupting requests in flight.
+	Drain()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+const spiffeScheme = "spiffe"
+
+// PeerIdentity is the identity of the remote peer of an inbound request, as
+// established by the client certificate it presented during a mutual TLS
+// handshake.
+//
+// Inbounds only report an identity for certificates that were verified
+// against the configured client CAs. Requests received over plaintext
+// connections, or from clients that did not present a certificate, have no
+// identity.
+type PeerIdentity struct {
+	// Subject is the distinguished name of the certificate subject, for
+	// example "CN=foo,O=Example".
+	Subject string
+
+	// CommonName is the common name of the certificate subject.
+	CommonName string
+
+	// DNSNames are the DNS name subject alternative names of the
+	// certificate.
+	DNSNames []string
+
+	// URIs are the URI subject alternative names of the certificate.
+	URIs []string
+
+	// EmailAddresses are the email subject alternative names of the
+	// certificate.
+	EmailAddresses []string
+
+	// SPIFFEID is the SPIFFE ID of the peer, if the certificate is an X.509
+	// SVID, for example "spiffe://example.org/ns/prod/sa/foo".
+	SPIFFEID string
+}
+
+// PeerIdentityFromTLS returns the identity of the peer of the given TLS
+// connection, or nil if the peer did not present a verified certificate.
+func PeerIdentityFromTLS(state *tls.ConnectionState) *PeerIdentity {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return PeerIdentityFromCertificate(state.VerifiedChains[0][0])
+}
+
+// PeerIdentityFromCertificate returns the identity described by the given
+// leaf certificate. The caller is responsible for verifying the certificate.
+func PeerIdentityFromCertificate(cert *x509.Certificate) *PeerIdentity {
+	if cert == nil {
+		return nil
+	}
+
+	id := &PeerIdentity{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+
+	// An X.509 SVID carries exactly one URI SAN, which is the SPIFFE ID.
+	if len(cert.URIs) == 1 && cert.URIs[0].Scheme == spiffeScheme {
+		id.SPIFFEID = cert.URIs[0].String()
+	}
+	return id
+}
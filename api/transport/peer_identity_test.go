@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package transport_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/yarpc/api/transport"
+)
+
+func TestPeerIdentityFromCertificate(t *testing.T) {
+	tests := []struct {
+		desc string
+		cert *x509.Certificate
+		want *transport.PeerIdentity
+	}{
+		{
+			desc: "nil certificate",
+		},
+		{
+			desc: "subject and SANs",
+			cert: &x509.Certificate{
+				Subject:        pkix.Name{CommonName: "foo", Organization: []string{"Example"}},
+				DNSNames:       []string{"foo.example.org"},
+				EmailAddresses: []string{"foo@example.org"},
+				URIs:           []*url.URL{{Scheme: "https", Host: "example.org", Path: "/foo"}},
+			},
+			want: &transport.PeerIdentity{
+				Subject:        "CN=foo,O=Example",
+				CommonName:     "foo",
+				DNSNames:       []string{"foo.example.org"},
+				EmailAddresses: []string{"foo@example.org"},
+				URIs:           []string{"https://example.org/foo"},
+			},
+		},
+		{
+			desc: "SPIFFE ID",
+			cert: &x509.Certificate{
+				URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/ns/prod/sa/foo"}},
+			},
+			want: &transport.PeerIdentity{
+				URIs:     []string{"spiffe://example.org/ns/prod/sa/foo"},
+				SPIFFEID: "spiffe://example.org/ns/prod/sa/foo",
+			},
+		},
+		{
+			desc: "not an SVID with multiple URIs",
+			cert: &x509.Certificate{
+				URIs: []*url.URL{
+					{Scheme: "spiffe", Host: "example.org", Path: "/foo"},
+					{Scheme: "spiffe", Host: "example.org", Path: "/bar"},
+				},
+			},
+			want: &transport.PeerIdentity{
+				URIs: []string{"spiffe://example.org/foo", "spiffe://example.org/bar"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, transport.PeerIdentityFromCertificate(tt.cert))
+		})
+	}
+}
+
+func TestPeerIdentityFromTLS(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "foo"}}
+
+	t.Run("nil state", func(t *testing.T) {
+		assert.Nil(t, transport.PeerIdentityFromTLS(nil))
+	})
+
+	t.Run("unverified certificate", func(t *testing.T) {
+		state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		assert.Nil(t, transport.PeerIdentityFromTLS(state))
+	})
+
+	t.Run("verified certificate", func(t *testing.T) {
+		state := &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		}
+		assert.Equal(t, &transport.PeerIdentity{
+			Subject:    "CN=foo",
+			CommonName: "foo",
+		}, transport.PeerIdentityFromTLS(state))
+	})
+}
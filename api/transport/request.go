@@ -67,6 +67,11 @@ type Request struct {
 	// CallerProcedure refers to the name of the rpc procedure from the service making this request.
 	CallerProcedure string
 
+	// PeerIdentity is the identity of the remote peer, as verified by a
+	// mutual TLS handshake. This is only set by inbounds, and is nil if the
+	// peer did not present a verified client certificate.
+	PeerIdentity *PeerIdentity
+
 	// Request payload.
 	Body io.Reader
 
@@ -90,6 +95,7 @@ func (r *Request) ToRequestMeta() *RequestMeta {
 		RoutingKey:      r.RoutingKey,
 		RoutingDelegate: r.RoutingDelegate,
 		CallerProcedure: r.CallerProcedure,
+		PeerIdentity:    r.PeerIdentity,
 	}
 }
 
@@ -201,6 +207,11 @@ type RequestMeta struct {
 
 	// CallerProcedure refers to the name of the rpc procedure of the service making this request.
 	CallerProcedure string
+
+	// PeerIdentity is the identity of the remote peer, as verified by a
+	// mutual TLS handshake. This is only set by inbounds, and is nil if the
+	// peer did not present a verified client certificate.
+	PeerIdentity *PeerIdentity
 }
 
 // ToRequest converts a RequestMeta into a Request.
@@ -219,5 +230,6 @@ func (r *RequestMeta) ToRequest() *Request {
 		RoutingKey:      r.RoutingKey,
 		RoutingDelegate: r.RoutingDelegate,
 		CallerProcedure: r.CallerProcedure,
+		PeerIdentity:    r.PeerIdentity,
 	}
 }
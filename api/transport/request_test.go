@@ -166,6 +166,7 @@ func TestRequestMetaToRequestConversionAndBack(t *testing.T) {
 		RoutingKey:      "rk",
 		RoutingDelegate: "rd",
 		CallerProcedure: "cp",
+		PeerIdentity:    &transport.PeerIdentity{SPIFFEID: "spiffe://example.org/foo"},
 	}
 
 	req := reqMeta.ToRequest()
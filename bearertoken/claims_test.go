@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaims(t *testing.T) {
+	claims := Claims{
+		"sub":   "foo",
+		"iss":   "https://auth.example.org",
+		"aud":   []interface{}{"kv", 42, "admin"},
+		"scope": "read  write",
+		"n":     42.0,
+	}
+	assert.Equal(t, "foo", claims.Subject())
+	assert.Equal(t, "https://auth.example.org", claims.Issuer())
+	assert.Equal(t, []string{"kv", "admin"}, claims.Audience())
+	assert.Equal(t, []string{"read", "write"}, claims.Scopes())
+	assert.Equal(t, "", claims.String("n"))
+	assert.Equal(t, "", claims.String("missing"))
+
+	claims = Claims{"aud": "kv", "scp": []string{"read"}}
+	assert.Equal(t, []string{"kv"}, claims.Audience())
+	assert.Equal(t, []string{"read"}, claims.Scopes())
+	assert.Nil(t, Claims{}.Audience())
+}
+
+func TestClaimsContext(t *testing.T) {
+	_, ok := ClaimsFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithClaims(context.Background(), Claims{"sub": "foo"})
+	claims, ok := ClaimsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", claims.Subject())
+}
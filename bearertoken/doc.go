@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package bearertoken authenticates requests with bearer tokens.
+//
+// On the client side, an outbound middleware attaches a token obtained from
+// a TokenSource to every request as an "authorization" application header.
+// ReuseTokenSource caches tokens until shortly before they expire, and
+// FileTokenSource reads tokens that are rotated on disk, such as projected
+// service account tokens.
+//
+//	mw := bearertoken.NewOutboundMiddleware(
+//		bearertoken.ReuseTokenSource(mySource, time.Minute),
+//	)
+//
+// Outbounds built by yarpcconfig may be configured to attach tokens with the
+// 'bearerToken' key. See the yarpcconfig documentation for details.
+//
+// On the server side, an inbound middleware validates the token of every
+// request with a Verifier and places the token's claims on the request
+// context, where handlers may retrieve them with ClaimsFromContext.
+// NewJWTVerifier verifies JSON Web Tokens signed by keys from a local JWKS
+// file.
+//
+//	verifier, err := bearertoken.NewJWTVerifier(bearertoken.JWTConfig{
+//		JWKSFile: "/etc/keys/jwks.json",
+//		Issuer:   "https://auth.example.org",
+//		Audience: "keyvalue",
+//	})
+//	// ...
+//	mw := bearertoken.NewInboundMiddleware(bearertoken.InboundConfig{
+//		Verifier:       verifier,
+//		RequiredScopes: []string{"keyvalue.read"},
+//	})
+//
+// Requests without a valid token fail with yarpcerrors.CodeUnauthenticated,
+// and requests whose tokens lack a required scope fail with
+// yarpcerrors.CodePermissionDenied.
+package bearertoken
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ middleware.UnaryInbound  = (*InboundMiddleware)(nil)
+	_ middleware.OnewayInbound = (*InboundMiddleware)(nil)
+	_ middleware.StreamInbound = (*InboundMiddleware)(nil)
+)
+
+// Verifier validates bearer tokens.
+type Verifier interface {
+	// Verify returns the claims of the given token, or an error if the
+	// token is not valid.
+	//
+	// Errors fail the request with yarpcerrors.CodeUnauthenticated, unless
+	// they are YARPC errors with yarpcerrors.CodePermissionDenied.
+	Verify(ctx context.Context, token string) (Claims, error)
+}
+
+// VerifierFunc adapts a function into a Verifier.
+type VerifierFunc func(ctx context.Context, token string) (Claims, error)
+
+// Verify implements Verifier.
+func (f VerifierFunc) Verify(ctx context.Context, token string) (Claims, error) {
+	return f(ctx, token)
+}
+
+// InboundConfig configures the inbound middleware.
+type InboundConfig struct {
+	// Verifier validates the tokens of incoming requests. It is required.
+	Verifier Verifier
+
+	// RequiredScopes are the scopes that every token must be granted.
+	// Requests whose tokens lack any of them fail with
+	// yarpcerrors.CodePermissionDenied.
+	RequiredScopes []string
+
+	// Unauthenticated lists procedures that may be called without a token,
+	// as patterns in the syntax of path.Match. Tokens sent to these
+	// procedures are still verified.
+	Unauthenticated []string
+}
+
+// InboundMiddleware is an inbound middleware that authenticates requests by
+// their bearer tokens.
+type InboundMiddleware struct {
+	cfg InboundConfig
+}
+
+// NewInboundMiddleware builds an inbound middleware that verifies tokens
+// with the configured Verifier.
+func NewInboundMiddleware(cfg InboundConfig) *InboundMiddleware {
+	return &InboundMiddleware{cfg: cfg}
+}
+
+// Handle implements middleware.UnaryInbound.
+func (m *InboundMiddleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	ctx, err := m.authenticate(ctx, req.Procedure, req.Headers)
+	if err != nil {
+		return err
+	}
+	return h.Handle(ctx, req, resw)
+}
+
+// HandleOneway implements middleware.OnewayInbound.
+func (m *InboundMiddleware) HandleOneway(ctx context.Context, req *transport.Request, h transport.OnewayHandler) error {
+	ctx, err := m.authenticate(ctx, req.Procedure, req.Headers)
+	if err != nil {
+		return err
+	}
+	return h.HandleOneway(ctx, req)
+}
+
+// HandleStream implements middleware.StreamInbound.
+func (m *InboundMiddleware) HandleStream(s *transport.ServerStream, h transport.StreamHandler) error {
+	meta := s.Request().Meta
+	ctx, err := m.authenticate(s.Context(), meta.Procedure, meta.Headers)
+	if err != nil {
+		return err
+	}
+	wrapped, err := transport.NewServerStream(&authenticatedServerStream{ServerStream: s, ctx: ctx})
+	if err != nil {
+		return err
+	}
+	return h.HandleStream(wrapped)
+}
+
+// authenticate verifies the bearer token in the given headers and returns a
+// context carrying its claims.
+func (m *InboundMiddleware) authenticate(ctx context.Context, procedure string, headers transport.Headers) (context.Context, error) {
+	header, ok := headers.Get(AuthorizationHeader)
+	if !ok {
+		if m.unauthenticated(procedure) {
+			return ctx, nil
+		}
+		return ctx, yarpcerrors.Newf(yarpcerrors.CodeUnauthenticated,
+			"procedure %q requires a bearer token", procedure)
+	}
+
+	token, ok := parseBearer(header)
+	if !ok {
+		return ctx, yarpcerrors.Newf(yarpcerrors.CodeUnauthenticated,
+			"malformed authorization header: expected a bearer token")
+	}
+
+	claims, err := m.cfg.Verifier.Verify(ctx, token)
+	if err != nil {
+		if yarpcerrors.FromError(err).Code() == yarpcerrors.CodePermissionDenied {
+			return ctx, err
+		}
+		return ctx, yarpcerrors.Newf(yarpcerrors.CodeUnauthenticated, "invalid bearer token: %v", err)
+	}
+
+	if missing := missingScopes(claims.Scopes(), m.cfg.RequiredScopes); len(missing) > 0 {
+		return ctx, yarpcerrors.Newf(yarpcerrors.CodePermissionDenied,
+			"bearer token is missing required scopes: %s", strings.Join(missing, ", "))
+	}
+	return ContextWithClaims(ctx, claims), nil
+}
+
+func (m *InboundMiddleware) unauthenticated(procedure string) bool {
+	for _, pattern := range m.cfg.Unauthenticated {
+		if ok, _ := path.Match(pattern, procedure); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBearer extracts the token from an authorization header value. The
+// scheme is case-insensitive.
+func parseBearer(header string) (string, bool) {
+	if len(header) < len(_bearerPrefix) || !strings.EqualFold(header[:len(_bearerPrefix)], _bearerPrefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(_bearerPrefix):])
+	return token, token != ""
+}
+
+func missingScopes(granted, required []string) []string {
+	var missing []string
+	for _, r := range required {
+		found := false
+		for _, g := range granted {
+			if g == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
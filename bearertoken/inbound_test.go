@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// testVerifier accepts the token "good" with the given claims, denies the
+// token "denied", and rejects all others.
+func testVerifier(claims Claims) Verifier {
+	return VerifierFunc(func(_ context.Context, token string) (Claims, error) {
+		switch token {
+		case "good":
+			return claims, nil
+		case "denied":
+			return nil, yarpcerrors.Newf(yarpcerrors.CodePermissionDenied, "banned")
+		}
+		return nil, errors.New("unknown token")
+	})
+}
+
+// claimsHandler is a unary handler that records the claims on its context.
+type claimsHandler struct {
+	claims Claims
+	ok     bool
+}
+
+func (h *claimsHandler) Handle(ctx context.Context, _ *transport.Request, _ transport.ResponseWriter) error {
+	h.claims, h.ok = ClaimsFromContext(ctx)
+	return nil
+}
+
+func TestInboundMiddleware(t *testing.T) {
+	claims := Claims{"sub": "foo", "scope": "read write"}
+
+	tests := []struct {
+		desc       string
+		cfg        InboundConfig
+		procedure  string
+		header     string
+		wantCode   yarpcerrors.Code
+		wantErr    string
+		wantClaims bool
+	}{
+		{
+			desc:       "valid token",
+			header:     "Bearer good",
+			wantClaims: true,
+		},
+		{
+			desc:       "case-insensitive scheme",
+			header:     "bearer  good",
+			wantClaims: true,
+		},
+		{
+			desc:     "missing token",
+			wantCode: yarpcerrors.CodeUnauthenticated,
+			wantErr:  `procedure "proc" requires a bearer token`,
+		},
+		{
+			desc:      "missing token for unauthenticated procedure",
+			cfg:       InboundConfig{Unauthenticated: []string{"Meta::*"}},
+			procedure: "Meta::health",
+		},
+		{
+			desc:       "token for unauthenticated procedure",
+			cfg:        InboundConfig{Unauthenticated: []string{"Meta::*"}},
+			procedure:  "Meta::health",
+			header:     "Bearer good",
+			wantClaims: true,
+		},
+		{
+			desc:     "other scheme",
+			header:   "Basic Zm9vOmJhcg==",
+			wantCode: yarpcerrors.CodeUnauthenticated,
+			wantErr:  "malformed authorization header",
+		},
+		{
+			desc:     "empty token",
+			header:   "Bearer ",
+			wantCode: yarpcerrors.CodeUnauthenticated,
+			wantErr:  "malformed authorization header",
+		},
+		{
+			desc:     "invalid token",
+			header:   "Bearer bad",
+			wantCode: yarpcerrors.CodeUnauthenticated,
+			wantErr:  "invalid bearer token: unknown token",
+		},
+		{
+			desc:     "verifier denies",
+			header:   "Bearer denied",
+			wantCode: yarpcerrors.CodePermissionDenied,
+			wantErr:  "banned",
+		},
+		{
+			desc:       "required scopes",
+			cfg:        InboundConfig{RequiredScopes: []string{"read"}},
+			header:     "Bearer good",
+			wantClaims: true,
+		},
+		{
+			desc:     "missing scopes",
+			cfg:      InboundConfig{RequiredScopes: []string{"read", "admin", "delete"}},
+			header:   "Bearer good",
+			wantCode: yarpcerrors.CodePermissionDenied,
+			wantErr:  "bearer token is missing required scopes: admin, delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Verifier = testVerifier(claims)
+			mw := NewInboundMiddleware(cfg)
+
+			procedure := tt.procedure
+			if procedure == "" {
+				procedure = "proc"
+			}
+			req := &transport.Request{Procedure: procedure, Headers: transport.NewHeaders()}
+			if tt.header != "" {
+				req.Headers = req.Headers.With("Authorization", tt.header)
+			}
+
+			h := new(claimsHandler)
+			err := mw.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), h)
+			if tt.wantCode != yarpcerrors.CodeOK {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, yarpcerrors.FromError(err).Code())
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantClaims, h.ok)
+			if tt.wantClaims {
+				assert.Equal(t, claims, h.claims)
+			}
+		})
+	}
+}
+
+func TestInboundMiddlewareOneway(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := NewInboundMiddleware(InboundConfig{Verifier: testVerifier(Claims{"sub": "foo"})})
+
+	h := transporttest.NewMockOnewayHandler(mockCtrl)
+	h.EXPECT().HandleOneway(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *transport.Request) error {
+			claims, ok := ClaimsFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, "foo", claims.Subject())
+			return nil
+		})
+
+	req := &transport.Request{Procedure: "proc", Headers: transport.NewHeaders().With("Authorization", "Bearer good")}
+	require.NoError(t, mw.HandleOneway(context.Background(), req, h))
+
+	err := mw.HandleOneway(context.Background(), &transport.Request{Procedure: "proc"}, h)
+	assert.Equal(t, yarpcerrors.CodeUnauthenticated, yarpcerrors.FromError(err).Code())
+}
+
+func TestInboundMiddlewareStream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := NewInboundMiddleware(InboundConfig{Verifier: testVerifier(Claims{"sub": "foo"})})
+
+	newServerStream := func(headers transport.Headers) *transport.ServerStream {
+		stream := transporttest.NewMockStream(mockCtrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().Request().Return(&transport.StreamRequest{
+			Meta: &transport.RequestMeta{Procedure: "proc", Headers: headers},
+		}).AnyTimes()
+		ss, err := transport.NewServerStream(stream)
+		require.NoError(t, err)
+		return ss
+	}
+
+	h := transporttest.NewMockStreamHandler(mockCtrl)
+	h.EXPECT().HandleStream(gomock.Any()).DoAndReturn(func(s *transport.ServerStream) error {
+		claims, ok := ClaimsFromContext(s.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "foo", claims.Subject())
+		return nil
+	})
+	require.NoError(t, mw.HandleStream(newServerStream(transport.NewHeaders().With("authorization", "Bearer good")), h))
+
+	err := mw.HandleStream(newServerStream(transport.NewHeaders().With("authorization", "Bearer bad")), h)
+	assert.Equal(t, yarpcerrors.CodeUnauthenticated, yarpcerrors.FromError(err).Code())
+}
+
+func TestOutboundToInbound(t *testing.T) {
+	out := NewOutboundMiddleware(StaticTokenSource("good"))
+	in := NewInboundMiddleware(InboundConfig{Verifier: testVerifier(Claims{"sub": "foo"})})
+
+	// Headers added by the outbound middleware must be accepted by the
+	// inbound middleware.
+	headers, err := out.authorize(context.Background(), "svc", transport.NewHeaders())
+	require.NoError(t, err)
+	req := &transport.Request{Procedure: "proc", Headers: headers, Body: strings.NewReader("")}
+
+	h := new(claimsHandler)
+	require.NoError(t, in.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), h))
+	assert.True(t, h.ok)
+}
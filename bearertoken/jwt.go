@@ -0,0 +1,317 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	// Register the hashes used by the supported algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// _jwksReloadInterval bounds how often the JWKS file is reloaded when a
+// token is signed by an unknown key.
+const _jwksReloadInterval = 30 * time.Second
+
+// JWTConfig configures a Verifier for JSON Web Tokens.
+type JWTConfig struct {
+	// JWKSFile is the path to a JSON Web Key Set holding the public keys
+	// that tokens may be signed with. RSA and ECDSA keys are supported. The
+	// file is reloaded when a token names a key that it does not hold, so
+	// keys may be rotated in place.
+	JWKSFile string
+
+	// Issuer, if set, is the required "iss" claim.
+	Issuer string
+
+	// Audience, if set, must be one of the token's "aud" claims.
+	Audience string
+
+	// Leeway is the clock skew tolerated when checking the "exp" and "nbf"
+	// claims.
+	Leeway time.Duration
+}
+
+// NewJWTVerifier builds a Verifier for JSON Web Tokens signed with the RS,
+// PS or ES family of algorithms by keys from a local JWKS file.
+//
+// Tokens must carry an "exp" claim.
+func NewJWTVerifier(cfg JWTConfig) (Verifier, error) {
+	if cfg.JWKSFile == "" {
+		return nil, errors.New("a JWKS file is required")
+	}
+	v := &jwtVerifier{cfg: cfg, now: time.Now}
+	if err := v.reload(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type jwtVerifier struct {
+	cfg JWTConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	keys     []jsonWebKey
+	loadedAt time.Time
+}
+
+// jwtHeader is the JOSE header of a token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v *jwtVerifier) Verify(_ context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JWT")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("failed to decode JWT header: %v", err)
+	}
+	alg, ok := _jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT signature: %v", err)
+	}
+
+	keys, err := v.keysFor(header)
+	if err != nil {
+		return nil, err
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range keys {
+		if alg.verify(k.public, signed, sig) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("invalid JWT signature")
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode JWT claims: %v", err)
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// keysFor returns the keys that may have signed a token with the given
+// header, reloading the key set if it does not hold the named key.
+func (v *jwtVerifier) keysFor(header jwtHeader) ([]jsonWebKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	keys := matchingKeys(v.keys, header)
+	if len(keys) == 0 && v.now().Sub(v.loadedAt) >= _jwksReloadInterval {
+		if err := v.reloadLocked(); err != nil {
+			return nil, err
+		}
+		keys = matchingKeys(v.keys, header)
+	}
+	if len(keys) == 0 {
+		if header.Kid != "" {
+			return nil, fmt.Errorf("unknown signing key %q", header.Kid)
+		}
+		return nil, fmt.Errorf("no signing key for algorithm %q", header.Alg)
+	}
+	return keys, nil
+}
+
+func (v *jwtVerifier) validate(claims Claims) error {
+	now := v.now()
+
+	exp, ok := numericDate(claims, "exp")
+	if !ok {
+		return errors.New(`JWT has no "exp" claim`)
+	}
+	if now.After(exp.Add(v.cfg.Leeway)) {
+		return errors.New("JWT has expired")
+	}
+	if nbf, ok := numericDate(claims, "nbf"); ok && now.Add(v.cfg.Leeway).Before(nbf) {
+		return errors.New("JWT is not valid yet")
+	}
+	if v.cfg.Issuer != "" && claims.Issuer() != v.cfg.Issuer {
+		return fmt.Errorf("JWT issuer %q is not trusted", claims.Issuer())
+	}
+	if v.cfg.Audience != "" && !contains(claims.Audience(), v.cfg.Audience) {
+		return fmt.Errorf("JWT is not intended for audience %q", v.cfg.Audience)
+	}
+	return nil
+}
+
+func (v *jwtVerifier) reload() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.reloadLocked()
+}
+
+func (v *jwtVerifier) reloadLocked() error {
+	v.loadedAt = v.now()
+	keys, err := loadJWKS(v.cfg.JWKSFile)
+	if err != nil {
+		return err
+	}
+	v.keys = keys
+	return nil
+}
+
+func matchingKeys(keys []jsonWebKey, header jwtHeader) []jsonWebKey {
+	alg := _jwtAlgorithms[header.Alg]
+	var matches []jsonWebKey
+	for _, k := range keys {
+		if header.Kid != "" && k.kid != header.Kid {
+			continue
+		}
+		if k.alg != "" && k.alg != header.Alg {
+			continue
+		}
+		if !alg.accepts(k.public) {
+			continue
+		}
+		matches = append(matches, k)
+	}
+	return matches
+}
+
+// jwtAlgorithm is a JWS signing algorithm.
+type jwtAlgorithm struct {
+	hash  crypto.Hash
+	kind  string         // "RS", "PS", or "ES"
+	curve elliptic.Curve // the curve of ECDSA keys, for "ES" only
+}
+
+var _jwtAlgorithms = map[string]jwtAlgorithm{
+	"RS256": {crypto.SHA256, "RS", nil},
+	"RS384": {crypto.SHA384, "RS", nil},
+	"RS512": {crypto.SHA512, "RS", nil},
+	"PS256": {crypto.SHA256, "PS", nil},
+	"PS384": {crypto.SHA384, "PS", nil},
+	"PS512": {crypto.SHA512, "PS", nil},
+	"ES256": {crypto.SHA256, "ES", elliptic.P256()},
+	"ES384": {crypto.SHA384, "ES", elliptic.P384()},
+	"ES512": {crypto.SHA512, "ES", elliptic.P521()},
+}
+
+// accepts reports whether the key can verify signatures made with the
+// algorithm. ECDSA keys must be on the algorithm's curve (RFC 7518, section
+// 3.4).
+func (a jwtAlgorithm) accepts(key crypto.PublicKey) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return a.kind == "RS" || a.kind == "PS"
+	case *ecdsa.PublicKey:
+		return a.kind == "ES" && k.Curve == a.curve
+	}
+	return false
+}
+
+func (a jwtAlgorithm) verify(key crypto.PublicKey, signed, sig []byte) error {
+	h := a.hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if a.kind == "PS" {
+			return rsa.VerifyPSS(k, a.hash, digest, sig, nil)
+		}
+		return rsa.VerifyPKCS1v15(k, a.hash, digest, sig)
+	case *ecdsa.PublicKey:
+		// ECDSA signatures are the concatenation of r and s, each padded to
+		// the size of the curve.
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid ECDSA signature length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported key type %T", key)
+}
+
+func decodeSegment(seg string, into interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, into)
+}
+
+// numericDate returns the named claim as a time if it is a JSON number.
+func numericDate(claims Claims, name string) (time.Time, bool) {
+	f, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*float64(time.Second))), true
+}
+
+// unverifiedExpiry returns the expiry of the given token if it is a JWT with
+// an "exp" claim. The token's signature is not verified.
+func unverifiedExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return time.Time{}, false
+	}
+	return numericDate(claims, "exp")
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
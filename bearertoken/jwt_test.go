@@ -0,0 +1,312 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _testNow = time.Unix(1700000000, 0)
+
+// testSigner signs JWTs for tests.
+type testSigner struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func newRSASigner(t *testing.T, kid, alg string) testSigner {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testSigner{kid: kid, alg: alg, key: key}
+}
+
+func newECSigner(t *testing.T, kid string) testSigner {
+	return newECSignerWithCurve(t, kid, "ES256", elliptic.P256())
+}
+
+func newECSignerWithCurve(t *testing.T, kid, alg string, curve elliptic.Curve) testSigner {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return testSigner{kid: kid, alg: alg, key: key}
+}
+
+func (s testSigner) jwk() map[string]string {
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	switch k := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": s.kid, "use": "sig", "n": b64(k.N), "e": b64(big.NewInt(int64(k.E)))}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": s.kid, "crv": k.Curve.Params().Name, "x": b64(k.X), "y": b64(k.Y)}
+	}
+	panic("unsupported key")
+}
+
+func (s testSigner) sign(t *testing.T, claims map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := encode(map[string]string{"alg": s.alg, "kid": s.kid, "typ": "JWT"}) + "." + encode(claims)
+
+	alg := _jwtAlgorithms[s.alg]
+	h := alg.hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	var sig []byte
+	switch k := s.key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if alg.kind == "PS" {
+			sig, err = rsa.SignPSS(rand.Reader, k, alg.hash, digest, nil)
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, k, alg.hash, digest)
+		}
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		require.NoError(t, err)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func writeJWKS(t *testing.T, path string, signers ...testSigner) {
+	keys := make([]map[string]string, len(signers))
+	for i, s := range signers {
+		keys[i] = s.jwk()
+	}
+	b, err := json.Marshal(map[string]interface{}{"keys": keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+}
+
+func newTestJWTVerifier(t *testing.T, cfg JWTConfig) *jwtVerifier {
+	v, err := NewJWTVerifier(cfg)
+	require.NoError(t, err)
+	jv := v.(*jwtVerifier)
+	jv.now = func() time.Time { return _testNow }
+	return jv
+}
+
+func TestJWTVerifier(t *testing.T) {
+	rs := newRSASigner(t, "rsa", "RS256")
+	ps := testSigner{kid: "rsa", alg: "PS384", key: rs.key}
+	es := newECSigner(t, "ec")
+	other := newECSigner(t, "ec")
+	es512 := newECSignerWithCurve(t, "ec521", "ES512", elliptic.P521())
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, rs, es, es512)
+	v := newTestJWTVerifier(t, JWTConfig{
+		JWKSFile: path,
+		Issuer:   "https://auth.example.org",
+		Audience: "kv",
+		Leeway:   time.Minute,
+	})
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss": "https://auth.example.org",
+			"aud": []string{"kv", "other"},
+			"sub": "foo",
+			"exp": _testNow.Add(time.Hour).Unix(),
+		}
+	}
+	with := func(k string, val interface{}) map[string]interface{} {
+		c := valid()
+		if val == nil {
+			delete(c, k)
+		} else {
+			c[k] = val
+		}
+		return c
+	}
+
+	tests := []struct {
+		desc    string
+		token   string
+		wantErr string
+	}{
+		{desc: "RS256", token: rs.sign(t, valid())},
+		{desc: "PS384", token: ps.sign(t, valid())},
+		{desc: "ES256", token: es.sign(t, valid())},
+		{desc: "ES512", token: es512.sign(t, valid())},
+		{desc: "single audience", token: es.sign(t, with("aud", "kv"))},
+		{desc: "expired within leeway", token: es.sign(t, with("exp", _testNow.Add(-30*time.Second).Unix()))},
+		{
+			desc:    "expired",
+			token:   es.sign(t, with("exp", _testNow.Add(-2*time.Minute).Unix())),
+			wantErr: "JWT has expired",
+		},
+		{
+			desc:    "no expiry",
+			token:   es.sign(t, with("exp", nil)),
+			wantErr: `JWT has no "exp" claim`,
+		},
+		{
+			desc:    "not valid yet",
+			token:   es.sign(t, with("nbf", _testNow.Add(2*time.Minute).Unix())),
+			wantErr: "JWT is not valid yet",
+		},
+		{
+			desc:    "wrong issuer",
+			token:   es.sign(t, with("iss", "https://evil.example.org")),
+			wantErr: `JWT issuer "https://evil.example.org" is not trusted`,
+		},
+		{
+			desc:    "wrong audience",
+			token:   es.sign(t, with("aud", "other")),
+			wantErr: `JWT is not intended for audience "kv"`,
+		},
+		{
+			desc:    "wrong key",
+			token:   other.sign(t, valid()),
+			wantErr: "invalid JWT signature",
+		},
+		{
+			desc:    "unknown key",
+			token:   testSigner{kid: "nope", alg: "ES256", key: other.key}.sign(t, valid()),
+			wantErr: `unknown signing key "nope"`,
+		},
+		{
+			desc:    "algorithm mismatch",
+			token:   testSigner{kid: "rsa", alg: "ES256", key: es.key}.sign(t, valid()),
+			wantErr: `unknown signing key "rsa"`,
+		},
+		{
+			desc:    "curve mismatch",
+			token:   testSigner{kid: "ec521", alg: "ES256", key: es512.key}.sign(t, valid()),
+			wantErr: `unknown signing key "ec521"`,
+		},
+		{
+			desc:    "unsigned",
+			token:   "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1800000000}`)) + ".",
+			wantErr: `unsupported signing algorithm "none"`,
+		},
+		{
+			desc:    "not a JWT",
+			token:   "opaque",
+			wantErr: "token is not a JWT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			claims, err := v.Verify(context.Background(), tt.token)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "foo", claims.Subject())
+		})
+	}
+}
+
+func TestJWTVerifierReload(t *testing.T) {
+	old := newECSigner(t, "old")
+	rotated := newECSigner(t, "new")
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, old)
+	v := newTestJWTVerifier(t, JWTConfig{JWKSFile: path})
+	v.loadedAt = _testNow
+
+	claims := map[string]interface{}{"exp": _testNow.Add(time.Hour).Unix()}
+	_, err := v.Verify(context.Background(), old.sign(t, claims))
+	require.NoError(t, err)
+
+	writeJWKS(t, path, old, rotated)
+	_, err = v.Verify(context.Background(), rotated.sign(t, claims))
+	assert.EqualError(t, err, `unknown signing key "new"`, "key set must not be reloaded too often")
+
+	v.now = func() time.Time { return _testNow.Add(_jwksReloadInterval) }
+	_, err = v.Verify(context.Background(), rotated.sign(t, claims))
+	require.NoError(t, err)
+}
+
+func TestNewJWTVerifierErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		desc    string
+		path    string
+		wantErr string
+	}{
+		{desc: "no file", wantErr: "a JWKS file is required"},
+		{desc: "missing file", path: filepath.Join(dir, "missing"), wantErr: "failed to read JWKS file"},
+		{desc: "not JSON", path: write("bad.json", "{"), wantErr: "failed to decode JWKS file"},
+		{
+			desc:    "no signing keys",
+			path:    write("enc.json", `{"keys": [{"kty": "RSA", "use": "enc"}, {"kty": "oct"}]}`),
+			wantErr: "holds no signing keys",
+		},
+		{
+			desc:    "bad RSA key",
+			path:    write("rsa.json", `{"keys": [{"kty": "RSA", "n": "AQAB"}]}`),
+			wantErr: "invalid key 0 in JWKS file",
+		},
+		{
+			desc:    "unsupported curve",
+			path:    write("curve.json", `{"keys": [{"kty": "EC", "crv": "P-224", "x": "AQ", "y": "AQ"}]}`),
+			wantErr: `unsupported curve "P-224"`,
+		},
+		{
+			desc:    "point not on curve",
+			path:    write("point.json", `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`),
+			wantErr: "invalid point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewJWTVerifier(JWTConfig{JWKSFile: tt.path})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const (
+	// AuthorizationHeader is the application header that carries the
+	// bearer token.
+	AuthorizationHeader = "authorization"
+
+	_bearerPrefix = "Bearer "
+)
+
+var (
+	_ middleware.UnaryOutbound  = (*OutboundMiddleware)(nil)
+	_ middleware.OnewayOutbound = (*OutboundMiddleware)(nil)
+	_ middleware.StreamOutbound = (*OutboundMiddleware)(nil)
+)
+
+// OutboundMiddleware is an outbound middleware that attaches bearer tokens
+// to requests. Requests that already carry an authorization header are left
+// untouched.
+type OutboundMiddleware struct {
+	src TokenSource
+}
+
+// NewOutboundMiddleware builds an outbound middleware that attaches tokens
+// from the given source.
+func NewOutboundMiddleware(src TokenSource) *OutboundMiddleware {
+	return &OutboundMiddleware{src: src}
+}
+
+// Call implements middleware.UnaryOutbound.
+func (m *OutboundMiddleware) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	headers, err := m.authorize(ctx, req.Service, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+	treq := *req
+	treq.Headers = headers
+	return out.Call(ctx, &treq)
+}
+
+// CallOneway implements middleware.OnewayOutbound.
+func (m *OutboundMiddleware) CallOneway(ctx context.Context, req *transport.Request, out transport.OnewayOutbound) (transport.Ack, error) {
+	headers, err := m.authorize(ctx, req.Service, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+	treq := *req
+	treq.Headers = headers
+	return out.CallOneway(ctx, &treq)
+}
+
+// CallStream implements middleware.StreamOutbound.
+func (m *OutboundMiddleware) CallStream(ctx context.Context, req *transport.StreamRequest, out transport.StreamOutbound) (*transport.ClientStream, error) {
+	headers, err := m.authorize(ctx, req.Meta.Service, req.Meta.Headers)
+	if err != nil {
+		return nil, err
+	}
+	meta := *req.Meta
+	meta.Headers = headers
+	return out.CallStream(ctx, &transport.StreamRequest{Meta: &meta})
+}
+
+// authorize returns the given headers with a bearer token added.
+func (m *OutboundMiddleware) authorize(ctx context.Context, service string, headers transport.Headers) (transport.Headers, error) {
+	if _, ok := headers.Get(AuthorizationHeader); ok {
+		return headers, nil
+	}
+
+	token, err := m.src.Token(ctx)
+	if err != nil {
+		return headers, yarpcerrors.Newf(yarpcerrors.CodeUnauthenticated,
+			"failed to get bearer token for service %q: %v", service, err)
+	}
+
+	// Copy the headers so that retried or hedged requests do not share them.
+	authorized := transport.NewHeadersWithCapacity(headers.OriginalItemsLen() + 1)
+	for k, v := range headers.OriginalItems() {
+		authorized = authorized.With(k, v)
+	}
+	return authorized.With(AuthorizationHeader, _bearerPrefix+token.Value), nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func TestOutboundMiddleware(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := NewOutboundMiddleware(StaticTokenSource("secret"))
+
+	t.Run("unary", func(t *testing.T) {
+		headers := transport.NewHeaders().With("foo", "bar")
+		req := &transport.Request{Service: "svc", Headers: headers}
+
+		out := transporttest.NewMockUnaryOutbound(mockCtrl)
+		out.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *transport.Request) (*transport.Response, error) {
+				assert.Equal(t, map[string]string{
+					"foo":           "bar",
+					"authorization": "Bearer secret",
+				}, req.Headers.Items())
+				return &transport.Response{}, nil
+			})
+		_, err := mw.Call(context.Background(), req, out)
+		require.NoError(t, err)
+
+		_, ok := headers.Get(AuthorizationHeader)
+		assert.False(t, ok, "original headers must not be modified")
+	})
+
+	t.Run("oneway", func(t *testing.T) {
+		out := transporttest.NewMockOnewayOutbound(mockCtrl)
+		out.EXPECT().CallOneway(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *transport.Request) (transport.Ack, error) {
+				value, _ := req.Headers.Get(AuthorizationHeader)
+				assert.Equal(t, "Bearer secret", value)
+				return nil, nil
+			})
+		_, err := mw.CallOneway(context.Background(), &transport.Request{Service: "svc"}, out)
+		require.NoError(t, err)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		out := transporttest.NewMockStreamOutbound(mockCtrl)
+		out.EXPECT().CallStream(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *transport.StreamRequest) (*transport.ClientStream, error) {
+				value, _ := req.Meta.Headers.Get(AuthorizationHeader)
+				assert.Equal(t, "Bearer secret", value)
+				return nil, nil
+			})
+		_, err := mw.CallStream(context.Background(), &transport.StreamRequest{Meta: &transport.RequestMeta{Service: "svc"}}, out)
+		require.NoError(t, err)
+	})
+
+	t.Run("existing header", func(t *testing.T) {
+		req := &transport.Request{
+			Service: "svc",
+			Headers: transport.NewHeaders().With("Authorization", "Bearer mine"),
+		}
+		out := transporttest.NewMockUnaryOutbound(mockCtrl)
+		out.EXPECT().Call(gomock.Any(), req).Return(&transport.Response{}, nil)
+		_, err := mw.Call(context.Background(), req, out)
+		require.NoError(t, err)
+	})
+}
+
+func TestOutboundMiddlewareTokenError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := NewOutboundMiddleware(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return nil, errors.New("great sadness")
+	}))
+
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	_, err := mw.Call(context.Background(), &transport.Request{Service: "svc"}, out)
+	require.Error(t, err)
+	assert.Equal(t, yarpcerrors.CodeUnauthenticated, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `failed to get bearer token for service "svc": great sadness`)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Token is a bearer token.
+type Token struct {
+	// Value is the opaque token string.
+	Value string
+
+	// Expiry is the time after which the token is no longer valid. Tokens
+	// with a zero Expiry never expire.
+	Expiry time.Time
+}
+
+// expiresWithin reports whether the token expires within the given duration
+// of now.
+func (t *Token) expiresWithin(now time.Time, d time.Duration) bool {
+	return !t.Expiry.IsZero() && !now.Add(d).Before(t.Expiry)
+}
+
+// TokenSource provides bearer tokens for outgoing requests.
+type TokenSource interface {
+	// Token returns a token. It may be called concurrently.
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function into a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token implements TokenSource.
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns the given
+// token, which never expires.
+func StaticTokenSource(value string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{Value: value}, nil
+	})
+}
+
+// FileTokenSource returns a TokenSource that reads the token from the given
+// file on every call, ignoring surrounding whitespace. Tokens read from the
+// file are considered valid for refreshInterval, or until they expire if they
+// are JSON Web Tokens that expire earlier.
+//
+// Wrap it in ReuseTokenSource to avoid reading the file on every request.
+func FileTokenSource(path string, refreshInterval time.Duration) TokenSource {
+	return &fileTokenSource{path: path, refreshInterval: refreshInterval, now: time.Now}
+}
+
+type fileTokenSource struct {
+	path            string
+	refreshInterval time.Duration
+	now             func() time.Time
+}
+
+func (s *fileTokenSource) Token(context.Context) (*Token, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %v", err)
+	}
+	value := string(bytes.TrimSpace(b))
+	if value == "" {
+		return nil, fmt.Errorf("token file %q is empty", s.path)
+	}
+
+	token := &Token{Value: value}
+	if s.refreshInterval > 0 {
+		token.Expiry = s.now().Add(s.refreshInterval)
+	}
+	// The token need not be a JWT; its expiry is only a hint.
+	if exp, ok := unverifiedExpiry(value); ok && (token.Expiry.IsZero() || exp.Before(token.Expiry)) {
+		token.Expiry = exp
+	}
+	return token, nil
+}
+
+// ReuseTokenSource returns a TokenSource that caches tokens from the given
+// source and fetches a new one only when the cached token expires within
+// refreshWindow. If fetching a new token fails, the cached token is used
+// until it expires.
+func ReuseTokenSource(src TokenSource, refreshWindow time.Duration) TokenSource {
+	return &reuseTokenSource{src: src, refreshWindow: refreshWindow, now: time.Now}
+}
+
+type reuseTokenSource struct {
+	src           TokenSource
+	refreshWindow time.Duration
+	now           func() time.Time
+
+	mu    sync.Mutex
+	token *Token
+}
+
+func (s *reuseTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != nil && !s.token.expiresWithin(now, s.refreshWindow) {
+		return s.token, nil
+	}
+
+	token, err := s.src.Token(ctx)
+	if err == nil && token == nil {
+		err = errors.New("token source returned no token")
+	}
+	if err != nil {
+		if s.token != nil && !s.token.expiresWithin(now, 0) {
+			return s.token, nil
+		}
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package bearertoken
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticTokenSource(t *testing.T) {
+	token, err := StaticTokenSource("foo").Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Token{Value: "foo"}, token)
+}
+
+func TestFileTokenSource(t *testing.T) {
+	now := time.Unix(1000, 0)
+	path := filepath.Join(t.TempDir(), "token")
+	src := &fileTokenSource{path: path, refreshInterval: time.Minute, now: func() time.Time { return now }}
+
+	_, err := src.Token(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read token file")
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0o600))
+	_, err = src.Token(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is empty")
+
+	require.NoError(t, os.WriteFile(path, []byte("opaque\n"), 0o600))
+	token, err := src.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Token{Value: "opaque", Expiry: now.Add(time.Minute)}, token)
+
+	// JWTs that expire before the refresh interval is up are refreshed
+	// early.
+	jwt := "e30." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1030}`)) + ".sig"
+	require.NoError(t, os.WriteFile(path, []byte(jwt), 0o600))
+	token, err = src.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Token{Value: jwt, Expiry: time.Unix(1030, 0)}, token)
+}
+
+func TestReuseTokenSource(t *testing.T) {
+	now := time.Unix(1000, 0)
+	var (
+		calls  int
+		expiry time.Time
+		err    error
+	)
+	src := &reuseTokenSource{
+		src: TokenSourceFunc(func(context.Context) (*Token, error) {
+			calls++
+			if err != nil {
+				return nil, err
+			}
+			return &Token{Value: "token", Expiry: expiry}, nil
+		}),
+		refreshWindow: 10 * time.Second,
+		now:           func() time.Time { return now },
+	}
+	get := func() (*Token, error) { return src.Token(context.Background()) }
+
+	expiry = now.Add(time.Minute)
+	_, e := get()
+	require.NoError(t, e)
+	_, e = get()
+	require.NoError(t, e)
+	assert.Equal(t, 1, calls, "token must be reused")
+
+	now = now.Add(55 * time.Second)
+	expiry = now.Add(time.Minute)
+	token, e := get()
+	require.NoError(t, e)
+	assert.Equal(t, expiry, token.Expiry)
+	assert.Equal(t, 2, calls, "token must be refreshed within the refresh window")
+
+	// A failed refresh falls back to the cached token until it expires.
+	now = now.Add(55 * time.Second)
+	err = errors.New("great sadness")
+	token, e = get()
+	require.NoError(t, e)
+	assert.Equal(t, expiry, token.Expiry)
+	assert.Equal(t, 3, calls)
+
+	now = now.Add(10 * time.Second)
+	_, e = get()
+	assert.EqualError(t, e, "great sadness")
+}
+
+func TestReuseTokenSourceNoExpiry(t *testing.T) {
+	calls := 0
+	src := ReuseTokenSource(TokenSourceFunc(func(context.Context) (*Token, error) {
+		calls++
+		return &Token{Value: "token"}, nil
+	}), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, err := src.Token(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, calls, "tokens without an expiry must be reused forever")
+}
+
+func TestReuseTokenSourceNilToken(t *testing.T) {
+	src := ReuseTokenSource(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return nil, nil
+	}), time.Minute)
+	_, err := src.Token(context.Background())
+	assert.EqualError(t, err, "token source returned no token")
+}
@@ -238,6 +238,13 @@ func (c *Call) CallerProcedure() string {
 	return (*encoding.Call)(c).CallerProcedure()
 }
 
+// PeerIdentity returns the identity of the remote peer, as verified by a
+// mutual TLS handshake, or nil if the peer did not present a verified client
+// certificate.
+func (c *Call) PeerIdentity() *transport.PeerIdentity {
+	return (*encoding.Call)(c).PeerIdentity()
+}
+
 // Headers returns a copy of the canonicalized request headers provided with the request.
 func (c *Call) Headers() map[string]string { return (*encoding.Call)(c).Headers() }
 
@@ -66,6 +66,7 @@ func TestCallFromContext(t *testing.T) {
 			RoutingKey:      "two",
 			RoutingDelegate: "three",
 			CallerProcedure: "four",
+			PeerIdentity:    &transport.PeerIdentity{CommonName: "five"},
 		},
 	)
 	assert.NoError(t, err)
@@ -90,6 +91,7 @@ func TestCallFromContext(t *testing.T) {
 	assert.Equal(t, "two", call.RoutingKey())
 	assert.Equal(t, "three", call.RoutingDelegate())
 	assert.Equal(t, "four", call.CallerProcedure())
+	assert.Equal(t, &transport.PeerIdentity{CommonName: "five"}, call.PeerIdentity())
 }
 
 func TestWithCrossZoneRoutingGRPC(t *testing.T) {
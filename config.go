@@ -29,6 +29,10 @@ import (
 	"go.uber.org/net/metrics"
 	"go.uber.org/net/metrics/tallypush"
 	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/faultinjection"
+	"go.uber.org/yarpc/internal/authorization"
+	"go.uber.org/yarpc/internal/concurrencylimiter"
+	"go.uber.org/yarpc/internal/lameduck"
 	"go.uber.org/yarpc/internal/observability"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -204,6 +208,159 @@ func (c MetricsConfig) scope(name string, logger *zap.Logger) (*metrics.Scope, c
 	return meter, stopMeter
 }
 
+// InboundConcurrencyConfig limits the number of inbound unary and streaming
+// requests that may be in flight at once for each procedure.
+//
+// Requests over the limit wait in a bounded queue for a slot. Requests that
+// find the queue full or wait too long are shed with a ResourceExhausted
+// error. Limits, in-flight requests, queue depths, and rejections are
+// reported through the dispatcher's metrics.
+type InboundConcurrencyConfig struct {
+	// Default is the limit applied to each procedure without its own limit.
+	// Procedures are not limited if this is nil.
+	Default *ConcurrencyLimit
+
+	// Procedures maps procedure names to their limits.
+	Procedures map[string]ConcurrencyLimit
+}
+
+// ConcurrencyLimit bounds the in-flight requests of a single procedure.
+type ConcurrencyLimit struct {
+	// MaxConcurrency is the number of requests that may be in flight at
+	// once. For adaptive limits, this is the initial limit.
+	MaxConcurrency int
+
+	// MaxQueue is the number of requests that may wait for a slot once the
+	// limit is reached. Excess requests are rejected outright if this is
+	// zero.
+	MaxQueue int
+
+	// MaxQueueWait bounds the time a request may wait in the queue. Queued
+	// requests wait until their deadline if this is zero.
+	MaxQueueWait time.Duration
+
+	// Adaptive, if set, lets the limit move between its bounds based on
+	// observed latencies.
+	Adaptive *AdaptiveConcurrencyLimit
+}
+
+// AdaptiveConcurrencyLimit configures a limit that grows by one while
+// requests complete within LatencyThreshold and the limit is in use, and
+// shrinks by BackoffRatio whenever a request is slower than LatencyThreshold
+// or misses its deadline.
+type AdaptiveConcurrencyLimit struct {
+	// MinConcurrency and MaxConcurrency bound the limit.
+	MinConcurrency int
+	MaxConcurrency int
+
+	// LatencyThreshold is the latency above which a request is taken as a
+	// sign of overload.
+	LatencyThreshold time.Duration
+
+	// BackoffRatio is the factor by which the limit shrinks on overload.
+	// Defaults to 0.9.
+	BackoffRatio float64
+}
+
+func (c InboundConcurrencyConfig) enabled() bool {
+	return c.Default != nil || len(c.Procedures) > 0
+}
+
+func (c InboundConcurrencyConfig) limiterConfig() concurrencylimiter.Config {
+	cfg := concurrencylimiter.Config{
+		Procedures: make(map[string]concurrencylimiter.Limit, len(c.Procedures)),
+	}
+	if c.Default != nil {
+		l := c.Default.limit()
+		cfg.Default = &l
+	}
+	for procedure, l := range c.Procedures {
+		cfg.Procedures[procedure] = l.limit()
+	}
+	return cfg
+}
+
+func (l ConcurrencyLimit) limit() concurrencylimiter.Limit {
+	limit := concurrencylimiter.Limit{
+		MaxConcurrency: l.MaxConcurrency,
+		MaxQueue:       l.MaxQueue,
+		MaxQueueWait:   l.MaxQueueWait,
+	}
+	if a := l.Adaptive; a != nil {
+		limit.Adaptive = &concurrencylimiter.AdaptiveLimit{
+			MinConcurrency:   a.MinConcurrency,
+			MaxConcurrency:   a.MaxConcurrency,
+			LatencyThreshold: a.LatencyThreshold,
+			BackoffRatio:     a.BackoffRatio,
+		}
+	}
+	return limit
+}
+
+// InboundAuthorizationConfig authorizes inbound requests based on the
+// identity of the calling peer, as verified by a mutual TLS handshake. See
+// yarpc.Call's PeerIdentity method.
+//
+// Once any rule is configured, a request is allowed only if a rule that
+// covers its service and procedure admits its caller. Other requests fail
+// with an Unauthenticated error if the caller did not present a verified
+// client certificate, and with a PermissionDenied error otherwise.
+type InboundAuthorizationConfig struct {
+	// Rules lists the allow rules.
+	Rules []AuthorizationRule
+}
+
+// AuthorizationRule allows a set of peers to call a set of procedures.
+//
+// Service, procedure and identity patterns use the syntax of path.Match, so
+// "KeyValue::*" matches every procedure of the KeyValue service.
+type AuthorizationRule struct {
+	// Service is the service the rule covers. The rule covers all services
+	// if this is empty.
+	Service string
+
+	// Procedures are the procedures the rule covers. The rule covers all
+	// procedures of the service if this is empty.
+	Procedures []string
+
+	// SPIFFEIDs, DNSNames and Subjects admit peers whose certificates carry
+	// a matching SPIFFE ID, DNS subject alternative name, or subject
+	// distinguished name.
+	SPIFFEIDs []string
+	DNSNames  []string
+	Subjects  []string
+
+	// AllowUnauthenticated admits all callers, including those that did not
+	// present a verified client certificate.
+	AllowUnauthenticated bool
+}
+
+func (c InboundAuthorizationConfig) enabled() bool {
+	return len(c.Rules) > 0
+}
+
+func (c InboundAuthorizationConfig) authorizationConfig() authorization.Config {
+	cfg := authorization.Config{Rules: make([]authorization.Rule, len(c.Rules))}
+	for i, r := range c.Rules {
+		cfg.Rules[i] = authorization.Rule(r)
+	}
+	return cfg
+}
+
+// DrainingHeader is the response header with which a draining dispatcher
+// marks its unary responses.
+const DrainingHeader = lameduck.Header
+
+// DrainConfig configures how the dispatcher drains its inbounds before it
+// stops them. See the PhasedStopper's DrainInbounds method for details.
+type DrainConfig struct {
+	// GracePeriod is how long the dispatcher continues to accept new requests
+	// after it begins draining, giving load balancers and callers time to
+	// notice that it is not serving. Stop drains the inbounds only if this is
+	// positive.
+	GracePeriod time.Duration
+}
+
 // Config specifies the parameters of a new Dispatcher constructed via
 // NewDispatcher.
 type Config struct {
@@ -244,6 +401,30 @@ type Config struct {
 	// Configures telemetry.
 	Metrics MetricsConfig
 
+	// Configures OpenTelemetry tracing.
+	Tracing TracingConfig
+
+	// Limits the number of in-flight inbound requests per procedure.
+	InboundConcurrency InboundConcurrencyConfig
+
+	// Authorizes inbound requests based on the verified identity of the
+	// calling peer.
+	InboundAuthorization InboundAuthorizationConfig
+
+	// Configures how the dispatcher drains its inbounds before stopping them.
+	Drain DrainConfig
+
+	// FaultInjection, if set, injects faults into inbound and outbound
+	// requests. The injector is placed nearest to handlers and outbounds,
+	// so that other middleware observes injected faults as genuine ones, and
+	// may be enabled, disabled and reconfigured while the dispatcher runs.
+	FaultInjection *faultinjection.Injector
+
+	// DisableHealthService stops the dispatcher from registering the
+	// grpc.health.v1.Health service, for services that implement it
+	// themselves.
+	DisableHealthService bool
+
 	// DisableAutoObservabilityMiddleware is used to stop the dispatcher from
 	// automatically attaching observability middleware to all inbounds and
 	// outbounds.  It is the assumption that if if this option is disabled the
@@ -23,14 +23,20 @@ package yarpc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc/api/middleware"
 	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/faultinjection"
+	"go.uber.org/yarpc/health"
 	"go.uber.org/yarpc/internal"
+	"go.uber.org/yarpc/internal/authorization"
+	"go.uber.org/yarpc/internal/concurrencylimiter"
 	"go.uber.org/yarpc/internal/firstoutboundmiddleware"
 	"go.uber.org/yarpc/internal/inboundmiddleware"
+	"go.uber.org/yarpc/internal/lameduck"
 	"go.uber.org/yarpc/internal/observability"
 	"go.uber.org/yarpc/internal/outboundmiddleware"
 	"go.uber.org/yarpc/internal/request"
@@ -80,21 +86,47 @@ func NewDispatcher(cfg Config) *Dispatcher {
 	extractor := cfg.Logging.extractor()
 
 	meter, stopMeter := cfg.Metrics.scope(cfg.Name, logger)
+	drain := lameduck.New()
+	cfg = addConcurrencyLimitingMiddleware(cfg, meter, logger)
+	cfg = addAuthorizationMiddleware(cfg)
+	cfg = addDrainMiddleware(cfg, drain)
 	cfg = addObservingMiddleware(cfg, meter, logger, extractor)
+	cfg = addFaultInjectionMiddleware(cfg)
+	cfg = addTracingMiddleware(cfg)
 	cfg = addFirstOutboundMiddleware(cfg)
 
-	return &Dispatcher{
+	d := &Dispatcher{
 		name:              cfg.Name,
 		table:             middleware.ApplyRouteTable(NewMapRouter(cfg.Name), cfg.RouterMiddleware),
 		inbounds:          cfg.Inbounds,
 		outbounds:         convertOutbounds(cfg.Outbounds, cfg.OutboundMiddleware),
 		transports:        collectTransports(cfg.Inbounds, cfg.Outbounds),
 		inboundMiddleware: cfg.InboundMiddleware,
+		health:            newHealthServer(cfg.Name),
+		healthServices:    map[string]struct{}{cfg.Name: {}},
+		drain:             drain,
+		drainGracePeriod:  cfg.Drain.GracePeriod,
+		faultInjector:     cfg.FaultInjection,
 		log:               logger,
 		meter:             meter,
 		stopMeter:         stopMeter,
 		once:              lifecycle.NewOnce(),
 	}
+	if !cfg.DisableHealthService {
+		// Health checks bypass inbound middleware so that load shedding and
+		// authorization never fail them.
+		d.table.Register(d.health.Procedures())
+	}
+	return d
+}
+
+// newHealthServer builds a health server that reports the dispatcher's
+// service as not serving until the dispatcher starts its inbounds.
+func newHealthServer(name string) *health.Server {
+	s := health.NewServer()
+	s.SetServingStatus(name, health.Serving)
+	s.Shutdown()
+	return s
 }
 
 func addObservingMiddleware(cfg Config, meter *metrics.Scope, logger *zap.Logger, extractor observability.ContextExtractor) Config {
@@ -143,6 +175,97 @@ func addObservingMiddleware(cfg Config, meter *metrics.Scope, logger *zap.Logger
 	return cfg
 }
 
+// Add the concurrency limiting middleware, if configured, beneath the
+// observability middleware so that shed requests are logged and counted.
+func addConcurrencyLimitingMiddleware(cfg Config, meter *metrics.Scope, logger *zap.Logger) Config {
+	if !cfg.InboundConcurrency.enabled() {
+		return cfg
+	}
+
+	limiterCfg := cfg.InboundConcurrency.limiterConfig()
+	if err := limiterCfg.Validate(); err != nil {
+		panic("yarpc.NewDispatcher expects a valid inbound concurrency configuration: " + err.Error())
+	}
+	limiter := concurrencylimiter.New(concurrencylimiter.Params{
+		Config: limiterCfg,
+		Meter:  meter,
+		Logger: logger,
+	})
+
+	cfg.InboundMiddleware.Unary = inboundmiddleware.UnaryChain(limiter, cfg.InboundMiddleware.Unary)
+	cfg.InboundMiddleware.Stream = inboundmiddleware.StreamChain(limiter, cfg.InboundMiddleware.Stream)
+	return cfg
+}
+
+// Add the authorization middleware, if configured, ahead of the concurrency
+// limiter so that unauthorized requests never take up a slot.
+func addAuthorizationMiddleware(cfg Config) Config {
+	if !cfg.InboundAuthorization.enabled() {
+		return cfg
+	}
+
+	authzCfg := cfg.InboundAuthorization.authorizationConfig()
+	if err := authzCfg.Validate(); err != nil {
+		panic("yarpc.NewDispatcher expects a valid inbound authorization configuration: " + err.Error())
+	}
+	authz := authorization.New(authzCfg)
+
+	cfg.InboundMiddleware.Unary = inboundmiddleware.UnaryChain(authz, cfg.InboundMiddleware.Unary)
+	cfg.InboundMiddleware.Oneway = inboundmiddleware.OnewayChain(authz, cfg.InboundMiddleware.Oneway)
+	cfg.InboundMiddleware.Stream = inboundmiddleware.StreamChain(authz, cfg.InboundMiddleware.Stream)
+	return cfg
+}
+
+// Add the drain middleware ahead of the concurrency limiter, so that requests
+// rejected while draining do not wait for a slot, and beneath the
+// observability middleware, so that they are logged and counted.
+func addDrainMiddleware(cfg Config, drain *lameduck.Middleware) Config {
+	cfg.InboundMiddleware.Unary = inboundmiddleware.UnaryChain(drain, cfg.InboundMiddleware.Unary)
+	cfg.InboundMiddleware.Oneway = inboundmiddleware.OnewayChain(drain, cfg.InboundMiddleware.Oneway)
+	cfg.InboundMiddleware.Stream = inboundmiddleware.StreamChain(drain, cfg.InboundMiddleware.Stream)
+	return cfg
+}
+
+// Add the fault injection middleware, if configured, beneath all other
+// middleware, so that injected faults look like those of handlers and
+// outbounds. This must follow the observability middleware, which also sits
+// beneath other outbound middleware.
+func addFaultInjectionMiddleware(cfg Config) Config {
+	injector := cfg.FaultInjection
+	if injector == nil {
+		return cfg
+	}
+
+	cfg.InboundMiddleware.Unary = inboundmiddleware.UnaryChain(cfg.InboundMiddleware.Unary, injector)
+	cfg.InboundMiddleware.Oneway = inboundmiddleware.OnewayChain(cfg.InboundMiddleware.Oneway, injector)
+	cfg.InboundMiddleware.Stream = inboundmiddleware.StreamChain(cfg.InboundMiddleware.Stream, injector)
+
+	cfg.OutboundMiddleware.Unary = outboundmiddleware.UnaryChain(cfg.OutboundMiddleware.Unary, injector)
+	cfg.OutboundMiddleware.Oneway = outboundmiddleware.OnewayChain(cfg.OutboundMiddleware.Oneway, injector)
+	cfg.OutboundMiddleware.Stream = outboundmiddleware.StreamChain(cfg.OutboundMiddleware.Stream, injector)
+	return cfg
+}
+
+// Add the OpenTelemetry tracing middleware, if configured, ahead of all other
+// middleware so that spans cover the full request and their contexts are
+// visible to logging.
+func addTracingMiddleware(cfg Config) Config {
+	tracer := cfg.Tracing.middleware()
+	if tracer == nil {
+		return cfg
+	}
+
+	cfg.InboundMiddleware.Unary = inboundmiddleware.UnaryChain(tracer, cfg.InboundMiddleware.Unary)
+	cfg.InboundMiddleware.Oneway = inboundmiddleware.OnewayChain(tracer, cfg.InboundMiddleware.Oneway)
+	cfg.InboundMiddleware.Stream = inboundmiddleware.StreamChain(tracer, cfg.InboundMiddleware.Stream)
+
+	cfg.OutboundMiddleware.Unary = outboundmiddleware.UnaryChain(tracer, cfg.OutboundMiddleware.Unary)
+	cfg.OutboundMiddleware.Oneway = outboundmiddleware.OnewayChain(tracer, cfg.OutboundMiddleware.Oneway)
+	cfg.OutboundMiddleware.Stream = outboundmiddleware.StreamChain(tracer, cfg.OutboundMiddleware.Stream)
+
+	return cfg
+}
+
 // Add the first outbound middleware, which ensures that `transport.Request`
 // will have appropriate fields.
 func addFirstOutboundMiddleware(cfg Config) Config {
@@ -255,6 +378,14 @@ type Dispatcher struct {
 
 	inboundMiddleware InboundMiddleware
 
+	health         *health.Server
+	healthServices map[string]struct{}
+
+	drain            *lameduck.Middleware
+	drainGracePeriod time.Duration
+
+	faultInjector *faultinjection.Injector
+
 	log       *zap.Logger
 	meter     *metrics.Scope
 	stopMeter context.CancelFunc
@@ -360,6 +491,13 @@ func (d *Dispatcher) Register(rs []transport.Procedure) {
 		procedures = append(procedures, r)
 		d.log.Info("Registration succeeded.", zap.Object("registeredProcedure", r))
 
+		if r.Service != "" {
+			if _, ok := d.healthServices[r.Service]; !ok {
+				d.healthServices[r.Service] = struct{}{}
+				d.health.SetServingStatus(r.Service, health.Serving)
+			}
+		}
+
 		if r.Encoding == transport.ThriftEncoding {
 			for exceptionName, code := range r.Exceptions {
 				if code != transport.RPCCodeNotSetLiteral {
@@ -454,6 +592,9 @@ func (d *Dispatcher) PhasedStart() (*PhasedStarter, error) {
 // Stop stops the Dispatcher, shutting down all inbounds, outbounds, and
 // transports. This function returns after everything has been stopped.
 //
+// If the dispatcher was configured with a drain grace period, Stop first
+// drains the inbounds, waiting for the grace period.
+//
 // Stop and PhasedStop are mutually exclusive. See the PhasedStop
 // documentation for details.
 func (d *Dispatcher) Stop() error {
@@ -463,6 +604,11 @@ func (d *Dispatcher) Stop() error {
 	}
 	return d.once.Stop(func() error {
 		d.log.Info("shutting down dispatcher")
+		if d.drainGracePeriod > 0 {
+			if err := stopper.DrainInbounds(); err != nil {
+				return err
+			}
+		}
 		return multierr.Combine(
 			stopper.StopInbounds(),
 			stopper.StopOutbounds(),
@@ -490,6 +636,24 @@ func (d *Dispatcher) PhasedStop() (*PhasedStopper, error) {
 	}, nil
 }
 
+// Health returns the health server that reports the serving status of the
+// dispatcher's services over the grpc.health.v1.Health service.
+//
+// The dispatcher reports its services as serving once its inbounds have
+// started and as not serving as soon as it begins to stop them. Every service
+// with registered procedures is serving by default; use SetServingStatus to
+// take a service out of rotation while the dispatcher is running.
+func (d *Dispatcher) Health() *health.Server {
+	return d.health
+}
+
+// FaultInjector returns the injector configured with Config.FaultInjection,
+// or nil if fault injection is not configured. Use it to enable, disable or
+// reconfigure fault injection while the dispatcher is running.
+func (d *Dispatcher) FaultInjector() *faultinjection.Injector {
+	return d.faultInjector
+}
+
 // Router returns the procedure router.
 func (d *Dispatcher) Router() transport.Router {
 	return d.table
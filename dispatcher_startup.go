@@ -23,6 +23,7 @@ package yarpc
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/internal/errorsync"
@@ -104,8 +105,9 @@ func (s *PhasedStarter) StartOutbounds() error {
 
 // StartInbounds is the final phase of startup. It starts all inbounds
 // configured on the dispatcher, which allows any registered procedures to
-// begin receiving requests. It's safe to call concurrently, but all calls
-// after the first return an error.
+// begin receiving requests, and reports the dispatcher's services as serving
+// to health checkers. It's safe to call concurrently, but all calls after the
+// first return an error.
 func (s *PhasedStarter) StartInbounds() error {
 	if !s.transportsStarted.Load() || !s.outboundsStarted.Load() {
 		return errors.New("must start inbounds after transports and outbounds")
@@ -122,6 +124,7 @@ func (s *PhasedStarter) StartInbounds() error {
 		return s.abort(errs)
 	}
 	s.log.Debug("started inbounds")
+	s.dispatcher.health.Resume()
 	return nil
 }
 
@@ -186,12 +189,15 @@ func (s *PhasedStarter) setRouters() {
 // shutdown, see the documentation for the Dispatcher's PhasedStop method.
 //
 // The user of a PhasedStopper is responsible for correctly ordering shutdown:
-// inbounds MUST be stopped before outbounds, which MUST be stopped before
-// transports. Attempting shutdown in any other order will return an error.
+// inbounds MAY be drained first, and MUST be stopped before outbounds, which
+// MUST be stopped before transports. Attempting shutdown in any other order
+// will return an error.
 type PhasedStopper struct {
 	dispatcher *Dispatcher
 	log        *zap.Logger
 
+	drainInitiated          atomic.Bool
+	drained                 atomic.Bool
 	inboundsStopInitiated   atomic.Bool
 	inboundsStopped         atomic.Bool
 	outboundsStopInitiated  atomic.Bool
@@ -199,15 +205,65 @@ type PhasedStopper struct {
 	transportsStopInitiated atomic.Bool
 }
 
-// StopInbounds is the first step in shutdown. It stops all inbounds
+// DrainInbounds is the optional first step in shutdown. It puts the
+// dispatcher in lame-duck mode ahead of stopping its inbounds, so that
+// callers move their traffic to other instances without failed requests.
+//
+// Draining reports the dispatcher's services as not serving to health
+// checkers, marks unary responses with the DrainingHeader, and drains
+// inbounds that implement transport.DrainableInbound. The HTTP, gRPC and
+// TChannel inbounds stop accepting new connections; HTTP also asks clients to
+// move off the connections they have, and gRPC does so with GOAWAY once its
+// inbound stops. The dispatcher continues to
+// accept new requests on existing connections for the grace period set in
+// its DrainConfig, after which it rejects them with CodeUnavailable, which
+// callers may retry elsewhere. DrainInbounds returns once the grace period
+// is over; requests in flight complete as the inbounds stop.
+//
+// It's safe to call concurrently, but all calls after the first return an
+// error.
+func (s *PhasedStopper) DrainInbounds() error {
+	if s.inboundsStopInitiated.Load() {
+		return errors.New("must drain inbounds before stopping them")
+	}
+	if s.drainInitiated.Swap(true) {
+		return errors.New("already began draining inbounds")
+	}
+	defer s.drained.Store(true)
+
+	d := s.dispatcher
+	s.log.Info("draining inbounds", zap.Duration("gracePeriod", d.drainGracePeriod))
+	d.health.Shutdown()
+	d.drain.Drain()
+	for _, ib := range d.inbounds {
+		if di, ok := ib.(transport.DrainableInbound); ok {
+			di.Drain()
+		}
+	}
+
+	if d.drainGracePeriod > 0 {
+		time.Sleep(d.drainGracePeriod)
+	}
+	d.drain.Reject()
+	s.log.Debug("drained inbounds")
+	return nil
+}
+
+// StopInbounds is the first step in shutdown, unless the inbounds are drained
+// first. It stops all inbounds
 // configured on the dispatcher, which stops routing RPCs to all registered
-// procedures. It's safe to call concurrently, but all calls after the first
-// return an error.
+// procedures, after reporting the dispatcher's services as not serving to
+// health checkers. It's safe to call concurrently, but all calls after the
+// first return an error.
 func (s *PhasedStopper) StopInbounds() error {
+	if s.drainInitiated.Load() && !s.drained.Load() {
+		return errors.New("must finish draining inbounds first")
+	}
 	if s.inboundsStopInitiated.Swap(true) {
 		return errors.New("already began stopping inbounds")
 	}
 	defer s.inboundsStopped.Store(true)
+	s.dispatcher.health.Shutdown()
 	s.log.Debug("stopping inbounds")
 	wait := errorsync.ErrorWaiter{}
 	for _, ib := range s.dispatcher.inbounds {
@@ -21,10 +21,13 @@
 package yarpc_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -33,9 +36,13 @@ import (
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/api/transport/transporttest"
 	"go.uber.org/yarpc/api/x/introspection"
+	"go.uber.org/yarpc/faultinjection"
+	"go.uber.org/yarpc/health"
 	"go.uber.org/yarpc/internal/observability"
+	"go.uber.org/yarpc/internal/testtime"
 	"go.uber.org/yarpc/transport/http"
 	"go.uber.org/yarpc/transport/tchannel"
+	"go.uber.org/yarpc/yarpcerrors"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -112,6 +119,126 @@ func TestDispatcherRegisterPanic(t *testing.T) {
 	}, "expected unknown handler type to panic")
 }
 
+func TestDispatcherInboundConcurrencyPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		NewDispatcher(Config{
+			Name: "test",
+			InboundConcurrency: InboundConcurrencyConfig{
+				Default: &ConcurrencyLimit{MaxConcurrency: -1},
+			},
+		})
+	}, "expected to panic on an invalid concurrency limit")
+}
+
+func TestDispatcherInboundAuthorizationPanic(t *testing.T) {
+	assert.Panics(t, func() {
+		NewDispatcher(Config{
+			Name: "test",
+			InboundAuthorization: InboundAuthorizationConfig{
+				Rules: []AuthorizationRule{{Service: "test"}},
+			},
+		})
+	}, "expected to panic on a rule that admits nobody")
+}
+
+func TestInboundAuthorization(t *testing.T) {
+	d := NewDispatcher(Config{
+		Name: "test",
+		InboundAuthorization: InboundAuthorizationConfig{
+			Rules: []AuthorizationRule{
+				{Procedures: []string{"echo"}, SPIFFEIDs: []string{"spiffe://example.org/*"}},
+			},
+		},
+	})
+	d.Register([]transport.Procedure{
+		{Name: "echo", HandlerSpec: transport.NewUnaryHandlerSpec(transporttest.EchoHandler{})},
+	})
+
+	call := func(id *transport.PeerIdentity) error {
+		req := &transport.Request{
+			Service:      "test",
+			Procedure:    "echo",
+			Caller:       "caller",
+			Encoding:     "raw",
+			PeerIdentity: id,
+			Body:         strings.NewReader("hello"),
+		}
+		spec, err := d.Router().Choose(context.Background(), req)
+		require.NoError(t, err)
+		return spec.Unary().Handle(context.Background(), req, new(transporttest.FakeResponseWriter))
+	}
+
+	assert.NoError(t, call(&transport.PeerIdentity{SPIFFEID: "spiffe://example.org/caller"}))
+
+	err := call(&transport.PeerIdentity{SPIFFEID: "spiffe://other.org/caller"})
+	assert.Equal(t, yarpcerrors.CodePermissionDenied, yarpcerrors.FromError(err).Code())
+
+	err = call(nil)
+	assert.Equal(t, yarpcerrors.CodeUnauthenticated, yarpcerrors.FromError(err).Code())
+}
+
+func TestFaultInjection(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Transports().AnyTimes()
+
+	injector, err := faultinjection.New(faultinjection.Config{
+		Enabled: true,
+		Rules: []faultinjection.Rule{
+			{Direction: faultinjection.Inbound, Procedure: "echo", Percentage: 100, Abort: yarpcerrors.CodeInternal},
+			{Direction: faultinjection.Outbound, Service: "other", Percentage: 100, Abort: yarpcerrors.CodeUnavailable},
+		},
+	})
+	require.NoError(t, err)
+
+	d := NewDispatcher(Config{
+		Name:           "test",
+		Outbounds:      Outbounds{"other": {Unary: out}},
+		FaultInjection: injector,
+	})
+	assert.Equal(t, injector, d.FaultInjector())
+	d.Register([]transport.Procedure{
+		{Name: "echo", HandlerSpec: transport.NewUnaryHandlerSpec(transporttest.EchoHandler{})},
+	})
+
+	handle := func() error {
+		req := &transport.Request{
+			Service:   "test",
+			Procedure: "echo",
+			Caller:    "caller",
+			Encoding:  "raw",
+			Body:      strings.NewReader("hello"),
+		}
+		spec, err := d.Router().Choose(context.Background(), req)
+		require.NoError(t, err)
+		return spec.Unary().Handle(context.Background(), req, new(transporttest.FakeResponseWriter))
+	}
+	call := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+		defer cancel()
+		_, err := d.ClientConfig("other").GetUnaryOutbound().Call(ctx, &transport.Request{
+			Service:   "other",
+			Procedure: "echo",
+			Caller:    "test",
+			Encoding:  "raw",
+			Body:      strings.NewReader("hello"),
+		})
+		return err
+	}
+
+	err = handle()
+	assert.Equal(t, yarpcerrors.CodeInternal, yarpcerrors.FromError(err).Code())
+	err = call()
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+	d.FaultInjector().Disable()
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+	assert.NoError(t, handle())
+	assert.NoError(t, call())
+
+	assert.Nil(t, NewDispatcher(Config{Name: "test"}).FaultInjector())
+}
+
 func TestInboundsReturnsACopy(t *testing.T) {
 	dispatcher := basicDispatcher(t)
 
@@ -503,6 +630,221 @@ func TestPhasedStartStop(t *testing.T) {
 	})
 }
 
+func TestHealthStatus(t *testing.T) {
+	checkStatus := func(t *testing.T, d *Dispatcher, service string, want health.Status) {
+		t.Helper()
+		got, err := d.Health().Check(service)
+		require.NoError(t, err, "checking service %q failed", service)
+		assert.Equal(t, want, got, "unexpected status for service %q", service)
+	}
+
+	d := NewDispatcher(outboundConfig(t))
+	d.Register([]transport.Procedure{
+		{Name: "foo", Service: "other", HandlerSpec: transport.NewUnaryHandlerSpec(transporttest.EchoHandler{})},
+	})
+	checkStatus(t, d, "test", health.NotServing)
+	checkStatus(t, d, "other", health.NotServing)
+
+	starter, err := d.PhasedStart()
+	require.NoError(t, err, "constructing phased starter failed")
+	require.NoError(t, starter.StartTransports(), "starting transports failed")
+	require.NoError(t, starter.StartOutbounds(), "starting outbounds failed")
+	checkStatus(t, d, "", health.NotServing)
+	require.NoError(t, starter.StartInbounds(), "starting inbounds failed")
+	checkStatus(t, d, "", health.Serving)
+	checkStatus(t, d, "test", health.Serving)
+	checkStatus(t, d, "other", health.Serving)
+
+	d.Health().SetServingStatus("other", health.NotServing)
+	checkStatus(t, d, "other", health.NotServing)
+	checkStatus(t, d, "test", health.Serving)
+
+	stopper, err := d.PhasedStop()
+	require.NoError(t, err, "constructing phased stopper failed")
+	require.NoError(t, stopper.StopInbounds(), "stopping inbounds failed")
+	checkStatus(t, d, "", health.NotServing)
+	checkStatus(t, d, "test", health.NotServing)
+	require.NoError(t, stopper.StopOutbounds(), "stopping outbounds failed")
+	require.NoError(t, stopper.StopTransports(), "stopping transports failed")
+}
+
+func TestHealthService(t *testing.T) {
+	hasHealthProcedures := func(d *Dispatcher) bool {
+		for _, p := range d.Router().Procedures() {
+			if health.IsProcedure(p.Name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, hasHealthProcedures(NewDispatcher(basicConfig(t))))
+
+	cfg := basicConfig(t)
+	cfg.DisableHealthService = true
+	assert.False(t, hasHealthProcedures(NewDispatcher(cfg)))
+}
+
+// drainableInbound records whether the dispatcher drained it.
+type drainableInbound struct {
+	transport.Inbound
+
+	drained atomic.Bool
+}
+
+func (i *drainableInbound) Drain() { i.drained.Store(true) }
+
+func TestDrainInbounds(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	in := transporttest.NewMockInbound(mockCtrl)
+	in.EXPECT().Transports()
+	in.EXPECT().SetRouter(gomock.Any())
+	in.EXPECT().Start().Return(nil)
+	in.EXPECT().Stop().Return(nil)
+	inbound := &drainableInbound{Inbound: in}
+
+	d := NewDispatcher(Config{
+		Name:     "test",
+		Inbounds: Inbounds{inbound},
+		Drain:    DrainConfig{GracePeriod: 200 * time.Millisecond},
+	})
+	d.Register([]transport.Procedure{
+		{Name: "echo", HandlerSpec: transport.NewUnaryHandlerSpec(transporttest.EchoHandler{})},
+	})
+	require.NoError(t, d.Start())
+
+	call := func() (*transporttest.FakeResponseWriter, error) {
+		req := &transport.Request{
+			Service:   "test",
+			Procedure: "echo",
+			Caller:    "caller",
+			Encoding:  "raw",
+			Body:      strings.NewReader("hello"),
+		}
+		spec, err := d.Router().Choose(context.Background(), req)
+		require.NoError(t, err)
+		resw := new(transporttest.FakeResponseWriter)
+		return resw, spec.Unary().Handle(context.Background(), req, resw)
+	}
+
+	resw, err := call()
+	require.NoError(t, err)
+	_, ok := resw.Headers.Get(DrainingHeader)
+	assert.False(t, ok, "responses must not be marked before draining")
+
+	stopper, err := d.PhasedStop()
+	require.NoError(t, err)
+	drained := make(chan error)
+	go func() { drained <- stopper.DrainInbounds() }()
+
+	// During the grace period, requests are served but marked, and health
+	// checkers see that the service is not serving.
+	require.Eventually(t, inbound.drained.Load, testtime.Second, 10*time.Millisecond,
+		"inbound must be drained")
+	status, err := d.Health().Check("test")
+	require.NoError(t, err)
+	assert.Equal(t, health.NotServing, status)
+	resw, err = call()
+	require.NoError(t, err)
+	value, _ := resw.Headers.Get(DrainingHeader)
+	assert.Equal(t, "true", value)
+
+	assert.Error(t, stopper.StopInbounds(), "succeeded stopping inbounds while draining")
+	assert.Error(t, stopper.DrainInbounds(), "succeeded draining inbounds again")
+
+	// After the grace period, new requests are rejected.
+	require.NoError(t, <-drained)
+	_, err = call()
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+	require.NoError(t, stopper.StopInbounds())
+	assert.Error(t, stopper.DrainInbounds(), "succeeded draining inbounds after stopping them")
+	require.NoError(t, stopper.StopOutbounds())
+	require.NoError(t, stopper.StopTransports())
+}
+
+func TestStopInboundsEndsWatchesOpenedWhileDraining(t *testing.T) {
+	watchDone := make(chan error, 1)
+	mockCtrl := gomock.NewController(t)
+	in := transporttest.NewMockInbound(mockCtrl)
+	in.EXPECT().Transports()
+	in.EXPECT().SetRouter(gomock.Any())
+	in.EXPECT().Start().Return(nil)
+	// Like the inbounds that stop gracefully, the inbound waits for open
+	// streams to end before it stops.
+	in.EXPECT().Stop().DoAndReturn(func() error {
+		select {
+		case <-watchDone:
+			return nil
+		case <-time.After(testtime.Second):
+			return errors.New("health watch is still open")
+		}
+	})
+
+	d := NewDispatcher(Config{
+		Name:     "test",
+		Inbounds: Inbounds{in},
+		Drain:    DrainConfig{GracePeriod: 10 * time.Millisecond},
+	})
+	require.NoError(t, d.Start())
+
+	stopper, err := d.PhasedStop()
+	require.NoError(t, err)
+	require.NoError(t, stopper.DrainInbounds())
+
+	// The watch outlives the inbound's wait unless the dispatcher ends it.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*testtime.Second)
+	defer cancel()
+	meta := &transport.RequestMeta{
+		Caller:    "caller",
+		Service:   "test",
+		Procedure: health.WatchProcedure,
+		Encoding:  "json",
+	}
+	spec, err := d.Router().Choose(ctx, meta.ToRequest())
+	require.NoError(t, err)
+	client, server, finish, err := transporttest.MessagePipe(ctx, &transport.StreamRequest{Meta: meta})
+	require.NoError(t, err)
+	go func() {
+		err := spec.Stream().HandleStream(server)
+		finish(err)
+		watchDone <- err
+	}()
+	body := []byte(`{}`)
+	require.NoError(t, client.SendMessage(ctx, &transport.StreamMessage{
+		Body:     io.NopCloser(bytes.NewReader(body)),
+		BodySize: len(body),
+	}))
+	msg, err := client.ReceiveMessage(ctx)
+	require.NoError(t, err)
+	b, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"NOT_SERVING"}`, string(b))
+	go func() {
+		for {
+			if _, err := client.ReceiveMessage(ctx); err != nil {
+				return
+			}
+		}
+	}()
+
+	require.NoError(t, stopper.StopInbounds(), "health watches must not hold up stopping inbounds")
+	require.NoError(t, stopper.StopOutbounds())
+	require.NoError(t, stopper.StopTransports())
+}
+
+func TestStopDrainsInbounds(t *testing.T) {
+	cfg := basicConfig(t)
+	cfg.Drain.GracePeriod = 10 * time.Millisecond
+	d := NewDispatcher(cfg)
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Stop())
+
+	status, err := d.Health().Check("test")
+	require.NoError(t, err)
+	assert.Equal(t, health.NotServing, status)
+}
+
 func TestPhasedStartRaces(t *testing.T) {
 	d := NewDispatcher(outboundConfig(t))
 	starter, err := d.PhasedStart()
@@ -855,7 +1197,9 @@ func TestIntrospect(t *testing.T) {
 
 	require.Equal(t, config.Name, dispatcherStatus.Name)
 	require.NotEmpty(t, dispatcherStatus.ID)
-	require.Empty(t, dispatcherStatus.Procedures)
+	for _, p := range dispatcherStatus.Procedures {
+		assert.True(t, health.IsProcedure(p.Name), "unexpected procedure %q", p.Name)
+	}
 	require.Len(t, dispatcherStatus.Inbounds, 3)
 	require.Len(t, dispatcherStatus.Outbounds, 4)
 
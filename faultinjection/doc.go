@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package faultinjection provides middleware that injects latency and
+// failures into a fraction of requests, to exercise how services cope with
+// slow or failing dependencies.
+//
+// An Injector holds a list of rules. Each rule selects requests by
+// direction, service, procedure, caller and headers, and applies its faults
+// to a percentage of them:
+//
+//	injector, err := faultinjection.New(faultinjection.Config{
+//		Enabled: true,
+//		Rules: []faultinjection.Rule{
+//			{
+//				Direction:  faultinjection.Outbound,
+//				Service:    "keyvalue",
+//				Procedure:  "KeyValue::*",
+//				Percentage: 5,
+//				Delay:      200 * time.Millisecond,
+//				Abort:      yarpcerrors.CodeUnavailable,
+//			},
+//		},
+//	})
+//
+// Install the Injector as inbound and outbound middleware, most easily
+// through the FaultInjection option of yarpc.Config, which places it
+// nearest to the handlers and transports so that injected faults look like
+// genuine ones to all other middleware.
+//
+// Injectors may be enabled, disabled and given new rules while requests
+// are in flight, so faults can be turned on in a running deployment without
+// a special build.
+//
+//	injector.Disable()
+package faultinjection
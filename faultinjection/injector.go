@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package faultinjection
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ middleware.UnaryInbound   = (*Injector)(nil)
+	_ middleware.OnewayInbound  = (*Injector)(nil)
+	_ middleware.StreamInbound  = (*Injector)(nil)
+	_ middleware.UnaryOutbound  = (*Injector)(nil)
+	_ middleware.OnewayOutbound = (*Injector)(nil)
+	_ middleware.StreamOutbound = (*Injector)(nil)
+)
+
+// Injector is inbound and outbound middleware that injects faults into
+// requests according to its rules. It is safe to enable, disable and
+// reconfigure an Injector while requests are in flight.
+type Injector struct {
+	enabled atomic.Bool
+	rules   atomic.Pointer[[]Rule]
+
+	// random returns a number in [0, 100).
+	random func() float64
+}
+
+// New builds an Injector from the given configuration.
+func New(cfg Config) (*Injector, error) {
+	i := &Injector{random: func() float64 { return rand.Float64() * 100 }}
+	if err := i.SetRules(cfg.Rules); err != nil {
+		return nil, err
+	}
+	i.enabled.Store(cfg.Enabled)
+	return i, nil
+}
+
+// Enable starts injecting faults.
+func (i *Injector) Enable() {
+	i.enabled.Store(true)
+}
+
+// Disable stops injecting faults. Requests that are already delayed are not
+// affected.
+func (i *Injector) Disable() {
+	i.enabled.Store(false)
+}
+
+// Enabled reports whether faults are being injected.
+func (i *Injector) Enabled() bool {
+	return i.enabled.Load()
+}
+
+// SetRules replaces the rules of the Injector. The rules are left unchanged
+// if any of the new rules is invalid.
+func (i *Injector) SetRules(rules []Rule) error {
+	if err := (Config{Rules: rules}).Validate(); err != nil {
+		return err
+	}
+	rules = append([]Rule(nil), rules...)
+	i.rules.Store(&rules)
+	return nil
+}
+
+// Rules returns the rules of the Injector.
+func (i *Injector) Rules() []Rule {
+	return append([]Rule(nil), *i.rules.Load()...)
+}
+
+// rule returns the rule whose faults apply to the request, or nil if no
+// faults apply.
+func (i *Injector) rule(dir Direction, req *transport.RequestMeta) *Rule {
+	rules := *i.rules.Load()
+	for idx := range rules {
+		r := &rules[idx]
+		if r.selects(dir, req) && i.random() < r.Percentage {
+			return r
+		}
+	}
+	return nil
+}
+
+// active reports whether faults may apply to any request.
+func (i *Injector) active() bool {
+	return i.enabled.Load() && len(*i.rules.Load()) > 0
+}
+
+// inject applies the delay and abort of a rule to a request. It returns an
+// error if the request must fail before it proceeds.
+func inject(ctx context.Context, r *Rule, req *transport.RequestMeta) error {
+	if r.Delay > 0 {
+		timer := time.NewTimer(r.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return yarpcerrors.DeadlineExceededErrorf(
+					"deadline exceeded during injected delay for procedure %q of service %q",
+					req.Procedure, req.Service)
+			}
+			return yarpcerrors.CancelledErrorf(
+				"request cancelled during injected delay for procedure %q of service %q",
+				req.Procedure, req.Service)
+		}
+	}
+	if r.Abort != yarpcerrors.CodeOK {
+		return yarpcerrors.Newf(r.Abort,
+			"injected fault for procedure %q of service %q", req.Procedure, req.Service)
+	}
+	return nil
+}
+
+func disconnectError(req *transport.RequestMeta) error {
+	return yarpcerrors.UnavailableErrorf(
+		"injected connection failure for procedure %q of service %q", req.Procedure, req.Service)
+}
+
+// Handle implements middleware.UnaryInbound.
+func (i *Injector) Handle(ctx context.Context, req *transport.Request, rw transport.ResponseWriter, h transport.UnaryHandler) error {
+	if !i.active() {
+		return h.Handle(ctx, req, rw)
+	}
+	meta := req.ToRequestMeta()
+	r := i.rule(Inbound, meta)
+	if r == nil {
+		return h.Handle(ctx, req, rw)
+	}
+	if err := inject(ctx, r, meta); err != nil {
+		return err
+	}
+	if r.Disconnect {
+		_ = h.Handle(ctx, req, discardResponseWriter{})
+		return disconnectError(meta)
+	}
+	return h.Handle(ctx, req, rw)
+}
+
+// HandleOneway implements middleware.OnewayInbound.
+func (i *Injector) HandleOneway(ctx context.Context, req *transport.Request, h transport.OnewayHandler) error {
+	if !i.active() {
+		return h.HandleOneway(ctx, req)
+	}
+	meta := req.ToRequestMeta()
+	r := i.rule(Inbound, meta)
+	if r == nil {
+		return h.HandleOneway(ctx, req)
+	}
+	if err := inject(ctx, r, meta); err != nil {
+		return err
+	}
+	err := h.HandleOneway(ctx, req)
+	if r.Disconnect {
+		return disconnectError(meta)
+	}
+	return err
+}
+
+// HandleStream implements middleware.StreamInbound.
+func (i *Injector) HandleStream(s *transport.ServerStream, h transport.StreamHandler) error {
+	if !i.active() {
+		return h.HandleStream(s)
+	}
+	meta := s.Request().Meta
+	r := i.rule(Inbound, meta)
+	if r == nil {
+		return h.HandleStream(s)
+	}
+	if err := inject(s.Context(), r, meta); err != nil {
+		return err
+	}
+	if r.Disconnect {
+		return disconnectError(meta)
+	}
+	return h.HandleStream(s)
+}
+
+// Call implements middleware.UnaryOutbound.
+func (i *Injector) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	if !i.active() {
+		return out.Call(ctx, req)
+	}
+	meta := req.ToRequestMeta()
+	r := i.rule(Outbound, meta)
+	if r == nil {
+		return out.Call(ctx, req)
+	}
+	if err := inject(ctx, r, meta); err != nil {
+		return nil, err
+	}
+	res, err := out.Call(ctx, req)
+	if r.Disconnect {
+		if err == nil && res.Body != nil {
+			_ = res.Body.Close()
+		}
+		return nil, disconnectError(meta)
+	}
+	return res, err
+}
+
+// CallOneway implements middleware.OnewayOutbound.
+func (i *Injector) CallOneway(ctx context.Context, req *transport.Request, out transport.OnewayOutbound) (transport.Ack, error) {
+	if !i.active() {
+		return out.CallOneway(ctx, req)
+	}
+	meta := req.ToRequestMeta()
+	r := i.rule(Outbound, meta)
+	if r == nil {
+		return out.CallOneway(ctx, req)
+	}
+	if err := inject(ctx, r, meta); err != nil {
+		return nil, err
+	}
+	ack, err := out.CallOneway(ctx, req)
+	if r.Disconnect {
+		return nil, disconnectError(meta)
+	}
+	return ack, err
+}
+
+// CallStream implements middleware.StreamOutbound.
+func (i *Injector) CallStream(ctx context.Context, req *transport.StreamRequest, out transport.StreamOutbound) (*transport.ClientStream, error) {
+	if !i.active() {
+		return out.CallStream(ctx, req)
+	}
+	r := i.rule(Outbound, req.Meta)
+	if r == nil {
+		return out.CallStream(ctx, req)
+	}
+	if err := inject(ctx, r, req.Meta); err != nil {
+		return nil, err
+	}
+	if r.Disconnect {
+		return nil, disconnectError(req.Meta)
+	}
+	return out.CallStream(ctx, req)
+}
+
+// discardResponseWriter drops the response of a handler whose caller is made
+// to believe the connection was lost.
+type discardResponseWriter struct{}
+
+func (discardResponseWriter) Write(p []byte) (int, error)                             { return len(p), nil }
+func (discardResponseWriter) AddHeaders(transport.Headers)                            {}
+func (discardResponseWriter) SetApplicationError()                                    {}
+func (discardResponseWriter) SetApplicationErrorMeta(*transport.ApplicationErrorMeta) {}
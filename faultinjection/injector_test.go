@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package faultinjection
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func newTestInjector(t *testing.T, roll float64, rules ...Rule) *Injector {
+	i, err := New(Config{Enabled: true, Rules: rules})
+	require.NoError(t, err)
+	i.random = func() float64 { return roll }
+	return i
+}
+
+func newRequest() *transport.Request {
+	return &transport.Request{
+		Caller:    "caller",
+		Service:   "service",
+		Procedure: "proc",
+		Encoding:  "raw",
+	}
+}
+
+func TestInjectorConfig(t *testing.T) {
+	_, err := New(Config{Rules: []Rule{{}}})
+	assert.Error(t, err)
+
+	rules := []Rule{{Percentage: 10, Abort: yarpcerrors.CodeInternal}}
+	i, err := New(Config{Rules: rules})
+	require.NoError(t, err)
+	assert.False(t, i.Enabled(), "injectors must be disabled by default")
+	assert.Equal(t, rules, i.Rules())
+
+	i.Enable()
+	assert.True(t, i.Enabled())
+	i.Disable()
+	assert.False(t, i.Enabled())
+
+	assert.Error(t, i.SetRules([]Rule{{Percentage: 10}}))
+	assert.Equal(t, rules, i.Rules(), "invalid rules must not replace the rules")
+
+	require.NoError(t, i.SetRules(nil))
+	assert.Empty(t, i.Rules())
+}
+
+func TestInjectorPercentage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	ctx := context.Background()
+
+	i := newTestInjector(t, 50,
+		Rule{Percentage: 50, Abort: yarpcerrors.CodeInternal},
+		Rule{Percentage: 50.5, Abort: yarpcerrors.CodeResourceExhausted},
+	)
+	_, err := i.Call(ctx, newRequest(), out)
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code(),
+		"the first rule triggered by its percentage must apply")
+
+	i.random = func() float64 { return 99 }
+	out.EXPECT().Call(ctx, gomock.Any()).Return(&transport.Response{}, nil)
+	_, err = i.Call(ctx, newRequest(), out)
+	assert.NoError(t, err)
+
+	i.random = func() float64 { return 0 }
+	i.Disable()
+	out.EXPECT().Call(ctx, gomock.Any()).Return(&transport.Response{}, nil)
+	_, err = i.Call(ctx, newRequest(), out)
+	assert.NoError(t, err, "disabled injectors must not inject faults")
+}
+
+func TestInjectorDelay(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	h := transporttest.NewMockUnaryHandler(mockCtrl)
+
+	i := newTestInjector(t, 0, Rule{Percentage: 100, Delay: 20 * time.Millisecond})
+
+	h.EXPECT().Handle(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	start := time.Now()
+	require.NoError(t, i.Handle(context.Background(), newRequest(), &transporttest.FakeResponseWriter{}, h))
+	assert.True(t, time.Since(start) >= 20*time.Millisecond, "request must be delayed")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := i.Handle(ctx, newRequest(), &transporttest.FakeResponseWriter{}, h)
+	assert.Equal(t, yarpcerrors.CodeDeadlineExceeded, yarpcerrors.FromError(err).Code())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = i.Handle(ctx, newRequest(), &transporttest.FakeResponseWriter{}, h)
+	assert.Equal(t, yarpcerrors.CodeCancelled, yarpcerrors.FromError(err).Code())
+}
+
+func TestInjectorInbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	abort := newTestInjector(t, 0, Rule{Direction: Inbound, Percentage: 100, Abort: yarpcerrors.CodeUnavailable})
+	disconnect := newTestInjector(t, 0, Rule{Direction: Inbound, Percentage: 100, Disconnect: true})
+	outboundOnly := newTestInjector(t, 0, Rule{Direction: Outbound, Percentage: 100, Disconnect: true})
+
+	t.Run("unary", func(t *testing.T) {
+		h := transporttest.NewMockUnaryHandler(mockCtrl)
+
+		err := abort.Handle(ctx, newRequest(), &transporttest.FakeResponseWriter{}, h)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+		assert.Contains(t, err.Error(), `injected fault for procedure "proc" of service "service"`)
+
+		rw := &transporttest.FakeResponseWriter{}
+		h.EXPECT().Handle(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *transport.Request, rw transport.ResponseWriter) error {
+				rw.AddHeaders(transport.NewHeaders().With("foo", "bar"))
+				_, err := rw.Write([]byte("response"))
+				return err
+			})
+		err = disconnect.Handle(ctx, newRequest(), rw, h)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+		assert.Contains(t, err.Error(), "injected connection failure")
+		assert.Empty(t, rw.Body.String(), "response must be discarded")
+		assert.Equal(t, 0, rw.Headers.Len())
+
+		h.EXPECT().Handle(ctx, gomock.Any(), rw).Return(nil)
+		assert.NoError(t, outboundOnly.Handle(ctx, newRequest(), rw, h))
+	})
+
+	t.Run("oneway", func(t *testing.T) {
+		h := transporttest.NewMockOnewayHandler(mockCtrl)
+
+		err := abort.HandleOneway(ctx, newRequest(), h)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+		h.EXPECT().HandleOneway(ctx, gomock.Any()).Return(nil)
+		err = disconnect.HandleOneway(ctx, newRequest(), h)
+		assert.Contains(t, err.Error(), "injected connection failure")
+
+		h.EXPECT().HandleOneway(ctx, gomock.Any()).Return(nil)
+		assert.NoError(t, outboundOnly.HandleOneway(ctx, newRequest(), h))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		h := transporttest.NewMockStreamHandler(mockCtrl)
+		mockStream := transporttest.NewMockStream(mockCtrl)
+		mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+		mockStream.EXPECT().Request().Return(&transport.StreamRequest{Meta: newRequest().ToRequestMeta()}).AnyTimes()
+		stream, err := transport.NewServerStream(mockStream)
+		require.NoError(t, err)
+
+		err = abort.HandleStream(stream, h)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+		err = disconnect.HandleStream(stream, h)
+		assert.Contains(t, err.Error(), "injected connection failure")
+
+		h.EXPECT().HandleStream(stream).Return(nil)
+		assert.NoError(t, outboundOnly.HandleStream(stream, h))
+	})
+}
+
+func TestInjectorOutbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	abort := newTestInjector(t, 0, Rule{Direction: Outbound, Percentage: 100, Abort: yarpcerrors.CodeAborted})
+	disconnect := newTestInjector(t, 0, Rule{Direction: Outbound, Percentage: 100, Disconnect: true})
+	inboundOnly := newTestInjector(t, 0, Rule{Direction: Inbound, Percentage: 100, Disconnect: true})
+
+	t.Run("unary", func(t *testing.T) {
+		out := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+		_, err := abort.Call(ctx, newRequest(), out)
+		assert.Equal(t, yarpcerrors.CodeAborted, yarpcerrors.FromError(err).Code())
+
+		body := &closeRecorder{Reader: bytes.NewReader(nil)}
+		out.EXPECT().Call(ctx, gomock.Any()).Return(&transport.Response{Body: body}, nil)
+		res, err := disconnect.Call(ctx, newRequest(), out)
+		assert.Nil(t, res)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+		assert.True(t, body.closed, "discarded response body must be closed")
+
+		out.EXPECT().Call(ctx, gomock.Any()).Return(&transport.Response{}, nil)
+		_, err = inboundOnly.Call(ctx, newRequest(), out)
+		assert.NoError(t, err)
+	})
+
+	t.Run("oneway", func(t *testing.T) {
+		out := transporttest.NewMockOnewayOutbound(mockCtrl)
+
+		_, err := abort.CallOneway(ctx, newRequest(), out)
+		assert.Equal(t, yarpcerrors.CodeAborted, yarpcerrors.FromError(err).Code())
+
+		out.EXPECT().CallOneway(ctx, gomock.Any()).Return(nil, nil)
+		_, err = disconnect.CallOneway(ctx, newRequest(), out)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+		out.EXPECT().CallOneway(ctx, gomock.Any()).Return(nil, nil)
+		_, err = inboundOnly.CallOneway(ctx, newRequest(), out)
+		assert.NoError(t, err)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		out := transporttest.NewMockStreamOutbound(mockCtrl)
+		req := &transport.StreamRequest{Meta: newRequest().ToRequestMeta()}
+
+		_, err := abort.CallStream(ctx, req, out)
+		assert.Equal(t, yarpcerrors.CodeAborted, yarpcerrors.FromError(err).Code())
+
+		_, err = disconnect.CallStream(ctx, req, out)
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+		out.EXPECT().CallStream(ctx, req).Return(nil, nil)
+		_, err = inboundOnly.CallStream(ctx, req, out)
+		assert.NoError(t, err)
+	})
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
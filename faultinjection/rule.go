@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package faultinjection
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Direction selects whether a rule applies to requests a service receives,
+// requests it makes, or both.
+type Direction string
+
+const (
+	// Inbound selects requests received by the service.
+	Inbound Direction = "inbound"
+
+	// Outbound selects requests made by the service.
+	Outbound Direction = "outbound"
+)
+
+// Config configures an Injector.
+type Config struct {
+	// Enabled specifies whether faults are injected. Injectors are disabled
+	// by default so that configuring rules is not enough to affect traffic.
+	Enabled bool
+
+	// Rules lists the fault injection rules. The first rule that selects a
+	// request and is triggered by its percentage applies.
+	Rules []Rule
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	for i, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid fault injection rule %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Rule injects faults into a percentage of the requests it selects.
+//
+// Service, procedure and caller patterns use the syntax of path.Match, so
+// "KeyValue::*" matches every procedure of the KeyValue service.
+type Rule struct {
+	// Direction selects inbound or outbound requests. The rule applies to
+	// both if this is empty.
+	Direction Direction
+
+	// Service, Procedure and Caller select requests by the service they are
+	// made to, their procedure, and the service making them. Empty patterns
+	// select all requests.
+	Service   string
+	Procedure string
+	Caller    string
+
+	// Headers selects requests carrying all of the given header values.
+	// This allows faults to be limited to requests that opt in to them.
+	Headers map[string]string
+
+	// Percentage of selected requests the faults apply to, greater than 0
+	// and at most 100.
+	Percentage float64
+
+	// Delay delays requests by the given duration before they proceed or
+	// fail. Requests whose deadline passes first fail with a
+	// DeadlineExceeded error.
+	Delay time.Duration
+
+	// Abort fails requests with the given code without handling or sending
+	// them.
+	Abort yarpcerrors.Code
+
+	// Disconnect fails requests with an Unavailable error after they have
+	// been handled or sent, as if the connection was lost before the
+	// response arrived. Streams fail before they are opened.
+	Disconnect bool
+}
+
+// Validate returns an error if the rule is invalid.
+func (r Rule) Validate() error {
+	switch r.Direction {
+	case "", Inbound, Outbound:
+	default:
+		return fmt.Errorf("unknown direction %q", r.Direction)
+	}
+
+	for _, pattern := range []string{r.Service, r.Procedure, r.Caller} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+
+	if r.Percentage <= 0 || r.Percentage > 100 {
+		return fmt.Errorf("percentage must be greater than 0 and at most 100, got %v", r.Percentage)
+	}
+	if r.Delay < 0 {
+		return fmt.Errorf("delay must not be negative, got %v", r.Delay)
+	}
+	if r.Abort != yarpcerrors.CodeOK && r.Disconnect {
+		return errors.New("only one of abort and disconnect may be specified")
+	}
+	if r.Delay == 0 && r.Abort == yarpcerrors.CodeOK && !r.Disconnect {
+		return errors.New("at least one of delay, abort or disconnect is required")
+	}
+	return nil
+}
+
+// selects reports whether the rule selects the request.
+func (r *Rule) selects(dir Direction, req *transport.RequestMeta) bool {
+	if r.Direction != "" && r.Direction != dir {
+		return false
+	}
+	if !matches(r.Service, req.Service) ||
+		!matches(r.Procedure, req.Procedure) ||
+		!matches(r.Caller, req.Caller) {
+		return false
+	}
+	for k, want := range r.Headers {
+		if got, ok := req.Headers.Get(k); !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether the name matches the pattern. Empty patterns match
+// all names. Patterns are validated ahead of time.
+func matches(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
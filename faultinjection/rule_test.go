@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package faultinjection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Rule
+		wantErr string
+	}{
+		{
+			desc: "delay",
+			give: Rule{Percentage: 100, Delay: time.Second},
+		},
+		{
+			desc: "abort",
+			give: Rule{Direction: Inbound, Service: "kv", Procedure: "KeyValue::*", Percentage: 0.5, Abort: yarpcerrors.CodeUnavailable},
+		},
+		{
+			desc: "delayed disconnect",
+			give: Rule{Direction: Outbound, Percentage: 1, Delay: time.Millisecond, Disconnect: true},
+		},
+		{
+			desc:    "unknown direction",
+			give:    Rule{Direction: "sideways", Percentage: 1, Disconnect: true},
+			wantErr: `unknown direction "sideways"`,
+		},
+		{
+			desc:    "bad pattern",
+			give:    Rule{Caller: "[", Percentage: 1, Disconnect: true},
+			wantErr: `invalid pattern "["`,
+		},
+		{
+			desc:    "no percentage",
+			give:    Rule{Disconnect: true},
+			wantErr: "percentage must be greater than 0 and at most 100, got 0",
+		},
+		{
+			desc:    "percentage too large",
+			give:    Rule{Percentage: 101, Disconnect: true},
+			wantErr: "percentage must be greater than 0 and at most 100, got 101",
+		},
+		{
+			desc:    "negative delay",
+			give:    Rule{Percentage: 1, Delay: -time.Second},
+			wantErr: "delay must not be negative",
+		},
+		{
+			desc:    "abort and disconnect",
+			give:    Rule{Percentage: 1, Abort: yarpcerrors.CodeInternal, Disconnect: true},
+			wantErr: "only one of abort and disconnect may be specified",
+		},
+		{
+			desc:    "no faults",
+			give:    Rule{Percentage: 1},
+			wantErr: "at least one of delay, abort or disconnect is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.give.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			err = Config{Rules: []Rule{{Percentage: 1, Disconnect: true}, tt.give}}.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid fault injection rule 1")
+		})
+	}
+}
+
+func TestRuleSelects(t *testing.T) {
+	req := &transport.RequestMeta{
+		Caller:    "frontend",
+		Service:   "kv",
+		Procedure: "KeyValue::get",
+		Headers:   transport.NewHeaders().With("X-Chaos", "on"),
+	}
+
+	tests := []struct {
+		desc string
+		give Rule
+		dir  Direction
+		want bool
+	}{
+		{desc: "everything", give: Rule{}, dir: Inbound, want: true},
+		{desc: "direction", give: Rule{Direction: Outbound}, dir: Outbound, want: true},
+		{desc: "other direction", give: Rule{Direction: Outbound}, dir: Inbound},
+		{desc: "service", give: Rule{Service: "kv"}, dir: Inbound, want: true},
+		{desc: "other service", give: Rule{Service: "users"}, dir: Inbound},
+		{desc: "procedure pattern", give: Rule{Procedure: "KeyValue::*"}, dir: Inbound, want: true},
+		{desc: "other procedure", give: Rule{Procedure: "KeyValue::set"}, dir: Inbound},
+		{desc: "caller", give: Rule{Caller: "front*"}, dir: Inbound, want: true},
+		{desc: "other caller", give: Rule{Caller: "batch"}, dir: Inbound},
+		{desc: "header", give: Rule{Headers: map[string]string{"x-chaos": "on"}}, dir: Inbound, want: true},
+		{desc: "header value", give: Rule{Headers: map[string]string{"x-chaos": "off"}}, dir: Inbound},
+		{desc: "missing header", give: Rule{Headers: map[string]string{"x-other": "on"}}, dir: Inbound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give.selects(tt.dir, req))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package health implements the standard gRPC health checking protocol,
+// grpc.health.v1.Health, for YARPC services.
+//
+// The dispatcher keeps a health server for its services, reports them as
+// serving once its inbounds have started, and reports them as not serving as
+// soon as it begins to stop. Services can change their own status through the
+// dispatcher's health server, for example while a dependency is unavailable.
+//
+//	dispatcher.Health().SetServingStatus("myservice", health.NotServing)
+//
+// The Check and Watch procedures of the health service are available on every
+// inbound. gRPC inbounds serve them to standard health checkers, like
+// grpc-health-probe and Kubernetes gRPC probes, which do not send YARPC
+// headers. HTTP and TChannel inbounds serve Check with the JSON and Protobuf
+// encodings, for example:
+//
+//	yab myservice grpc.health.v1.Health::Check -r '{"service": "myservice"}'
+//
+// The empty service name reports the status of the server as a whole.
+package health
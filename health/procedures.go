@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package health
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/pkg/errors"
+	pb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// CheckProcedure is the name of the procedure that reports the serving
+	// status of a service.
+	CheckProcedure = "grpc.health.v1.Health::Check"
+
+	// WatchProcedure is the name of the streaming procedure that reports the
+	// serving status of a service every time it changes.
+	WatchProcedure = "grpc.health.v1.Health::Watch"
+)
+
+const (
+	_protoEncoding transport.Encoding = "proto"
+	_jsonEncoding  transport.Encoding = "json"
+)
+
+// IsProcedure reports whether the procedure belongs to the health service.
+func IsProcedure(procedure string) bool {
+	return procedure == CheckProcedure || procedure == WatchProcedure
+}
+
+// Procedures returns the procedures of the health service, with the
+// Protobuf and JSON encodings, for the dispatcher's default service.
+func (s *Server) Procedures() []transport.Procedure {
+	var procedures []transport.Procedure
+	for _, encoding := range []transport.Encoding{_protoEncoding, _jsonEncoding} {
+		procedures = append(procedures,
+			transport.Procedure{
+				Name:        CheckProcedure,
+				Encoding:    encoding,
+				HandlerSpec: transport.NewUnaryHandlerSpec(checkHandler{s}),
+				Signature:   "Check(*grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse)",
+			},
+			transport.Procedure{
+				Name:        WatchProcedure,
+				Encoding:    encoding,
+				HandlerSpec: transport.NewStreamHandlerSpec(watchHandler{s}),
+				Signature:   "Watch(*grpc_health_v1.HealthCheckRequest) (stream *grpc_health_v1.HealthCheckResponse)",
+			},
+		)
+	}
+	return procedures
+}
+
+type checkHandler struct {
+	server *Server
+}
+
+func (h checkHandler) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	var in pb.HealthCheckRequest
+	if err := unmarshal(req.Encoding, req.Body, &in); err != nil {
+		return errors.RequestBodyDecodeError(req, err)
+	}
+
+	status, err := h.server.Check(in.Service)
+	if err != nil {
+		return err
+	}
+
+	body, err := marshal(req.Encoding, &pb.HealthCheckResponse{
+		Status: pb.HealthCheckResponse_ServingStatus(status),
+	})
+	if err != nil {
+		return errors.ResponseBodyEncodeError(req, err)
+	}
+	_, err = resw.Write(body)
+	return err
+}
+
+type watchHandler struct {
+	server *Server
+}
+
+func (h watchHandler) HandleStream(stream *transport.ServerStream) error {
+	ctx := stream.Context()
+	req := stream.Request().Meta.ToRequest()
+
+	msg, err := stream.ReceiveMessage(ctx)
+	if err != nil {
+		return err
+	}
+	defer msg.Body.Close()
+
+	var in pb.HealthCheckRequest
+	if err := unmarshal(req.Encoding, msg.Body, &in); err != nil {
+		return errors.RequestBodyDecodeError(req, err)
+	}
+
+	return h.server.watch(ctx, in.Service, func(status pb.HealthCheckResponse_ServingStatus) error {
+		body, err := marshal(req.Encoding, &pb.HealthCheckResponse{Status: status})
+		if err != nil {
+			return errors.ResponseBodyEncodeError(req, err)
+		}
+		return stream.SendMessage(ctx, &transport.StreamMessage{
+			Body:     io.NopCloser(bytes.NewReader(body)),
+			BodySize: len(body),
+		})
+	})
+}
+
+// ReadCheckResponse decodes the body of a response from the Check procedure,
+// sent with the given encoding, and returns the serving status it reports.
+func ReadCheckResponse(encoding transport.Encoding, body io.Reader) (Status, error) {
+	var out pb.HealthCheckResponse
+	if err := unmarshal(encoding, body, &out); err != nil {
+		return NotServing, err
+	}
+	return Status(out.Status), nil
+}
+
+func unmarshal(encoding transport.Encoding, r io.Reader, msg proto.Message) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if encoding == _jsonEncoding {
+		if len(body) == 0 {
+			return nil
+		}
+		return protojson.Unmarshal(body, msg)
+	}
+	return proto.Unmarshal(body, msg)
+}
+
+func marshal(encoding transport.Encoding, msg proto.Message) ([]byte, error) {
+	if encoding == _jsonEncoding {
+		return protojson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package health
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+	pb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIsProcedure(t *testing.T) {
+	assert.True(t, IsProcedure("grpc.health.v1.Health::Check"))
+	assert.True(t, IsProcedure("grpc.health.v1.Health::Watch"))
+	assert.False(t, IsProcedure("grpc.health.v1.Health::List"))
+}
+
+func TestProcedures(t *testing.T) {
+	var got []string
+	for _, p := range NewServer().Procedures() {
+		got = append(got, p.Name+" "+string(p.Encoding)+" "+p.HandlerSpec.Type().String())
+	}
+	assert.ElementsMatch(t, []string{
+		"grpc.health.v1.Health::Check proto Unary",
+		"grpc.health.v1.Health::Check json Unary",
+		"grpc.health.v1.Health::Watch proto Streaming",
+		"grpc.health.v1.Health::Watch json Streaming",
+	}, got)
+}
+
+func TestCheckHandler(t *testing.T) {
+	s := NewServer()
+	s.SetServingStatus("foo", NotServing)
+	h := checkHandler{s}
+
+	protoBody, err := proto.Marshal(&pb.HealthCheckRequest{Service: "foo"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		encoding transport.Encoding
+		body     []byte
+		want     string
+		wantCode yarpcerrors.Code
+	}{
+		{
+			desc:     "json",
+			encoding: "json",
+			body:     []byte(`{"service": "foo"}`),
+			want:     `{"status":"NOT_SERVING"}`,
+		},
+		{
+			desc:     "json empty body",
+			encoding: "json",
+			want:     `{"status":"SERVING"}`,
+		},
+		{
+			desc:     "proto",
+			encoding: "proto",
+			body:     protoBody,
+		},
+		{
+			desc:     "unknown service",
+			encoding: "json",
+			body:     []byte(`{"service": "bar"}`),
+			wantCode: yarpcerrors.CodeNotFound,
+		},
+		{
+			desc:     "invalid body",
+			encoding: "json",
+			body:     []byte(`{"service": 1}`),
+			wantCode: yarpcerrors.CodeInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			resw := new(transporttest.FakeResponseWriter)
+			err := h.Handle(context.Background(), &transport.Request{
+				Service:   "foo",
+				Procedure: CheckProcedure,
+				Encoding:  tt.encoding,
+				Body:      bytes.NewReader(tt.body),
+			}, resw)
+			if tt.wantCode != yarpcerrors.CodeOK {
+				assert.Equal(t, tt.wantCode, yarpcerrors.FromError(err).Code())
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.encoding == "proto" {
+				var res pb.HealthCheckResponse
+				require.NoError(t, proto.Unmarshal(resw.Body.Bytes(), &res))
+				assert.Equal(t, pb.HealthCheckResponse_NOT_SERVING, res.Status)
+				return
+			}
+			assert.JSONEq(t, tt.want, resw.Body.String())
+		})
+	}
+}
+
+func TestReadCheckResponse(t *testing.T) {
+	protoBody, err := proto.Marshal(&pb.HealthCheckResponse{Status: pb.HealthCheckResponse_SERVING})
+	require.NoError(t, err)
+
+	status, err := ReadCheckResponse("proto", bytes.NewReader(protoBody))
+	require.NoError(t, err)
+	assert.Equal(t, Serving, status)
+
+	status, err = ReadCheckResponse("json", bytes.NewReader([]byte(`{"status":"NOT_SERVING"}`)))
+	require.NoError(t, err)
+	assert.Equal(t, NotServing, status)
+
+	_, err = ReadCheckResponse("json", bytes.NewReader([]byte(`{"status":true}`)))
+	assert.Error(t, err)
+}
+
+func TestWatchHandler(t *testing.T) {
+	s := NewServer()
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	client, server, finish, err := transporttest.MessagePipe(ctx, &transport.StreamRequest{
+		Meta: &transport.RequestMeta{
+			Service:   "foo",
+			Procedure: WatchProcedure,
+			Encoding:  "json",
+		},
+	})
+	require.NoError(t, err)
+	go func() { finish(watchHandler{s}.HandleStream(server)) }()
+
+	body := []byte(`{}`)
+	require.NoError(t, client.SendMessage(ctx, &transport.StreamMessage{
+		Body:     io.NopCloser(bytes.NewReader(body)),
+		BodySize: len(body),
+	}))
+
+	receive := func() string {
+		msg, err := client.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		defer msg.Body.Close()
+		b, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	assert.JSONEq(t, `{"status":"SERVING"}`, receive())
+	s.Shutdown()
+	assert.JSONEq(t, `{"status":"NOT_SERVING"}`, receive())
+
+	_, err = client.ReceiveMessage(ctx)
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+}
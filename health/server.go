@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package health
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/yarpc/yarpcerrors"
+	pb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Status is the serving status of a service.
+type Status int32
+
+const (
+	// Serving indicates that a service is ready to handle requests.
+	Serving Status = Status(pb.HealthCheckResponse_SERVING)
+
+	// NotServing indicates that a service should not receive requests.
+	NotServing Status = Status(pb.HealthCheckResponse_NOT_SERVING)
+)
+
+// String returns the name of the status, as reported by the health service.
+func (s Status) String() string {
+	return pb.HealthCheckResponse_ServingStatus(s).String()
+}
+
+// Server tracks the serving status of services and reports them over the
+// grpc.health.v1.Health service.
+//
+// The server reports every service as not serving while it is shut down,
+// regardless of the status set for the service, and reports the status set
+// for each service again once resumed.
+type Server struct {
+	mu       sync.Mutex
+	shutdown bool
+	statuses map[string]Status
+	watchers map[string]map[*watcher]struct{}
+}
+
+// NewServer builds a new health server that reports the server as a whole,
+// with the empty service name, as serving.
+func NewServer() *Server {
+	return &Server{
+		statuses: map[string]Status{"": Serving},
+		watchers: make(map[string]map[*watcher]struct{}),
+	}
+}
+
+// SetServingStatus sets the serving status of a service, adding the service
+// to the server if it is not yet known.
+func (s *Server) SetServingStatus(service string, status Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statuses[service] = status
+	s.notify(service)
+}
+
+// Shutdown reports every service as not serving until the server resumes.
+//
+// Watches that are open when the server shuts down, or that open while it
+// is shut down, end with an Unavailable error after reporting that their
+// service is not serving, so that they do not hold up the graceful shutdown
+// of inbounds. Every call to Shutdown ends the watches open at the time.
+func (s *Server) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shutdown = true
+	for service, watchers := range s.watchers {
+		s.notify(service)
+		for w := range watchers {
+			w.end()
+		}
+	}
+}
+
+// Resume reports the serving status set for each service again.
+func (s *Server) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.shutdown {
+		return
+	}
+	s.shutdown = false
+	for service := range s.watchers {
+		s.notify(service)
+	}
+}
+
+// Check returns the reported serving status of a service, or a NotFound
+// error if the service is not known.
+func (s *Server) Check(service string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.statuses[service]; !ok {
+		return NotServing, yarpcerrors.NotFoundErrorf("unknown service %q", service)
+	}
+	return Status(s.current(service)), nil
+}
+
+// current returns the serving status to report for a service. The caller
+// must hold the lock.
+func (s *Server) current(service string) pb.HealthCheckResponse_ServingStatus {
+	status, ok := s.statuses[service]
+	switch {
+	case !ok:
+		return pb.HealthCheckResponse_SERVICE_UNKNOWN
+	case s.shutdown:
+		return pb.HealthCheckResponse_NOT_SERVING
+	default:
+		return pb.HealthCheckResponse_ServingStatus(status)
+	}
+}
+
+// notify sends the current status of a service to its watchers. The caller
+// must hold the lock.
+func (s *Server) notify(service string) {
+	status := s.current(service)
+	for w := range s.watchers[service] {
+		w.update(status)
+	}
+}
+
+// watch calls send with the reported serving status of a service, and again
+// every time the status changes, until the context is done, send fails, or
+// the server shuts down.
+func (s *Server) watch(ctx context.Context, service string, send func(pb.HealthCheckResponse_ServingStatus) error) error {
+	w := newWatcher()
+
+	s.mu.Lock()
+	if s.watchers[service] == nil {
+		s.watchers[service] = make(map[*watcher]struct{})
+	}
+	s.watchers[service][w] = struct{}{}
+	w.update(s.current(service))
+	if s.shutdown {
+		w.end()
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watchers[service], w)
+		if len(s.watchers[service]) == 0 {
+			delete(s.watchers, service)
+		}
+	}()
+
+	last := pb.HealthCheckResponse_ServingStatus(-1)
+	sendChanged := func(status pb.HealthCheckResponse_ServingStatus) error {
+		if status == last {
+			return nil
+		}
+		last = status
+		return send(status)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case status := <-w.updates:
+			if err := sendChanged(status); err != nil {
+				return err
+			}
+		case <-w.ended:
+			select {
+			case status := <-w.updates:
+				if err := sendChanged(status); err != nil {
+					return err
+				}
+			default:
+			}
+			return yarpcerrors.UnavailableErrorf("health server is shutting down")
+		}
+	}
+}
+
+// watcher receives the latest serving status of a service. Watchers that
+// fall behind skip intermediate statuses.
+type watcher struct {
+	updates chan pb.HealthCheckResponse_ServingStatus
+	ended   chan struct{}
+	ending  bool // guarded by the server's lock
+}
+
+func newWatcher() *watcher {
+	return &watcher{
+		updates: make(chan pb.HealthCheckResponse_ServingStatus, 1),
+		ended:   make(chan struct{}),
+	}
+}
+
+// end tells the watcher to stop after its pending update. The caller must
+// hold the server's lock.
+func (w *watcher) end() {
+	if !w.ending {
+		w.ending = true
+		close(w.ended)
+	}
+}
+
+func (w *watcher) update(status pb.HealthCheckResponse_ServingStatus) {
+	// Replace any status the watcher has not received yet.
+	select {
+	case <-w.updates:
+	default:
+	}
+	w.updates <- status
+}
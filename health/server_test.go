@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+	pb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "SERVING", Serving.String())
+	assert.Equal(t, "NOT_SERVING", NotServing.String())
+}
+
+func TestServerCheck(t *testing.T) {
+	s := NewServer()
+
+	status, err := s.Check("")
+	require.NoError(t, err)
+	assert.Equal(t, Serving, status, "the server as a whole must be serving")
+
+	_, err = s.Check("foo")
+	assert.Equal(t, yarpcerrors.CodeNotFound, yarpcerrors.FromError(err).Code())
+
+	s.SetServingStatus("foo", NotServing)
+	status, err = s.Check("foo")
+	require.NoError(t, err)
+	assert.Equal(t, NotServing, status)
+
+	s.SetServingStatus("foo", Serving)
+	s.Shutdown()
+	for _, service := range []string{"", "foo"} {
+		status, err = s.Check(service)
+		require.NoError(t, err)
+		assert.Equal(t, NotServing, status, "service %q must not be serving while shut down", service)
+	}
+
+	s.Resume()
+	status, err = s.Check("foo")
+	require.NoError(t, err)
+	assert.Equal(t, Serving, status, "service must report its own status once resumed")
+}
+
+// watchStatuses watches a service in the background, collecting the statuses
+// it receives.
+type watchStatuses struct {
+	statuses chan pb.HealthCheckResponse_ServingStatus
+	err      chan error
+}
+
+func startWatch(ctx context.Context, s *Server, service string) *watchStatuses {
+	w := &watchStatuses{
+		statuses: make(chan pb.HealthCheckResponse_ServingStatus, 10),
+		err:      make(chan error, 1),
+	}
+	go func() {
+		w.err <- s.watch(ctx, service, func(status pb.HealthCheckResponse_ServingStatus) error {
+			w.statuses <- status
+			return nil
+		})
+	}()
+	return w
+}
+
+func (w *watchStatuses) next(t *testing.T) pb.HealthCheckResponse_ServingStatus {
+	select {
+	case status := <-w.statuses:
+		return status
+	case <-time.After(testtime.Second):
+		t.Fatal("timed out waiting for a status")
+		return 0
+	}
+}
+
+func (w *watchStatuses) wait(t *testing.T) error {
+	select {
+	case err := <-w.err:
+		return err
+	case <-time.After(testtime.Second):
+		t.Fatal("timed out waiting for the watch to end")
+		return nil
+	}
+}
+
+func TestServerWatch(t *testing.T) {
+	s := NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := startWatch(ctx, s, "foo")
+	assert.Equal(t, pb.HealthCheckResponse_SERVICE_UNKNOWN, w.next(t))
+
+	s.SetServingStatus("foo", Serving)
+	assert.Equal(t, pb.HealthCheckResponse_SERVING, w.next(t))
+
+	s.SetServingStatus("foo", NotServing)
+	assert.Equal(t, pb.HealthCheckResponse_NOT_SERVING, w.next(t))
+
+	// Unchanged statuses are not sent again.
+	s.SetServingStatus("foo", NotServing)
+	s.SetServingStatus("foo", Serving)
+	assert.Equal(t, pb.HealthCheckResponse_SERVING, w.next(t))
+
+	cancel()
+	assert.Equal(t, context.Canceled, w.wait(t))
+
+	s.mu.Lock()
+	assert.Empty(t, s.watchers, "watchers must be removed when they end")
+	s.mu.Unlock()
+}
+
+func TestServerWatchShutdown(t *testing.T) {
+	s := NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := startWatch(ctx, s, "")
+	assert.Equal(t, pb.HealthCheckResponse_SERVING, w.next(t))
+
+	s.Shutdown()
+	assert.Equal(t, pb.HealthCheckResponse_NOT_SERVING, w.next(t))
+	err := w.wait(t)
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code(),
+		"watches must end when the server shuts down")
+
+	// Watches that begin while the server is shut down end at once.
+	w = startWatch(ctx, s, "")
+	assert.Equal(t, pb.HealthCheckResponse_NOT_SERVING, w.next(t))
+	err = w.wait(t)
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code(),
+		"watches must not begin while the server is shut down")
+
+	// Every shutdown ends the watches open at the time.
+	s.Resume()
+	w = startWatch(ctx, s, "")
+	assert.Equal(t, pb.HealthCheckResponse_SERVING, w.next(t))
+	s.Shutdown()
+	s.Shutdown()
+	assert.Error(t, w.wait(t))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package attempt tracks concurrent attempts of a single logical outbound
+// call, such as hedged requests, so that peer lists can spread them across
+// distinct peers and observability can tell them apart.
+package attempt
+
+import (
+	"context"
+	"sync"
+)
+
+type attemptKey struct{}
+
+// Group records the peers chosen by all attempts of one logical call.
+// It is safe for concurrent use.
+type Group struct {
+	mu     sync.Mutex
+	chosen map[string]struct{}
+}
+
+// NewGroup returns a new, empty Group.
+func NewGroup() *Group {
+	return &Group{chosen: make(map[string]struct{})}
+}
+
+// Chosen returns whether a peer with the given identifier was already chosen
+// by an attempt in this group.
+func (g *Group) Chosen(id string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, ok := g.chosen[id]
+	return ok
+}
+
+// Choose records that an attempt in this group chose the peer with the given
+// identifier.
+func (g *Group) Choose(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.chosen[id] = struct{}{}
+}
+
+// Attempt describes a single attempt of a logical call.
+type Attempt struct {
+	// Index is the zero-based position of the attempt. The first attempt is
+	// the primary; the rest are hedges.
+	Index int
+
+	// Group is shared by all attempts of the same call.
+	Group *Group
+}
+
+// Hedged returns whether this attempt was sent in addition to the primary
+// attempt.
+func (a Attempt) Hedged() bool {
+	return a.Index > 0
+}
+
+// WithAttempt attaches the attempt to the context.
+func WithAttempt(ctx context.Context, a Attempt) context.Context {
+	return context.WithValue(ctx, attemptKey{}, a)
+}
+
+// FromContext returns the attempt attached to the context, if any.
+func FromContext(ctx context.Context) (Attempt, bool) {
+	a, ok := ctx.Value(attemptKey{}).(Attempt)
+	return a, ok
+}
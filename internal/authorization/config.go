@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package authorization
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"go.uber.org/yarpc/api/transport"
+)
+
+// Config configures the rules enforced by the middleware.
+type Config struct {
+	// Rules lists the allow rules. A request is allowed if any rule that
+	// covers its service and procedure admits its caller.
+	Rules []Rule
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	for i, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid authorization rule %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Rule allows a set of peers to call a set of procedures.
+//
+// Service, procedure and identity patterns use the syntax of path.Match, so
+// "KeyValue::*" matches every procedure of the KeyValue service and
+// "spiffe://example.org/ns/prod/*" matches every workload in the prod
+// namespace.
+type Rule struct {
+	// Service is the service the rule covers. The rule covers all services
+	// if this is empty.
+	Service string
+
+	// Procedures are the procedures the rule covers. The rule covers all
+	// procedures of the service if this is empty.
+	Procedures []string
+
+	// SPIFFEIDs, DNSNames and Subjects admit peers whose certificates carry
+	// a matching SPIFFE ID, DNS subject alternative name, or subject
+	// distinguished name.
+	SPIFFEIDs []string
+	DNSNames  []string
+	Subjects  []string
+
+	// AllowUnauthenticated admits all callers, including those that did not
+	// present a verified client certificate.
+	AllowUnauthenticated bool
+}
+
+// Validate returns an error if the rule is invalid.
+func (r Rule) Validate() error {
+	if !r.AllowUnauthenticated && len(r.SPIFFEIDs) == 0 && len(r.DNSNames) == 0 && len(r.Subjects) == 0 {
+		return errors.New("at least one of spiffeIDs, dnsNames, subjects or allowUnauthenticated is required")
+	}
+
+	patterns := append([]string{r.Service}, r.Procedures...)
+	patterns = append(patterns, r.SPIFFEIDs...)
+	patterns = append(patterns, r.DNSNames...)
+	patterns = append(patterns, r.Subjects...)
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", p, err)
+		}
+	}
+	return nil
+}
+
+// covers reports whether the rule applies to the given procedure.
+func (r Rule) covers(service, procedure string) bool {
+	if r.Service != "" && !match(r.Service, service) {
+		return false
+	}
+	return len(r.Procedures) == 0 || matchAny(r.Procedures, procedure)
+}
+
+// admits reports whether the rule allows the given peer.
+func (r Rule) admits(id *transport.PeerIdentity) bool {
+	if r.AllowUnauthenticated {
+		return true
+	}
+	if id == nil {
+		return false
+	}
+	if id.SPIFFEID != "" && matchAny(r.SPIFFEIDs, id.SPIFFEID) {
+		return true
+	}
+	for _, name := range id.DNSNames {
+		if matchAny(r.DNSNames, name) {
+			return true
+		}
+	}
+	return id.Subject != "" && matchAny(r.Subjects, id.Subject)
+}
+
+func matchAny(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if match(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether s matches the pattern. Patterns are validated ahead
+// of time, so a malformed pattern simply does not match.
+func match(pattern, s string) bool {
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
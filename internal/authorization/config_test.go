@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Config
+		wantErr string
+	}{
+		{desc: "empty"},
+		{
+			desc: "valid",
+			give: Config{Rules: []Rule{
+				{Service: "kv", Procedures: []string{"KeyValue::*"}, SPIFFEIDs: []string{"spiffe://example.org/*"}},
+				{Procedures: []string{"Public::*"}, AllowUnauthenticated: true},
+			}},
+		},
+		{
+			desc:    "no principals",
+			give:    Config{Rules: []Rule{{Service: "kv"}}},
+			wantErr: "invalid authorization rule 0: at least one of spiffeIDs, dnsNames, subjects or allowUnauthenticated is required",
+		},
+		{
+			desc: "bad pattern",
+			give: Config{Rules: []Rule{
+				{AllowUnauthenticated: true},
+				{DNSNames: []string{"[foo"}},
+			}},
+			wantErr: `invalid authorization rule 1: invalid pattern "[foo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.give.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRuleCovers(t *testing.T) {
+	r := Rule{Service: "kv", Procedures: []string{"KeyValue::get*", "Admin::reset"}}
+
+	assert.True(t, r.covers("kv", "KeyValue::getValue"))
+	assert.True(t, r.covers("kv", "Admin::reset"))
+	assert.False(t, r.covers("kv", "KeyValue::setValue"))
+	assert.False(t, r.covers("other", "KeyValue::getValue"))
+
+	assert.True(t, Rule{}.covers("any", "thing"), "empty rules cover everything")
+}
+
+func TestRuleAdmits(t *testing.T) {
+	id := &transport.PeerIdentity{
+		Subject:  "CN=foo,O=Example",
+		DNSNames: []string{"foo.internal", "foo.example.org"},
+		SPIFFEID: "spiffe://example.org/ns/prod/sa/foo",
+	}
+
+	tests := []struct {
+		desc string
+		rule Rule
+		id   *transport.PeerIdentity
+		want bool
+	}{
+		{desc: "spiffe id", rule: Rule{SPIFFEIDs: []string{"spiffe://example.org/ns/prod/*/*"}}, id: id, want: true},
+		{desc: "other spiffe id", rule: Rule{SPIFFEIDs: []string{"spiffe://example.org/ns/dev/*/*"}}, id: id},
+		{desc: "dns name", rule: Rule{DNSNames: []string{"*.example.org"}}, id: id, want: true},
+		{desc: "subject", rule: Rule{Subjects: []string{"CN=foo,O=Example"}}, id: id, want: true},
+		{desc: "no identity", rule: Rule{Subjects: []string{"*"}}},
+		{desc: "empty spiffe id", rule: Rule{SPIFFEIDs: []string{"*"}}, id: &transport.PeerIdentity{}},
+		{desc: "unauthenticated", rule: Rule{AllowUnauthenticated: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.admits(tt.id))
+		})
+	}
+}
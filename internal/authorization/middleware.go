@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package authorization
+
+import (
+	"context"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ middleware.UnaryInbound  = (*Middleware)(nil)
+	_ middleware.OnewayInbound = (*Middleware)(nil)
+	_ middleware.StreamInbound = (*Middleware)(nil)
+)
+
+// Middleware is an inbound middleware that authorizes requests against a
+// set of allow rules.
+type Middleware struct {
+	rules []Rule
+}
+
+// New constructs an authorization middleware. The configuration must be
+// valid.
+func New(cfg Config) *Middleware {
+	return &Middleware{rules: cfg.Rules}
+}
+
+// Handle implements middleware.UnaryInbound.
+func (m *Middleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	if err := m.authorize(req.Service, req.Procedure, req.PeerIdentity); err != nil {
+		return err
+	}
+	return h.Handle(ctx, req, resw)
+}
+
+// HandleOneway implements middleware.OnewayInbound.
+func (m *Middleware) HandleOneway(ctx context.Context, req *transport.Request, h transport.OnewayHandler) error {
+	if err := m.authorize(req.Service, req.Procedure, req.PeerIdentity); err != nil {
+		return err
+	}
+	return h.HandleOneway(ctx, req)
+}
+
+// HandleStream implements middleware.StreamInbound.
+func (m *Middleware) HandleStream(s *transport.ServerStream, h transport.StreamHandler) error {
+	meta := s.Request().Meta
+	if err := m.authorize(meta.Service, meta.Procedure, meta.PeerIdentity); err != nil {
+		return err
+	}
+	return h.HandleStream(s)
+}
+
+// authorize returns nil if a rule covering the procedure admits the peer.
+func (m *Middleware) authorize(service, procedure string, id *transport.PeerIdentity) error {
+	for _, r := range m.rules {
+		if r.covers(service, procedure) && r.admits(id) {
+			return nil
+		}
+	}
+
+	if id == nil {
+		return yarpcerrors.Newf(yarpcerrors.CodeUnauthenticated,
+			"procedure %q of service %q requires a verified client certificate", procedure, service)
+	}
+	return yarpcerrors.Newf(yarpcerrors.CodePermissionDenied,
+		"peer %q is not allowed to call procedure %q of service %q", describe(id), procedure, service)
+}
+
+// describe returns the most specific name of the peer.
+func describe(id *transport.PeerIdentity) string {
+	if id.SPIFFEID != "" {
+		return id.SPIFFEID
+	}
+	return id.Subject
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package authorization
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_fooIdentity = &transport.PeerIdentity{Subject: "CN=foo", SPIFFEID: "spiffe://example.org/foo"}
+	_barIdentity = &transport.PeerIdentity{Subject: "CN=bar"}
+)
+
+func newTestMiddleware() *Middleware {
+	return New(Config{Rules: []Rule{
+		{Service: "kv", Procedures: []string{"KeyValue::*"}, SPIFFEIDs: []string{"spiffe://example.org/foo"}},
+		{Service: "kv", Procedures: []string{"KeyValue::getValue"}, Subjects: []string{"CN=bar"}},
+		{Service: "kv", Procedures: []string{"Meta::*"}, AllowUnauthenticated: true},
+	}})
+}
+
+func TestUnaryInbound(t *testing.T) {
+	mw := newTestMiddleware()
+
+	tests := []struct {
+		desc      string
+		procedure string
+		id        *transport.PeerIdentity
+		wantCode  yarpcerrors.Code
+		wantErr   string
+	}{
+		{desc: "allowed", procedure: "KeyValue::setValue", id: _fooIdentity},
+		{desc: "allowed by second rule", procedure: "KeyValue::getValue", id: _barIdentity},
+		{desc: "unauthenticated allowed", procedure: "Meta::introspect"},
+		{
+			desc:      "denied",
+			procedure: "KeyValue::setValue",
+			id:        _barIdentity,
+			wantCode:  yarpcerrors.CodePermissionDenied,
+			wantErr:   `peer "CN=bar" is not allowed to call procedure "KeyValue::setValue" of service "kv"`,
+		},
+		{
+			desc:      "uncovered procedure",
+			procedure: "Admin::reset",
+			id:        _fooIdentity,
+			wantCode:  yarpcerrors.CodePermissionDenied,
+			wantErr:   `peer "spiffe://example.org/foo" is not allowed`,
+		},
+		{
+			desc:      "unauthenticated",
+			procedure: "KeyValue::getValue",
+			wantCode:  yarpcerrors.CodeUnauthenticated,
+			wantErr:   `procedure "KeyValue::getValue" of service "kv" requires a verified client certificate`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			req := &transport.Request{
+				Service:      "kv",
+				Procedure:    tt.procedure,
+				PeerIdentity: tt.id,
+				Body:         strings.NewReader("hello"),
+			}
+			resw := new(transporttest.FakeResponseWriter)
+			err := mw.Handle(context.Background(), req, resw, transporttest.EchoHandler{})
+			if tt.wantCode == yarpcerrors.CodeOK {
+				require.NoError(t, err)
+				assert.Equal(t, "hello", resw.Body.String())
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, yarpcerrors.FromError(err).Code())
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Empty(t, resw.Body.String(), "handler must not be called")
+		})
+	}
+}
+
+func TestOnewayInbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := newTestMiddleware()
+
+	allowed := &transport.Request{Service: "kv", Procedure: "KeyValue::setValue", PeerIdentity: _fooIdentity}
+	h := transporttest.NewMockOnewayHandler(mockCtrl)
+	h.EXPECT().HandleOneway(gomock.Any(), allowed).Return(nil)
+	require.NoError(t, mw.HandleOneway(context.Background(), allowed, h))
+
+	denied := &transport.Request{Service: "kv", Procedure: "KeyValue::setValue", PeerIdentity: _barIdentity}
+	err := mw.HandleOneway(context.Background(), denied, h)
+	assert.Equal(t, yarpcerrors.CodePermissionDenied, yarpcerrors.FromError(err).Code())
+}
+
+func TestStreamInbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := newTestMiddleware()
+
+	newServerStream := func(id *transport.PeerIdentity) *transport.ServerStream {
+		stream := transporttest.NewMockStream(mockCtrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().Request().Return(&transport.StreamRequest{
+			Meta: &transport.RequestMeta{Service: "kv", Procedure: "KeyValue::watch", PeerIdentity: id},
+		}).AnyTimes()
+		ss, err := transport.NewServerStream(stream)
+		require.NoError(t, err)
+		return ss
+	}
+
+	allowed := newServerStream(_fooIdentity)
+	h := transporttest.NewMockStreamHandler(mockCtrl)
+	h.EXPECT().HandleStream(allowed).Return(nil)
+	require.NoError(t, mw.HandleStream(allowed, h))
+
+	err := mw.HandleStream(newServerStream(nil), h)
+	assert.Equal(t, yarpcerrors.CodeUnauthenticated, yarpcerrors.FromError(err).Code())
+}
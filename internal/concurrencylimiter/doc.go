@@ -0,0 +1,34 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package concurrencylimiter provides an inbound middleware that caps the
+// number of in-flight unary and streaming requests per procedure and sheds
+// excess load.
+//
+// Requests over the limit wait in a bounded FIFO queue for a slot to free
+// up. Requests that find the queue full, or that wait longer than allowed,
+// fail with yarpcerrors.CodeResourceExhausted.
+//
+// Limits may be static or adaptive. An adaptive limit follows an additive
+// increase, multiplicative decrease (AIMD) scheme: it grows by one while the
+// limit is in use and requests complete within a latency threshold, and
+// shrinks by a ratio whenever a request is slower than the threshold or
+// misses its deadline.
+package concurrencylimiter
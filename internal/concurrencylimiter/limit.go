@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrencylimiter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const _defaultBackoffRatio = 0.9
+
+// Config configures the limits enforced by the middleware.
+type Config struct {
+	// Default is the limit applied to every procedure without its own limit.
+	// Each procedure is limited separately. Procedures are not limited if
+	// this is nil.
+	Default *Limit
+
+	// Procedures maps procedure names to their limits.
+	Procedures map[string]Limit
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if c.Default != nil {
+		if err := c.Default.Validate(); err != nil {
+			return fmt.Errorf("invalid default concurrency limit: %v", err)
+		}
+	}
+	for procedure, l := range c.Procedures {
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("invalid concurrency limit for procedure %q: %v", procedure, err)
+		}
+	}
+	return nil
+}
+
+// Limit bounds the in-flight requests of a single procedure.
+type Limit struct {
+	// MaxConcurrency is the number of requests that may be in flight at
+	// once. For adaptive limits, this is the initial limit.
+	MaxConcurrency int
+
+	// MaxQueue is the number of requests that may wait for a slot once the
+	// limit is reached. Requests are rejected outright if this is zero.
+	MaxQueue int
+
+	// MaxQueueWait bounds the time a request may wait in the queue. Queued
+	// requests wait until their deadline if this is zero.
+	MaxQueueWait time.Duration
+
+	// Adaptive, if set, lets the limit move with observed latencies.
+	Adaptive *AdaptiveLimit
+}
+
+// Validate returns an error if the limit is invalid.
+func (l Limit) Validate() error {
+	if l.MaxConcurrency <= 0 {
+		return errors.New("maxConcurrency must be positive")
+	}
+	if l.MaxQueue < 0 {
+		return errors.New("maxQueue must not be negative")
+	}
+	if l.MaxQueueWait < 0 {
+		return errors.New("maxQueueWait must not be negative")
+	}
+	if a := l.Adaptive; a != nil {
+		if a.MinConcurrency <= 0 {
+			return errors.New("adaptive minConcurrency must be positive")
+		}
+		if a.MinConcurrency > l.MaxConcurrency || l.MaxConcurrency > a.MaxConcurrency {
+			return fmt.Errorf("maxConcurrency %d must be between adaptive minConcurrency %d and maxConcurrency %d",
+				l.MaxConcurrency, a.MinConcurrency, a.MaxConcurrency)
+		}
+		if a.LatencyThreshold <= 0 {
+			return errors.New("adaptive latencyThreshold must be positive")
+		}
+		if a.BackoffRatio < 0 || a.BackoffRatio >= 1 {
+			return fmt.Errorf("adaptive backoffRatio must be in [0, 1), got %v", a.BackoffRatio)
+		}
+	}
+	return nil
+}
+
+// AdaptiveLimit configures how an adaptive limit moves.
+type AdaptiveLimit struct {
+	// MinConcurrency and MaxConcurrency bound the limit.
+	MinConcurrency int
+	MaxConcurrency int
+
+	// LatencyThreshold is the latency above which a request is taken as a
+	// sign of overload.
+	LatencyThreshold time.Duration
+
+	// BackoffRatio is the factor by which the limit shrinks on overload.
+	// Defaults to 0.9.
+	BackoffRatio float64
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrencylimiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Config
+		wantErr string
+	}{
+		{desc: "empty"},
+		{
+			desc: "valid",
+			give: Config{
+				Default: &Limit{MaxConcurrency: 10, MaxQueue: 5, MaxQueueWait: time.Millisecond},
+				Procedures: map[string]Limit{
+					"proc": {
+						MaxConcurrency: 10,
+						Adaptive: &AdaptiveLimit{
+							MinConcurrency:   1,
+							MaxConcurrency:   100,
+							LatencyThreshold: time.Second,
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:    "default without concurrency",
+			give:    Config{Default: &Limit{}},
+			wantErr: "invalid default concurrency limit: maxConcurrency must be positive",
+		},
+		{
+			desc:    "negative queue",
+			give:    Config{Procedures: map[string]Limit{"proc": {MaxConcurrency: 1, MaxQueue: -1}}},
+			wantErr: `invalid concurrency limit for procedure "proc": maxQueue must not be negative`,
+		},
+		{
+			desc:    "negative queue wait",
+			give:    Config{Default: &Limit{MaxConcurrency: 1, MaxQueueWait: -time.Second}},
+			wantErr: "maxQueueWait must not be negative",
+		},
+		{
+			desc: "adaptive without minimum",
+			give: Config{Default: &Limit{
+				MaxConcurrency: 1,
+				Adaptive:       &AdaptiveLimit{MaxConcurrency: 10, LatencyThreshold: time.Second},
+			}},
+			wantErr: "adaptive minConcurrency must be positive",
+		},
+		{
+			desc: "initial limit out of bounds",
+			give: Config{Default: &Limit{
+				MaxConcurrency: 20,
+				Adaptive:       &AdaptiveLimit{MinConcurrency: 1, MaxConcurrency: 10, LatencyThreshold: time.Second},
+			}},
+			wantErr: "maxConcurrency 20 must be between adaptive minConcurrency 1 and maxConcurrency 10",
+		},
+		{
+			desc: "adaptive without latency threshold",
+			give: Config{Default: &Limit{
+				MaxConcurrency: 1,
+				Adaptive:       &AdaptiveLimit{MinConcurrency: 1, MaxConcurrency: 10},
+			}},
+			wantErr: "adaptive latencyThreshold must be positive",
+		},
+		{
+			desc: "backoff ratio out of range",
+			give: Config{Default: &Limit{
+				MaxConcurrency: 1,
+				Adaptive: &AdaptiveLimit{
+					MinConcurrency:   1,
+					MaxConcurrency:   10,
+					LatencyThreshold: time.Second,
+					BackoffRatio:     1,
+				},
+			}},
+			wantErr: "adaptive backoffRatio must be in [0, 1), got 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.give.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
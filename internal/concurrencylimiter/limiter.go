@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrencylimiter
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/zap"
+)
+
+const (
+	_reasonQueueFull    = "queue_full"
+	_reasonQueueTimeout = "queue_timeout"
+	_reasonCancelled    = "cancelled"
+)
+
+// limiter bounds the in-flight requests of a single procedure.
+type limiter struct {
+	procedure    string
+	maxQueue     int
+	maxQueueWait time.Duration
+	adaptive     *AdaptiveLimit
+	metrics      limiterMetrics
+
+	mu       sync.Mutex
+	limit    int
+	inflight int
+	queue    list.List // of chan struct{}, closed when a slot is granted
+}
+
+func newLimiter(procedure string, l Limit, m limiterMetrics) *limiter {
+	lim := &limiter{
+		procedure:    procedure,
+		maxQueue:     l.MaxQueue,
+		maxQueueWait: l.MaxQueueWait,
+		metrics:      m,
+		limit:        l.MaxConcurrency,
+	}
+	if l.Adaptive != nil {
+		adaptive := *l.Adaptive
+		if adaptive.BackoffRatio == 0 {
+			adaptive.BackoffRatio = _defaultBackoffRatio
+		}
+		lim.adaptive = &adaptive
+	}
+	m.limit.Store(int64(lim.limit))
+	return lim
+}
+
+// acquire takes a slot for a request, waiting in the queue if necessary.
+// It returns a ResourceExhausted error if the request is shed.
+func (l *limiter) acquire(ctx context.Context) error {
+	l.mu.Lock()
+	if l.inflight < l.limit && l.queue.Len() == 0 {
+		l.inflight++
+		l.metrics.inflight.Store(int64(l.inflight))
+		l.mu.Unlock()
+		return nil
+	}
+	if l.queue.Len() >= l.maxQueue {
+		l.mu.Unlock()
+		return l.reject(_reasonQueueFull)
+	}
+	ready := make(chan struct{})
+	elem := l.queue.PushBack(ready)
+	l.metrics.queueDepth.Store(int64(l.queue.Len()))
+	l.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if l.maxQueueWait > 0 {
+		timer := time.NewTimer(l.maxQueueWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var reason string
+	select {
+	case <-ready:
+		return nil
+	case <-timeout:
+		reason = _reasonQueueTimeout
+	case <-ctx.Done():
+		reason = _reasonCancelled
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-ready:
+		// A slot was granted as the wait ended.
+		return nil
+	default:
+	}
+	l.queue.Remove(elem)
+	l.metrics.queueDepth.Store(int64(l.queue.Len()))
+	return l.reject(reason)
+}
+
+// release frees the slot of a finished request. If sample is set, the
+// request's latency and outcome adjust an adaptive limit.
+func (l *limiter) release(latency time.Duration, overloaded, sample bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if sample && l.adaptive != nil {
+		l.observe(latency, overloaded)
+	}
+	l.inflight--
+
+	// Hand freed slots to queued requests in arrival order.
+	for l.inflight < l.limit && l.queue.Len() > 0 {
+		ready := l.queue.Remove(l.queue.Front()).(chan struct{})
+		l.inflight++
+		close(ready)
+	}
+	l.metrics.inflight.Store(int64(l.inflight))
+	l.metrics.queueDepth.Store(int64(l.queue.Len()))
+}
+
+// observe adjusts an adaptive limit. The limit shrinks multiplicatively on
+// signs of overload and grows by one if requests are healthy and the limit
+// is in use. Callers must hold the lock.
+func (l *limiter) observe(latency time.Duration, overloaded bool) {
+	a := l.adaptive
+	switch {
+	case overloaded || latency > a.LatencyThreshold:
+		l.limit = max(a.MinConcurrency, int(float64(l.limit)*a.BackoffRatio))
+	case l.inflight*2 >= l.limit:
+		l.limit = min(a.MaxConcurrency, l.limit+1)
+	}
+	l.metrics.limit.Store(int64(l.limit))
+}
+
+func (l *limiter) reject(reason string) error {
+	if c, err := l.metrics.rejections.Get(_reason, reason); err == nil {
+		c.Inc()
+	}
+	return yarpcerrors.ResourceExhaustedErrorf(
+		"concurrency limit reached for procedure %q: request rejected (%s)", l.procedure, reason)
+}
+
+const (
+	_procedure = "procedure"
+	_reason    = "reason"
+)
+
+type limiterMetrics struct {
+	limit      *metrics.Gauge
+	inflight   *metrics.Gauge
+	queueDepth *metrics.Gauge
+	rejections *metrics.CounterVector
+}
+
+func newLimiterMetrics(meter *metrics.Scope, logger *zap.Logger, procedure string) limiterMetrics {
+	tags := metrics.Tags{_procedure: procedure}
+
+	limit, err := meter.Gauge(metrics.Spec{
+		Name:      "concurrency_limit",
+		Help:      "Number of requests that may be in flight at once.",
+		ConstTags: tags,
+	})
+	if err != nil {
+		logger.Error("Failed to create concurrency limit gauge.", zap.Error(err))
+	}
+	inflight, err := meter.Gauge(metrics.Spec{
+		Name:      "concurrency_in_flight",
+		Help:      "Number of requests in flight.",
+		ConstTags: tags,
+	})
+	if err != nil {
+		logger.Error("Failed to create in-flight requests gauge.", zap.Error(err))
+	}
+	queueDepth, err := meter.Gauge(metrics.Spec{
+		Name:      "concurrency_queue_depth",
+		Help:      "Number of requests waiting for the concurrency limit.",
+		ConstTags: tags,
+	})
+	if err != nil {
+		logger.Error("Failed to create queue depth gauge.", zap.Error(err))
+	}
+	rejections, err := meter.CounterVector(metrics.Spec{
+		Name:      "concurrency_rejections",
+		Help:      "Number of requests shed by the concurrency limit.",
+		ConstTags: tags,
+		VarTags:   []string{_reason},
+	})
+	if err != nil {
+		logger.Error("Failed to create rejections counter.", zap.Error(err))
+	}
+
+	return limiterMetrics{
+		limit:      limit,
+		inflight:   inflight,
+		queueDepth: queueDepth,
+		rejections: rejections,
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrencylimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/zap"
+)
+
+func newTestLimiter(t *testing.T, l Limit) (*limiter, *metrics.Root) {
+	root := metrics.New()
+	return newLimiter("proc", l, newLimiterMetrics(root.Scope(), zap.NewNop(), "proc")), root
+}
+
+// waitForQueue blocks until the limiter has n queued requests.
+func waitForQueue(t *testing.T, l *limiter, n int) {
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.queue.Len() == n
+	}, testtime.Second, time.Millisecond)
+}
+
+func gauges(root *metrics.Root) map[string]int64 {
+	values := make(map[string]int64)
+	for _, g := range root.Snapshot().Gauges {
+		values[g.Name] = g.Value
+	}
+	return values
+}
+
+func rejections(root *metrics.Root) map[string]int64 {
+	values := make(map[string]int64)
+	for _, c := range root.Snapshot().Counters {
+		if c.Name == "concurrency_rejections" {
+			values[c.Tags[_reason]] = c.Value
+		}
+	}
+	return values
+}
+
+func TestLimiterRejectsWithoutQueue(t *testing.T) {
+	l, root := newTestLimiter(t, Limit{MaxConcurrency: 2})
+	ctx := context.Background()
+
+	require.NoError(t, l.acquire(ctx))
+	require.NoError(t, l.acquire(ctx))
+
+	err := l.acquire(ctx)
+	require.Error(t, err)
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `concurrency limit reached for procedure "proc"`)
+	assert.Equal(t, map[string]int64{_reasonQueueFull: 1}, rejections(root))
+	assert.Equal(t, map[string]int64{
+		"concurrency_limit":       2,
+		"concurrency_in_flight":   2,
+		"concurrency_queue_depth": 0,
+	}, gauges(root))
+
+	l.release(0, false, false)
+	assert.NoError(t, l.acquire(ctx), "a released slot must be reusable")
+}
+
+func TestLimiterQueue(t *testing.T) {
+	l, root := newTestLimiter(t, Limit{MaxConcurrency: 1, MaxQueue: 2})
+	ctx := context.Background()
+	require.NoError(t, l.acquire(ctx))
+
+	// Two requests queue up in order; a third finds the queue full.
+	order := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		i := i
+		go func() {
+			assert.NoError(t, l.acquire(ctx))
+			order <- i
+		}()
+		waitForQueue(t, l, i+1)
+	}
+	assert.Equal(t, int64(2), gauges(root)["concurrency_queue_depth"])
+	assert.Error(t, l.acquire(ctx))
+
+	l.release(0, false, false)
+	assert.Equal(t, 0, <-order, "queued requests must be served in order")
+	l.release(0, false, false)
+	assert.Equal(t, 1, <-order)
+
+	assert.Equal(t, map[string]int64{
+		"concurrency_limit":       1,
+		"concurrency_in_flight":   1,
+		"concurrency_queue_depth": 0,
+	}, gauges(root))
+}
+
+func TestLimiterQueueTimeout(t *testing.T) {
+	l, root := newTestLimiter(t, Limit{MaxConcurrency: 1, MaxQueue: 1, MaxQueueWait: 10 * time.Millisecond})
+	require.NoError(t, l.acquire(context.Background()))
+
+	err := l.acquire(context.Background())
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code())
+	assert.Equal(t, map[string]int64{_reasonQueueTimeout: 1}, rejections(root))
+	assert.Equal(t, int64(0), gauges(root)["concurrency_queue_depth"])
+}
+
+func TestLimiterQueueCancelled(t *testing.T) {
+	l, root := newTestLimiter(t, Limit{MaxConcurrency: 1, MaxQueue: 1})
+	require.NoError(t, l.acquire(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- l.acquire(ctx) }()
+	waitForQueue(t, l, 1)
+	cancel()
+
+	assert.Error(t, <-done)
+	assert.Equal(t, map[string]int64{_reasonCancelled: 1}, rejections(root))
+
+	// The cancelled request must not have taken the freed slot.
+	l.release(0, false, false)
+	assert.NoError(t, l.acquire(context.Background()))
+}
+
+func TestLimiterAdaptive(t *testing.T) {
+	l, root := newTestLimiter(t, Limit{
+		MaxConcurrency: 4,
+		MaxQueue:       10,
+		Adaptive: &AdaptiveLimit{
+			MinConcurrency:   2,
+			MaxConcurrency:   5,
+			LatencyThreshold: 100 * time.Millisecond,
+		},
+	})
+	ctx := context.Background()
+	assert.Equal(t, _defaultBackoffRatio, l.adaptive.BackoffRatio)
+
+	// A healthy request with the limit barely in use does not grow it.
+	require.NoError(t, l.acquire(ctx))
+	l.release(time.Millisecond, false, true)
+	assert.Equal(t, int64(4), gauges(root)["concurrency_limit"])
+
+	// Healthy requests with the limit in use grow it up to the maximum.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.acquire(ctx))
+		require.NoError(t, l.acquire(ctx))
+		l.release(time.Millisecond, false, true)
+		l.release(time.Millisecond, false, true)
+	}
+	assert.Equal(t, int64(5), gauges(root)["concurrency_limit"])
+
+	// Slow requests shrink it down to the minimum.
+	require.NoError(t, l.acquire(ctx))
+	l.release(time.Second, false, true)
+	assert.Equal(t, int64(4), gauges(root)["concurrency_limit"])
+	for i := 0; i < 5; i++ {
+		require.NoError(t, l.acquire(ctx))
+		l.release(time.Millisecond, true /* overloaded */, true)
+	}
+	assert.Equal(t, int64(2), gauges(root)["concurrency_limit"])
+
+	// Requests that are not sampled leave the limit alone.
+	require.NoError(t, l.acquire(ctx))
+	l.release(time.Hour, true, false)
+	assert.Equal(t, int64(2), gauges(root)["concurrency_limit"])
+}
+
+func TestLimiterAdaptiveGrowthServesQueue(t *testing.T) {
+	l, _ := newTestLimiter(t, Limit{
+		MaxConcurrency: 2,
+		MaxQueue:       10,
+		Adaptive: &AdaptiveLimit{
+			MinConcurrency:   1,
+			MaxConcurrency:   10,
+			LatencyThreshold: time.Second,
+		},
+	})
+	ctx := context.Background()
+	require.NoError(t, l.acquire(ctx))
+	require.NoError(t, l.acquire(ctx))
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { done <- l.acquire(ctx) }()
+	}
+	waitForQueue(t, l, 2)
+
+	// The limit grows to 3 as the request completes, admitting both queued
+	// requests.
+	l.release(time.Millisecond, false, true)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-done)
+}
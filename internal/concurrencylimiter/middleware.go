@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrencylimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/zap"
+)
+
+var (
+	_ middleware.UnaryInbound  = (*Middleware)(nil)
+	_ middleware.StreamInbound = (*Middleware)(nil)
+)
+
+// Params defines the parameters for creating the Middleware.
+type Params struct {
+	Config Config
+
+	// Meter is used to report limits, in-flight requests, queue depths and
+	// rejections. Metrics are not reported if this is nil.
+	Meter  *metrics.Scope
+	Logger *zap.Logger
+}
+
+// Middleware is an inbound middleware that limits the number of in-flight
+// unary and streaming requests per procedure.
+type Middleware struct {
+	cfg    Config
+	meter  *metrics.Scope
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	limiters map[string]*limiter // nil for procedures without a limit
+}
+
+// New constructs a concurrency limiting middleware. The configuration must
+// be valid.
+func New(p Params) *Middleware {
+	logger := p.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Middleware{
+		cfg:      p.Config,
+		meter:    p.Meter,
+		logger:   logger,
+		limiters: make(map[string]*limiter),
+	}
+}
+
+// Handle implements middleware.UnaryInbound.
+func (m *Middleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) (err error) {
+	l := m.limiter(req.Procedure)
+	if l == nil {
+		return h.Handle(ctx, req, resw)
+	}
+	if err := l.acquire(ctx); err != nil {
+		return err
+	}
+
+	// Panics are recovered outside of this middleware, so the slot must be
+	// released on the way out. Panicking requests do not adjust adaptive
+	// limits.
+	start := time.Now()
+	returned := false
+	defer func() {
+		l.release(time.Since(start), isOverloaded(ctx, err), returned /* sample */)
+	}()
+	err = h.Handle(ctx, req, resw)
+	returned = true
+	return err
+}
+
+// HandleStream implements middleware.StreamInbound.
+//
+// Streams hold a slot for their entire lifetime. Their durations say little
+// about the health of the service, so they do not adjust adaptive limits.
+func (m *Middleware) HandleStream(s *transport.ServerStream, h transport.StreamHandler) error {
+	l := m.limiter(s.Request().Meta.Procedure)
+	if l == nil {
+		return h.HandleStream(s)
+	}
+	if err := l.acquire(s.Context()); err != nil {
+		return err
+	}
+	defer l.release(0, false, false /* sample */)
+	return h.HandleStream(s)
+}
+
+// limiter returns the limiter for the given procedure, or nil if the
+// procedure is not limited.
+func (m *Middleware) limiter(procedure string) *limiter {
+	m.mu.RLock()
+	l, ok := m.limiters[procedure]
+	m.mu.RUnlock()
+	if ok {
+		return l
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.limiters[procedure]; ok {
+		return l
+	}
+
+	limit, ok := m.cfg.Procedures[procedure]
+	if !ok && m.cfg.Default != nil {
+		limit, ok = *m.cfg.Default, true
+	}
+	if ok {
+		l = newLimiter(procedure, limit, newLimiterMetrics(m.meter, m.logger, procedure))
+	}
+	m.limiters[procedure] = l
+	return l
+}
+
+// isOverloaded reports whether a request failed in a way that suggests the
+// service is overloaded.
+func isOverloaded(ctx context.Context, err error) bool {
+	if ctx.Err() == context.DeadlineExceeded {
+		return true
+	}
+	return yarpcerrors.FromError(err).Code() == yarpcerrors.CodeDeadlineExceeded
+}
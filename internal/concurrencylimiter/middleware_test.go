@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package concurrencylimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// blockingHandler is a unary handler that blocks until released.
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	h.started <- struct{}{}
+	<-h.release
+	return nil
+}
+
+func TestUnaryInbound(t *testing.T) {
+	root := metrics.New()
+	mw := New(Params{
+		Config: Config{
+			Default: &Limit{MaxConcurrency: 1},
+			Procedures: map[string]Limit{
+				"wide": {MaxConcurrency: 2},
+			},
+		},
+		Meter: root.Scope(),
+	})
+
+	call := func(procedure string, h transport.UnaryHandler) error {
+		req := &transport.Request{Procedure: procedure}
+		return mw.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), h)
+	}
+
+	h := newBlockingHandler()
+	done := make(chan error, 3)
+	go func() { done <- call("narrow", h) }()
+	go func() { done <- call("wide", h) }()
+	go func() { done <- call("wide", h) }()
+	for i := 0; i < 3; i++ {
+		<-h.started
+	}
+
+	for _, procedure := range []string{"narrow", "wide"} {
+		err := call(procedure, h)
+		assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code(),
+			"procedure %q must shed requests above its limit", procedure)
+	}
+
+	close(h.release)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, <-done)
+	}
+
+	assert.NoError(t, call("narrow", h), "requests must be admitted once capacity frees up")
+
+	var rejected int64
+	for _, c := range root.Snapshot().Counters {
+		if c.Name == "concurrency_rejections" {
+			rejected += c.Value
+		}
+	}
+	assert.Equal(t, int64(2), rejected)
+}
+
+func TestUnaryInboundUnlimited(t *testing.T) {
+	mw := New(Params{Config: Config{
+		Procedures: map[string]Limit{"limited": {MaxConcurrency: 1}},
+	}})
+
+	h := newBlockingHandler()
+	done := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			req := &transport.Request{Procedure: "unlimited"}
+			done <- mw.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), h)
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		<-h.started
+	}
+	close(h.release)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, <-done)
+	}
+}
+
+func TestUnaryInboundPanicReleasesSlot(t *testing.T) {
+	mw := New(Params{Config: Config{Default: &Limit{MaxConcurrency: 1}}})
+
+	req := &transport.Request{Procedure: "proc"}
+	panicking := transport.UnaryHandler(handlerFunc(func(context.Context, *transport.Request, transport.ResponseWriter) error {
+		panic("great sadness")
+	}))
+	ok := transport.UnaryHandler(handlerFunc(func(context.Context, *transport.Request, transport.ResponseWriter) error {
+		return nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		assert.Panics(t, func() {
+			_ = mw.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), panicking)
+		})
+	}
+	assert.NoError(t, mw.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), ok),
+		"slots held by panicking handlers must be released")
+}
+
+func TestUnaryInboundAdaptsToDeadlines(t *testing.T) {
+	mw := New(Params{Config: Config{Default: &Limit{
+		MaxConcurrency: 4,
+		Adaptive: &AdaptiveLimit{
+			MinConcurrency:   1,
+			MaxConcurrency:   4,
+			LatencyThreshold: time.Hour,
+			BackoffRatio:     0.5,
+		},
+	}}})
+
+	req := &transport.Request{Procedure: "proc"}
+	h := transport.UnaryHandler(handlerFunc(func(context.Context, *transport.Request, transport.ResponseWriter) error {
+		return yarpcerrors.DeadlineExceededErrorf("too slow")
+	}))
+	assert.Error(t, mw.Handle(context.Background(), req, new(transporttest.FakeResponseWriter), h))
+
+	l := mw.limiter("proc")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	assert.Equal(t, 2, l.limit, "deadline errors must shrink the limit")
+}
+
+func TestStreamInbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := New(Params{Config: Config{Default: &Limit{MaxConcurrency: 1}}})
+
+	newStream := func() *transport.ServerStream {
+		stream := transporttest.NewMockStream(mockCtrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().Request().Return(&transport.StreamRequest{
+			Meta: &transport.RequestMeta{Procedure: "proc"},
+		}).AnyTimes()
+		ss, err := transport.NewServerStream(stream)
+		require.NoError(t, err)
+		return ss
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	h := transporttest.NewMockStreamHandler(mockCtrl)
+	h.EXPECT().HandleStream(gomock.Any()).DoAndReturn(func(*transport.ServerStream) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- mw.HandleStream(newStream(), h) }()
+	<-started
+
+	err := mw.HandleStream(newStream(), h)
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code(),
+		"an open stream must hold its slot")
+
+	close(release)
+	assert.NoError(t, <-done)
+
+	h.EXPECT().HandleStream(gomock.Any()).Return(nil)
+	assert.NoError(t, mw.HandleStream(newStream(), h))
+}
+
+type handlerFunc func(context.Context, *transport.Request, transport.ResponseWriter) error
+
+func (f handlerFunc) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	return f(ctx, req, resw)
+}
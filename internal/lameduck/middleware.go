@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package lameduck
+
+import (
+	"context"
+
+	"go.uber.org/atomic"
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Header is the response header that marks responses from a draining
+// service.
+const Header = "yarpc-draining"
+
+var (
+	_ middleware.UnaryInbound  = (*Middleware)(nil)
+	_ middleware.OnewayInbound = (*Middleware)(nil)
+	_ middleware.StreamInbound = (*Middleware)(nil)
+)
+
+const (
+	serving int32 = iota
+	draining
+	rejecting
+)
+
+var _drainingHeaders = transport.NewHeaders().With(Header, "true")
+
+// Middleware is an inbound middleware that drains requests in two steps:
+// first advertising that the service is draining, then rejecting new
+// requests.
+type Middleware struct {
+	state atomic.Int32
+}
+
+// New builds a middleware that serves requests until it is told to drain.
+func New() *Middleware {
+	return &Middleware{}
+}
+
+// Drain marks unary responses with the draining header from now on.
+func (m *Middleware) Drain() {
+	m.state.CompareAndSwap(serving, draining)
+}
+
+// Reject rejects all new requests from now on.
+func (m *Middleware) Reject() {
+	m.state.Store(rejecting)
+}
+
+// Handle implements middleware.UnaryInbound.
+func (m *Middleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	switch m.state.Load() {
+	case draining:
+		resw.AddHeaders(_drainingHeaders)
+	case rejecting:
+		return rejectedError(req.Service)
+	}
+	return h.Handle(ctx, req, resw)
+}
+
+// HandleOneway implements middleware.OnewayInbound.
+func (m *Middleware) HandleOneway(ctx context.Context, req *transport.Request, h transport.OnewayHandler) error {
+	if m.state.Load() == rejecting {
+		return rejectedError(req.Service)
+	}
+	return h.HandleOneway(ctx, req)
+}
+
+// HandleStream implements middleware.StreamInbound.
+func (m *Middleware) HandleStream(s *transport.ServerStream, h transport.StreamHandler) error {
+	if m.state.Load() == rejecting {
+		return rejectedError(s.Request().Meta.Service)
+	}
+	return h.HandleStream(s)
+}
+
+func rejectedError(service string) error {
+	return yarpcerrors.UnavailableErrorf("service %q is draining and does not accept new requests", service)
+}
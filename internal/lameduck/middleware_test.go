@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package lameduck
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func TestUnaryInbound(t *testing.T) {
+	mw := New()
+
+	handle := func() (*transporttest.FakeResponseWriter, error) {
+		req := &transport.Request{Service: "svc", Procedure: "proc", Body: strings.NewReader("")}
+		resw := new(transporttest.FakeResponseWriter)
+		return resw, mw.Handle(context.Background(), req, resw, transporttest.EchoHandler{})
+	}
+
+	resw, err := handle()
+	require.NoError(t, err)
+	assert.Empty(t, resw.Headers.Items(), "serving responses must not be marked")
+
+	mw.Drain()
+	resw, err = handle()
+	require.NoError(t, err)
+	value, ok := resw.Headers.Get(Header)
+	assert.True(t, ok, "draining responses must be marked")
+	assert.Equal(t, "true", value)
+
+	mw.Reject()
+	mw.Drain() // does not undo rejection
+	_, err = handle()
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `service "svc" is draining`)
+}
+
+func TestOnewayInbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := New()
+	req := &transport.Request{Service: "svc", Procedure: "proc"}
+
+	h := transporttest.NewMockOnewayHandler(mockCtrl)
+	h.EXPECT().HandleOneway(gomock.Any(), req).Return(nil).Times(2)
+
+	require.NoError(t, mw.HandleOneway(context.Background(), req, h))
+	mw.Drain()
+	require.NoError(t, mw.HandleOneway(context.Background(), req, h))
+	mw.Reject()
+	err := mw.HandleOneway(context.Background(), req, h)
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+}
+
+func TestStreamInbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mw := New()
+
+	stream := transporttest.NewMockStream(mockCtrl)
+	stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+	stream.EXPECT().Request().Return(&transport.StreamRequest{
+		Meta: &transport.RequestMeta{Service: "svc", Procedure: "proc"},
+	}).AnyTimes()
+	ss, err := transport.NewServerStream(stream)
+	require.NoError(t, err)
+
+	h := transporttest.NewMockStreamHandler(mockCtrl)
+	h.EXPECT().HandleStream(ss).Return(nil).Times(2)
+
+	require.NoError(t, mw.HandleStream(ss, h))
+	mw.Drain()
+	require.NoError(t, mw.HandleStream(ss, h))
+	mw.Reject()
+	err = mw.HandleStream(ss, h)
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+}
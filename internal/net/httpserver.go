@@ -43,7 +43,9 @@ type HTTPServer struct {
 	lock     sync.RWMutex
 	listener net.Listener
 	done     chan error
+	served   chan struct{} // closed once Serve() returns
 	stopped  atomic.Bool
+	draining atomic.Bool
 }
 
 // NewHTTPServer wraps the given http.Server into an HTTPServer.
@@ -51,6 +53,7 @@ func NewHTTPServer(s *http.Server) *HTTPServer {
 	return &HTTPServer{
 		Server: s,
 		done:   make(chan error, 1),
+		served: make(chan struct{}),
 	}
 }
 
@@ -120,15 +123,38 @@ func (h *HTTPServer) Serve(lis net.Listener) error {
 
 func (h *HTTPServer) serve(listener net.Listener) {
 	// Serve always returns a non-nil error. For us, it's an error only if
-	// we didn't call Stop().
+	// we didn't call Stop() or Drain().
 	err := h.Server.Serve(listener)
-	if !h.stopped.Load() {
+	close(h.served)
+	if !h.stopped.Load() && !h.draining.Load() {
 		h.done <- err
 	} else {
 		h.done <- nil
 	}
 }
 
+// Drain stops accepting new connections. Connections that were already
+// accepted are served until Shutdown is called, but keep-alives are disabled
+// so that clients close them after their current requests.
+func (h *HTTPServer) Drain() {
+	if h.stopped.Load() || h.draining.Swap(true) {
+		return
+	}
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	h.Server.SetKeepAlivesEnabled(false)
+	if h.listener == nil {
+		return
+	}
+
+	// Wait until Serve() stops using the listener so that Shutdown does not
+	// close it again.
+	h.listener.Close()
+	<-h.served
+}
+
 // Shutdown stops the server. An error is returned if the server stopped
 // unexpectedly.
 //
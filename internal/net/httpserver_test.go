@@ -72,6 +72,39 @@ func TestStartAndShutdown(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDrainAndShutdown(t *testing.T) {
+	server := NewHTTPServer(&http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}),
+	})
+	require.NoError(t, server.ListenAndServe())
+	addr := yarpctest.ZeroAddrToHostPort(server.Listener().Addr())
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	server.Drain()
+	server.Drain() // must be a no-op
+
+	_, err = net.Dial("tcp", addr)
+	require.Error(t, err, "new connections must be refused after draining")
+
+	// Connections accepted before draining are still served.
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(testtime.Second)))
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf[:n]), "200 OK")
+	assert.Contains(t, string(buf[:n]), "Connection: close")
+
+	require.NoError(t, server.Shutdown(context.Background()))
+}
+
 func TestStartAddrInUse(t *testing.T) {
 	s1 := NewHTTPServer(&http.Server{Addr: "127.0.0.1:0"})
 	require.NoError(t, s1.ListenAndServe())
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/attempt"
 	"go.uber.org/yarpc/yarpcerrors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -238,6 +239,11 @@ func (c call) endLogs(
 		fields = append(fields, zap.Duration("timeout", deadlineTime.Sub(c.started)))
 	}
 
+	if a, ok := attempt.FromContext(c.ctx); ok {
+		fields = append(fields, zap.Int("attempt", a.Index))
+		fields = append(fields, zap.Bool("hedged", a.Hedged()))
+	}
+
 	if appErrBitWithNoError { // Thrift exception
 		fields = append(fields, zap.String(_error, "application_error"))
 		if applicationErrorMeta != nil {
@@ -327,6 +333,9 @@ func (c call) endStats(
 	res callResult,
 ) {
 	c.edge.calls.Inc()
+	if a, ok := attempt.FromContext(c.ctx); ok && a.Hedged() {
+		c.edge.hedgedCalls.Inc()
+	}
 
 	if deadlineTime, ok := c.ctx.Deadline(); ok {
 		c.edge.ttls.Observe(deadlineTime.Sub(c.started))
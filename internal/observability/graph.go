@@ -279,6 +279,7 @@ type edge struct {
 	panics         *metrics.Counter
 	callerFailures *metrics.CounterVector
 	serverFailures *metrics.CounterVector
+	hedgedCalls    *lazyCounter
 
 	latencies            *metrics.Histogram
 	callerErrLatencies   *metrics.Histogram
@@ -446,6 +447,20 @@ func newEdge(logger *zap.Logger, meter *metrics.Scope, tagToIgnore *metricsTagIg
 		}
 	}
 
+	// metrics for only outbound unary, the only RPCs that may be hedged
+	var hedgedCalls *lazyCounter
+	if rpcType == transport.Unary && direction == string(_directionOutbound) {
+		hedgedCalls = &lazyCounter{
+			meter:  meter,
+			logger: logger,
+			spec: metrics.Spec{
+				Name:      "hedged_calls",
+				Help:      "Number of RPCs sent as hedged attempts alongside a primary attempt.",
+				ConstTags: tags,
+			},
+		}
+	}
+
 	// metrics for only streams
 	var streaming *streamEdge
 	if rpcType == transport.Streaming {
@@ -585,6 +600,7 @@ func newEdge(logger *zap.Logger, meter *metrics.Scope, tagToIgnore *metricsTagIg
 		panics:               panics,
 		callerFailures:       callerFailures,
 		serverFailures:       serverFailures,
+		hedgedCalls:          hedgedCalls,
 		requestPayloadSizes:  requestPayloadSizes,
 		responsePayloadSizes: responsePayloadSizes,
 		latencies:            latencies,
@@ -603,3 +619,28 @@ func unknownIfEmpty(t string) string {
 	}
 	return t
 }
+
+// lazyCounter is a counter that is only registered when it is first
+// incremented, so that edges that never use it do not report it.
+type lazyCounter struct {
+	meter  *metrics.Scope
+	logger *zap.Logger
+	spec   metrics.Spec
+
+	once    sync.Once
+	counter *metrics.Counter
+}
+
+func (c *lazyCounter) Inc() {
+	if c == nil {
+		return
+	}
+	c.once.Do(func() {
+		var err error
+		c.counter, err = c.meter.Counter(c.spec)
+		if err != nil {
+			c.logger.Error("Failed to create counter.", zap.String("name", c.spec.Name), zap.Error(err))
+		}
+	})
+	c.counter.Inc()
+}
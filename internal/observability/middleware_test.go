@@ -35,8 +35,10 @@ import (
 	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/attempt"
 	"go.uber.org/yarpc/internal/bufferpool"
 	"go.uber.org/yarpc/internal/digester"
+	"go.uber.org/yarpc/internal/testtime"
 	"go.uber.org/yarpc/yarpcerrors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -2259,6 +2261,45 @@ func TestMiddlewareSuccessSnapshotForCall(t *testing.T) {
 	assert.Equal(t, want, snap, "Unexpected snapshot of metrics.")
 }
 
+func TestMiddlewareHedgedAttempts(t *testing.T) {
+	root := metrics.New()
+	core, logs := observer.New(zapcore.DebugLevel)
+	mw := NewMiddleware(Config{
+		Logger:           zap.New(core),
+		Scope:            root.Scope(),
+		ContextExtractor: NewNopContextExtractor(),
+	})
+
+	req := &transport.Request{
+		Caller:    "caller",
+		Service:   "service",
+		Encoding:  "raw",
+		Procedure: "procedure",
+	}
+
+	group := attempt.NewGroup()
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(attempt.WithAttempt(context.Background(), attempt.Attempt{Index: i, Group: group}), testtime.Second)
+		_, err := mw.Call(ctx, req, &fakeOutbound{})
+		cancel()
+		require.NoError(t, err)
+	}
+
+	counters := make(map[string]int64)
+	for _, c := range root.Snapshot().Counters {
+		counters[c.Name] = c.Value
+	}
+	assert.Equal(t, int64(3), counters["calls"])
+	assert.Equal(t, int64(2), counters["hedged_calls"])
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 3)
+	for i, e := range entries {
+		assert.Equal(t, int64(i), e.ContextMap()["attempt"])
+		assert.Equal(t, i > 0, e.ContextMap()["hedged"])
+	}
+}
+
 func TestMiddlewareSuccessSnapshotForCallOnWay(t *testing.T) {
 	timeVal := time.Now()
 	defer stubTimeWithTimeVal(timeVal)()
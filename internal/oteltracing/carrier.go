@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package oteltracing
+
+import (
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/yarpc/api/transport"
+)
+
+var _ propagation.TextMapCarrier = headersCarrier{}
+
+// headersCarrier adapts transport.Headers to the OpenTelemetry
+// TextMapCarrier interface so that propagators may read and write span
+// contexts from request headers.
+type headersCarrier struct {
+	headers *transport.Headers
+}
+
+// Get returns the value of the header with the given key.
+func (c headersCarrier) Get(key string) string {
+	v, _ := c.headers.Get(key)
+	return v
+}
+
+// Set sets the header with the given key.
+func (c headersCarrier) Set(key, value string) {
+	*c.headers = c.headers.With(key, value)
+}
+
+// Keys lists the keys stored in the carrier.
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, c.headers.Len())
+	for k := range c.headers.Items() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// copyHeaders returns a copy of the given headers that may be modified
+// without affecting the original.
+//
+// Outbound middleware such as hedging may share a request's headers between
+// concurrent attempts, so span contexts must not be injected in place.
+func copyHeaders(from transport.Headers) transport.Headers {
+	to := transport.NewHeadersWithCapacity(from.OriginalItemsLen())
+	for k, v := range from.OriginalItems() {
+		to = to.With(k, v)
+	}
+	return to
+}
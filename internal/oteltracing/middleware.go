@@ -0,0 +1,254 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package oteltracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ middleware.UnaryInbound   = (*Middleware)(nil)
+	_ middleware.UnaryOutbound  = (*Middleware)(nil)
+	_ middleware.OnewayInbound  = (*Middleware)(nil)
+	_ middleware.OnewayOutbound = (*Middleware)(nil)
+	_ middleware.StreamInbound  = (*Middleware)(nil)
+	_ middleware.StreamOutbound = (*Middleware)(nil)
+)
+
+const (
+	// TracerName is the instrumentation scope name of the tracer used by the
+	// middleware.
+	TracerName = "go.uber.org/yarpc"
+
+	_rpcSystem = "yarpc"
+)
+
+// Span attribute keys.
+const (
+	rpcSystemKey     = attribute.Key("rpc.system")
+	rpcServiceKey    = attribute.Key("rpc.service")
+	rpcMethodKey     = attribute.Key("rpc.method")
+	callerKey        = attribute.Key("rpc.yarpc.caller")
+	encodingKey      = attribute.Key("rpc.yarpc.encoding")
+	transportKey     = attribute.Key("rpc.yarpc.transport")
+	statusCodeKey    = attribute.Key("rpc.yarpc.status_code")
+	errorNameKey     = attribute.Key("error.name")
+	applicationError = "application_error"
+)
+
+// Params defines the parameters for creating the Middleware.
+type Params struct {
+	// TracerProvider is used to create spans. Defaults to the global
+	// TracerProvider.
+	TracerProvider trace.TracerProvider
+
+	// Propagator is used to inject and extract span contexts from request
+	// headers. Defaults to W3C Trace Context and Baggage.
+	Propagator propagation.TextMapPropagator
+
+	// Version is the instrumentation version reported with every span.
+	Version string
+}
+
+// Middleware is the OpenTelemetry tracing middleware for all RPC types.
+type Middleware struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// New constructs an OpenTelemetry tracing middleware with the provided
+// parameters.
+func New(p Params) *Middleware {
+	tp := p.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	propagator := p.Propagator
+	if propagator == nil {
+		propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	return &Middleware{
+		tracer:     tp.Tracer(TracerName, trace.WithInstrumentationVersion(p.Version)),
+		propagator: propagator,
+	}
+}
+
+// Handle implements middleware.UnaryInbound.
+func (m *Middleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	ctx, span := m.startServerSpan(ctx, req.ToRequestMeta())
+	defer span.End()
+
+	err := h.Handle(ctx, req, resw)
+	if extendedWriter, ok := resw.(transport.ExtendedResponseWriter); ok {
+		setSpanError(span, extendedWriter.IsApplicationError(), extendedWriter.ApplicationErrorMeta(), err)
+	} else {
+		setSpanError(span, false, nil, err)
+	}
+	return err
+}
+
+// Call implements middleware.UnaryOutbound.
+func (m *Middleware) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	ctx, span := m.startClientSpan(ctx, req.ToRequestMeta())
+	defer span.End()
+
+	treq := *req
+	treq.Headers = m.inject(ctx, req.Headers)
+
+	res, err := out.Call(ctx, &treq)
+	if res != nil {
+		setSpanError(span, res.ApplicationError, res.ApplicationErrorMeta, err)
+	} else {
+		setSpanError(span, false, nil, err)
+	}
+	return res, err
+}
+
+// HandleOneway implements middleware.OnewayInbound.
+func (m *Middleware) HandleOneway(ctx context.Context, req *transport.Request, h transport.OnewayHandler) error {
+	ctx, span := m.startServerSpan(ctx, req.ToRequestMeta())
+	defer span.End()
+
+	err := h.HandleOneway(ctx, req)
+	setSpanError(span, false, nil, err)
+	return err
+}
+
+// CallOneway implements middleware.OnewayOutbound.
+func (m *Middleware) CallOneway(ctx context.Context, req *transport.Request, out transport.OnewayOutbound) (transport.Ack, error) {
+	ctx, span := m.startClientSpan(ctx, req.ToRequestMeta())
+	defer span.End()
+
+	treq := *req
+	treq.Headers = m.inject(ctx, req.Headers)
+
+	ack, err := out.CallOneway(ctx, &treq)
+	setSpanError(span, false, nil, err)
+	return ack, err
+}
+
+// HandleStream implements middleware.StreamInbound.
+func (m *Middleware) HandleStream(s *transport.ServerStream, h transport.StreamHandler) error {
+	ctx, span := m.startServerSpan(s.Context(), s.Request().Meta)
+	defer span.End()
+
+	wrapped, err := transport.NewServerStream(&tracedServerStream{ServerStream: s, ctx: ctx})
+	if err != nil {
+		setSpanError(span, false, nil, err)
+		return err
+	}
+	err = h.HandleStream(wrapped)
+	setSpanError(span, false, nil, err)
+	return err
+}
+
+// CallStream implements middleware.StreamOutbound.
+func (m *Middleware) CallStream(ctx context.Context, req *transport.StreamRequest, out transport.StreamOutbound) (*transport.ClientStream, error) {
+	ctx, span := m.startClientSpan(ctx, req.Meta)
+
+	meta := *req.Meta
+	meta.Headers = m.inject(ctx, req.Meta.Headers)
+
+	cs, err := out.CallStream(ctx, &transport.StreamRequest{Meta: &meta})
+	if err != nil {
+		setSpanError(span, false, nil, err)
+		span.End()
+		return nil, err
+	}
+
+	traced := &tracedClientStream{ClientStream: cs, span: span}
+	wrapped, err := transport.NewClientStream(traced)
+	if err != nil {
+		// This should not happen, since NewClientStream only fails for nil
+		// streams.
+		traced.end(err)
+		return cs, nil
+	}
+	return wrapped, nil
+}
+
+func (m *Middleware) startServerSpan(ctx context.Context, meta *transport.RequestMeta) (context.Context, trace.Span) {
+	ctx = m.propagator.Extract(ctx, headersCarrier{&meta.Headers})
+	return m.tracer.Start(ctx, meta.Procedure,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(requestAttributes(meta)...),
+	)
+}
+
+func (m *Middleware) startClientSpan(ctx context.Context, meta *transport.RequestMeta) (context.Context, trace.Span) {
+	return m.tracer.Start(ctx, meta.Procedure,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(requestAttributes(meta)...),
+	)
+}
+
+// inject returns a copy of the given headers with the span context of ctx
+// injected into it.
+func (m *Middleware) inject(ctx context.Context, headers transport.Headers) transport.Headers {
+	headers = copyHeaders(headers)
+	m.propagator.Inject(ctx, headersCarrier{&headers})
+	return headers
+}
+
+func requestAttributes(meta *transport.RequestMeta) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		rpcSystemKey.String(_rpcSystem),
+		rpcServiceKey.String(meta.Service),
+		rpcMethodKey.String(meta.Procedure),
+		callerKey.String(meta.Caller),
+		encodingKey.String(string(meta.Encoding)),
+		transportKey.String(meta.Transport),
+	}
+}
+
+// setSpanError records the outcome of a request on the span. Requests that
+// fail with an error or an application error are marked with an error
+// status.
+func setSpanError(span trace.Span, isApplicationError bool, appErrorMeta *transport.ApplicationErrorMeta, err error) {
+	if err == nil && !isApplicationError {
+		return
+	}
+	if status := yarpcerrors.FromError(err); status != nil {
+		span.SetAttributes(statusCodeKey.Int(int(status.Code())))
+		span.SetStatus(codes.Error, status.Message())
+		return
+	}
+
+	span.SetAttributes(statusCodeKey.String(applicationError))
+	if appErrorMeta != nil {
+		if appErrorMeta.Code != nil {
+			span.SetAttributes(statusCodeKey.Int(int(*appErrorMeta.Code)))
+		}
+		if appErrorMeta.Name != "" {
+			span.SetAttributes(errorNameKey.String(appErrorMeta.Name))
+		}
+	}
+	span.SetStatus(codes.Error, applicationError)
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package oteltracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func newTestMiddleware(t *testing.T) (*Middleware, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return New(Params{TracerProvider: tp, Version: "1.2.3"}), recorder
+}
+
+func newTestRequest() *transport.Request {
+	return &transport.Request{
+		Caller:    "caller",
+		Service:   "service",
+		Transport: "http",
+		Encoding:  "raw",
+		Procedure: "procedure",
+		Headers:   transport.NewHeaders().With("foo", "bar"),
+	}
+}
+
+// extendedResponseWriter adds the transport.ExtendedResponseWriter accessors
+// to transporttest.FakeResponseWriter.
+var _ transport.ExtendedResponseWriter = (*extendedResponseWriter)(nil)
+
+type extendedResponseWriter struct {
+	transporttest.FakeResponseWriter
+}
+
+func (w *extendedResponseWriter) IsApplicationError() bool {
+	return w.FakeResponseWriter.IsApplicationError
+}
+
+func (w *extendedResponseWriter) ApplicationErrorMeta() *transport.ApplicationErrorMeta {
+	return w.FakeResponseWriter.ApplicationErrorMeta
+}
+
+func (w *extendedResponseWriter) ResponseSize() int {
+	return w.Body.Len()
+}
+
+func attributeMap(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestUnaryOutbound(t *testing.T) {
+	mw, recorder := newTestMiddleware(t)
+	mockCtrl := gomock.NewController(t)
+
+	req := newTestRequest()
+	var sent *transport.Request
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, r *transport.Request) (*transport.Response, error) {
+			sent = r
+			return &transport.Response{}, nil
+		})
+
+	_, err := mw.Call(context.Background(), req, out)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "procedure", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Equal(t, TracerName, span.InstrumentationScope().Name)
+	assert.Equal(t, "1.2.3", span.InstrumentationScope().Version)
+
+	attrs := attributeMap(span)
+	assert.Equal(t, "yarpc", attrs[rpcSystemKey].AsString())
+	assert.Equal(t, "service", attrs[rpcServiceKey].AsString())
+	assert.Equal(t, "procedure", attrs[rpcMethodKey].AsString())
+	assert.Equal(t, "caller", attrs[callerKey].AsString())
+	assert.Equal(t, "raw", attrs[encodingKey].AsString())
+	assert.Equal(t, "http", attrs[transportKey].AsString())
+
+	traceparent, ok := sent.Headers.Get("traceparent")
+	require.True(t, ok, "traceparent must be propagated")
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, span.SpanContext().SpanID().String())
+	foo, _ := sent.Headers.Get("foo")
+	assert.Equal(t, "bar", foo)
+
+	_, ok = req.Headers.Get("traceparent")
+	assert.False(t, ok, "caller's request headers must not be modified")
+}
+
+func TestUnaryInbound(t *testing.T) {
+	mw, recorder := newTestMiddleware(t)
+	mockCtrl := gomock.NewController(t)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := newTestRequest()
+	req.Headers = req.Headers.With("traceparent", "00-"+parent.TraceID().String()+"-"+parent.SpanID().String()+"-01")
+
+	h := transporttest.NewMockUnaryHandler(mockCtrl)
+	h.EXPECT().Handle(gomock.Any(), req, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *transport.Request, _ transport.ResponseWriter) error {
+			sc := trace.SpanContextFromContext(ctx)
+			assert.Equal(t, parent.TraceID(), sc.TraceID(), "handler must observe the server span")
+			assert.NotEqual(t, parent.SpanID(), sc.SpanID())
+			return nil
+		})
+
+	require.NoError(t, mw.Handle(context.Background(), req, &transporttest.FakeResponseWriter{}, h))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, parent.TraceID(), span.Parent().TraceID())
+	assert.Equal(t, parent.SpanID(), span.Parent().SpanID())
+	assert.True(t, span.Parent().IsRemote())
+}
+
+func TestUnaryErrors(t *testing.T) {
+	appErrCode := yarpcerrors.CodeNotFound
+	tests := []struct {
+		desc      string
+		res       *transport.Response
+		err       error
+		wantCode  attribute.Value
+		wantName  string
+		wantDescr string
+	}{
+		{
+			desc:      "yarpc error",
+			err:       yarpcerrors.UnavailableErrorf("no peers"),
+			wantCode:  attribute.IntValue(int(yarpcerrors.CodeUnavailable)),
+			wantDescr: "no peers",
+		},
+		{
+			desc:      "unknown error",
+			err:       errors.New("great sadness"),
+			wantCode:  attribute.IntValue(int(yarpcerrors.CodeUnknown)),
+			wantDescr: "great sadness",
+		},
+		{
+			desc:      "application error",
+			res:       &transport.Response{ApplicationError: true},
+			wantCode:  attribute.StringValue(applicationError),
+			wantDescr: applicationError,
+		},
+		{
+			desc: "application error with meta",
+			res: &transport.Response{
+				ApplicationError: true,
+				ApplicationErrorMeta: &transport.ApplicationErrorMeta{
+					Name: "MyError",
+					Code: &appErrCode,
+				},
+			},
+			wantCode:  attribute.IntValue(int(yarpcerrors.CodeNotFound)),
+			wantName:  "MyError",
+			wantDescr: applicationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mw, recorder := newTestMiddleware(t)
+			mockCtrl := gomock.NewController(t)
+
+			out := transporttest.NewMockUnaryOutbound(mockCtrl)
+			out.EXPECT().Call(gomock.Any(), gomock.Any()).Return(tt.res, tt.err)
+
+			_, err := mw.Call(context.Background(), newTestRequest(), out)
+			assert.Equal(t, tt.err, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+			assert.Equal(t, tt.wantDescr, spans[0].Status().Description)
+
+			attrs := attributeMap(spans[0])
+			assert.Equal(t, tt.wantCode, attrs[statusCodeKey])
+			if tt.wantName != "" {
+				assert.Equal(t, tt.wantName, attrs[errorNameKey].AsString())
+			}
+		})
+	}
+}
+
+func TestInboundApplicationError(t *testing.T) {
+	mw, recorder := newTestMiddleware(t)
+	mockCtrl := gomock.NewController(t)
+
+	h := transporttest.NewMockUnaryHandler(mockCtrl)
+	h.EXPECT().Handle(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *transport.Request, resw transport.ResponseWriter) error {
+			resw.SetApplicationError()
+			return nil
+		})
+
+	require.NoError(t, mw.Handle(context.Background(), newTestRequest(), &extendedResponseWriter{}, h))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, applicationError, attributeMap(spans[0])[statusCodeKey].AsString())
+}
+
+func TestOneway(t *testing.T) {
+	mw, recorder := newTestMiddleware(t)
+	mockCtrl := gomock.NewController(t)
+
+	var sent *transport.Request
+	out := transporttest.NewMockOnewayOutbound(mockCtrl)
+	out.EXPECT().CallOneway(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, r *transport.Request) (transport.Ack, error) {
+			sent = r
+			return nil, nil
+		})
+	_, err := mw.CallOneway(context.Background(), newTestRequest(), out)
+	require.NoError(t, err)
+
+	h := transporttest.NewMockOnewayHandler(mockCtrl)
+	h.EXPECT().HandleOneway(gomock.Any(), sent).Return(errors.New("great sadness"))
+	assert.Error(t, mw.HandleOneway(context.Background(), sent, h))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	client, server := spans[0], spans[1]
+	assert.Equal(t, trace.SpanKindClient, client.SpanKind())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, client.SpanContext().TraceID(), server.SpanContext().TraceID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, codes.Unset, client.Status().Code)
+	assert.Equal(t, codes.Error, server.Status().Code)
+}
+
+func TestHeadersCarrier(t *testing.T) {
+	headers := transport.NewHeaders().With("Foo", "bar")
+	carrier := headersCarrier{&headers}
+
+	carrier.Set("Baz", "qux")
+	assert.Equal(t, "bar", carrier.Get("foo"))
+	assert.Equal(t, "qux", carrier.Get("baz"))
+	assert.Equal(t, "", carrier.Get("missing"))
+	assert.ElementsMatch(t, []string{"foo", "baz"}, carrier.Keys())
+
+	copied := copyHeaders(headers)
+	copied = copied.With("extra", "value")
+	_, ok := headers.Get("extra")
+	assert.False(t, ok)
+	assert.Equal(t, headers.OriginalItems(), map[string]string{"Foo": "bar", "Baz": "qux"})
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package oteltracing
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
+	"go.uber.org/yarpc/api/transport"
+)
+
+var (
+	_ transport.StreamCloser        = (*tracedClientStream)(nil)
+	_ transport.StreamHeadersReader = (*tracedClientStream)(nil)
+	_ transport.StreamHeadersSender = (*tracedServerStream)(nil)
+)
+
+// tracedServerStream wraps a transport.ServerStream to expose a context
+// carrying the server span to the stream handler.
+type tracedServerStream struct {
+	*transport.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the context carrying the server span.
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// tracedClientStream wraps a transport.ClientStream and ends the client span
+// when the stream terminates.
+type tracedClientStream struct {
+	*transport.ClientStream
+
+	span  trace.Span
+	ended atomic.Bool
+}
+
+// SendMessage sends a message and ends the span if the stream failed.
+func (s *tracedClientStream) SendMessage(ctx context.Context, msg *transport.StreamMessage) error {
+	if err := s.ClientStream.SendMessage(ctx, msg); err != nil {
+		return s.end(err)
+	}
+	return nil
+}
+
+// ReceiveMessage receives a message and ends the span if the stream ended.
+func (s *tracedClientStream) ReceiveMessage(ctx context.Context) (*transport.StreamMessage, error) {
+	msg, err := s.ClientStream.ReceiveMessage(ctx)
+	if err != nil {
+		return nil, s.end(err)
+	}
+	return msg, nil
+}
+
+// Close closes the stream and ends the span.
+func (s *tracedClientStream) Close(ctx context.Context) error {
+	return s.end(s.ClientStream.Close(ctx))
+}
+
+// Headers reads the stream response headers and ends the span if the
+// stream failed.
+func (s *tracedClientStream) Headers() (transport.Headers, error) {
+	headers, err := s.ClientStream.Headers()
+	if err != nil {
+		return headers, s.end(err)
+	}
+	return headers, nil
+}
+
+// end ends the span once, recording err unless it signals a clean end of
+// stream, and returns err.
+func (s *tracedClientStream) end(err error) error {
+	if s.ended.Swap(true) {
+		return err
+	}
+	if !errors.Is(err, io.EOF) {
+		setSpanError(s.span, false, nil, err)
+	}
+	s.span.End()
+	return err
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package oteltracing
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func newTestStreamRequest() *transport.StreamRequest {
+	return &transport.StreamRequest{Meta: newTestRequest().ToRequestMeta()}
+}
+
+func TestStreamOutbound(t *testing.T) {
+	tests := []struct {
+		desc       string
+		receiveErr error
+		wantStatus codes.Code
+	}{
+		{desc: "end of stream", receiveErr: io.EOF, wantStatus: codes.Unset},
+		{desc: "stream error", receiveErr: yarpcerrors.InternalErrorf("great sadness"), wantStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mw, recorder := newTestMiddleware(t)
+			mockCtrl := gomock.NewController(t)
+
+			req := newTestStreamRequest()
+			out := transporttest.NewMockStreamOutbound(mockCtrl)
+			out.EXPECT().CallStream(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, sreq *transport.StreamRequest) (*transport.ClientStream, error) {
+					_, ok := sreq.Meta.Headers.Get("traceparent")
+					assert.True(t, ok, "traceparent must be propagated")
+
+					stream := transporttest.NewMockStreamCloser(mockCtrl)
+					stream.EXPECT().Context().Return(ctx).AnyTimes()
+					stream.EXPECT().Request().Return(sreq).AnyTimes()
+					stream.EXPECT().ReceiveMessage(gomock.Any()).Return(nil, tt.receiveErr)
+					stream.EXPECT().Close(gomock.Any()).Return(nil)
+					return transport.NewClientStream(stream)
+				})
+
+			cs, err := mw.CallStream(context.Background(), req, out)
+			require.NoError(t, err)
+			_, ok := req.Meta.Headers.Get("traceparent")
+			assert.False(t, ok, "caller's request headers must not be modified")
+			assert.Empty(t, recorder.Ended(), "span must remain open while the stream is")
+
+			_, err = cs.ReceiveMessage(context.Background())
+			assert.Equal(t, tt.receiveErr, err)
+			require.NoError(t, cs.Close(context.Background()))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1, "span must end exactly once")
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+			assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+		})
+	}
+}
+
+func TestStreamOutboundCallError(t *testing.T) {
+	mw, recorder := newTestMiddleware(t)
+	mockCtrl := gomock.NewController(t)
+
+	out := transporttest.NewMockStreamOutbound(mockCtrl)
+	out.EXPECT().CallStream(gomock.Any(), gomock.Any()).Return(nil, yarpcerrors.UnavailableErrorf("no peers"))
+
+	_, err := mw.CallStream(context.Background(), newTestStreamRequest(), out)
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, int64(yarpcerrors.CodeUnavailable), attributeMap(spans[0])[statusCodeKey].AsInt64())
+}
+
+func TestStreamInbound(t *testing.T) {
+	mw, recorder := newTestMiddleware(t)
+	mockCtrl := gomock.NewController(t)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{3},
+		SpanID:     trace.SpanID{4},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req := newTestStreamRequest()
+	req.Meta.Headers = req.Meta.Headers.With("traceparent", "00-"+parent.TraceID().String()+"-"+parent.SpanID().String()+"-01")
+
+	stream := transporttest.NewMockStream(mockCtrl)
+	stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+	stream.EXPECT().Request().Return(req).AnyTimes()
+	ss, err := transport.NewServerStream(stream)
+	require.NoError(t, err)
+
+	h := transporttest.NewMockStreamHandler(mockCtrl)
+	h.EXPECT().HandleStream(gomock.Any()).DoAndReturn(func(s *transport.ServerStream) error {
+		sc := trace.SpanContextFromContext(s.Context())
+		assert.Equal(t, parent.TraceID(), sc.TraceID(), "handler must observe the server span")
+		assert.Equal(t, req, s.Request())
+		return yarpcerrors.InternalErrorf("great sadness")
+	})
+
+	assert.Error(t, mw.HandleStream(ss, h))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Equal(t, parent.SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// bucket is a token bucket. Tokens may be borrowed ahead of time: the
+// number of tokens goes negative and callers wait for the debt to be repaid.
+type bucket struct {
+	limit Limit
+	name  string // describes the limit in errors
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(name string, l Limit, now func() time.Time) *bucket {
+	return &bucket{
+		limit:  l,
+		name:   name,
+		now:    now,
+		tokens: float64(l.burst()),
+		last:   now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller must
+// wait before using it. If that is longer than maxWait, no token is taken
+// and reserve returns false.
+func (b *bucket) reserve(maxWait time.Duration) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	var wait time.Duration
+	if missing := 1 - b.tokens; missing > 0 {
+		wait = time.Duration(missing / b.limit.RPS * float64(time.Second))
+	}
+	if wait > maxWait {
+		return 0, false
+	}
+	b.tokens--
+	return wait, true
+}
+
+// cancel returns a token taken by reserve that will not be used.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	if max := float64(b.limit.burst()); b.tokens+1 < max {
+		b.tokens++
+	} else {
+		b.tokens = max
+	}
+}
+
+func (b *bucket) advance() {
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.limit.RPS
+		if max := float64(b.limit.burst()); b.tokens > max {
+			b.tokens = max
+		}
+		b.last = now
+	}
+}
+
+func (b *bucket) exhausted() error {
+	return yarpcerrors.ResourceExhaustedErrorf(
+		"rate limit of %v requests per second exceeded for %s", b.limit.RPS, b.name)
+}
+
+func describe(service, procedure string) string {
+	if procedure == "" {
+		return fmt.Sprintf("service %q", service)
+	}
+	return fmt.Sprintf("procedure %q of service %q", procedure, service)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time      { return c.t }
+func (c *fakeClock) Add(d time.Duration) { c.t = c.t.Add(d) }
+func newFakeClock() *fakeClock           { return &fakeClock{t: time.Now()} }
+
+func TestBucket(t *testing.T) {
+	clock := newFakeClock()
+	b := newBucket("test", Limit{RPS: 10, Burst: 2}, clock.Now)
+
+	// The bucket starts full.
+	for i := 0; i < 2; i++ {
+		wait, ok := b.reserve(0)
+		assert.True(t, ok)
+		assert.Zero(t, wait)
+	}
+
+	// The next token arrives in 100ms.
+	_, ok := b.reserve(50 * time.Millisecond)
+	assert.False(t, ok, "must not reserve a token that arrives too late")
+
+	wait, ok := b.reserve(time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, wait)
+
+	// Tokens are borrowed ahead of time.
+	wait, ok = b.reserve(time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 200*time.Millisecond, wait)
+
+	// Cancelled reservations are returned.
+	b.cancel()
+	b.cancel()
+	clock.Add(100 * time.Millisecond)
+	wait, ok = b.reserve(0)
+	assert.True(t, ok)
+	assert.Zero(t, wait)
+
+	// The bucket never holds more than its burst.
+	clock.Add(time.Hour)
+	b.cancel()
+	for i := 0; i < 2; i++ {
+		_, ok := b.reserve(0)
+		assert.True(t, ok)
+	}
+	_, ok = b.reserve(0)
+	assert.False(t, ok)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Config configures the limits enforced by the middleware.
+type Config struct {
+	// Outbound is the limit shared by all requests made through the
+	// outbound. Requests are only limited per procedure if this is nil.
+	Outbound *Limit
+
+	// Procedures maps procedure names to their limits. These apply in
+	// addition to the outbound limit.
+	Procedures map[string]Limit
+
+	// MaxWait bounds the time a request may wait for a token. Requests wait
+	// until their deadline if this is zero.
+	MaxWait time.Duration
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if c.Outbound != nil {
+		if err := c.Outbound.Validate(); err != nil {
+			return fmt.Errorf("invalid outbound rate limit: %v", err)
+		}
+	}
+	for procedure, l := range c.Procedures {
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limit for procedure %q: %v", procedure, err)
+		}
+	}
+	if c.MaxWait < 0 {
+		return errors.New("maxWait must not be negative")
+	}
+	return nil
+}
+
+// Limit is a token bucket.
+type Limit struct {
+	// RPS is the rate, in requests per second, at which tokens are added to
+	// the bucket.
+	RPS float64
+
+	// Burst is the capacity of the bucket: the number of requests that may
+	// be sent at once after a quiet period. Defaults to RPS, rounded up.
+	Burst int
+}
+
+// Validate returns an error if the limit is invalid.
+func (l Limit) Validate() error {
+	if l.RPS <= 0 || math.IsInf(l.RPS, 0) || math.IsNaN(l.RPS) {
+		return errors.New("rps must be positive")
+	}
+	if l.Burst < 0 {
+		return errors.New("burst must not be negative")
+	}
+	return nil
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Ceil(l.RPS))
+}
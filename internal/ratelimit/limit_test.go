@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Config
+		wantErr string
+	}{
+		{desc: "empty"},
+		{
+			desc: "valid",
+			give: Config{
+				Outbound:   &Limit{RPS: 100, Burst: 10},
+				Procedures: map[string]Limit{"proc": {RPS: 0.5}},
+				MaxWait:    time.Second,
+			},
+		},
+		{
+			desc:    "outbound without rate",
+			give:    Config{Outbound: &Limit{Burst: 10}},
+			wantErr: "invalid outbound rate limit: rps must be positive",
+		},
+		{
+			desc:    "infinite rate",
+			give:    Config{Procedures: map[string]Limit{"proc": {RPS: math.Inf(1)}}},
+			wantErr: `invalid rate limit for procedure "proc": rps must be positive`,
+		},
+		{
+			desc:    "negative burst",
+			give:    Config{Procedures: map[string]Limit{"proc": {RPS: 1, Burst: -1}}},
+			wantErr: `invalid rate limit for procedure "proc": burst must not be negative`,
+		},
+		{
+			desc:    "negative wait",
+			give:    Config{MaxWait: -time.Second},
+			wantErr: "maxWait must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.give.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLimitBurst(t *testing.T) {
+	assert.Equal(t, 5, Limit{RPS: 100, Burst: 5}.burst())
+	assert.Equal(t, 100, Limit{RPS: 100}.burst())
+	assert.Equal(t, 3, Limit{RPS: 2.5}.burst())
+	assert.Equal(t, 1, Limit{RPS: 0.1}.burst())
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ middleware.UnaryOutbound  = (*Middleware)(nil)
+	_ middleware.OnewayOutbound = (*Middleware)(nil)
+	_ middleware.StreamOutbound = (*Middleware)(nil)
+)
+
+// Params defines the parameters for creating the Middleware.
+type Params struct {
+	// Service is the name of the service the outbound sends requests to. It
+	// is used to describe limits in errors.
+	Service string
+
+	Config Config
+}
+
+// Middleware is an outbound middleware that limits the rate of unary and
+// oneway requests, and of new streams, sent through an outbound.
+type Middleware struct {
+	maxWait    time.Duration
+	outbound   *bucket
+	procedures map[string]*bucket
+	now        func() time.Time
+}
+
+// New constructs a rate limiting middleware. The configuration must be
+// valid.
+func New(p Params) *Middleware {
+	return newMiddleware(p, time.Now)
+}
+
+func newMiddleware(p Params, now func() time.Time) *Middleware {
+	m := &Middleware{
+		maxWait:    p.Config.MaxWait,
+		procedures: make(map[string]*bucket, len(p.Config.Procedures)),
+		now:        now,
+	}
+	if l := p.Config.Outbound; l != nil {
+		m.outbound = newBucket(describe(p.Service, ""), *l, now)
+	}
+	for procedure, l := range p.Config.Procedures {
+		m.procedures[procedure] = newBucket(describe(p.Service, procedure), l, now)
+	}
+	return m
+}
+
+// Call implements middleware.UnaryOutbound.
+func (m *Middleware) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	if err := m.wait(ctx, req.Procedure); err != nil {
+		return nil, err
+	}
+	return out.Call(ctx, req)
+}
+
+// CallOneway implements middleware.OnewayOutbound.
+func (m *Middleware) CallOneway(ctx context.Context, req *transport.Request, out transport.OnewayOutbound) (transport.Ack, error) {
+	if err := m.wait(ctx, req.Procedure); err != nil {
+		return nil, err
+	}
+	return out.CallOneway(ctx, req)
+}
+
+// CallStream implements middleware.StreamOutbound.
+func (m *Middleware) CallStream(ctx context.Context, req *transport.StreamRequest, out transport.StreamOutbound) (*transport.ClientStream, error) {
+	if err := m.wait(ctx, req.Meta.Procedure); err != nil {
+		return nil, err
+	}
+	return out.CallStream(ctx, req)
+}
+
+// wait blocks until the request may be sent under all limits that apply to
+// the given procedure, or fails fast if it would have to wait too long.
+func (m *Middleware) wait(ctx context.Context, procedure string) error {
+	buckets := make([]*bucket, 0, 2)
+	if m.outbound != nil {
+		buckets = append(buckets, m.outbound)
+	}
+	if b, ok := m.procedures[procedure]; ok {
+		buckets = append(buckets, b)
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	maxWait := time.Duration(math.MaxInt64)
+	if m.maxWait > 0 {
+		maxWait = m.maxWait
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if untilDeadline := deadline.Sub(m.now()); untilDeadline < maxWait {
+			maxWait = untilDeadline
+		}
+	}
+
+	var wait time.Duration
+	for i, b := range buckets {
+		w, ok := b.reserve(maxWait)
+		if !ok {
+			cancelAll(buckets[:i])
+			return b.exhausted()
+		}
+		if w > wait {
+			wait = w
+		}
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancelAll(buckets)
+		name := buckets[len(buckets)-1].name
+		if ctx.Err() == context.DeadlineExceeded {
+			return yarpcerrors.DeadlineExceededErrorf("deadline exceeded while waiting on the rate limit for %s", name)
+		}
+		return yarpcerrors.CancelledErrorf("cancelled while waiting on the rate limit for %s", name)
+	}
+}
+
+func cancelAll(buckets []*bucket) {
+	for _, b := range buckets {
+		b.cancel()
+	}
+}
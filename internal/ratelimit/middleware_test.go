@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func TestUnaryOutbound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil).AnyTimes()
+
+	clock := newFakeClock()
+	mw := newMiddleware(Params{
+		Service: "keyvalue",
+		Config: Config{
+			Outbound:   &Limit{RPS: 0.001, Burst: 3},
+			Procedures: map[string]Limit{"get": {RPS: 0.001, Burst: 1}},
+		},
+	}, clock.Now)
+
+	call := func(procedure string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+		defer cancel()
+		_, err := mw.Call(ctx, &transport.Request{Procedure: procedure}, out)
+		return err
+	}
+
+	require.NoError(t, call("get"))
+
+	err := call("get")
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `rate limit of 0.001 requests per second exceeded for procedure "get" of service "keyvalue"`)
+
+	// The rejected request must not have used up an outbound token.
+	require.NoError(t, call("set"))
+	require.NoError(t, call("set"))
+
+	err = call("set")
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `exceeded for service "keyvalue"`)
+}
+
+func TestUnaryOutboundUnlimited(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil).Times(10)
+
+	mw := New(Params{Config: Config{
+		Procedures: map[string]Limit{"get": {RPS: 0.001}},
+	}})
+	for i := 0; i < 10; i++ {
+		_, err := mw.Call(context.Background(), &transport.Request{Procedure: "set"}, out)
+		require.NoError(t, err)
+	}
+}
+
+func TestOutboundWaitsForToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockOnewayOutbound(mockCtrl)
+	out.EXPECT().CallOneway(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	// One token every 20ms.
+	mw := New(Params{Config: Config{Outbound: &Limit{RPS: 50, Burst: 1}}})
+	req := &transport.Request{Procedure: "proc"}
+
+	_, err := mw.CallOneway(context.Background(), req, out)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = mw.CallOneway(context.Background(), req, out)
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) >= 10*time.Millisecond, "must wait for the next token")
+}
+
+func TestOutboundMaxWait(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+
+	mw := New(Params{
+		Service: "keyvalue",
+		Config: Config{
+			Outbound: &Limit{RPS: 1, Burst: 1},
+			MaxWait:  10 * time.Millisecond,
+		},
+	})
+	req := &transport.Request{Procedure: "proc"}
+
+	_, err := mw.Call(context.Background(), req, out)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = mw.Call(context.Background(), req, out)
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code())
+	assert.True(t, time.Since(start) < testtime.Second, "must fail fast")
+}
+
+func TestOutboundCancelledWhileWaiting(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockStreamOutbound(mockCtrl)
+	out.EXPECT().CallStream(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mw := New(Params{
+		Service: "keyvalue",
+		Config:  Config{Outbound: &Limit{RPS: 0.001, Burst: 1}},
+	})
+	req := &transport.StreamRequest{Meta: &transport.RequestMeta{Procedure: "proc"}}
+
+	_, err := mw.CallStream(context.Background(), req, out)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err = mw.CallStream(ctx, req, out)
+	assert.Equal(t, yarpcerrors.CodeCancelled, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `cancelled while waiting on the rate limit for service "keyvalue"`)
+}
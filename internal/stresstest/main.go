@@ -80,6 +80,7 @@ import (
 	"time"
 
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/peakewma"
 	"go.uber.org/yarpc/peer/pendingheap"
 	"go.uber.org/yarpc/peer/randpeer"
 	"go.uber.org/yarpc/peer/roundrobin"
@@ -118,6 +119,12 @@ func main() {
 				return tworandomchoices.New(trans)
 			},
 		},
+		{
+			name: "peak-ewma",
+			newFunc: func(trans peer.Transport) peer.ChooserList {
+				return peakewma.New(trans)
+			},
+		},
 	} {
 		for i := 1; i <= 1000; i *= 10 {
 			for _, lowStress := range []bool{false, true} {
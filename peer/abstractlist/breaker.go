@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package abstractlist
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const (
+	_defaultConsecutiveFailures = 5
+	_defaultFailureWindow       = 20
+	_defaultCoolDown            = 10 * time.Second
+)
+
+// CircuitBreakerConfig configures a per-peer circuit breaker.
+//
+// A peer's circuit opens after ConsecutiveFailures failed requests in a row,
+// or when at least FailureRate of the last Window requests failed.
+// While the circuit is open, the list does not choose the peer.
+// After CoolDown, the circuit half-opens and the list sends the peer a single
+// request: the circuit closes if that request succeeds and opens again if it
+// fails.
+//
+// Only errors that suggest a problem with the peer count as failures:
+// unavailable, deadline exceeded, internal, and unknown errors.
+//
+//	circuitBreaker:
+//	  consecutiveFailures: 5
+//	  failureRate: 0.5
+//	  window: 20
+//	  coolDown: 10s
+type CircuitBreakerConfig struct {
+	// ConsecutiveFailures is the number of failed requests in a row that
+	// open the circuit.
+	//
+	// Defaults to 5 if neither ConsecutiveFailures nor FailureRate is set.
+	ConsecutiveFailures int `config:"consecutiveFailures"`
+	// FailureRate is the fraction of failed requests, between 0 and 1, among
+	// the last Window requests that opens the circuit.
+	// The failure rate is disabled if zero.
+	FailureRate float64 `config:"failureRate"`
+	// Window is the number of most recent requests over which FailureRate is
+	// measured.
+	//
+	// Defaults to 20.
+	Window int `config:"window"`
+	// CoolDown is how long the circuit stays open before half-opening.
+	//
+	// Defaults to 10s.
+	CoolDown time.Duration `config:"coolDown"`
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c CircuitBreakerConfig) Validate() error {
+	if c.ConsecutiveFailures < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"circuit breaker consecutiveFailures must not be negative, got %d", c.ConsecutiveFailures)
+	}
+	if c.FailureRate < 0 || c.FailureRate > 1 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"circuit breaker failureRate must be between 0 and 1, got %v", c.FailureRate)
+	}
+	if c.Window < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"circuit breaker window must not be negative, got %d", c.Window)
+	}
+	if c.CoolDown < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"circuit breaker coolDown must not be negative, got %v", c.CoolDown)
+	}
+	return nil
+}
+
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if c.ConsecutiveFailures == 0 && c.FailureRate == 0 {
+		c.ConsecutiveFailures = _defaultConsecutiveFailures
+	}
+	if c.Window == 0 {
+		c.Window = _defaultFailureWindow
+	}
+	if c.CoolDown == 0 {
+		c.CoolDown = _defaultCoolDown
+	}
+	return c
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// breaker tracks the outcomes of requests sent to a single peer.
+//
+// breaker is not thread safe and must be used under a list lock.
+type breaker struct {
+	config *CircuitBreakerConfig
+
+	state breakerState
+	// probing indicates that the single request allowed through a
+	// half-open circuit is in flight.
+	probing bool
+	timer   *time.Timer
+
+	consecutive int
+	// outcomes is a ring of the most recent request outcomes, true for
+	// failures.
+	outcomes []bool
+	next     int
+	count    int
+	failures int
+}
+
+func newBreaker(config *CircuitBreakerConfig) *breaker {
+	return &breaker{
+		config:   config,
+		outcomes: make([]bool, config.Window),
+	}
+}
+
+// allows returns whether the peer may be chosen.
+func (b *breaker) allows() bool {
+	return b.state == breakerClosed || (b.state == breakerHalfOpen && !b.probing)
+}
+
+// record adds the outcome of a request while the circuit is closed and
+// returns whether the circuit should open.
+func (b *breaker) record(failed bool) bool {
+	if failed {
+		b.consecutive++
+	} else {
+		b.consecutive = 0
+	}
+
+	if b.count == len(b.outcomes) {
+		if b.outcomes[b.next] {
+			b.failures--
+		}
+	} else {
+		b.count++
+	}
+	b.outcomes[b.next] = failed
+	if failed {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.outcomes)
+
+	if b.config.ConsecutiveFailures > 0 && b.consecutive >= b.config.ConsecutiveFailures {
+		return true
+	}
+	return b.config.FailureRate > 0 &&
+		b.count == len(b.outcomes) &&
+		float64(b.failures) >= b.config.FailureRate*float64(b.count)
+}
+
+// reset forgets all recorded outcomes.
+func (b *breaker) reset() {
+	b.consecutive = 0
+	b.next = 0
+	b.count = 0
+	b.failures = 0
+}
+
+// stop cancels a pending transition to half-open.
+func (b *breaker) stop() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
+
+// isPeerFailure returns whether the error from a request suggests that the
+// peer is unhealthy.
+func isPeerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch yarpcerrors.FromError(err).Code() {
+	case yarpcerrors.CodeUnavailable,
+		yarpcerrors.CodeDeadlineExceeded,
+		yarpcerrors.CodeInternal,
+		yarpcerrors.CodeUnknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package abstractlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+func TestCircuitBreakerConfigValidate(t *testing.T) {
+	tests := []struct {
+		msg     string
+		config  CircuitBreakerConfig
+		wantErr string
+	}{
+		{msg: "zero value", config: CircuitBreakerConfig{}},
+		{
+			msg:    "valid",
+			config: CircuitBreakerConfig{ConsecutiveFailures: 3, FailureRate: 0.5, Window: 10, CoolDown: time.Second},
+		},
+		{
+			msg:     "negative consecutive failures",
+			config:  CircuitBreakerConfig{ConsecutiveFailures: -1},
+			wantErr: "consecutiveFailures must not be negative",
+		},
+		{
+			msg:     "failure rate above one",
+			config:  CircuitBreakerConfig{FailureRate: 1.5},
+			wantErr: "failureRate must be between 0 and 1",
+		},
+		{
+			msg:     "negative window",
+			config:  CircuitBreakerConfig{Window: -1},
+			wantErr: "window must not be negative",
+		},
+		{
+			msg:     "negative cool-down",
+			config:  CircuitBreakerConfig{CoolDown: -time.Second},
+			wantErr: "coolDown must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCircuitBreakerConfigDefaults(t *testing.T) {
+	assert.Equal(t, CircuitBreakerConfig{
+		ConsecutiveFailures: 5,
+		Window:              20,
+		CoolDown:            10 * time.Second,
+	}, CircuitBreakerConfig{}.withDefaults())
+
+	assert.Equal(t, CircuitBreakerConfig{
+		FailureRate: 0.5,
+		Window:      20,
+		CoolDown:    10 * time.Second,
+	}, CircuitBreakerConfig{FailureRate: 0.5}.withDefaults(),
+		"consecutive failures must not default when a failure rate is set")
+}
+
+func TestBreakerRecord(t *testing.T) {
+	tests := []struct {
+		msg      string
+		config   CircuitBreakerConfig
+		outcomes []bool
+		want     []bool
+	}{
+		{
+			msg:      "consecutive failures",
+			config:   CircuitBreakerConfig{ConsecutiveFailures: 3, Window: 10},
+			outcomes: []bool{true, true, false, true, true, true},
+			want:     []bool{false, false, false, false, false, true},
+		},
+		{
+			msg:      "failure rate waits for a full window",
+			config:   CircuitBreakerConfig{FailureRate: 0.5, Window: 4},
+			outcomes: []bool{true, true, false, false},
+			want:     []bool{false, false, false, true},
+		},
+		{
+			msg:      "failure rate slides",
+			config:   CircuitBreakerConfig{FailureRate: 0.5, Window: 4},
+			outcomes: []bool{true, false, false, false, false, true, true},
+			want:     []bool{false, false, false, false, false, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			b := newBreaker(&tt.config)
+			got := make([]bool, 0, len(tt.outcomes))
+			for _, failed := range tt.outcomes {
+				got = append(got, b.record(failed))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsPeerFailure(t *testing.T) {
+	assert.False(t, isPeerFailure(nil))
+	assert.True(t, isPeerFailure(yarpcerrors.UnavailableErrorf("unavailable")))
+	assert.True(t, isPeerFailure(yarpcerrors.DeadlineExceededErrorf("timeout")))
+	assert.True(t, isPeerFailure(errors.New("unknown")))
+	assert.False(t, isPeerFailure(yarpcerrors.InvalidArgumentErrorf("bad request")))
+	assert.False(t, isPeerFailure(yarpcerrors.CancelledErrorf("cancelled")))
+}
+
+func TestCircuitBreakerEjectsPeer(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	impl := &cycleList{}
+	list := New("cycle", fake, impl, NoShuffle(), FailFast(), CircuitBreaker(CircuitBreakerConfig{
+		ConsecutiveFailures: 2,
+		CoolDown:            20 * testtime.Millisecond,
+	}))
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1, id2},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	// choose returns the next peer and finishes the request with the error
+	// from fail for that peer.
+	choose := func(fail func(id string) error) string {
+		p, onFinish, err := list.Choose(ctx, &transport.Request{})
+		require.NoError(t, err)
+		onFinish(fail(p.Identifier()))
+		return p.Identifier()
+	}
+	failID1 := func(id string) error {
+		if id == id1.Identifier() {
+			return yarpcerrors.UnavailableErrorf("unavailable")
+		}
+		return nil
+	}
+
+	for i := 0; i < 4; i++ {
+		choose(failID1)
+	}
+	assert.Equal(t, 1, list.NumAvailable(), "failing peer must be ejected")
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, id2.Identifier(), choose(failID1))
+	}
+
+	status := list.Introspect()
+	assert.Equal(t, "Running (2/2 available)", status.State)
+	for _, ps := range status.Peers {
+		if ps.Identifier == id1.Identifier() {
+			assert.Equal(t, "Available, 0 pending request(s), circuit open", ps.State)
+		}
+	}
+
+	// After the cool-down, the peer receives a single probe request.
+	require.Eventually(t, func() bool { return list.NumAvailable() == 2 },
+		testtime.Second, testtime.Millisecond, "peer must half-open after the cool-down")
+
+	p, onFinish, err := list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	if p.Identifier() != id1.Identifier() {
+		onFinish(nil)
+		p, onFinish, err = list.Choose(ctx, &transport.Request{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, id1.Identifier(), p.Identifier())
+	assert.Equal(t, 1, list.NumAvailable(), "half-open peer must serve one request at a time")
+
+	// A successful probe closes the circuit.
+	onFinish(nil)
+	assert.Equal(t, 2, list.NumAvailable())
+	for _, ps := range list.Introspect().Peers {
+		assert.NotContains(t, ps.State, "circuit")
+	}
+}
+
+func TestCircuitBreakerFailedProbeReopens(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	list := New("cycle", fake, &cycleList{}, FailFast(), CircuitBreaker(CircuitBreakerConfig{
+		ConsecutiveFailures: 1,
+		CoolDown:            20 * testtime.Millisecond,
+	}))
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	_, onFinish, err := list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	onFinish(yarpcerrors.UnavailableErrorf("unavailable"))
+
+	_, _, err = list.Choose(ctx, &transport.Request{})
+	assert.True(t, yarpcerrors.IsUnavailable(err), "ejected peer must not be chosen")
+
+	require.Eventually(t, func() bool { return list.NumAvailable() == 1 },
+		testtime.Second, testtime.Millisecond)
+
+	_, onFinish, err = list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	assert.Contains(t, list.Introspect().Peers[0].State, "circuit half-open")
+	onFinish(yarpcerrors.DeadlineExceededErrorf("timeout"))
+
+	assert.Equal(t, 0, list.NumAvailable(), "failed probe must open the circuit again")
+	assert.Contains(t, list.Introspect().Peers[0].State, "circuit open")
+}
+
+func TestCircuitBreakerIgnoresApplicationErrors(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	list := New("cycle", fake, &cycleList{}, FailFast(), CircuitBreaker(CircuitBreakerConfig{
+		ConsecutiveFailures: 1,
+	}))
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	for i := 0; i < 3; i++ {
+		_, onFinish, err := list.Choose(context.Background(), &transport.Request{})
+		require.NoError(t, err)
+		onFinish(yarpcerrors.InvalidArgumentErrorf("bad request"))
+	}
+	assert.Equal(t, 1, list.NumAvailable())
+}
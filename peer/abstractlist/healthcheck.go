@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package abstractlist
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/health"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/zap"
+)
+
+const (
+	_defaultHealthCheckEncoding           = transport.Encoding("proto")
+	_defaultHealthCheckInterval           = 5 * time.Second
+	_defaultHealthCheckTimeout            = time.Second
+	_defaultHealthCheckUnhealthyThreshold = 2
+	_defaultHealthCheckHealthyThreshold   = 1
+
+	_healthCheckCaller = "yarpc-health-check"
+)
+
+// Prober is implemented by transports and outbounds that can send a request
+// directly to one of their peers, without choosing a peer from a list.
+//
+// The list sends health checks through the transport's Prober when active
+// health checks are enabled, unless another Prober is set with SetProber.
+type Prober interface {
+	Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error)
+}
+
+// HealthCheckConfig configures active health checks for every peer in the
+// list.
+//
+// While the list is running, it calls a health check procedure on each
+// connected peer every Interval, through the transport's Prober.
+// The list stops choosing a peer after UnhealthyThreshold health checks in a
+// row fail, even if its connection remains open, and chooses the peer again
+// after HealthyThreshold health checks in a row succeed.
+//
+// A health check fails if the call returns an error or an application error,
+// or does not complete within Timeout.
+// By default, the list calls the grpc.health.v1.Health::Check procedure that
+// dispatchers register for every service, and the health check also fails if
+// the peer reports that it is not serving.
+//
+//	healthCheck:
+//	  service: myservice
+//	  interval: 5s
+//	  timeout: 1s
+//	  unhealthyThreshold: 2
+//	  healthyThreshold: 1
+type HealthCheckConfig struct {
+	// Service is the name of the service that the peers serve.
+	Service string `config:"service"`
+	// Procedure is the health check procedure to call on every peer.
+	//
+	// Defaults to the grpc.health.v1.Health::Check procedure.
+	Procedure string `config:"procedure"`
+	// Encoding is the encoding of the health check request, which has an
+	// empty body.
+	//
+	// Defaults to proto.
+	Encoding transport.Encoding `config:"encoding"`
+	// Interval is how long the list waits between health checks of a peer.
+	//
+	// Defaults to 5s.
+	Interval time.Duration `config:"interval"`
+	// Timeout is how long the list waits for a health check to complete.
+	//
+	// Defaults to 1s.
+	Timeout time.Duration `config:"timeout"`
+	// UnhealthyThreshold is the number of failed health checks in a row that
+	// make a peer unhealthy.
+	//
+	// Defaults to 2.
+	UnhealthyThreshold int `config:"unhealthyThreshold"`
+	// HealthyThreshold is the number of successful health checks in a row
+	// that make an unhealthy peer healthy again.
+	//
+	// Defaults to 1.
+	HealthyThreshold int `config:"healthyThreshold"`
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c HealthCheckConfig) Validate() error {
+	if c.Service == "" {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"health check service is required")
+	}
+	if c.Interval < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"health check interval must not be negative, got %v", c.Interval)
+	}
+	if c.Timeout < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"health check timeout must not be negative, got %v", c.Timeout)
+	}
+	if c.UnhealthyThreshold < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"health check unhealthyThreshold must not be negative, got %d", c.UnhealthyThreshold)
+	}
+	if c.HealthyThreshold < 0 {
+		return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+			"health check healthyThreshold must not be negative, got %d", c.HealthyThreshold)
+	}
+	return nil
+}
+
+func (c HealthCheckConfig) withDefaults() HealthCheckConfig {
+	if c.Procedure == "" {
+		c.Procedure = health.CheckProcedure
+	}
+	if c.Encoding == "" {
+		c.Encoding = _defaultHealthCheckEncoding
+	}
+	if c.Interval == 0 {
+		c.Interval = _defaultHealthCheckInterval
+	}
+	if c.Timeout == 0 {
+		c.Timeout = _defaultHealthCheckTimeout
+	}
+	if c.UnhealthyThreshold == 0 {
+		c.UnhealthyThreshold = _defaultHealthCheckUnhealthyThreshold
+	}
+	if c.HealthyThreshold == 0 {
+		c.HealthyThreshold = _defaultHealthCheckHealthyThreshold
+	}
+	return c
+}
+
+// healthCheck tracks the outcomes of health checks sent to a single peer.
+//
+// healthCheck is not thread safe and must be used under a list lock.
+type healthCheck struct {
+	config *HealthCheckConfig
+
+	healthy     bool
+	consecutive int
+	timer       *time.Timer
+	stopped     bool
+}
+
+func newHealthCheck(config *HealthCheckConfig) *healthCheck {
+	return &healthCheck{
+		config:  config,
+		healthy: true,
+	}
+}
+
+// record adds the outcome of a health check and returns whether the peer's
+// health changed.
+func (h *healthCheck) record(failed bool) bool {
+	if failed == !h.healthy {
+		h.consecutive = 0
+		return false
+	}
+
+	h.consecutive++
+	threshold := h.config.UnhealthyThreshold
+	if !h.healthy {
+		threshold = h.config.HealthyThreshold
+	}
+	if h.consecutive < threshold {
+		return false
+	}
+	h.healthy = !h.healthy
+	h.consecutive = 0
+	return true
+}
+
+// stop cancels the next health check.
+func (h *healthCheck) stop() {
+	h.stopped = true
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+	}
+}
+
+// scheduleHealthCheck arranges for the peer to be checked after the delay.
+//
+// scheduleHealthCheck must be run under a list lock.
+func (pl *List) scheduleHealthCheck(pf *peerFacade, delay time.Duration) {
+	pf.health.timer = time.AfterFunc(delay, func() {
+		pl.checkHealth(pf)
+	})
+}
+
+// checkHealth sends a health check to the peer if it is connected, records
+// the outcome, and schedules the next health check.
+//
+// checkHealth must not be run under a list lock, since the health check
+// may take as long as its timeout.
+func (pl *List) checkHealth(pf *peerFacade) {
+	pl.lock.RLock()
+	stopped := pf.health.stopped
+	connected := pf.status.ConnectionStatus == peer.Available
+	prober := pl.prober
+	pl.lock.RUnlock()
+	if stopped {
+		return
+	}
+
+	var err error
+	if connected {
+		err = pl.probe(prober, pf)
+	}
+
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+
+	// The peer may have been removed while the health check was in flight.
+	if pf.health.stopped {
+		return
+	}
+	if connected {
+		pl.recordHealthCheck(pf, err)
+	}
+	pl.scheduleHealthCheck(pf, pf.health.config.Interval)
+}
+
+// SetProber sets the Prober through which the list sends health checks, in
+// place of its transport. Outbounds that own the list call SetProber so that
+// health checks reach peers with the same settings as requests, like TLS
+// configuration and URL templates.
+//
+// SetProber has no effect if health checks are disabled.
+func (pl *List) SetProber(prober Prober) {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	if pl.healthCheck != nil {
+		pl.prober = prober
+	}
+}
+
+// probe calls the health check procedure on the peer.
+func (pl *List) probe(prober Prober, pf *peerFacade) error {
+	config := pl.healthCheck
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	req := &transport.Request{
+		Caller:    _healthCheckCaller,
+		Service:   config.Service,
+		Procedure: config.Procedure,
+		Encoding:  config.Encoding,
+		Body:      bytes.NewReader(nil),
+	}
+	res, err := prober.Probe(ctx, pf.peer, req)
+	if err != nil {
+		return err
+	}
+	var body io.Reader = bytes.NewReader(nil)
+	if res.Body != nil {
+		body = res.Body
+		defer res.Body.Close()
+	}
+	if res.ApplicationError {
+		return fmt.Errorf("health check procedure %q returned an application error", config.Procedure)
+	}
+	if config.Procedure != health.CheckProcedure {
+		return nil
+	}
+
+	status, err := health.ReadCheckResponse(config.Encoding, body)
+	if err != nil {
+		return err
+	}
+	if status != health.Serving {
+		return fmt.Errorf("peer reported status %v", status)
+	}
+	return nil
+}
+
+// recordHealthCheck feeds the outcome of a health check to the peer's health
+// and stops or resumes choosing the peer when its health changes.
+//
+// recordHealthCheck must be run under a list lock.
+func (pl *List) recordHealthCheck(pf *peerFacade, err error) {
+	if !pf.health.record(err != nil) {
+		return
+	}
+
+	if pf.health.healthy {
+		pl.logger.Info("peer passed health checks",
+			zap.String("peerList", pl.name),
+			zap.String("peer", pf.id.Identifier()))
+	} else {
+		pl.logger.Warn("peer failed health checks",
+			zap.String("peerList", pl.name),
+			zap.String("peer", pf.id.Identifier()),
+			zap.Error(err))
+	}
+	pl.updateChoosable(pf)
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package abstractlist
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/yarpc/yarpctest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	pb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// probingTransport is a fake transport that answers health checks with the
+// response or error of a function of the probed peer.
+type probingTransport struct {
+	*yarpctest.FakeTransport
+
+	mu       sync.Mutex
+	requests []*transport.Request
+	respond  func(id string) (*transport.Response, error)
+}
+
+var _ Prober = (*probingTransport)(nil)
+
+func (t *probingTransport) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	respond := t.respond
+	t.mu.Unlock()
+	return respond(p.Identifier())
+}
+
+func (t *probingTransport) setRespond(respond func(id string) (*transport.Response, error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.respond = respond
+}
+
+func (t *probingTransport) probed() []*transport.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*transport.Request(nil), t.requests...)
+}
+
+func servingResponse(t *testing.T, status pb.HealthCheckResponse_ServingStatus) *transport.Response {
+	body, err := proto.Marshal(&pb.HealthCheckResponse{Status: status})
+	require.NoError(t, err)
+	return &transport.Response{Body: io.NopCloser(bytes.NewReader(body))}
+}
+
+func TestHealthCheckConfigValidate(t *testing.T) {
+	tests := []struct {
+		msg     string
+		config  HealthCheckConfig
+		wantErr string
+	}{
+		{msg: "minimal", config: HealthCheckConfig{Service: "foo"}},
+		{
+			msg: "valid",
+			config: HealthCheckConfig{
+				Service:            "foo",
+				Procedure:          "health",
+				Encoding:           "raw",
+				Interval:           time.Second,
+				Timeout:            time.Second,
+				UnhealthyThreshold: 3,
+				HealthyThreshold:   2,
+			},
+		},
+		{
+			msg:     "missing service",
+			config:  HealthCheckConfig{},
+			wantErr: "health check service is required",
+		},
+		{
+			msg:     "negative interval",
+			config:  HealthCheckConfig{Service: "foo", Interval: -time.Second},
+			wantErr: "interval must not be negative",
+		},
+		{
+			msg:     "negative timeout",
+			config:  HealthCheckConfig{Service: "foo", Timeout: -time.Second},
+			wantErr: "timeout must not be negative",
+		},
+		{
+			msg:     "negative unhealthy threshold",
+			config:  HealthCheckConfig{Service: "foo", UnhealthyThreshold: -1},
+			wantErr: "unhealthyThreshold must not be negative",
+		},
+		{
+			msg:     "negative healthy threshold",
+			config:  HealthCheckConfig{Service: "foo", HealthyThreshold: -1},
+			wantErr: "healthyThreshold must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestHealthCheckConfigDefaults(t *testing.T) {
+	assert.Equal(t, HealthCheckConfig{
+		Service:            "foo",
+		Procedure:          "grpc.health.v1.Health::Check",
+		Encoding:           "proto",
+		Interval:           5 * time.Second,
+		Timeout:            time.Second,
+		UnhealthyThreshold: 2,
+		HealthyThreshold:   1,
+	}, HealthCheckConfig{Service: "foo"}.withDefaults())
+}
+
+func TestHealthCheckRecord(t *testing.T) {
+	h := newHealthCheck(&HealthCheckConfig{UnhealthyThreshold: 2, HealthyThreshold: 2})
+
+	assert.False(t, h.record(true))
+	assert.False(t, h.record(false), "success must reset the failure count")
+	assert.False(t, h.record(true))
+	assert.True(t, h.record(true), "second failure in a row must make the peer unhealthy")
+	assert.False(t, h.healthy)
+
+	assert.False(t, h.record(false))
+	assert.False(t, h.record(true), "failure must reset the success count")
+	assert.False(t, h.record(false))
+	assert.True(t, h.record(false), "second success in a row must make the peer healthy")
+	assert.True(t, h.healthy)
+}
+
+func TestHealthCheckEjectsHungPeer(t *testing.T) {
+	pt := &probingTransport{
+		FakeTransport: yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available)),
+	}
+	hung := func(id string) (*transport.Response, error) {
+		if id == id1.Identifier() {
+			return nil, yarpcerrors.DeadlineExceededErrorf("timeout")
+		}
+		return servingResponse(t, pb.HealthCheckResponse_SERVING), nil
+	}
+	pt.setRespond(hung)
+
+	list := New("cycle", pt, &cycleList{}, FailFast(), HealthCheck(HealthCheckConfig{
+		Service:  "foo",
+		Interval: 5 * testtime.Millisecond,
+	}))
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1, id2},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	require.Eventually(t, func() bool { return list.NumAvailable() == 1 },
+		testtime.Second, testtime.Millisecond, "hung peer must be ejected")
+	assert.True(t, list.Available(id1), "hung peer must remain connected")
+	for i := 0; i < 4; i++ {
+		p, onFinish, err := list.Choose(context.Background(), &transport.Request{})
+		require.NoError(t, err)
+		assert.Equal(t, id2.Identifier(), p.Identifier())
+		onFinish(nil)
+	}
+	for _, ps := range list.Introspect().Peers {
+		if ps.Identifier == id1.Identifier() {
+			assert.Equal(t, "Available, 0 pending request(s), failing health checks", ps.State)
+		}
+	}
+
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return servingResponse(t, pb.HealthCheckResponse_SERVING), nil
+	})
+	require.Eventually(t, func() bool { return list.NumAvailable() == 2 },
+		testtime.Second, testtime.Millisecond, "recovered peer must be chosen again")
+
+	req := pt.probed()[0]
+	assert.Equal(t, "yarpc-health-check", req.Caller)
+	assert.Equal(t, "foo", req.Service)
+	assert.Equal(t, "grpc.health.v1.Health::Check", req.Procedure)
+	assert.Equal(t, transport.Encoding("proto"), req.Encoding)
+}
+
+func TestHealthCheckReadsServingStatus(t *testing.T) {
+	pt := &probingTransport{
+		FakeTransport: yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available)),
+	}
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return servingResponse(t, pb.HealthCheckResponse_NOT_SERVING), nil
+	})
+
+	list := New("cycle", pt, &cycleList{}, FailFast(), HealthCheck(HealthCheckConfig{
+		Service:            "foo",
+		Interval:           5 * testtime.Millisecond,
+		UnhealthyThreshold: 1,
+	}))
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	require.Eventually(t, func() bool { return list.NumAvailable() == 0 },
+		testtime.Second, testtime.Millisecond, "peer that is not serving must be ejected")
+
+	_, _, err := list.Choose(context.Background(), &transport.Request{})
+	assert.True(t, yarpcerrors.IsUnavailable(err))
+}
+
+func TestHealthCheckCustomProcedure(t *testing.T) {
+	pt := &probingTransport{
+		FakeTransport: yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available)),
+	}
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return &transport.Response{ApplicationError: true}, nil
+	})
+
+	list := New("cycle", pt, &cycleList{}, HealthCheck(HealthCheckConfig{
+		Service:            "foo",
+		Procedure:          "health",
+		Encoding:           "raw",
+		Interval:           5 * testtime.Millisecond,
+		UnhealthyThreshold: 1,
+	}))
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	require.Eventually(t, func() bool { return list.NumAvailable() == 0 },
+		testtime.Second, testtime.Millisecond, "application errors must fail health checks")
+
+	// Any successful response passes a custom health check.
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return &transport.Response{}, nil
+	})
+	require.Eventually(t, func() bool { return list.NumAvailable() == 1 },
+		testtime.Second, testtime.Millisecond)
+
+	req := pt.probed()[0]
+	assert.Equal(t, "health", req.Procedure)
+	assert.Equal(t, transport.Encoding("raw"), req.Encoding)
+}
+
+func TestHealthCheckSkipsDisconnectedPeers(t *testing.T) {
+	pt := &probingTransport{
+		FakeTransport: yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Unavailable)),
+	}
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return nil, yarpcerrors.UnavailableErrorf("unavailable")
+	})
+
+	list := New("cycle", pt, &cycleList{}, HealthCheck(HealthCheckConfig{
+		Service:  "foo",
+		Interval: testtime.Millisecond,
+	}))
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+
+	time.Sleep(20 * testtime.Millisecond)
+	assert.Empty(t, pt.probed(), "disconnected peers must not receive health checks")
+
+	require.NoError(t, list.Stop())
+}
+
+func TestHealthCheckStopsWithList(t *testing.T) {
+	pt := &probingTransport{
+		FakeTransport: yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available)),
+	}
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return servingResponse(t, pb.HealthCheckResponse_SERVING), nil
+	})
+
+	list := New("cycle", pt, &cycleList{}, HealthCheck(HealthCheckConfig{
+		Service:  "foo",
+		Interval: testtime.Millisecond,
+	}))
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	require.Eventually(t, func() bool { return len(pt.probed()) > 0 },
+		testtime.Second, testtime.Millisecond)
+	require.NoError(t, list.Stop())
+
+	// Allow a health check that was in flight to finish.
+	time.Sleep(5 * testtime.Millisecond)
+	n := len(pt.probed())
+	time.Sleep(20 * testtime.Millisecond)
+	assert.Equal(t, n, len(pt.probed()), "stopped list must not send health checks")
+}
+
+func TestHealthCheckSetProber(t *testing.T) {
+	pt := &probingTransport{
+		FakeTransport: yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available)),
+	}
+	pt.setRespond(func(string) (*transport.Response, error) {
+		return servingResponse(t, pb.HealthCheckResponse_NOT_SERVING), nil
+	})
+	outbound := &probingTransport{}
+	outbound.setRespond(func(string) (*transport.Response, error) {
+		return servingResponse(t, pb.HealthCheckResponse_SERVING), nil
+	})
+
+	list := New("cycle", pt, &cycleList{}, FailFast(), HealthCheck(HealthCheckConfig{
+		Service:            "foo",
+		Interval:           5 * testtime.Millisecond,
+		UnhealthyThreshold: 1,
+	}))
+	list.SetProber(outbound)
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	require.Eventually(t, func() bool { return len(outbound.probed()) >= 3 },
+		testtime.Second, testtime.Millisecond, "health checks must be sent through the prober")
+	assert.Empty(t, pt.probed(), "health checks must not be sent through the transport")
+	assert.Equal(t, 1, list.NumAvailable())
+}
+
+func TestHealthCheckRequiresProber(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	list := New("cycle", fake, &cycleList{}, Logger(zap.New(core)), HealthCheck(HealthCheckConfig{
+		Service: "foo",
+	}))
+
+	assert.Equal(t, 1, logs.FilterMessage("peer list transport does not support health checks, disabling health checks").Len())
+
+	prober := &probingTransport{}
+	prober.setRespond(func(string) (*transport.Response, error) {
+		return nil, yarpcerrors.UnavailableErrorf("unavailable")
+	})
+	list.SetProber(prober)
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	require.NoError(t, list.Start())
+	defer list.Stop()
+	assert.Equal(t, 1, list.NumAvailable())
+	assert.Empty(t, prober.probed(), "SetProber must not enable health checks")
+}
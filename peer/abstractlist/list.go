@@ -34,6 +34,7 @@ import (
 	"go.uber.org/yarpc/api/peer"
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/api/x/introspection"
+	"go.uber.org/yarpc/internal/attempt"
 	intyarpcerrors "go.uber.org/yarpc/internal/yarpcerrors"
 	"go.uber.org/yarpc/pkg/lifecycle"
 	"go.uber.org/yarpc/yarpcerrors"
@@ -80,6 +81,20 @@ type Subscriber interface {
 	UpdatePendingRequestCount(int)
 }
 
+// LatencySubscriber is a Subscriber that also observes how long requests to
+// its peer take.
+//
+// If the Subscriber an implementation returns for a peer is a
+// LatencySubscriber, the list measures the latency of every request it sends
+// to the peer, from the moment Choose returns the peer until the request
+// finishes, and reports it to ObserveLatency before updating the pending
+// request count.
+type LatencySubscriber interface {
+	Subscriber
+
+	ObserveLatency(time.Duration)
+}
+
 type options struct {
 	capacity             int
 	defaultChooseTimeout time.Duration
@@ -87,6 +102,8 @@ type options struct {
 	failFast             bool
 	seed                 int64
 	logger               *zap.Logger
+	circuitBreaker       *CircuitBreakerConfig
+	healthCheck          *HealthCheckConfig
 }
 
 var defaultOptions = options{
@@ -158,6 +175,35 @@ func DefaultChooseTimeout(timeout time.Duration) Option {
 	})
 }
 
+// CircuitBreaker enables a circuit breaker for every peer in the list, which
+// stops choosing a peer after it fails too many requests.
+// See CircuitBreakerConfig for details.
+//
+// Circuit breakers are disabled by default.
+func CircuitBreaker(config CircuitBreakerConfig) Option {
+	return optionFunc(func(options *options) {
+		config = config.withDefaults()
+		options.circuitBreaker = &config
+	})
+}
+
+// HealthCheck enables active health checks for every peer in the list, which
+// stops choosing a peer while it fails health checks, even if its connection
+// remains open.
+// See HealthCheckConfig for details.
+//
+// Health checks require a transport that implements Prober, like the HTTP,
+// gRPC and TChannel transports, and are disabled with a warning otherwise.
+// Outbounds that own the list may send the health checks themselves; see
+// SetProber.
+// Health checks are disabled by default.
+func HealthCheck(config HealthCheckConfig) Option {
+	return optionFunc(func(options *options) {
+		config = config.withDefaults()
+		options.healthCheck = &config
+	})
+}
+
 // New creates a new peer list with an identifier chooser for available peers.
 func New(name string, transport peer.Transport, implementation Implementation, opts ...Option) *List {
 	options := defaultOptions
@@ -170,6 +216,16 @@ func New(name string, transport peer.Transport, implementation Implementation, o
 		logger = zap.NewNop()
 	}
 
+	var prober Prober
+	if options.healthCheck != nil {
+		var ok bool
+		if prober, ok = transport.(Prober); !ok {
+			logger.Warn("peer list transport does not support health checks, disabling health checks",
+				zap.String("peerList", name))
+			options.healthCheck = nil
+		}
+	}
+
 	return &List{
 		once:               lifecycle.NewOnce(),
 		name:               name,
@@ -182,6 +238,9 @@ func New(name string, transport peer.Transport, implementation Implementation, o
 		failFast:           options.failFast,
 		randSrc:            rand.NewSource(options.seed),
 		peerAvailableEvent: make(chan struct{}, 1),
+		circuitBreaker:     options.circuitBreaker,
+		healthCheck:        options.healthCheck,
+		prober:             prober,
 	}
 }
 
@@ -220,6 +279,9 @@ type List struct {
 	noShuffle            bool
 	failFast             bool
 	randSrc              rand.Source
+	circuitBreaker       *CircuitBreakerConfig
+	healthCheck          *HealthCheckConfig
+	prober               Prober
 }
 
 // Name returns the name of the list.
@@ -234,9 +296,10 @@ func (pl *List) Transport() peer.Transport { return pl.transport }
 //
 // Updates must be serialized so no peer is removed if it is absent and no peer
 // is added if it is present.
-// Updates should not have overlapping additions and removals, but the list
-// will tollerate this case, but may cause existing connections to close and be
-// replaced.
+// An update that both removes and adds a peer that is in the list replaces
+// the peer's identifier in place, without releasing the peer, so updaters can
+// change attributes carried by identifiers, like weights, without closing
+// existing connections.
 //
 // Update will return errors if its invariants are violated, regardless of
 // whether updates are sent while the list is running.
@@ -262,8 +325,13 @@ func (pl *List) Update(updates peer.ListUpdates) error {
 
 // updateOnline must be run under a list lock.
 func (pl *List) updateOnline(updates peer.ListUpdates) error {
+	replaced := pl.replacements(updates)
+
 	var errs error
 	for _, id := range updates.Removals {
+		if _, ok := replaced[id.Identifier()]; ok {
+			continue
+		}
 		errs = multierr.Append(errs, pl.remove(id))
 	}
 
@@ -273,11 +341,61 @@ func (pl *List) updateOnline(updates peer.ListUpdates) error {
 	}
 
 	for _, id := range add {
+		if _, ok := replaced[id.Identifier()]; ok {
+			pl.replace(id)
+			continue
+		}
 		errs = multierr.Append(errs, pl.add(id))
 	}
 	return errs
 }
 
+// replacements returns the addresses of retained peers that the updates both
+// remove and add.
+//
+// replacements must be run under a list lock.
+func (pl *List) replacements(updates peer.ListUpdates) map[string]struct{} {
+	if len(updates.Removals) == 0 || len(updates.Additions) == 0 {
+		return nil
+	}
+
+	removed := make(map[string]struct{}, len(updates.Removals))
+	for _, id := range updates.Removals {
+		removed[id.Identifier()] = struct{}{}
+	}
+
+	var replaced map[string]struct{}
+	for _, id := range updates.Additions {
+		addr := id.Identifier()
+		if _, ok := removed[addr]; !ok {
+			continue
+		}
+		if _, ok := pl.peers[addr]; !ok {
+			continue
+		}
+		if replaced == nil {
+			replaced = make(map[string]struct{})
+		}
+		replaced[addr] = struct{}{}
+	}
+	return replaced
+}
+
+// replace swaps the identifier of a retained peer, giving the implementation
+// a chance to observe the new identifier.
+//
+// replace must be run under a list lock.
+func (pl *List) replace(id peer.Identifier) {
+	pf := pl.peers[id.Identifier()]
+	if pf.choosable {
+		pl.implementation.Remove(pf, pf.id, pf.subscriber)
+		pf.id = id
+		pf.subscriber = pl.implementation.Add(pf, id)
+		return
+	}
+	pf.id = id
+}
+
 // updateOffline must be run under a list lock.
 func (pl *List) updateOffline(updates peer.ListUpdates) error {
 	var errs error
@@ -356,6 +474,12 @@ func (pl *List) add(id peer.Identifier) error {
 
 	pf := &peerFacade{list: pl, id: id}
 	pf.onFinish = pl.onFinishFunc(pf)
+	if pl.circuitBreaker != nil {
+		pf.breaker = newBreaker(pl.circuitBreaker)
+	}
+	if pl.healthCheck != nil {
+		pf.health = newHealthCheck(pl.healthCheck)
+	}
 
 	// The transport must not call back before returning.
 	p, err := pl.transport.RetainPeer(id, pf)
@@ -367,6 +491,9 @@ func (pl *List) add(id peer.Identifier) error {
 	pl.peers[addr] = pf
 	pl.numPeers.Inc()
 	pl.notifyStatusChanged(pf)
+	if pf.health != nil {
+		pl.scheduleHealthCheck(pf, 0)
+	}
 
 	return nil
 }
@@ -394,12 +521,14 @@ func (pl *List) remove(id peer.Identifier) error {
 		return peer.ErrPeerRemoveNotInList(addr)
 	}
 
-	if pf.status.ConnectionStatus == peer.Available {
-		pl.numAvailable.Dec()
-		pl.implementation.Remove(pf, pf.id, pf.subscriber)
-		pf.subscriber = nil
-	}
 	pf.status.ConnectionStatus = peer.Unavailable
+	pl.updateChoosable(pf)
+	if pf.breaker != nil {
+		pf.breaker.stop()
+	}
+	if pf.health != nil {
+		pf.health.stop()
+	}
 
 	pl.numPeers.Dec()
 	delete(pl.peers, addr)
@@ -438,10 +567,17 @@ func (pl *List) Choose(ctx context.Context, req *transport.Request) (peer.Peer,
 		return nil, nil, intyarpcerrors.AnnotateWithInfo(yarpcerrors.FromError(err), "%q peer list is not running", pl.name)
 	}
 
+	// Concurrent attempts of the same call, like hedged requests, share a
+	// group so that they land on distinct peers when possible.
+	var group *attempt.Group
+	if a, ok := attempt.FromContext(ctx); ok {
+		group = a.Group
+	}
+
 	// Choose runs without a lock because it spends the bulk of its time in a
 	// wait loop.
 	for {
-		p := pl.choose(req)
+		p := pl.choose(req, group)
 		// choose signals that there are no available peers by returning nil.
 		// Thereafter, every Choose call will wait for a peer or peers to
 		// become available again.
@@ -456,7 +592,10 @@ func (pl *List) Choose(ctx context.Context, req *transport.Request) (peer.Peer,
 			// must trigger the rest to resume.
 			pl.notifyPeerAvailable()
 			pf := p.(*peerFacade)
-			pl.onStart(pf)
+			if pl.onStart(pf) {
+				start := time.Now()
+				return pf.peer, func(err error) { pl.onTimedFinish(pf, start, err) }, nil
+			}
 			return pf.peer, pf.onFinish, nil
 		}
 		if pl.failFast {
@@ -470,7 +609,7 @@ func (pl *List) Choose(ctx context.Context, req *transport.Request) (peer.Peer,
 
 // choose guards the underlying implementation's consistency around a lock, and
 // recovers the lock if the underlying list panics.
-func (pl *List) choose(req *transport.Request) peer.StatusPeer {
+func (pl *List) choose(req *transport.Request, group *attempt.Group) peer.StatusPeer {
 	// Even if all of the implementation provided by yarpc
 	// implements their own locking system - since v1.50.0
 	// this lock is needed for supporting potential
@@ -478,10 +617,42 @@ func (pl *List) choose(req *transport.Request) peer.StatusPeer {
 	pl.lock.Lock()
 	defer pl.lock.Unlock()
 
-	return pl.implementation.Choose(req)
+	p := pl.implementation.Choose(req)
+	if p == nil {
+		return nil
+	}
+	if group != nil {
+		p = pl.chooseDistinct(req, group, p)
+	}
+
+	// A peer with a half-open circuit breaker serves a single request until
+	// its outcome is known.
+	if pf := p.(*peerFacade); pf.breaker != nil && pf.breaker.state == breakerHalfOpen {
+		pf.breaker.probing = true
+		pl.updateChoosable(pf)
+	}
+	return p
 }
 
-func (pl *List) onStart(pf *peerFacade) {
+// chooseDistinct must be run under a list lock.
+func (pl *List) chooseDistinct(req *transport.Request, group *attempt.Group, p peer.StatusPeer) peer.StatusPeer {
+	// Ask the implementation for another peer while the chosen one is already
+	// serving an attempt of the same call, giving up after as many tries as
+	// there are available peers.
+	// Implementations that always choose the same peer for a request, like
+	// hash rings, fall back to that peer.
+	for i := 1; i < pl.NumAvailable() && group.Chosen(p.Identifier()); i++ {
+		if next := pl.implementation.Choose(req); next != nil {
+			p = next
+		}
+	}
+	group.Choose(p.Identifier())
+	return p
+}
+
+// onStart reports whether the implementation observes the latency of
+// requests to the peer.
+func (pl *List) onStart(pf *peerFacade) (timed bool) {
 	pl.lock.Lock()
 	defer pl.lock.Unlock()
 
@@ -489,16 +660,102 @@ func (pl *List) onStart(pf *peerFacade) {
 	if pf.subscriber != nil {
 		pf.subscriber.UpdatePendingRequestCount(pf.status.PendingRequestCount)
 	}
+	_, timed = pf.subscriber.(LatencySubscriber)
+	return timed
 }
 
 func (pl *List) onFinish(pf *peerFacade, err error) {
 	pl.lock.Lock()
 	defer pl.lock.Unlock()
 
+	pl.finish(pf, err)
+}
+
+func (pl *List) onTimedFinish(pf *peerFacade, start time.Time, err error) {
+	latency := time.Since(start)
+
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+
+	// The peer may have become unavailable, or replaced its subscriber,
+	// while the request was in flight.
+	if ls, ok := pf.subscriber.(LatencySubscriber); ok {
+		ls.ObserveLatency(latency)
+	}
+	pl.finish(pf, err)
+}
+
+// finish must be run under a list lock.
+func (pl *List) finish(pf *peerFacade, err error) {
 	pf.status.PendingRequestCount--
 	if pf.subscriber != nil {
 		pf.subscriber.UpdatePendingRequestCount(pf.status.PendingRequestCount)
 	}
+	if pf.breaker != nil {
+		pl.recordOutcome(pf, err)
+	}
+}
+
+// recordOutcome feeds the result of a request to the peer's circuit breaker.
+//
+// recordOutcome must be run under a list lock.
+func (pl *List) recordOutcome(pf *peerFacade, err error) {
+	b := pf.breaker
+	failed := isPeerFailure(err)
+
+	switch b.state {
+	case breakerClosed:
+		if b.record(failed) {
+			pl.openCircuit(pf)
+		}
+	case breakerHalfOpen:
+		b.probing = false
+		if failed {
+			pl.openCircuit(pf)
+		} else {
+			b.state = breakerClosed
+			b.reset()
+			pl.logger.Info("peer circuit breaker closed",
+				zap.String("peerList", pl.name),
+				zap.String("peer", pf.id.Identifier()))
+		}
+	case breakerOpen:
+		// Requests that were already in flight when the circuit opened do
+		// not count.
+		return
+	}
+	pl.updateChoosable(pf)
+}
+
+// openCircuit stops choosing the peer until the circuit breaker's cool-down
+// elapses.
+//
+// openCircuit must be run under a list lock.
+func (pl *List) openCircuit(pf *peerFacade) {
+	b := pf.breaker
+	b.state = breakerOpen
+	b.reset()
+	b.stop()
+	b.timer = time.AfterFunc(b.config.CoolDown, func() {
+		pl.halfOpenCircuit(pf)
+	})
+	pl.logger.Info("peer circuit breaker opened",
+		zap.String("peerList", pl.name),
+		zap.String("peer", pf.id.Identifier()),
+		zap.Duration("coolDown", b.config.CoolDown))
+}
+
+func (pl *List) halfOpenCircuit(pf *peerFacade) {
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+
+	// The peer may have been removed while its circuit was open.
+	if pl.peers[pf.id.Identifier()] != pf || pf.breaker.state != breakerOpen {
+		return
+	}
+	pf.breaker.state = breakerHalfOpen
+	pf.breaker.timer = nil
+	pl.updateChoosable(pf)
 }
 
 func (pl *List) onFinishFunc(pf *peerFacade) func(error) {
@@ -540,20 +797,33 @@ func (pl *List) notifyStatusChanged(pf *peerFacade) {
 		return
 	}
 
-	status := pf.peer.Status().ConnectionStatus
-	if pf.status.ConnectionStatus != status {
-		pf.status.ConnectionStatus = status
-		switch status {
-		case peer.Available:
-			sub := pf.list.implementation.Add(pf, pf.id)
-			pf.subscriber = sub
-			pl.numAvailable.Inc()
-			pf.list.notifyPeerAvailable()
-		default:
-			pl.numAvailable.Dec()
-			pf.list.implementation.Remove(pf, pf.id, pf.subscriber)
-			pf.subscriber = nil
-		}
+	pf.status.ConnectionStatus = pf.peer.Status().ConnectionStatus
+	pl.updateChoosable(pf)
+}
+
+// updateChoosable adds the peer to or removes it from the implementation
+// depending on whether it is available, its circuit breaker, if any, allows
+// it to be chosen, and it passes health checks, if enabled.
+//
+// updateChoosable must be run under a list lock.
+func (pl *List) updateChoosable(pf *peerFacade) {
+	choosable := pf.status.ConnectionStatus == peer.Available &&
+		(pf.breaker == nil || pf.breaker.allows()) &&
+		(pf.health == nil || pf.health.healthy)
+	if pf.choosable == choosable {
+		return
+	}
+
+	pf.choosable = choosable
+	if choosable {
+		sub := pl.implementation.Add(pf, pf.id)
+		pf.subscriber = sub
+		pl.numAvailable.Inc()
+		pl.notifyPeerAvailable()
+	} else {
+		pl.numAvailable.Dec()
+		pl.implementation.Remove(pf, pf.id, pf.subscriber)
+		pf.subscriber = nil
 	}
 }
 
@@ -611,6 +881,8 @@ func (pl *List) unavailablePeersMessage(err error) string {
 }
 
 // NumAvailable returns how many peers are available.
+// Peers whose circuit breaker is open or that fail health checks are not
+// available.
 func (pl *List) NumAvailable() int {
 	return int(pl.numAvailable.Load())
 }
@@ -720,11 +992,18 @@ func (pl *List) Introspect() introspection.ChooserStatus {
 
 	buildPeerStatus := func(pf *peerFacade) introspection.PeerStatus {
 		ps := pf.status
+		state := fmt.Sprintf("%s, %d pending request(s)",
+			ps.ConnectionStatus.String(),
+			ps.PendingRequestCount)
+		if pf.breaker != nil && pf.breaker.state != breakerClosed {
+			state += ", circuit " + pf.breaker.state.String()
+		}
+		if pf.health != nil && !pf.health.healthy {
+			state += ", failing health checks"
+		}
 		return introspection.PeerStatus{
 			Identifier: pf.peer.Identifier(),
-			State: fmt.Sprintf("%s, %d pending request(s)",
-				ps.ConnectionStatus.String(),
-				ps.PendingRequestCount),
+			State:      state,
 		}
 	}
 
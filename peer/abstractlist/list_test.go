@@ -22,6 +22,7 @@ package abstractlist
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"testing"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"go.uber.org/yarpc/api/peer"
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/api/x/introspection"
+	"go.uber.org/yarpc/internal/attempt"
 	"go.uber.org/yarpc/internal/testtime"
 	"go.uber.org/yarpc/peer/abstractpeer"
 	"go.uber.org/yarpc/peer/hostport"
@@ -406,3 +408,189 @@ func TestDefaultChooseTimeout(t *testing.T) {
 	_, _, err = list.Choose(ctx, req)
 	assert.NoError(t, err, "expected to choose peer without context deadline")
 }
+
+// cycling peer list implementation for the test.
+type cycleList struct {
+	peers []peer.StatusPeer
+	next  int
+}
+
+var _ Implementation = (*cycleList)(nil)
+
+func (l *cycleList) Add(p peer.StatusPeer, pid peer.Identifier) Subscriber {
+	l.peers = append(l.peers, p)
+	return &mraSub{}
+}
+
+func (l *cycleList) Remove(p peer.StatusPeer, pid peer.Identifier, ps Subscriber) {
+	for i, q := range l.peers {
+		if q == p {
+			l.peers = append(l.peers[:i], l.peers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *cycleList) Choose(req *transport.Request) peer.StatusPeer {
+	if len(l.peers) == 0 {
+		return nil
+	}
+	p := l.peers[l.next%len(l.peers)]
+	l.next++
+	return p
+}
+
+func TestChooseDistinctPeersForAttemptGroup(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	impl := &cycleList{}
+	list := New("cycle", fake, impl, NoShuffle())
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1, id2},
+	}))
+	require.NoError(t, list.Start())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	// Advance the cycle so that a naive choice would repeat the same peer.
+	group := attempt.NewGroup()
+	group.Choose(id1.Identifier())
+	impl.next = 0
+
+	p, onFinish, err := list.Choose(attempt.WithAttempt(ctx, attempt.Attempt{Index: 1, Group: group}), &transport.Request{})
+	require.NoError(t, err)
+	onFinish(nil)
+	assert.Equal(t, id2.Identifier(), p.Identifier(), "hedged attempt must avoid the peer of the primary")
+	assert.True(t, group.Chosen(id2.Identifier()))
+
+	// With every peer taken, fall back to whatever the implementation chooses.
+	p, onFinish, err = list.Choose(attempt.WithAttempt(ctx, attempt.Attempt{Index: 2, Group: group}), &transport.Request{})
+	require.NoError(t, err)
+	onFinish(nil)
+	assert.Contains(t, []string{id1.Identifier(), id2.Identifier()}, p.Identifier())
+}
+
+// taggedID is an identifier that carries an attribute alongside its address.
+type taggedID struct {
+	addr string
+	tag  string
+}
+
+func (id taggedID) Identifier() string { return id.addr }
+
+// recordingList records the identifiers the list adds and removes.
+type recordingList struct {
+	mraList
+
+	added   []peer.Identifier
+	removed []peer.Identifier
+}
+
+func (l *recordingList) Add(p peer.StatusPeer, pid peer.Identifier) Subscriber {
+	l.added = append(l.added, pid)
+	return l.mraList.Add(p, pid)
+}
+
+func (l *recordingList) Remove(p peer.StatusPeer, pid peer.Identifier, ps Subscriber) {
+	l.removed = append(l.removed, pid)
+	l.mraList.Remove(p, pid, ps)
+}
+
+func TestReplaceIdentifier(t *testing.T) {
+	const addr = "1.1.1.1:4040"
+
+	// Releasing the peer would fail, so a replacement must not release it.
+	fake := yarpctest.NewFakeTransport(
+		yarpctest.InitialConnectionStatus(peer.Available),
+		yarpctest.ReleaseErrors(errors.New("released"), []string{addr}),
+	)
+	impl := &recordingList{}
+	list := New("recording", fake, impl, NoShuffle())
+	require.NoError(t, list.Start())
+
+	before := taggedID{addr: addr, tag: "before"}
+	after := taggedID{addr: addr, tag: "after"}
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{before},
+	}))
+	fake.Flush()
+	require.Equal(t, []peer.Identifier{before}, impl.added)
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{after},
+		Removals:  []peer.Identifier{before},
+	}))
+	assert.Equal(t, []peer.Identifier{before, after}, impl.added, "implementation must see the new identifier")
+	assert.Equal(t, []peer.Identifier{before}, impl.removed, "implementation must forget the old identifier")
+	assert.Equal(t, 1, list.NumAvailable())
+	assert.True(t, list.Available(after))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+	p, onFinish, err := list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	onFinish(nil)
+	assert.Equal(t, addr, p.Identifier())
+
+	// Replacing a peer that is not in the list adds it like any other.
+	other := taggedID{addr: "2.2.2.2:4040", tag: "after"}
+	assert.Error(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{other},
+		Removals:  []peer.Identifier{taggedID{addr: "2.2.2.2:4040", tag: "before"}},
+	}), "removing an unknown peer must still fail")
+}
+
+// latencyList records the latencies its subscribers observe.
+type latencyList struct {
+	mraList
+
+	latencies []time.Duration
+}
+
+func (l *latencyList) Add(p peer.StatusPeer, pid peer.Identifier) Subscriber {
+	l.mraList.Add(p, pid)
+	return &latencySub{list: l}
+}
+
+type latencySub struct {
+	mraSub
+
+	list *latencyList
+}
+
+func (s *latencySub) ObserveLatency(latency time.Duration) {
+	s.list.latencies = append(s.list.latencies, latency)
+}
+
+func TestObserveLatency(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	impl := &latencyList{}
+	list := New("latency", fake, impl)
+	require.NoError(t, list.Start())
+	defer func() { assert.NoError(t, list.Stop()) }()
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{id1},
+	}))
+	fake.Flush()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+	p, onFinish, err := list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	onFinish(nil)
+
+	assert.Equal(t, id1.Identifier(), p.Identifier())
+	require.Len(t, impl.latencies, 1)
+	assert.True(t, impl.latencies[0] >= time.Millisecond, "latency must cover the request")
+
+	// Requests in flight while the peer becomes unavailable are not observed.
+	_, onFinish, err = list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	fake.SimulateDisconnect(id1)
+	onFinish(nil)
+	assert.Len(t, impl.latencies, 1)
+}
@@ -40,6 +40,12 @@ type peerFacade struct {
 	status     peer.Status
 	subscriber Subscriber
 	onFinish   func(error)
+	// choosable indicates that the peer is in the implementation.
+	choosable bool
+	// breaker is nil unless the list has circuit breakers enabled.
+	breaker *breaker
+	// health is nil unless the list has health checks enabled.
+	health *healthCheck
 }
 
 // StartRequest is vestigial.
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Configuration describes how to build an aperture peer list.
+type Configuration struct {
+	Capacity *int `config:"capacity"`
+	// ClientIndex and ClientCount place the list on the ring as one of
+	// ClientCount clients of the same service.
+	ClientIndex *int `config:"clientIndex"`
+	ClientCount *int `config:"clientCount"`
+	// ClientID places the list on the ring by a hash, if ClientIndex and
+	// ClientCount are absent.
+	ClientID string `config:"clientID,interpolate"`
+	// MinAperture is the minimum number of peers to connect to. Defaults
+	// to 10.
+	MinAperture *int `config:"minAperture"`
+	// LowLoad and HighLoad are the average numbers of pending requests per
+	// peer below which the aperture narrows and above which it widens.
+	// Default to 0.5 and 2.
+	LowLoad  *float64 `config:"lowLoad"`
+	HighLoad *float64 `config:"highLoad"`
+	FailFast bool     `config:"failFast"`
+	// DefaultChooseTimeout specifies the deadline to add to Choose calls if not
+	// present. This enables calls without deadlines, ie streaming, to choose
+	// peers without waiting indefinitely.
+	DefaultChooseTimeout *time.Duration `config:"defaultChooseTimeout"`
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
+}
+
+// Spec returns a configuration specification for the aperture peer list
+// implementation.
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(aperture.Spec())
+//
+// This enables the aperture peer list:
+//
+//	outbounds:
+//	  otherservice:
+//	    unary:
+//	      http:
+//	        url: https://host:port/rpc
+//	        aperture:
+//	          clientIndex: ${INSTANCE_INDEX}
+//	          clientCount: ${INSTANCE_COUNT}
+//	          minAperture: 12
+//	          peers:
+//	            - 127.0.0.1:8080
+//	            - 127.0.0.1:8081
+func Spec() yarpcconfig.PeerListSpec {
+	return SpecWithOptions()
+}
+
+// SpecWithOptions accepts additional list constructor options.
+func SpecWithOptions(options ...ListOption) yarpcconfig.PeerListSpec {
+	return yarpcconfig.PeerListSpec{
+		Name: "aperture",
+		BuildPeerList: func(cfg Configuration, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error) {
+			opts := make([]ListOption, 0, len(options)+8)
+
+			opts = append(opts, options...)
+
+			if cfg.Capacity != nil {
+				if *cfg.Capacity <= 0 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"Capacity must be greater than 0. Got: %d.", *cfg.Capacity)
+				}
+				opts = append(opts, Capacity(*cfg.Capacity))
+			}
+
+			if cfg.ClientID != "" {
+				opts = append(opts, ClientID(cfg.ClientID))
+			}
+			if cfg.ClientIndex != nil || cfg.ClientCount != nil {
+				if cfg.ClientIndex == nil || cfg.ClientCount == nil {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"ClientIndex and ClientCount must be set together.")
+				}
+				index, count := *cfg.ClientIndex, *cfg.ClientCount
+				if count <= 0 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"ClientCount must be greater than 0. Got: %d.", count)
+				}
+				if index < 0 || index >= count {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"ClientIndex must be between 0 and ClientCount-1. Got: %d.", index)
+				}
+				opts = append(opts, Coordinate(index, count))
+			}
+			if cfg.MinAperture != nil {
+				if *cfg.MinAperture <= 0 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"MinAperture must be greater than 0. Got: %d.", *cfg.MinAperture)
+				}
+				opts = append(opts, MinAperture(*cfg.MinAperture))
+			}
+			if cfg.LowLoad != nil || cfg.HighLoad != nil {
+				low, high := defaultListOptions.lowLoad, defaultListOptions.highLoad
+				if cfg.LowLoad != nil {
+					low = *cfg.LowLoad
+				}
+				if cfg.HighLoad != nil {
+					high = *cfg.HighLoad
+				}
+				if low < 0 || high <= low {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"LowLoad must be at least 0 and less than HighLoad. Got: %v and %v.", low, high)
+				}
+				opts = append(opts, LoadBand(low, high))
+			}
+			if cfg.FailFast {
+				opts = append(opts, FailFast())
+			}
+			if cfg.DefaultChooseTimeout != nil {
+				opts = append(opts, DefaultChooseTimeout(*cfg.DefaultChooseTimeout))
+			}
+			if cfg.CircuitBreaker != nil {
+				if err := cfg.CircuitBreaker.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, CircuitBreaker(*cfg.CircuitBreaker))
+			}
+			if cfg.HealthCheck != nil {
+				if err := cfg.HealthCheck.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, HealthCheck(*cfg.HealthCheck))
+			}
+			return New(t, opts...), nil
+		},
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+type attrs map[string]interface{}
+
+func loadConfig(listConfig attrs) error {
+	cfg := yarpcconfig.New()
+	cfg.RegisterPeerList(Spec())
+	cfg.RegisterTransport(yarpctest.FakeTransportSpec())
+	_, err := cfg.LoadConfig("our-service", attrs{
+		"outbounds": attrs{
+			"their-service": attrs{
+				"fake-transport": attrs{
+					"aperture": listConfig,
+				},
+			},
+		},
+	})
+	return err
+}
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		desc string
+		give attrs
+	}{
+		{
+			desc: "coordinate",
+			give: attrs{
+				"clientIndex": 3,
+				"clientCount": 10,
+				"minAperture": 4,
+				"lowLoad":     1,
+				"highLoad":    3,
+				"failFast":    true,
+			},
+		},
+		{
+			desc: "client ID",
+			give: attrs{
+				"clientID": "host-1",
+				"highLoad": 4,
+			},
+		},
+		{
+			desc: "defaults",
+			give: attrs{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.give["peers"] = []string{"1.1.1.1:1111", "2.2.2.2:2222"}
+			require.NoError(t, loadConfig(tt.give))
+		})
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    attrs
+		wantErr string
+	}{
+		{
+			desc:    "capacity",
+			give:    attrs{"capacity": 0},
+			wantErr: "Capacity must be greater than 0",
+		},
+		{
+			desc:    "client index without count",
+			give:    attrs{"clientIndex": 1},
+			wantErr: "ClientIndex and ClientCount must be set together",
+		},
+		{
+			desc:    "client count",
+			give:    attrs{"clientIndex": 0, "clientCount": 0},
+			wantErr: "ClientCount must be greater than 0",
+		},
+		{
+			desc:    "client index",
+			give:    attrs{"clientIndex": 5, "clientCount": 5},
+			wantErr: "ClientIndex must be between 0 and ClientCount-1",
+		},
+		{
+			desc:    "min aperture",
+			give:    attrs{"minAperture": 0},
+			wantErr: "MinAperture must be greater than 0",
+		},
+		{
+			desc:    "load band",
+			give:    attrs{"lowLoad": 3},
+			wantErr: "LowLoad must be at least 0 and less than HighLoad",
+		},
+		{
+			desc:    "circuit breaker",
+			give:    attrs{"circuitBreaker": attrs{"failureRate": 2}},
+			wantErr: "failureRate must be between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.give["peers"] = []string{"1.1.1.1:1111"}
+			err := loadConfig(tt.give)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package aperture provides a peer list that connects to a small,
+// deterministic subset of a large peer set, for services with so many peers
+// that connecting every client to every peer would be too expensive.
+//
+// Every list orders the full peer set on the same ring, by a hash of each
+// peer's address, and places itself on that ring at an offset derived from
+// its client coordinate. The list retains, and so connects to, only the peers
+// in the window of the ring (its aperture) that starts at its offset:
+//
+//	list := aperture.New(transport, aperture.Coordinate(instanceIndex, instanceCount))
+//
+// When every client of a service knows its index among the instanceCount
+// clients, the clients' windows tile the ring evenly and every peer carries
+// about the same number of clients. Without a coordinate, a list places
+// itself at a hash of its ClientID, or at a random offset, which spreads
+// clients evenly on average.
+//
+// The aperture spans at least MinAperture peers, and at least enough peers to
+// cover the client's share of the ring. Under load, when the average number
+// of pending requests per available peer in the aperture exceeds the high
+// watermark of the LoadBand, the list widens its aperture one peer at a
+// time, and narrows it again once the load falls below the low watermark.
+// The list also widens its aperture while none of its peers are available.
+//
+// Because the ring only depends on the addresses of peers, adding or
+// removing a peer changes the subset of any one client by at most a couple
+// of peers, keeping connection churn low as the peer set changes.
+//
+// Within its aperture, the list chooses the less loaded of two random
+// available peers.
+package aperture
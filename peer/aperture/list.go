@@ -0,0 +1,463 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/multierr"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/x/introspection"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/peer/tworandomchoices"
+	"go.uber.org/zap"
+)
+
+const _adjustInterval = 500 * time.Millisecond
+
+type listOptions struct {
+	capacity             int
+	coordinate           *coordinate
+	minAperture          int
+	lowLoad              float64
+	highLoad             float64
+	failFast             bool
+	defaultChooseTimeout *time.Duration
+	logger               *zap.Logger
+	circuitBreaker       *abstractlist.CircuitBreakerConfig
+	healthCheck          *abstractlist.HealthCheckConfig
+
+	// for tests
+	adjustInterval time.Duration
+	now            func() time.Time
+}
+
+var defaultListOptions = listOptions{
+	capacity:       10,
+	minAperture:    10,
+	lowLoad:        0.5,
+	highLoad:       2,
+	adjustInterval: _adjustInterval,
+	now:            time.Now,
+}
+
+// ListOption customizes the behavior of an aperture list.
+type ListOption interface {
+	apply(*listOptions)
+}
+
+type listOptionFunc func(*listOptions)
+
+func (f listOptionFunc) apply(options *listOptions) { f(options) }
+
+// Capacity specifies the default capacity of the underlying
+// data structures for this list.
+//
+// Defaults to 10.
+func Capacity(capacity int) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.capacity = capacity
+	})
+}
+
+// Coordinate places the list on the ring as the client with the given index
+// among count clients of the same service. When every client uses a distinct
+// index, their apertures cover the peers evenly.
+//
+// Coordinate takes precedence over ClientID.
+func Coordinate(index, count int) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		if count <= 0 {
+			return
+		}
+		c := clientCoordinate(index, count)
+		options.coordinate = &c
+	})
+}
+
+// ClientID places the list on the ring at a position derived from the given
+// ID, like a host name, for clients that do not know their index among the
+// clients of a service.
+//
+// Without a Coordinate or ClientID, the list places itself at a random
+// position.
+func ClientID(id string) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		if options.coordinate != nil && options.coordinate.width > 0 {
+			return
+		}
+		c := idCoordinate(id)
+		options.coordinate = &c
+	})
+}
+
+// MinAperture specifies the minimum number of peers the list connects to.
+//
+// Defaults to 10.
+func MinAperture(aperture int) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.minAperture = aperture
+	})
+}
+
+// LoadBand specifies the average number of pending requests per available
+// peer above which the list widens its aperture, and below which it narrows
+// it again.
+//
+// Defaults to 0.5 and 2.
+func LoadBand(low, high float64) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.lowLoad = low
+		options.highLoad = high
+	})
+}
+
+// FailFast indicates that the peer list should not wait for a peer to become
+// available when choosing a peer.
+//
+// This option is preferrable when the better failure mode is to retry from the
+// origin, since another proxy instance might already have a connection.
+func FailFast() ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.failFast = true
+	})
+}
+
+// Logger specifies a logger.
+func Logger(logger *zap.Logger) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.logger = logger
+	})
+}
+
+// DefaultChooseTimeout specifies the default timeout to add to 'Choose' calls
+// without context deadlines. This prevents long-lived streams from setting
+// calling deadlines.
+//
+// Defaults to 500ms.
+func DefaultChooseTimeout(timeout time.Duration) ListOption {
+	return listOptionFunc(func(c *listOptions) {
+		c.defaultChooseTimeout = &timeout
+	})
+}
+
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.circuitBreaker = &config
+	})
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.healthCheck = &config
+	})
+}
+
+// New creates a new aperture peer list.
+func New(transport peer.Transport, opts ...ListOption) *List {
+	options := defaultListOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.minAperture < 1 {
+		options.minAperture = 1
+	}
+
+	coord := coordinate{offset: rand.Float64()}
+	if options.coordinate != nil {
+		coord = *options.coordinate
+	}
+
+	plOpts := []abstractlist.Option{
+		abstractlist.Capacity(options.capacity),
+	}
+	if options.logger != nil {
+		plOpts = append(plOpts, abstractlist.Logger(options.logger))
+	}
+	if options.failFast {
+		plOpts = append(plOpts, abstractlist.FailFast())
+	}
+	if options.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*options.circuitBreaker))
+	}
+	if options.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*options.healthCheck))
+	}
+	if options.defaultChooseTimeout != nil {
+		plOpts = append(plOpts, abstractlist.DefaultChooseTimeout(*options.defaultChooseTimeout))
+	}
+
+	logger := options.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	impl := newLoadList(tworandomchoices.NewImplementation())
+	return &List{
+		list:       abstractlist.New("aperture", transport, impl, plOpts...),
+		impl:       impl,
+		options:    options,
+		coordinate: coord,
+		logger:     logger,
+		peers:      make(map[string]peer.Identifier, options.capacity),
+		subset:     make(map[string]peer.Identifier, options.minAperture),
+	}
+}
+
+// List is a PeerList that retains and chooses among a deterministic subset
+// of its peers.
+type List struct {
+	list       *abstractlist.List
+	impl       *loadList
+	options    listOptions
+	coordinate coordinate
+	logger     *zap.Logger
+
+	m sync.Mutex
+	// peers holds every peer in the list, and subset the peers within the
+	// aperture, which the underlying list retains.
+	peers  map[string]peer.Identifier
+	subset map[string]peer.Identifier
+	ring   []peer.Identifier
+	// extra is the number of peers by which load has widened the aperture.
+	extra int
+
+	nextAdjust atomic.Int64
+}
+
+// Start causes the peer list to start.
+//
+// Starting will retain all peers within the aperture that have been added
+// but not removed the first time it is called.
+//
+// Start may be called any number of times and in any order in relation to Stop
+// but will only cause the list to start the first time, and only if it has not
+// already been stopped.
+func (l *List) Start() error {
+	return l.list.Start()
+}
+
+// Stop causes the peer list to stop.
+//
+// Stopping will release all retained peers to the underlying transport.
+//
+// Stop may be called any number of times and in order in relation to Start but
+// will only cause the list to stop the first time, and only if it has
+// previously been started.
+func (l *List) Stop() error {
+	return l.list.Stop()
+}
+
+// IsRunning returns whether the list has started and not yet stopped.
+func (l *List) IsRunning() bool {
+	return l.list.IsRunning()
+}
+
+// Choose returns a peer, suitable for sending a request.
+//
+// The peer is not guaranteed to be connected and available, but the peer list
+// makes every attempt to ensure this and minimize the probability that a
+// chosen peer will fail to carry a request.
+func (l *List) Choose(ctx context.Context, req *transport.Request) (peer peer.Peer, onFinish func(error), err error) {
+	l.adjust()
+	return l.list.Choose(ctx, req)
+}
+
+// Update may add and remove logical peers in the list.
+//
+// The list only retains the peers that fall within its aperture, and
+// forwards updates to them to the underlying list.
+func (l *List) Update(updates peer.ListUpdates) error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	var errs error
+	var replaced map[string]struct{}
+	removed := make(map[string]struct{}, len(updates.Removals))
+	for _, id := range updates.Removals {
+		addr := id.Identifier()
+		if _, ok := l.peers[addr]; !ok {
+			errs = multierr.Append(errs, peer.ErrPeerRemoveNotInList(addr))
+			continue
+		}
+		delete(l.peers, addr)
+		removed[addr] = struct{}{}
+	}
+	for _, id := range updates.Additions {
+		addr := id.Identifier()
+		if _, ok := l.peers[addr]; ok {
+			errs = multierr.Append(errs, peer.ErrPeerAddAlreadyInList(addr))
+			continue
+		}
+		l.peers[addr] = id
+		if _, ok := removed[addr]; ok {
+			if replaced == nil {
+				replaced = make(map[string]struct{})
+			}
+			replaced[addr] = struct{}{}
+		}
+	}
+
+	if len(removed) > 0 || len(updates.Additions) > 0 {
+		l.ring = ring(l.peers)
+	}
+	return multierr.Append(errs, l.apply(replaced))
+}
+
+// apertureSize returns the number of peers the aperture spans.
+//
+// apertureSize must be run under the list lock.
+func (l *List) apertureSize() (size, base int) {
+	n := len(l.ring)
+	_, base = l.coordinate.span(n)
+	if base < l.options.minAperture {
+		base = l.options.minAperture
+	}
+	if base > n {
+		base = n
+	}
+	size = base + l.extra
+	if size > n {
+		size = n
+	}
+	return size, base
+}
+
+// apply retains the peers within the aperture and releases those outside
+// it. Peers whose identifiers were replaced stay retained with their new
+// identifiers.
+//
+// apply must be run under the list lock.
+func (l *List) apply(replaced map[string]struct{}) error {
+	start, _ := l.coordinate.span(len(l.ring))
+	size, _ := l.apertureSize()
+	ids := window(l.ring, start, size)
+
+	subset := make(map[string]peer.Identifier, len(ids))
+	var updates peer.ListUpdates
+	for _, id := range ids {
+		addr := id.Identifier()
+		subset[addr] = id
+		if old, ok := l.subset[addr]; ok {
+			if _, ok := replaced[addr]; ok {
+				updates.Removals = append(updates.Removals, old)
+				updates.Additions = append(updates.Additions, id)
+			}
+			continue
+		}
+		updates.Additions = append(updates.Additions, id)
+	}
+	for addr, id := range l.subset {
+		if _, ok := subset[addr]; !ok {
+			updates.Removals = append(updates.Removals, id)
+		}
+	}
+	l.subset = subset
+
+	return l.list.Update(updates)
+}
+
+// adjust widens the aperture when its peers are overloaded or unavailable,
+// and narrows it when they are underloaded, at most once per adjustment
+// interval.
+func (l *List) adjust() {
+	now := l.options.now().UnixNano()
+	if now < l.nextAdjust.Load() {
+		return
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if now < l.nextAdjust.Load() {
+		return
+	}
+	l.nextAdjust.Store(now + int64(l.options.adjustInterval))
+
+	if !l.list.IsRunning() {
+		return
+	}
+
+	load, available := l.impl.load()
+	size, base := l.apertureSize()
+	switch {
+	case (available == 0 || load > l.options.highLoad) && size < len(l.ring):
+		l.extra++
+	case available > 0 && load < l.options.lowLoad && available >= size && size > base:
+		l.extra = size - base - 1
+	default:
+		return
+	}
+
+	if err := l.apply(nil); err != nil {
+		l.logger.Warn("failed to resize peer list aperture", zap.Error(err))
+	}
+}
+
+// NotifyStatusChanged forwards a status change notification to an individual
+// peer in the list.
+//
+// This satisfies the peer.Subscriber interface and should only be used to
+// send notifications in tests.
+// The list's RetainPeer and ReleasePeer methods deal with an individual
+// peer.Subscriber instance for each peer in the list, avoiding a map lookup.
+func (l *List) NotifyStatusChanged(pid peer.Identifier) {
+	l.list.NotifyStatusChanged(pid)
+}
+
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
+// Introspect reveals information about the list to the internal YARPC
+// introspection system, including the size of its aperture.
+func (l *List) Introspect() introspection.ChooserStatus {
+	status := l.list.Introspect()
+
+	l.m.Lock()
+	size, _ := l.apertureSize()
+	n := len(l.ring)
+	l.m.Unlock()
+
+	status.State += fmt.Sprintf(", aperture: %d of %d peers", size, n)
+	return status
+}
+
+// Peers produces a slice of all retained peers, which are the peers within
+// the aperture.
+func (l *List) Peers() []peer.StatusPeer {
+	return l.list.Peers()
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+func identifiers(peers map[string]peer.Identifier) []peer.Identifier {
+	ids := make([]peer.Identifier, 0, len(peers))
+	for _, id := range peers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func newTestList(t *testing.T, status peer.ConnectionStatus, opts ...ListOption) (*List, *yarpctest.FakeTransport) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(status))
+	opts = append([]ListOption{FailFast()}, opts...)
+	list := New(fake, opts...)
+	list.options.adjustInterval = 0
+	require.NoError(t, list.Start())
+	t.Cleanup(func() { assert.NoError(t, list.Stop()) })
+	return list, fake
+}
+
+func retained(list *List) map[string]struct{} {
+	addrs := make(map[string]struct{})
+	for _, p := range list.Peers() {
+		addrs[p.Identifier()] = struct{}{}
+	}
+	return addrs
+}
+
+func TestSubset(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []ListOption
+		wantSize int
+	}{
+		{
+			desc:     "share of ring",
+			opts:     []ListOption{Coordinate(3, 10), MinAperture(5)},
+			wantSize: 10,
+		},
+		{
+			desc:     "min aperture",
+			opts:     []ListOption{Coordinate(3, 100), MinAperture(5)},
+			wantSize: 5,
+		},
+		{
+			desc:     "client ID",
+			opts:     []ListOption{ClientID("host-1")},
+			wantSize: 10,
+		},
+		{
+			desc:     "random",
+			wantSize: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			peers := peerMap(100)
+			list, _ := newTestList(t, peer.Available, tt.opts...)
+			require.NoError(t, list.Update(peer.ListUpdates{Additions: identifiers(peers)}))
+			assert.Len(t, list.Peers(), tt.wantSize)
+		})
+	}
+}
+
+func TestSubsetMatchesRing(t *testing.T) {
+	peers := peerMap(50)
+	list, _ := newTestList(t, peer.Available, Coordinate(2, 5))
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: identifiers(peers)}))
+
+	want := make(map[string]struct{})
+	for _, id := range window(ring(peers), 20, 10) {
+		want[id.Identifier()] = struct{}{}
+	}
+	assert.Equal(t, want, retained(list))
+}
+
+func TestSubsetIsStable(t *testing.T) {
+	peers := peerMap(100)
+	list, _ := newTestList(t, peer.Available, Coordinate(4, 10))
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: identifiers(peers)}))
+	before := retained(list)
+
+	extra := peerMap(110)
+	for addr := range peers {
+		delete(extra, addr)
+	}
+	for _, id := range identifiers(extra) {
+		require.NoError(t, list.Update(peer.ListUpdates{Additions: []peer.Identifier{id}}))
+		after := retained(list)
+
+		var changed int
+		for addr := range after {
+			if _, ok := before[addr]; !ok {
+				changed++
+			}
+		}
+		assert.True(t, changed <= 2, "adding %v changed %d peers of the subset", id, changed)
+		before = after
+	}
+}
+
+func TestUpdateErrors(t *testing.T) {
+	list, _ := newTestList(t, peer.Available)
+	a := hostport.PeerIdentifier("10.0.0.1:4040")
+	b := hostport.PeerIdentifier("10.0.0.2:4040")
+
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: []peer.Identifier{a}}))
+	assert.Error(t, list.Update(peer.ListUpdates{Additions: []peer.Identifier{a}}))
+	assert.Error(t, list.Update(peer.ListUpdates{Removals: []peer.Identifier{b}}))
+
+	require.NoError(t, list.Update(peer.ListUpdates{Removals: []peer.Identifier{a}}))
+	assert.Empty(t, list.Peers())
+}
+
+func TestReplace(t *testing.T) {
+	list, fake := newTestList(t, peer.Available)
+	a := hostport.PeerIdentifier("10.0.0.1:4040")
+
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: []peer.Identifier{a}}))
+	fake.Flush()
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Removals:  []peer.Identifier{a},
+		Additions: []peer.Identifier{a},
+	}))
+	fake.Flush()
+
+	peers := list.Peers()
+	require.Len(t, peers, 1)
+	assert.Equal(t, peer.Available, peers[0].Status().ConnectionStatus, "peer must stay connected")
+}
+
+func TestApertureGrowsUnderLoad(t *testing.T) {
+	peers := peerMap(20)
+	list, fake := newTestList(t, peer.Available, Coordinate(0, 20), MinAperture(2), LoadBand(0.5, 2))
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: identifiers(peers)}))
+	fake.Flush()
+	require.Len(t, list.Peers(), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	var finish []func(error)
+	for i := 0; i < 10; i++ {
+		_, onFinish, err := list.Choose(ctx, &transport.Request{})
+		require.NoError(t, err)
+		finish = append(finish, onFinish)
+		fake.Flush()
+	}
+	grown := len(list.Peers())
+	assert.True(t, grown > 2, "aperture must grow under load, got %d", grown)
+	assert.Contains(t, list.Introspect().State, "of 20 peers")
+
+	for _, onFinish := range finish {
+		onFinish(nil)
+	}
+	for i := 0; i < grown; i++ {
+		_, onFinish, err := list.Choose(ctx, &transport.Request{})
+		require.NoError(t, err)
+		onFinish(nil)
+		fake.Flush()
+	}
+	assert.Len(t, list.Peers(), 2, "aperture must shrink back without load")
+	assert.Contains(t, list.Introspect().State, "aperture: 2 of 20 peers")
+}
+
+func TestApertureGrowsWhileUnavailable(t *testing.T) {
+	peers := peerMap(20)
+	list, fake := newTestList(t, peer.Unavailable, Coordinate(0, 20), MinAperture(2))
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: identifiers(peers)}))
+	fake.Flush()
+	require.Len(t, list.Peers(), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	_, _, err := list.Choose(ctx, &transport.Request{})
+	assert.Error(t, err)
+	require.Len(t, list.Peers(), 3)
+	fake.Flush()
+
+	var added peer.Identifier
+	for addr := range retained(list) {
+		added = hostport.PeerIdentifier(addr)
+	}
+	fake.SimulateConnect(added)
+
+	_, onFinish, err := list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	onFinish(nil)
+}
+
+func TestAdjustInterval(t *testing.T) {
+	peers := peerMap(20)
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Unavailable))
+	list := New(fake, FailFast(), Coordinate(0, 20), MinAperture(2))
+	require.NoError(t, list.Start())
+	defer func() { assert.NoError(t, list.Stop()) }()
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: identifiers(peers)}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		_, _, err := list.Choose(ctx, &transport.Request{})
+		assert.Error(t, err)
+	}
+	assert.Len(t, list.Peers(), 3, "aperture must grow once per interval")
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"go.uber.org/atomic"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+// loadList is an abstractlist.Implementation that tracks the number of
+// available peers and their total pending requests, to let the list size its
+// aperture, and delegates choosing peers to another implementation.
+type loadList struct {
+	impl abstractlist.Implementation
+
+	available atomic.Int64
+	pending   atomic.Int64
+}
+
+func newLoadList(impl abstractlist.Implementation) *loadList {
+	return &loadList{impl: impl}
+}
+
+func (l *loadList) Add(p peer.StatusPeer, id peer.Identifier) abstractlist.Subscriber {
+	l.available.Inc()
+	return &loadSubscriber{
+		Subscriber: l.impl.Add(p, id),
+		list:       l,
+	}
+}
+
+func (l *loadList) Remove(p peer.StatusPeer, id peer.Identifier, ps abstractlist.Subscriber) {
+	sub, ok := ps.(*loadSubscriber)
+	if !ok {
+		return
+	}
+	sub.removed.Store(true)
+	l.pending.Sub(sub.pending.Swap(0))
+	l.available.Dec()
+	l.impl.Remove(p, id, sub.Subscriber)
+}
+
+func (l *loadList) Choose(req *transport.Request) peer.StatusPeer {
+	return l.impl.Choose(req)
+}
+
+func (l *loadList) Start() error {
+	return nil
+}
+
+func (l *loadList) Stop() error {
+	return nil
+}
+
+func (l *loadList) IsRunning() bool {
+	return true
+}
+
+// load returns the average number of pending requests per available peer,
+// and the number of available peers.
+func (l *loadList) load() (float64, int) {
+	available := l.available.Load()
+	if available <= 0 {
+		return 0, 0
+	}
+	return float64(l.pending.Load()) / float64(available), int(available)
+}
+
+type loadSubscriber struct {
+	abstractlist.Subscriber
+
+	list    *loadList
+	pending atomic.Int64
+	removed atomic.Bool
+}
+
+func (s *loadSubscriber) UpdatePendingRequestCount(pendingRequestCount int) {
+	if !s.removed.Load() {
+		old := s.pending.Swap(int64(pendingRequestCount))
+		s.list.pending.Add(int64(pendingRequestCount) - old)
+	}
+	s.Subscriber.UpdatePendingRequestCount(pendingRequestCount)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+
+	"go.uber.org/yarpc/api/peer"
+)
+
+// coordinate is the position of a client on the ring, as an offset and a
+// width, both fractions of the ring.
+type coordinate struct {
+	offset float64
+	width  float64
+}
+
+// clientCoordinate returns the coordinate of the client with the given index
+// among count clients.
+func clientCoordinate(index, count int) coordinate {
+	if count <= 0 {
+		return coordinate{}
+	}
+	index %= count
+	if index < 0 {
+		index += count
+	}
+	return coordinate{
+		offset: float64(index) / float64(count),
+		width:  1 / float64(count),
+	}
+}
+
+// idCoordinate returns a coordinate of no width at a position derived from
+// the given client ID.
+func idCoordinate(id string) coordinate {
+	return coordinate{offset: unit(hash(id))}
+}
+
+// span returns the index of the first of the n peers on the ring whose slots
+// overlap the coordinate, and the number of peers that overlap it.
+//
+// The i-th peer on the ring occupies the slot [i/n, (i+1)/n).
+func (c coordinate) span(n int) (start, count int) {
+	if n == 0 {
+		return 0, 0
+	}
+	start = int(math.Floor(c.offset * float64(n)))
+	// Tolerate rounding errors, so that a coordinate ending exactly on a
+	// slot boundary does not overlap the next slot.
+	end := int(math.Ceil((c.offset+c.width)*float64(n) - 1e-9))
+	count = end - start
+	if count < 1 {
+		count = 1
+	}
+	if count > n {
+		count = n
+	}
+	return start % n, count
+}
+
+// ring orders peers by a hash of their addresses, so that every client
+// agrees on the order without coordinating.
+func ring(peers map[string]peer.Identifier) []peer.Identifier {
+	type position struct {
+		hash uint64
+		id   peer.Identifier
+	}
+
+	positions := make([]position, 0, len(peers))
+	for addr, id := range peers {
+		positions = append(positions, position{hash: hash(addr), id: id})
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].hash != positions[j].hash {
+			return positions[i].hash < positions[j].hash
+		}
+		return positions[i].id.Identifier() < positions[j].id.Identifier()
+	})
+
+	ids := make([]peer.Identifier, len(positions))
+	for i, p := range positions {
+		ids[i] = p.id
+	}
+	return ids
+}
+
+// window returns size peers of the ring, starting at start and wrapping
+// around the end of the ring.
+func window(ring []peer.Identifier, start, size int) []peer.Identifier {
+	if size > len(ring) {
+		size = len(ring)
+	}
+	ids := make([]peer.Identifier, 0, size)
+	for i := 0; i < size; i++ {
+		ids = append(ids, ring[(start+i)%len(ring)])
+	}
+	return ids
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// unit maps a hash onto [0, 1).
+func unit(h uint64) float64 {
+	return float64(h>>11) / (1 << 53)
+}
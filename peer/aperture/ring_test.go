@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aperture
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/hostport"
+)
+
+func peerMap(n int) map[string]peer.Identifier {
+	peers := make(map[string]peer.Identifier, n)
+	for i := 0; i < n; i++ {
+		addr := fmt.Sprintf("10.0.%d.%d:4040", i/256, i%256)
+		peers[addr] = hostport.PeerIdentifier(addr)
+	}
+	return peers
+}
+
+func TestSpan(t *testing.T) {
+	tests := []struct {
+		desc      string
+		give      coordinate
+		n         int
+		wantStart int
+		wantCount int
+	}{
+		{
+			desc: "no peers",
+			give: clientCoordinate(1, 4),
+			n:    0,
+		},
+		{
+			desc:      "aligned",
+			give:      clientCoordinate(1, 4),
+			n:         8,
+			wantStart: 2,
+			wantCount: 2,
+		},
+		{
+			desc:      "straddling",
+			give:      clientCoordinate(1, 3),
+			n:         8,
+			wantStart: 2,
+			wantCount: 4,
+		},
+		{
+			desc:      "more clients than peers",
+			give:      clientCoordinate(5, 10),
+			n:         3,
+			wantStart: 1,
+			wantCount: 1,
+		},
+		{
+			desc:      "wrapping",
+			give:      clientCoordinate(2, 3),
+			n:         4,
+			wantStart: 2,
+			wantCount: 2,
+		},
+		{
+			desc:      "no width",
+			give:      coordinate{offset: 0.5},
+			n:         10,
+			wantStart: 5,
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			start, count := tt.give.span(tt.n)
+			assert.Equal(t, tt.wantStart, start, "start")
+			assert.Equal(t, tt.wantCount, count, "count")
+		})
+	}
+}
+
+func TestClientCoordinate(t *testing.T) {
+	assert.Equal(t, coordinate{}, clientCoordinate(1, 0))
+	assert.Equal(t, clientCoordinate(1, 4), clientCoordinate(5, 4))
+	assert.Equal(t, clientCoordinate(3, 4), clientCoordinate(-1, 4))
+}
+
+func TestIDCoordinate(t *testing.T) {
+	c := idCoordinate("host-1")
+	assert.Equal(t, c, idCoordinate("host-1"), "must be deterministic")
+	assert.NotEqual(t, c, idCoordinate("host-2"))
+	assert.True(t, c.offset >= 0 && c.offset < 1)
+	assert.Zero(t, c.width)
+}
+
+func TestRingIsDeterministic(t *testing.T) {
+	peers := peerMap(100)
+	want := ring(peers)
+	require.Len(t, want, 100)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, want, ring(peers))
+	}
+}
+
+func TestWindow(t *testing.T) {
+	r := []peer.Identifier{
+		hostport.PeerIdentifier("a"),
+		hostport.PeerIdentifier("b"),
+		hostport.PeerIdentifier("c"),
+	}
+	assert.Equal(t, r[1:], window(r, 1, 2))
+	assert.Equal(t, []peer.Identifier{r[2], r[0]}, window(r, 2, 2))
+	assert.Len(t, window(r, 0, 5), 3)
+}
+
+func TestSubsetsAreEven(t *testing.T) {
+	const (
+		numClients = 30
+		numPeers   = 100
+		aperture   = 10
+	)
+	r := ring(peerMap(numPeers))
+
+	clients := make(map[string]int, numPeers)
+	for i := 0; i < numClients; i++ {
+		start, _ := clientCoordinate(i, numClients).span(numPeers)
+		for _, id := range window(r, start, aperture) {
+			clients[id.Identifier()]++
+		}
+	}
+
+	require.Len(t, clients, numPeers, "every peer must have a client")
+	for addr, n := range clients {
+		assert.True(t, n == 3 || n == 4, "peer %v has %d clients", addr, n)
+	}
+}
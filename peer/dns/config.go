@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/yarpcconfig"
+)
+
+// Configuration describes how to build a DNS peer list updater.
+type Configuration struct {
+	// Name is the name to resolve.
+	Name string `config:"name,interpolate"`
+
+	// Record is the type of record to resolve: "A" for A and AAAA records,
+	// or "SRV". Defaults to "A".
+	Record string `config:"record"`
+
+	// Port is the port of peers found in A and AAAA records.
+	Port int `config:"port,interpolate"`
+
+	RefreshInterval    time.Duration `config:"refreshInterval"`
+	MinRefreshInterval time.Duration `config:"minRefreshInterval"`
+	HonorTTL           bool          `config:"honorTTL"`
+	Timeout            time.Duration `config:"timeout"`
+
+	// OnFailure is what to do with known peers when a resolution fails:
+	// "keep" them or "clear" them. Defaults to "keep".
+	OnFailure string `config:"onFailure"`
+}
+
+// Spec returns a configuration specification for the DNS peer list updater,
+// making it possible to discover peers through DNS with any peer list.
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(roundrobin.Spec())
+//	cfg.MustRegisterPeerListUpdater(dns.Spec())
+//
+// This enables the dns peer list updater:
+//
+//	outbounds:
+//	  otherservice:
+//	    unary:
+//	      http:
+//	        url: https://host:port/rpc
+//	        round-robin:
+//	          dns:
+//	            name: otherservice.example.com
+//	            port: 8080
+//
+// SRV records provide ports of their own.
+//
+//	round-robin:
+//	  dns:
+//	    name: _otherservice._tcp.example.com
+//	    record: SRV
+//
+// The name is resolved again every refresh interval. When a resolution fails,
+// peers are kept unless onFailure is "clear".
+//
+//	round-robin:
+//	  dns:
+//	    name: _otherservice._tcp.example.com
+//	    record: SRV
+//	    refreshInterval: 30s
+//	    minRefreshInterval: 1s
+//	    timeout: 5s
+//	    onFailure: clear
+//
+// The options given to Spec apply to every updater it builds, before those
+// from the configuration. If they include a Resolver that reports TTLs,
+// honorTTL may be set to resolve the name again sooner when its records
+// expire. The default resolver does not report TTLs, so honorTTL is rejected
+// unless a resolver is given to Spec with WithResolver.
+func Spec(opts ...Option) yarpcconfig.PeerListUpdaterSpec {
+	return yarpcconfig.PeerListUpdaterSpec{
+		Name: "dns",
+		BuildPeerListUpdater: func(cfg Configuration, k *yarpcconfig.Kit) (peer.Binder, error) {
+			all := append(make([]Option, 0, len(opts)+7), opts...)
+
+			switch strings.ToUpper(cfg.Record) {
+			case "", "A":
+				// default
+			case "SRV":
+				all = append(all, SRV())
+			default:
+				return nil, fmt.Errorf(`invalid DNS record type %q, need one of "A", "SRV"`, cfg.Record)
+			}
+
+			switch strings.ToLower(cfg.OnFailure) {
+			case "", "keep":
+				// default
+			case "clear":
+				all = append(all, ClearOnFailure())
+			default:
+				return nil, fmt.Errorf(`invalid onFailure %q, need one of "keep", "clear"`, cfg.OnFailure)
+			}
+
+			if cfg.Port != 0 {
+				all = append(all, Port(cfg.Port))
+			}
+			if cfg.RefreshInterval != 0 {
+				all = append(all, RefreshInterval(cfg.RefreshInterval))
+			}
+			if cfg.MinRefreshInterval != 0 {
+				all = append(all, MinRefreshInterval(cfg.MinRefreshInterval))
+			}
+			if cfg.HonorTTL {
+				all = append(all, HonorTTL())
+			}
+			if cfg.Timeout != 0 {
+				all = append(all, Timeout(cfg.Timeout))
+			}
+			return NewBinder(cfg.Name, all...)
+		},
+	}
+}
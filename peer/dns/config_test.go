@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/yarpcconfig"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		desc      string
+		cfg       Configuration
+		records   func(*fakeResolver)
+		wantPeers []string
+		wantErr   string
+	}{
+		{
+			desc: "A records",
+			cfg:  Configuration{Name: "example.com", Port: 8080},
+			records: func(r *fakeResolver) {
+				r.set(ipRecords("10.0.0.1"), nil, nil)
+			},
+			wantPeers: []string{"10.0.0.1:8080"},
+		},
+		{
+			desc: "SRV records",
+			cfg: Configuration{
+				Name:               "_keyvalue._tcp.example.com",
+				Record:             "srv",
+				RefreshInterval:    time.Minute,
+				MinRefreshInterval: time.Second,
+				HonorTTL:           true,
+				Timeout:            time.Second,
+				OnFailure:          "clear",
+			},
+			records: func(r *fakeResolver) {
+				r.set(nil, []SRVRecord{{Target: "host.example.com.", Port: 1234}}, nil)
+			},
+			wantPeers: []string{"host.example.com:1234"},
+		},
+		{
+			desc:    "unknown record type",
+			cfg:     Configuration{Name: "example.com", Record: "MX"},
+			wantErr: `invalid DNS record type "MX"`,
+		},
+		{
+			desc:    "unknown failure behavior",
+			cfg:     Configuration{Name: "example.com", Port: 80, OnFailure: "panic"},
+			wantErr: `invalid onFailure "panic"`,
+		},
+		{
+			desc:    "missing port",
+			cfg:     Configuration{Name: "example.com"},
+			wantErr: "a valid port is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			resolver := &fakeResolver{}
+			if tt.records != nil {
+				tt.records(resolver)
+			}
+
+			build := Spec(WithResolver(resolver)).BuildPeerListUpdater.(func(Configuration, *yarpcconfig.Kit) (peer.Binder, error))
+			bind, err := build(tt.cfg, nil)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			list := newFakeList()
+			updater := bind(list)
+			require.NoError(t, updater.Start())
+			defer updater.Stop()
+			assert.Equal(t, tt.wantPeers, list.Peers())
+		})
+	}
+}
+
+func TestConfigHonorTTLRequiresResolver(t *testing.T) {
+	build := Spec().BuildPeerListUpdater.(func(Configuration, *yarpcconfig.Kit) (peer.Binder, error))
+	_, err := build(Configuration{Name: "example.com", Port: 8080, HonorTTL: true}, nil)
+	assert.ErrorContains(t, err, "honoring TTLs requires a resolver that reports them")
+}
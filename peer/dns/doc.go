@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package dns provides a peer list updater that discovers peers by
+// periodically resolving a DNS name.
+//
+// The updater resolves either the A and AAAA records of a host, combining
+// the addresses with a fixed port, or the SRV records of a name, which carry
+// their own ports. After every resolution, it adds newly discovered peers to
+// the peer list and removes peers that are no longer present.
+//
+//	list := roundrobin.New(transport)
+//	updater, err := dns.New(list, "keyvalue.example.com", dns.Port(8080))
+//
+// Names are resolved again after a refresh interval, or sooner if the records
+// expire before then and the updater is configured to honor their TTLs with a
+// Resolver that reports them. If a
+// resolution fails, the updater keeps the peers it knows about by default and
+// retries with exponential backoff.
+package dns
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dns
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// IPRecord is an address found in an A or AAAA record.
+type IPRecord struct {
+	IP net.IP
+
+	// TTL is the time to live of the record, or zero if it is unknown.
+	TTL time.Duration
+}
+
+// SRVRecord is a service location found in an SRV record.
+type SRVRecord struct {
+	Target string
+	Port   uint16
+
+	// TTL is the time to live of the record, or zero if it is unknown.
+	TTL time.Duration
+}
+
+// Resolver looks up DNS records.
+//
+// Resolvers that know the TTLs of the records they return should report
+// them so that the updater can honor them.
+type Resolver interface {
+	// LookupIP returns the addresses in the A and AAAA records of the given
+	// host.
+	LookupIP(ctx context.Context, host string) ([]IPRecord, error)
+
+	// LookupSRV returns the SRV records of the given name. The name is
+	// looked up directly, like "_http._tcp.keyvalue.example.com".
+	LookupSRV(ctx context.Context, name string) ([]SRVRecord, error)
+}
+
+// NetResolver adapts a *net.Resolver into a Resolver. The standard library
+// does not expose TTLs, so records returned by this resolver have none.
+func NetResolver(r *net.Resolver) Resolver {
+	return netResolver{r: r}
+}
+
+type netResolver struct{ r *net.Resolver }
+
+// reportsTTL reports whether records returned by the given resolver may have
+// TTLs.
+func reportsTTL(r Resolver) bool {
+	_, ok := r.(netResolver)
+	return !ok
+}
+
+func (n netResolver) LookupIP(ctx context.Context, host string) ([]IPRecord, error) {
+	addrs, err := n.r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]IPRecord, len(addrs))
+	for i, addr := range addrs {
+		records[i] = IPRecord{IP: addr.IP}
+	}
+	return records, nil
+}
+
+func (n netResolver) LookupSRV(ctx context.Context, name string) ([]SRVRecord, error) {
+	_, addrs, err := n.r.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]SRVRecord, len(addrs))
+	for i, addr := range addrs {
+		records[i] = SRVRecord{Target: addr.Target, Port: addr.Port}
+	}
+	return records, nil
+}
@@ -0,0 +1,405 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/yarpc/api/backoff"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	intbackoff "go.uber.org/yarpc/internal/backoff"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/pkg/lifecycle"
+	"go.uber.org/zap"
+)
+
+var _ transport.Lifecycle = (*Updater)(nil)
+
+// Option customizes the behavior of a DNS peer list updater.
+type Option func(*options)
+
+type options struct {
+	srv                bool
+	port               int
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	honorTTL           bool
+	timeout            time.Duration
+	clearOnFailure     bool
+	resolver           Resolver
+	logger             *zap.Logger
+}
+
+var defaultOptions = options{
+	refreshInterval:    30 * time.Second,
+	minRefreshInterval: time.Second,
+	timeout:            5 * time.Second,
+	resolver:           NetResolver(net.DefaultResolver),
+}
+
+// SRV resolves the SRV records of the name rather than its A and AAAA
+// records. Each record provides the host and port of a peer.
+func SRV() Option {
+	return func(o *options) {
+		o.srv = true
+	}
+}
+
+// Port is the port of the peers found in A and AAAA records. It is required
+// unless SRV records are resolved.
+func Port(port int) Option {
+	return func(o *options) {
+		o.port = port
+	}
+}
+
+// RefreshInterval is how often the name is resolved.
+//
+// Defaults to 30 seconds.
+func RefreshInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.refreshInterval = d
+	}
+}
+
+// HonorTTL resolves the name again as soon as the first of its records
+// expires, if that happens before the refresh interval has passed. This
+// requires a Resolver that reports TTLs, specified with WithResolver;
+// updaters using the default resolver fail to build with this option.
+func HonorTTL() Option {
+	return func(o *options) {
+		o.honorTTL = true
+	}
+}
+
+// MinRefreshInterval is the minimum time between two resolutions. It keeps
+// short TTLs and repeated failures from overwhelming DNS servers.
+//
+// Defaults to 1 second.
+func MinRefreshInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.minRefreshInterval = d
+	}
+}
+
+// Timeout bounds the time a single resolution may take.
+//
+// Defaults to 5 seconds.
+func Timeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// ClearOnFailure removes all peers from the peer list when a resolution
+// fails. By default, the peer list keeps the peers from the last successful
+// resolution.
+func ClearOnFailure() Option {
+	return func(o *options) {
+		o.clearOnFailure = true
+	}
+}
+
+// WithResolver specifies the resolver used to look up records.
+//
+// Defaults to a resolver backed by net.DefaultResolver.
+func WithResolver(r Resolver) Option {
+	return func(o *options) {
+		o.resolver = r
+	}
+}
+
+// Logger specifies a logger.
+func Logger(logger *zap.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+func newOptions(name string, opts []Option) (options, error) {
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger == nil {
+		o.logger = zap.NewNop()
+	}
+
+	switch {
+	case name == "":
+		return o, errors.New("a name to resolve is required")
+	case !o.srv && (o.port <= 0 || o.port > 65535):
+		return o, fmt.Errorf("a valid port is required to resolve A and AAAA records, got %d", o.port)
+	case o.refreshInterval <= 0:
+		return o, fmt.Errorf("refresh interval must be positive, got %v", o.refreshInterval)
+	case o.minRefreshInterval <= 0 || o.minRefreshInterval > o.refreshInterval:
+		return o, fmt.Errorf("minimum refresh interval must be positive and at most the refresh interval, got %v", o.minRefreshInterval)
+	case o.timeout <= 0:
+		return o, fmt.Errorf("timeout must be positive, got %v", o.timeout)
+	case o.resolver == nil:
+		return o, errors.New("a resolver is required")
+	case o.honorTTL && !reportsTTL(o.resolver):
+		return o, errors.New("honoring TTLs requires a resolver that reports them, " +
+			"the resolver backed by net.Resolver does not")
+	}
+	return o, nil
+}
+
+// Updater keeps a peer list up to date with the peers found in the DNS
+// records of a name.
+type Updater struct {
+	once *lifecycle.Once
+	list peer.List
+	name string
+	opts options
+
+	backoff  backoff.Backoff
+	failures uint
+	peers    map[string]peer.Identifier // peers added to the list
+
+	stop    chan struct{}
+	stopped chan struct{}
+	mu      sync.Mutex // guards failures and peers
+}
+
+// New creates a DNS peer list updater for the given peer list. The updater
+// starts resolving the name once it is started.
+func New(list peer.List, name string, opts ...Option) (*Updater, error) {
+	o, err := newOptions(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newUpdater(list, name, o), nil
+}
+
+// NewBinder returns a peer.Binder that binds peer lists to DNS peer list
+// updaters for the given name.
+func NewBinder(name string, opts ...Option) (peer.Binder, error) {
+	o, err := newOptions(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return func(list peer.List) transport.Lifecycle {
+		return newUpdater(list, name, o)
+	}, nil
+}
+
+func newUpdater(list peer.List, name string, o options) *Updater {
+	// The options have been validated so this cannot fail.
+	strategy, _ := intbackoff.NewExponential(
+		intbackoff.FirstBackoff(o.minRefreshInterval),
+		intbackoff.MaxBackoff(o.refreshInterval),
+	)
+	return &Updater{
+		once:    lifecycle.NewOnce(),
+		list:    list,
+		name:    name,
+		opts:    o,
+		backoff: strategy.Backoff(),
+		peers:   make(map[string]peer.Identifier),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start resolves the name, adds the peers it finds to the peer list, and
+// keeps resolving the name in the background. Start does not fail if the
+// name cannot be resolved; it retries in the background instead.
+func (u *Updater) Start() error {
+	return u.once.Start(u.start)
+}
+
+func (u *Updater) start() error {
+	delay := u.refresh()
+	go u.run(delay)
+	return nil
+}
+
+// Stop stops resolving the name and removes all peers it added from the peer
+// list.
+func (u *Updater) Stop() error {
+	return u.once.Stop(u.stopUpdates)
+}
+
+func (u *Updater) stopUpdates() error {
+	close(u.stop)
+	<-u.stopped
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.update(nil)
+}
+
+// IsRunning returns whether the updater is running.
+func (u *Updater) IsRunning() bool {
+	return u.once.IsRunning()
+}
+
+func (u *Updater) run(delay time.Duration) {
+	defer close(u.stopped)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-u.stop:
+			return
+		case <-timer.C:
+			timer.Reset(u.refresh())
+		}
+	}
+}
+
+// refresh resolves the name, updates the peer list, and returns the time to
+// wait until the next refresh.
+func (u *Updater) refresh() time.Duration {
+	ctx, cancel := context.WithTimeout(context.Background(), u.opts.timeout)
+	addrs, ttl, err := u.resolve(ctx)
+	cancel()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err != nil {
+		u.failures++
+		u.opts.logger.Warn("failed to resolve peers",
+			zap.String("name", u.name),
+			zap.Uint("failures", u.failures),
+			zap.Error(err))
+		if u.opts.clearOnFailure {
+			u.logUpdateError(u.update(nil))
+		}
+		return u.clamp(u.backoff.Duration(u.failures - 1))
+	}
+
+	u.failures = 0
+	u.logUpdateError(u.update(addrs))
+
+	next := u.opts.refreshInterval
+	if u.opts.honorTTL && ttl > 0 && ttl < next {
+		next = ttl
+	}
+	return u.clamp(next)
+}
+
+func (u *Updater) clamp(d time.Duration) time.Duration {
+	if d < u.opts.minRefreshInterval {
+		return u.opts.minRefreshInterval
+	}
+	if d > u.opts.refreshInterval {
+		return u.opts.refreshInterval
+	}
+	return d
+}
+
+// resolve returns the addresses of all peers found for the name and the
+// shortest TTL among their records.
+func (u *Updater) resolve(ctx context.Context) (addrs []string, ttl time.Duration, err error) {
+	minTTL := func(d time.Duration) {
+		if d > 0 && (ttl == 0 || d < ttl) {
+			ttl = d
+		}
+	}
+
+	if u.opts.srv {
+		records, err := u.opts.resolver.LookupSRV(ctx, u.name)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, r := range records {
+			target := strings.TrimSuffix(r.Target, ".")
+			addrs = append(addrs, net.JoinHostPort(target, strconv.Itoa(int(r.Port))))
+			minTTL(r.TTL)
+		}
+	} else {
+		records, err := u.opts.resolver.LookupIP(ctx, u.name)
+		if err != nil {
+			return nil, 0, err
+		}
+		port := strconv.Itoa(u.opts.port)
+		for _, r := range records {
+			addrs = append(addrs, net.JoinHostPort(r.IP.String(), port))
+			minTTL(r.TTL)
+		}
+	}
+
+	if len(addrs) == 0 {
+		return nil, 0, fmt.Errorf("no records found for %q", u.name)
+	}
+	return addrs, ttl, nil
+}
+
+// update adds and removes peers so that the peer list holds exactly the
+// given addresses. The caller must hold the lock.
+func (u *Updater) update(addrs []string) error {
+	want := make(map[string]struct{}, len(addrs))
+	var updates peer.ListUpdates
+	for _, addr := range addrs {
+		if _, ok := want[addr]; ok {
+			continue
+		}
+		want[addr] = struct{}{}
+		if _, ok := u.peers[addr]; !ok {
+			updates.Additions = append(updates.Additions, hostport.PeerIdentifier(addr))
+		}
+	}
+	for addr, pid := range u.peers {
+		if _, ok := want[addr]; !ok {
+			updates.Removals = append(updates.Removals, pid)
+		}
+	}
+	if len(updates.Additions) == 0 && len(updates.Removals) == 0 {
+		return nil
+	}
+	sortIdentifiers(updates.Removals)
+
+	for _, pid := range updates.Removals {
+		delete(u.peers, pid.Identifier())
+	}
+	for _, pid := range updates.Additions {
+		u.peers[pid.Identifier()] = pid
+	}
+	return u.list.Update(updates)
+}
+
+func (u *Updater) logUpdateError(err error) {
+	if err != nil {
+		u.opts.logger.Error("failed to update peer list",
+			zap.String("name", u.name),
+			zap.Error(err))
+	}
+}
+
+// sortIdentifiers sorts peers for deterministic updates.
+func sortIdentifiers(ids []peer.Identifier) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].Identifier() < ids[j].Identifier()
+	})
+}
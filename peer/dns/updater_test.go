@@ -0,0 +1,394 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/internal/testtime"
+)
+
+// fakeResolver serves records set by tests.
+type fakeResolver struct {
+	mu      sync.Mutex
+	ips     []IPRecord
+	srvs    []SRVRecord
+	err     error
+	lookups int
+}
+
+func (r *fakeResolver) set(ips []IPRecord, srvs []SRVRecord, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ips, r.srvs, r.err = ips, srvs, err
+}
+
+func (r *fakeResolver) LookupIP(ctx context.Context, host string) ([]IPRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	return r.ips, r.err
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, name string) ([]SRVRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	return r.srvs, r.err
+}
+
+// fakeList is a peer list that records its peers.
+type fakeList struct {
+	mu      sync.Mutex
+	peers   map[string]struct{}
+	updates int
+}
+
+func newFakeList() *fakeList {
+	return &fakeList{peers: make(map[string]struct{})}
+}
+
+func (l *fakeList) Update(updates peer.ListUpdates) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.updates++
+	for _, pid := range updates.Removals {
+		delete(l.peers, pid.Identifier())
+	}
+	for _, pid := range updates.Additions {
+		l.peers[pid.Identifier()] = struct{}{}
+	}
+	return nil
+}
+
+func (l *fakeList) Peers() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	peers := make([]string, 0, len(l.peers))
+	for p := range l.peers {
+		peers = append(peers, p)
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+func ipRecords(ips ...string) []IPRecord {
+	records := make([]IPRecord, len(ips))
+	for i, ip := range ips {
+		records[i] = IPRecord{IP: net.ParseIP(ip)}
+	}
+	return records
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			desc:    "no name",
+			opts:    []Option{Port(80)},
+			wantErr: "a name to resolve is required",
+		},
+		{
+			desc:    "no port",
+			name:    "example.com",
+			wantErr: "a valid port is required to resolve A and AAAA records, got 0",
+		},
+		{
+			desc:    "port out of range",
+			name:    "example.com",
+			opts:    []Option{Port(70000)},
+			wantErr: "a valid port is required",
+		},
+		{
+			desc:    "invalid refresh interval",
+			name:    "example.com",
+			opts:    []Option{SRV(), RefreshInterval(-time.Second)},
+			wantErr: "refresh interval must be positive",
+		},
+		{
+			desc:    "minimum refresh interval above refresh interval",
+			name:    "example.com",
+			opts:    []Option{SRV(), RefreshInterval(time.Second), MinRefreshInterval(time.Minute)},
+			wantErr: "minimum refresh interval must be positive and at most the refresh interval",
+		},
+		{
+			desc:    "invalid timeout",
+			name:    "example.com",
+			opts:    []Option{SRV(), Timeout(0)},
+			wantErr: "timeout must be positive",
+		},
+		{
+			desc:    "no resolver",
+			name:    "example.com",
+			opts:    []Option{SRV(), WithResolver(nil)},
+			wantErr: "a resolver is required",
+		},
+		{
+			desc:    "honor TTL with default resolver",
+			name:    "example.com",
+			opts:    []Option{SRV(), HonorTTL()},
+			wantErr: "honoring TTLs requires a resolver that reports them",
+		},
+		{
+			desc:    "honor TTL with net resolver",
+			name:    "example.com",
+			opts:    []Option{SRV(), HonorTTL(), WithResolver(NetResolver(&net.Resolver{}))},
+			wantErr: "honoring TTLs requires a resolver that reports them",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := New(newFakeList(), tt.name, tt.opts...)
+			assert.ErrorContains(t, err, tt.wantErr)
+
+			_, err = NewBinder(tt.name, tt.opts...)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUpdaterHostRecords(t *testing.T) {
+	resolver := &fakeResolver{}
+	resolver.set(ipRecords("10.0.0.1", "10.0.0.2", "10.0.0.2", "::1"), nil, nil)
+	list := newFakeList()
+
+	u, err := New(list, "example.com", Port(8080), WithResolver(resolver), RefreshInterval(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, u.Start())
+	assert.True(t, u.IsRunning())
+	assert.Equal(t, []string{"10.0.0.1:8080", "10.0.0.2:8080", "[::1]:8080"}, list.Peers(),
+		"peers must be added when the updater starts")
+
+	resolver.set(ipRecords("10.0.0.2", "10.0.0.3"), nil, nil)
+	assert.Equal(t, time.Hour, u.refresh())
+	assert.Equal(t, []string{"10.0.0.2:8080", "10.0.0.3:8080"}, list.Peers())
+
+	// Unchanged records do not update the peer list.
+	updates := list.updates
+	u.refresh()
+	assert.Equal(t, updates, list.updates)
+
+	require.NoError(t, u.Stop())
+	assert.False(t, u.IsRunning())
+	assert.Empty(t, list.Peers(), "peers must be removed when the updater stops")
+}
+
+func TestUpdaterSRVRecords(t *testing.T) {
+	resolver := &fakeResolver{}
+	resolver.set(nil, []SRVRecord{
+		{Target: "a.example.com.", Port: 1000},
+		{Target: "b.example.com.", Port: 2000},
+	}, nil)
+	list := newFakeList()
+
+	u, err := New(list, "_keyvalue._tcp.example.com", SRV(), WithResolver(resolver))
+	require.NoError(t, err)
+	require.NoError(t, u.Start())
+	defer u.Stop()
+
+	assert.Equal(t, []string{"a.example.com:1000", "b.example.com:2000"}, list.Peers())
+}
+
+func TestUpdaterTTL(t *testing.T) {
+	tests := []struct {
+		desc      string
+		honorTTL  bool
+		ttls      []time.Duration
+		wantDelay time.Duration
+	}{
+		{
+			desc:      "ignored",
+			ttls:      []time.Duration{5 * time.Second},
+			wantDelay: time.Minute,
+		},
+		{
+			desc:      "shortest TTL",
+			honorTTL:  true,
+			ttls:      []time.Duration{20 * time.Second, 5 * time.Second, 0},
+			wantDelay: 5 * time.Second,
+		},
+		{
+			desc:      "unknown TTLs",
+			honorTTL:  true,
+			ttls:      []time.Duration{0},
+			wantDelay: time.Minute,
+		},
+		{
+			desc:      "TTL beyond refresh interval",
+			honorTTL:  true,
+			ttls:      []time.Duration{time.Hour},
+			wantDelay: time.Minute,
+		},
+		{
+			desc:      "TTL below minimum refresh interval",
+			honorTTL:  true,
+			ttls:      []time.Duration{time.Millisecond},
+			wantDelay: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var records []SRVRecord
+			for i, ttl := range tt.ttls {
+				records = append(records, SRVRecord{Target: "example.com", Port: uint16(1000 + i), TTL: ttl})
+			}
+			resolver := &fakeResolver{}
+			resolver.set(nil, records, nil)
+
+			opts := []Option{
+				SRV(),
+				WithResolver(resolver),
+				RefreshInterval(time.Minute),
+				MinRefreshInterval(2 * time.Second),
+			}
+			if tt.honorTTL {
+				opts = append(opts, HonorTTL())
+			}
+			u, err := New(newFakeList(), "example.com", opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDelay, u.refresh())
+		})
+	}
+}
+
+func TestUpdaterFailures(t *testing.T) {
+	tests := []struct {
+		desc           string
+		clearOnFailure bool
+		failWith       error
+		wantPeers      []string
+	}{
+		{
+			desc:      "keep peers on error",
+			failWith:  errors.New("great sadness"),
+			wantPeers: []string{"10.0.0.1:80"},
+		},
+		{
+			desc:      "keep peers on empty response",
+			wantPeers: []string{"10.0.0.1:80"},
+		},
+		{
+			desc:           "clear peers on error",
+			clearOnFailure: true,
+			failWith:       errors.New("great sadness"),
+			wantPeers:      []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			resolver := &fakeResolver{}
+			resolver.set(ipRecords("10.0.0.1"), nil, nil)
+			list := newFakeList()
+
+			opts := []Option{
+				Port(80),
+				WithResolver(resolver),
+				RefreshInterval(time.Minute),
+				MinRefreshInterval(time.Second),
+			}
+			if tt.clearOnFailure {
+				opts = append(opts, ClearOnFailure())
+			}
+			u, err := New(list, "example.com", opts...)
+			require.NoError(t, err)
+			u.refresh()
+
+			resolver.set(nil, nil, tt.failWith)
+			for i := 0; i < 10; i++ {
+				delay := u.refresh()
+				assert.True(t, time.Second <= delay && delay <= time.Minute,
+					"retry delay %v must be within the refresh intervals", delay)
+			}
+			assert.Equal(t, uint(10), u.failures)
+			assert.Equal(t, tt.wantPeers, list.Peers())
+
+			resolver.set(ipRecords("10.0.0.2"), nil, nil)
+			assert.Equal(t, time.Minute, u.refresh(), "must recover after a success")
+			assert.Equal(t, uint(0), u.failures)
+			assert.Equal(t, []string{"10.0.0.2:80"}, list.Peers())
+		})
+	}
+}
+
+func TestUpdaterStartFailure(t *testing.T) {
+	resolver := &fakeResolver{}
+	resolver.set(nil, nil, errors.New("great sadness"))
+	list := newFakeList()
+
+	u, err := New(list, "example.com", Port(80), WithResolver(resolver))
+	require.NoError(t, err)
+	require.NoError(t, u.Start(), "must start even if the name cannot be resolved")
+	assert.Empty(t, list.Peers())
+	require.NoError(t, u.Stop())
+}
+
+func TestUpdaterRefreshesInBackground(t *testing.T) {
+	resolver := &fakeResolver{}
+	resolver.set(ipRecords("10.0.0.1"), nil, nil)
+	list := newFakeList()
+
+	u, err := New(list, "example.com",
+		Port(80),
+		WithResolver(resolver),
+		RefreshInterval(10*time.Millisecond),
+		MinRefreshInterval(time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, u.Start())
+	defer u.Stop()
+
+	resolver.set(ipRecords("10.0.0.2"), nil, nil)
+	assert.Eventually(t, func() bool {
+		peers := list.Peers()
+		return len(peers) == 1 && peers[0] == "10.0.0.2:80"
+	}, testtime.Second, time.Millisecond)
+}
+
+func TestBinder(t *testing.T) {
+	resolver := &fakeResolver{}
+	resolver.set(ipRecords("10.0.0.1"), nil, nil)
+
+	bind, err := NewBinder("example.com", Port(80), WithResolver(resolver))
+	require.NoError(t, err)
+
+	list := newFakeList()
+	updater := bind(list)
+	require.NoError(t, updater.Start())
+	assert.Equal(t, []string{"10.0.0.1:80"}, list.Peers())
+	require.NoError(t, updater.Stop())
+	assert.Empty(t, list.Peers())
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/yarpcconfig"
+)
+
+// Configuration describes how to build a file peer list updater.
+type Configuration struct {
+	// Path is the path to the peers file.
+	Path string `config:"path,interpolate"`
+
+	// PollInterval is how often the file is checked for changes.
+	PollInterval time.Duration `config:"pollInterval"`
+}
+
+// Spec returns a configuration specification for the file peer list
+// updater, making it possible to read peers from a file with any peer list.
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(roundrobin.Spec())
+//	cfg.MustRegisterPeerListUpdater(file.Spec())
+//
+// This enables the file peer list updater:
+//
+//	outbounds:
+//	  otherservice:
+//	    unary:
+//	      http:
+//	        url: https://host:port/rpc
+//	        round-robin:
+//	          file:
+//	            path: /etc/otherservice/peers.yaml
+//	            pollInterval: 5s
+//
+// The options given to Spec apply to every updater it builds, before those
+// from the configuration.
+func Spec(opts ...Option) yarpcconfig.PeerListUpdaterSpec {
+	return yarpcconfig.PeerListUpdaterSpec{
+		Name: "file",
+		BuildPeerListUpdater: func(cfg Configuration, k *yarpcconfig.Kit) (peer.Binder, error) {
+			all := append(make([]Option, 0, len(opts)+1), opts...)
+			if cfg.PollInterval != 0 {
+				all = append(all, PollInterval(cfg.PollInterval))
+			}
+			return NewBinder(cfg.Path, all...)
+		},
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/yarpcconfig"
+)
+
+func TestConfig(t *testing.T) {
+	build := Spec().BuildPeerListUpdater.(func(Configuration, *yarpcconfig.Kit) (peer.Binder, error))
+
+	_, err := build(Configuration{}, nil)
+	assert.Error(t, err, "must require a path")
+
+	path := filepath.Join(t.TempDir(), "peers.yaml")
+	writeFile(t, path, "peers:\n  - 10.0.0.1:80\n")
+
+	bind, err := build(Configuration{Path: path, PollInterval: time.Minute}, nil)
+	require.NoError(t, err)
+
+	list := newFakeList()
+	updater := bind(list)
+	require.NoError(t, updater.Start())
+	defer updater.Stop()
+	assert.Equal(t, map[string]string{"10.0.0.1:80": ""}, list.Peers())
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package file provides a peer list updater that reads peers from a file and
+// keeps the peer list in sync as the file changes.
+//
+// The file holds JSON or YAML. It is either a list of peers or an object
+// with a "peers" list. Each peer is a "host:port" address, or an object with
+// an address, an optional shard, for peer lists like hashring32 that place
+// peers by shard, an optional weight, for peer lists like
+// weighted-round-robin that choose peers in proportion to their weights, and
+// an optional zone and region, for peer lists like zone-aware that prefer
+// nearby peers.
+//
+//	peers:
+//	  - 10.0.0.1:8080
+//	  - address: 10.0.0.2:8080
+//	    shard: shard-2
+//	  - address: 10.0.0.3:8080
+//	    weight: 0 # draining
+//	  - address: 10.0.0.4:8080
+//	    zone: us-east-1a
+//	    region: us-east-1
+//
+// Changing the shard, weight, zone or region of a peer replaces its
+// identifier in the peer list without closing its connections.
+//
+// The updater checks the file for changes periodically. Whenever the file
+// changes, it adds new peers to the peer list and removes peers that are no
+// longer present. If the file cannot be read or is invalid, the peer list
+// keeps its peers until the file is fixed. Tools writing the file should
+// replace it atomically, by writing to a temporary file and renaming it, so
+// that the updater never sees a partially written file.
+package file
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/hostport"
+	"gopkg.in/yaml.v2"
+)
+
+// labeledIdentifier identifies a peer with the weight, zone and region from
+// its entry, for peer lists like weighted-round-robin and zone-aware.
+type labeledIdentifier struct {
+	address string
+	weight  int
+	zone    string
+	region  string
+}
+
+func (i labeledIdentifier) Identifier() string { return i.address }
+
+func (i labeledIdentifier) Weight() int { return i.weight }
+
+func (i labeledIdentifier) Zone() string { return i.zone }
+
+func (i labeledIdentifier) Region() string { return i.region }
+
+// shardIdentifier identifies a peer that belongs to a shard. Peer lists that
+// place peers by shard, like hashring32, use the shard rather than the
+// address.
+type shardIdentifier struct {
+	labeledIdentifier
+
+	shard string
+}
+
+func (i shardIdentifier) Shard() string { return i.shard }
+
+// peerEntry is a peer in the file: either a "host:port" string or an object
+// with an address, a shard, a weight, a zone and a region.
+type peerEntry struct {
+	Address string `yaml:"address"`
+	Shard   string `yaml:"shard"`
+	Weight  *int   `yaml:"weight"`
+	Zone    string `yaml:"zone"`
+	Region  string `yaml:"region"`
+}
+
+func (e *peerEntry) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&e.Address); err == nil {
+		return nil
+	}
+	type entry peerEntry // avoid recursing into this method
+	return unmarshal((*entry)(e))
+}
+
+func (e peerEntry) identifier() peer.Identifier {
+	labeled := labeledIdentifier{
+		address: e.Address,
+		weight:  e.weight(),
+		zone:    e.Zone,
+		region:  e.Region,
+	}
+	switch {
+	case e.Shard != "":
+		return shardIdentifier{labeledIdentifier: labeled, shard: e.Shard}
+	case e.Weight != nil || e.Zone != "" || e.Region != "":
+		return labeled
+	default:
+		return hostport.PeerIdentifier(e.Address)
+	}
+}
+
+func (e peerEntry) weight() int {
+	if e.Weight == nil {
+		return 1
+	}
+	return *e.Weight
+}
+
+// key uniquely identifies a peer with its labels, so that changing any of
+// them replaces the peer's identifier in the peer list.
+func (e peerEntry) key() string {
+	return e.Address + "\x00" + e.Shard + "\x00" + strconv.Itoa(e.weight()) + "\x00" + e.Zone + "\x00" + e.Region
+}
+
+// parsePeers parses the contents of a peers file.
+func parsePeers(data []byte) ([]peerEntry, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var entries []peerEntry
+	switch raw.(type) {
+	case nil:
+		// empty file
+	case []interface{}:
+		if err := yaml.UnmarshalStrict(data, &entries); err != nil {
+			return nil, err
+		}
+	default:
+		var file struct {
+			Peers []peerEntry `yaml:"peers"`
+		}
+		if err := yaml.UnmarshalStrict(data, &file); err != nil {
+			return nil, err
+		}
+		entries = file.Peers
+	}
+
+	addresses := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if e.Address == "" {
+			return nil, errors.New("peer address is required")
+		}
+		if _, _, err := net.SplitHostPort(e.Address); err != nil {
+			return nil, fmt.Errorf("invalid peer address %q: %v", e.Address, err)
+		}
+		if e.Weight != nil && *e.Weight < 0 {
+			return nil, fmt.Errorf("invalid weight %d for peer %q: weight must not be negative", *e.Weight, e.Address)
+		}
+		if _, ok := addresses[e.Address]; ok {
+			return nil, fmt.Errorf("duplicate peer address %q", e.Address)
+		}
+		addresses[e.Address] = struct{}{}
+	}
+	return entries, nil
+}
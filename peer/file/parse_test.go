@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/peer/weighted"
+	"go.uber.org/yarpc/peer/zoneaware"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestParsePeers(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    string
+		want    []peerEntry
+		wantErr string
+	}{
+		{desc: "empty"},
+		{
+			desc: "YAML list",
+			give: "- 10.0.0.1:80\n- 10.0.0.2:80\n",
+			want: []peerEntry{{Address: "10.0.0.1:80"}, {Address: "10.0.0.2:80"}},
+		},
+		{
+			desc: "JSON list",
+			give: `["10.0.0.1:80", {"address": "10.0.0.2:80", "shard": "b"}]`,
+			want: []peerEntry{{Address: "10.0.0.1:80"}, {Address: "10.0.0.2:80", Shard: "b"}},
+		},
+		{
+			desc: "YAML object",
+			give: "peers:\n  - 10.0.0.1:80\n  - address: 10.0.0.2:80\n    shard: b\n",
+			want: []peerEntry{{Address: "10.0.0.1:80"}, {Address: "10.0.0.2:80", Shard: "b"}},
+		},
+		{
+			desc: "JSON object",
+			give: `{"peers": ["[::1]:80"]}`,
+			want: []peerEntry{{Address: "[::1]:80"}},
+		},
+		{
+			desc: "weights",
+			give: "- address: 10.0.0.1:80\n  weight: 0\n- address: 10.0.0.2:80\n  shard: b\n  weight: 3\n",
+			want: []peerEntry{{Address: "10.0.0.1:80", Weight: intPtr(0)}, {Address: "10.0.0.2:80", Shard: "b", Weight: intPtr(3)}},
+		},
+		{
+			desc: "no peers",
+			give: `{"peers": []}`,
+			want: []peerEntry{},
+		},
+		{
+			desc:    "malformed",
+			give:    `{"peers": [`,
+			wantErr: "yaml:",
+		},
+		{
+			desc:    "unknown attribute",
+			give:    `{"peers": [], "hosts": []}`,
+			wantErr: "field hosts not found",
+		},
+		{
+			desc:    "missing address",
+			give:    `[{"shard": "a"}]`,
+			wantErr: "peer address is required",
+		},
+		{
+			desc:    "missing port",
+			give:    `["10.0.0.1"]`,
+			wantErr: `invalid peer address "10.0.0.1"`,
+		},
+		{
+			desc: "zones",
+			give: "- address: 10.0.0.1:80\n  zone: us-east-1a\n  region: us-east-1\n",
+			want: []peerEntry{{Address: "10.0.0.1:80", Zone: "us-east-1a", Region: "us-east-1"}},
+		},
+		{
+			desc:    "negative weight",
+			give:    `[{"address": "10.0.0.1:80", "weight": -1}]`,
+			wantErr: `invalid weight -1 for peer "10.0.0.1:80"`,
+		},
+		{
+			desc:    "duplicate address",
+			give:    `["10.0.0.1:80", {"address": "10.0.0.1:80", "shard": "a"}]`,
+			wantErr: `duplicate peer address "10.0.0.1:80"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parsePeers([]byte(tt.give))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPeerEntryIdentifier(t *testing.T) {
+	pid := peerEntry{Address: "10.0.0.1:80"}.identifier()
+	assert.Equal(t, "10.0.0.1:80", pid.Identifier())
+	_, ok := pid.(interface{ Shard() string })
+	assert.False(t, ok, "peers without a shard must not have one")
+
+	pid = peerEntry{Address: "10.0.0.1:80", Shard: "a"}.identifier()
+	assert.Equal(t, "10.0.0.1:80", pid.Identifier())
+	sharded, ok := pid.(interface{ Shard() string })
+	require.True(t, ok, "peers with a shard must expose it")
+	assert.Equal(t, "a", sharded.Shard())
+}
+
+func TestPeerEntryWeight(t *testing.T) {
+	pid := peerEntry{Address: "10.0.0.1:80"}.identifier()
+	_, ok := pid.(weighted.Identifier)
+	assert.False(t, ok, "peers without a weight must not have one")
+
+	pid = peerEntry{Address: "10.0.0.1:80", Weight: intPtr(0)}.identifier()
+	assert.Equal(t, "10.0.0.1:80", pid.Identifier())
+	assert.Equal(t, 0, weighted.Weight(pid))
+
+	pid = peerEntry{Address: "10.0.0.1:80", Shard: "a", Weight: intPtr(3)}.identifier()
+	assert.Equal(t, 3, weighted.Weight(pid))
+	assert.Equal(t, "a", pid.(interface{ Shard() string }).Shard())
+
+	pid = peerEntry{Address: "10.0.0.1:80", Shard: "a"}.identifier()
+	assert.Equal(t, 1, weighted.Weight(pid))
+
+	assert.NotEqual(t,
+		peerEntry{Address: "10.0.0.1:80"}.key(),
+		peerEntry{Address: "10.0.0.1:80", Weight: intPtr(0)}.key(),
+		"changing the weight must change the key")
+	assert.Equal(t,
+		peerEntry{Address: "10.0.0.1:80"}.key(),
+		peerEntry{Address: "10.0.0.1:80", Weight: intPtr(1)}.key())
+}
+
+func TestPeerEntryLocality(t *testing.T) {
+	pid := peerEntry{Address: "10.0.0.1:80", Zone: "us-east-1a", Region: "us-east-1"}.identifier()
+	assert.Equal(t, "10.0.0.1:80", pid.Identifier())
+	located, ok := pid.(zoneaware.Identifier)
+	require.True(t, ok, "peers with a zone must expose it")
+	assert.Equal(t, "us-east-1a", located.Zone())
+	assert.Equal(t, "us-east-1", located.Region())
+	assert.Equal(t, 1, weighted.Weight(pid))
+	_, ok = pid.(interface{ Shard() string })
+	assert.False(t, ok, "peers without a shard must not have one")
+
+	pid = peerEntry{Address: "10.0.0.1:80", Shard: "a", Zone: "us-east-1a"}.identifier()
+	assert.Equal(t, "us-east-1a", pid.(zoneaware.Identifier).Zone())
+
+	assert.NotEqual(t,
+		peerEntry{Address: "10.0.0.1:80", Zone: "us-east-1a"}.key(),
+		peerEntry{Address: "10.0.0.1:80", Zone: "us-east-1b"}.key(),
+		"changing the zone must change the key")
+	assert.NotEqual(t,
+		peerEntry{Address: "10.0.0.1:80", Region: "us-east-1"}.key(),
+		peerEntry{Address: "10.0.0.1:80", Region: "us-west-2"}.key(),
+		"changing the region must change the key")
+}
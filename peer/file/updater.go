@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/pkg/lifecycle"
+	"go.uber.org/zap"
+)
+
+var _ transport.Lifecycle = (*Updater)(nil)
+
+// Option customizes the behavior of a file peer list updater.
+type Option func(*options)
+
+type options struct {
+	pollInterval time.Duration
+	logger       *zap.Logger
+}
+
+var defaultOptions = options{
+	pollInterval: time.Second,
+}
+
+// PollInterval is how often the file is checked for changes.
+//
+// Defaults to 1 second.
+func PollInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = d
+	}
+}
+
+// Logger specifies a logger.
+func Logger(logger *zap.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+func newOptions(path string, opts []Option) (options, error) {
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger == nil {
+		o.logger = zap.NewNop()
+	}
+
+	switch {
+	case path == "":
+		return o, errors.New("a path to the peers file is required")
+	case o.pollInterval <= 0:
+		return o, fmt.Errorf("poll interval must be positive, got %v", o.pollInterval)
+	}
+	return o, nil
+}
+
+// Updater keeps a peer list up to date with the peers listed in a file.
+type Updater struct {
+	once *lifecycle.Once
+	list peer.List
+	path string
+	opts options
+
+	failing  bool                       // whether the file could not be read
+	loaded   bool                       // whether contents holds the file
+	contents []byte                     // contents of the file when last read
+	peers    map[string]peer.Identifier // peers added to the list, by peerEntry.key
+
+	stop    chan struct{}
+	stopped chan struct{}
+	mu      sync.Mutex // guards failing, loaded, contents and peers
+}
+
+// New creates a file peer list updater for the given peer list. The updater
+// starts reading the file once it is started.
+func New(list peer.List, path string, opts ...Option) (*Updater, error) {
+	o, err := newOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newUpdater(list, path, o), nil
+}
+
+// NewBinder returns a peer.Binder that binds peer lists to file peer list
+// updaters for the given file.
+func NewBinder(path string, opts ...Option) (peer.Binder, error) {
+	o, err := newOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return func(list peer.List) transport.Lifecycle {
+		return newUpdater(list, path, o)
+	}, nil
+}
+
+func newUpdater(list peer.List, path string, o options) *Updater {
+	return &Updater{
+		once:    lifecycle.NewOnce(),
+		list:    list,
+		path:    path,
+		opts:    o,
+		peers:   make(map[string]peer.Identifier),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start reads the file, adds its peers to the peer list, and watches the
+// file for changes in the background. Start does not fail if the file cannot
+// be read; the updater keeps checking it instead.
+func (u *Updater) Start() error {
+	return u.once.Start(u.start)
+}
+
+func (u *Updater) start() error {
+	u.reload()
+	go u.run()
+	return nil
+}
+
+// Stop stops watching the file and removes all peers it added from the peer
+// list.
+func (u *Updater) Stop() error {
+	return u.once.Stop(u.stopWatching)
+}
+
+func (u *Updater) stopWatching() error {
+	close(u.stop)
+	<-u.stopped
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.update(nil)
+}
+
+// IsRunning returns whether the updater is running.
+func (u *Updater) IsRunning() bool {
+	return u.once.IsRunning()
+}
+
+func (u *Updater) run() {
+	defer close(u.stopped)
+
+	ticker := time.NewTicker(u.opts.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-u.stop:
+			return
+		case <-ticker.C:
+			u.reload()
+		}
+	}
+}
+
+// reload reads the file and updates the peer list if the file has changed.
+func (u *Updater) reload() {
+	contents, err := os.ReadFile(u.path)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err != nil {
+		// Log only the first of consecutive failures, and process the file
+		// again once it is readable, even if it is unchanged.
+		if !u.failing {
+			u.opts.logger.Warn("failed to read peers file",
+				zap.String("path", u.path),
+				zap.Error(err))
+		}
+		u.failing = true
+		u.loaded = false
+		return
+	}
+	u.failing = false
+	if u.loaded && bytes.Equal(contents, u.contents) {
+		return
+	}
+	u.loaded = true
+	u.contents = contents
+
+	entries, err := parsePeers(contents)
+	if err != nil {
+		u.opts.logger.Error("invalid peers file, keeping the current peers",
+			zap.String("path", u.path),
+			zap.Error(err))
+		return
+	}
+
+	if err := u.update(entries); err != nil {
+		// Retry on the next poll, even if the file is unchanged.
+		u.loaded = false
+		u.opts.logger.Error("failed to update peer list",
+			zap.String("path", u.path),
+			zap.Error(err))
+	}
+}
+
+// update adds and removes peers so that the peer list holds exactly the
+// given peers. The caller must hold the lock.
+//
+// The updater only records the change if the peer list accepts it, so that
+// later updates are computed against the peers the list holds.
+func (u *Updater) update(entries []peerEntry) error {
+	want := make(map[string]struct{}, len(entries))
+	added := make(map[string]peer.Identifier)
+	var updates peer.ListUpdates
+	for _, e := range entries {
+		key := e.key()
+		want[key] = struct{}{}
+		if _, ok := u.peers[key]; !ok {
+			pid := e.identifier()
+			added[key] = pid
+			updates.Additions = append(updates.Additions, pid)
+		}
+	}
+	var removed []string
+	for key, pid := range u.peers {
+		if _, ok := want[key]; !ok {
+			removed = append(removed, key)
+			updates.Removals = append(updates.Removals, pid)
+		}
+	}
+	if len(updates.Additions) == 0 && len(updates.Removals) == 0 {
+		return nil
+	}
+
+	// Deterministic order for removals, which come from a map.
+	sort.Slice(updates.Removals, func(i, j int) bool {
+		return updates.Removals[i].Identifier() < updates.Removals[j].Identifier()
+	})
+	if err := u.list.Update(updates); err != nil {
+		return err
+	}
+
+	for _, key := range removed {
+		delete(u.peers, key)
+	}
+	for key, pid := range added {
+		u.peers[key] = pid
+	}
+	return nil
+}
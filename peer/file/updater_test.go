@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/weighted"
+)
+
+// fakeList is a peer list that records its peers and their shards.
+type fakeList struct {
+	mu      sync.Mutex
+	peers   map[string]string // address to shard
+	updates []peer.ListUpdates
+	err     error // if set, updates fail without changing the peers
+}
+
+func newFakeList() *fakeList {
+	return &fakeList{peers: make(map[string]string)}
+}
+
+func (l *fakeList) Update(updates peer.ListUpdates) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.updates = append(l.updates, updates)
+	if l.err != nil {
+		return l.err
+	}
+	for _, pid := range updates.Removals {
+		delete(l.peers, pid.Identifier())
+	}
+	for _, pid := range updates.Additions {
+		var shard string
+		if s, ok := pid.(interface{ Shard() string }); ok {
+			shard = s.Shard()
+		}
+		l.peers[pid.Identifier()] = shard
+	}
+	return nil
+}
+
+func (l *fakeList) SetError(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.err = err
+}
+
+func (l *fakeList) Peers() map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	peers := make(map[string]string, len(l.peers))
+	for addr, shard := range l.peers {
+		peers[addr] = shard
+	}
+	return peers
+}
+
+func (l *fakeList) Updates() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.updates)
+}
+
+// writeFile replaces the file atomically.
+func writeFile(t *testing.T, path, contents string) {
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(contents), 0o644))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(newFakeList(), "")
+	assert.EqualError(t, err, "a path to the peers file is required")
+
+	_, err = NewBinder("peers.yaml", PollInterval(0))
+	assert.EqualError(t, err, "poll interval must be positive, got 0s")
+}
+
+func TestUpdater(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.yaml")
+	writeFile(t, path, "- 10.0.0.1:80\n- 10.0.0.2:80\n")
+	list := newFakeList()
+
+	u, err := New(list, path, PollInterval(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, u.Start())
+	assert.True(t, u.IsRunning())
+	assert.Equal(t, map[string]string{"10.0.0.1:80": "", "10.0.0.2:80": ""}, list.Peers(),
+		"peers must be added when the updater starts")
+
+	// Peers are updated incrementally.
+	writeFile(t, path, `{"peers": ["10.0.0.2:80", {"address": "10.0.0.3:80", "shard": "c"}]}`)
+	u.reload()
+	assert.Equal(t, map[string]string{"10.0.0.2:80": "", "10.0.0.3:80": "c"}, list.Peers())
+	last := list.updates[len(list.updates)-1]
+	assert.Len(t, last.Additions, 1)
+	assert.Len(t, last.Removals, 1)
+
+	// Moving a peer to another shard replaces it.
+	writeFile(t, path, `["10.0.0.2:80", {"address": "10.0.0.3:80", "shard": "d"}]`)
+	u.reload()
+	assert.Equal(t, map[string]string{"10.0.0.2:80": "", "10.0.0.3:80": "d"}, list.Peers())
+
+	// Changing the weight of a peer replaces it in the same update.
+	writeFile(t, path, `[{"address": "10.0.0.2:80", "weight": 0}, {"address": "10.0.0.3:80", "shard": "d"}]`)
+	u.reload()
+	last = list.updates[len(list.updates)-1]
+	require.Len(t, last.Additions, 1)
+	require.Len(t, last.Removals, 1)
+	assert.Equal(t, "10.0.0.2:80", last.Removals[0].Identifier())
+	assert.Equal(t, 0, weighted.Weight(last.Additions[0]))
+
+	// Unchanged files do not update the peer list.
+	updates := list.Updates()
+	u.reload()
+	assert.Equal(t, updates, list.Updates())
+
+	require.NoError(t, u.Stop())
+	assert.False(t, u.IsRunning())
+	assert.Empty(t, list.Peers(), "peers must be removed when the updater stops")
+}
+
+func TestUpdaterKeepsPeersOnErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.yaml")
+	writeFile(t, path, `["10.0.0.1:80"]`)
+	list := newFakeList()
+
+	u, err := New(list, path, PollInterval(time.Hour))
+	require.NoError(t, err)
+	u.reload()
+	want := map[string]string{"10.0.0.1:80": ""}
+
+	writeFile(t, path, `["not an address"]`)
+	u.reload()
+	assert.Equal(t, want, list.Peers(), "invalid files must not change the peers")
+
+	require.NoError(t, os.Remove(path))
+	u.reload()
+	assert.Equal(t, want, list.Peers(), "missing files must not change the peers")
+
+	writeFile(t, path, `["10.0.0.2:80"]`)
+	u.reload()
+	assert.Equal(t, map[string]string{"10.0.0.2:80": ""}, list.Peers())
+}
+
+func TestUpdaterRetriesFailedUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.yaml")
+	writeFile(t, path, `["10.0.0.1:80"]`)
+	list := newFakeList()
+
+	u, err := New(list, path, PollInterval(time.Hour))
+	require.NoError(t, err)
+	u.reload()
+
+	list.SetError(errors.New("great sadness"))
+	writeFile(t, path, `["10.0.0.2:80"]`)
+	u.reload()
+	assert.Equal(t, map[string]string{"10.0.0.1:80": ""}, list.Peers())
+
+	list.SetError(nil)
+	u.reload()
+	assert.Equal(t, map[string]string{"10.0.0.2:80": ""}, list.Peers(),
+		"failed updates must be retried when the file is read again")
+}
+
+func TestUpdaterMissingFileOnStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.yaml")
+	list := newFakeList()
+
+	u, err := New(list, path, PollInterval(time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, u.Start(), "must start even if the file does not exist yet")
+	defer u.Stop()
+	assert.Empty(t, list.Peers())
+
+	writeFile(t, path, `["10.0.0.1:80"]`)
+	assert.Eventually(t, func() bool {
+		return len(list.Peers()) == 1
+	}, testtime.Second, time.Millisecond, "must pick up the file once it is written")
+}
+
+func TestBinder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.json")
+	writeFile(t, path, `["10.0.0.1:80", "10.0.0.2:80"]`)
+
+	bind, err := NewBinder(path)
+	require.NoError(t, err)
+
+	list := newFakeList()
+	updater := bind(list)
+	require.NoError(t, updater.Start())
+
+	addrs := make([]string, 0, 2)
+	for addr := range list.Peers() {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	assert.Equal(t, []string{"10.0.0.1:80", "10.0.0.2:80"}, addrs)
+
+	require.NoError(t, updater.Stop())
+	assert.Empty(t, list.Peers())
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hashring32
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/hashring32/internal/farmhashring"
+	"go.uber.org/yarpc/peer/hashring32/internal/hashring32"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/yarpctest"
+	"go.uber.org/zap"
+)
+
+func counterValue(root *metrics.Root, name string) int64 {
+	for _, c := range root.Snapshot().Counters {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return 0
+}
+
+func TestBoundedLoadChoose(t *testing.T) {
+	root := metrics.New()
+	pr := newPeerRing(farmhashring.Fingerprint32, options{
+		boundedLoad: 1.25,
+		meter:       root.Scope(),
+		logger:      zap.NewNop(),
+	})
+	req := &transport.Request{ShardKey: "hot"}
+	assert.Nil(t, pr.Choose(req), "empty ring must choose nothing")
+
+	fake := yarpctest.NewFakeTransport()
+	subs := make(map[string]*subscriber)
+	for i := 0; i < 4; i++ {
+		id := hostport.PeerIdentifier(fmt.Sprintf("10.0.0.%d:4040", i))
+		subs[string(id)] = pr.Add(fake.Peer(id), id).(*subscriber)
+	}
+
+	ids, err := pr.ring.Choose(hashring32.Shard{Key: "hot", N: 1})
+	require.NoError(t, err)
+	primary, next := ids[0], ids[1]
+	assert.Equal(t, primary, pr.Choose(req).Identifier(), "must choose the primary peer below capacity")
+
+	// The capacity is ceil(1.25 * (10 + 1) / 4) = 4.
+	subs[primary].UpdatePendingRequestCount(10)
+	assert.Equal(t, next, pr.Choose(req).Identifier(), "must overflow to the next peer")
+
+	subs[primary].UpdatePendingRequestCount(0)
+	assert.Equal(t, primary, pr.Choose(req).Identifier(), "must return to the primary peer")
+
+	requests, overflows := pr.boundedLoad()
+	assert.Equal(t, int64(3), requests)
+	assert.Equal(t, int64(1), overflows)
+	assert.Equal(t, int64(3), counterValue(root, "hashring32_bounded_load_requests"))
+	assert.Equal(t, int64(1), counterValue(root, "hashring32_bounded_load_overflows"))
+
+	pr.Remove(fake.Peer(hostport.PeerIdentifier(primary)), hostport.PeerIdentifier(primary), subs[primary])
+	assert.Equal(t, int64(0), pr.pending.Load(), "removed peers must not count")
+}
+
+func TestBoundedLoadSpreadsHotKey(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	pl := New(fake, farmhashring.Fingerprint32, BoundedLoad(1.25))
+	require.NoError(t, pl.Start())
+	defer func() { assert.NoError(t, pl.Stop()) }()
+
+	var ids []peer.Identifier
+	for i := 0; i < 4; i++ {
+		ids = append(ids, hostport.PeerIdentifier(fmt.Sprintf("10.0.0.%d:4040", i)))
+	}
+	require.NoError(t, pl.Update(peer.ListUpdates{Additions: ids}))
+	fake.Flush()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	const requests = 20
+	pending := make(map[string]int)
+	var finish []func(error)
+	for i := 0; i < requests; i++ {
+		p, onFinish, err := pl.Choose(ctx, &transport.Request{ShardKey: "hot"})
+		require.NoError(t, err)
+		pending[p.Identifier()]++
+		finish = append(finish, onFinish)
+	}
+	for _, onFinish := range finish {
+		onFinish(nil)
+	}
+
+	assert.True(t, len(pending) > 1, "hot key must spill over to other peers")
+	for id, n := range pending {
+		// The capacity never exceeds ceil(1.25 * 20 / 4) = 7.
+		assert.True(t, n <= 7, "peer %v received %d concurrent requests", id, n)
+	}
+	assert.Contains(t, pl.Introspect().State, "bounded load: ")
+	assert.Contains(t, pl.Introspect().State, fmt.Sprintf("of %d requests overflowed", requests))
+}
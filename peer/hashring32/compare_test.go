@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hashring32
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/peer/maglev"
+	"go.uber.org/yarpc/peer/rendezvous"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+// The benchmarks in this file compare hashring32 with the alternative
+// consistent hashing peer list implementations, reporting how evenly they
+// spread shard keys (the largest share of keys of any peer over the mean
+// share) and how many shard keys of other peers move when a peer leaves.
+
+const (
+	_benchPeers = 100
+	_benchKeys  = 100000
+)
+
+var _implementations = []struct {
+	name string
+	new  func() abstractlist.Implementation
+}{
+	{name: "hashring32", new: func() abstractlist.Implementation { return NewImplementation() }},
+	{name: "maglev", new: func() abstractlist.Implementation { return maglev.NewImplementation() }},
+	{name: "rendezvous", new: func() abstractlist.Implementation { return rendezvous.NewImplementation() }},
+}
+
+type benchPeer struct {
+	id  peer.Identifier
+	p   peer.StatusPeer
+	sub abstractlist.Subscriber
+}
+
+func addBenchPeers(impl abstractlist.Implementation) []*benchPeer {
+	fake := yarpctest.NewFakeTransport()
+	peers := make([]*benchPeer, _benchPeers)
+	for i := range peers {
+		id := hostport.PeerIdentifier(fmt.Sprintf("10.0.%d.%d:4040", i/256, i%256))
+		p := fake.Peer(id)
+		peers[i] = &benchPeer{id: id, p: p, sub: impl.Add(p, id)}
+	}
+	return peers
+}
+
+func benchKeys() []*transport.Request {
+	reqs := make([]*transport.Request, _benchKeys)
+	for i := range reqs {
+		reqs[i] = &transport.Request{ShardKey: fmt.Sprintf("key-%d", i)}
+	}
+	return reqs
+}
+
+func owners(impl abstractlist.Implementation, reqs []*transport.Request) []string {
+	owners := make([]string, len(reqs))
+	for i, req := range reqs {
+		owners[i] = impl.Choose(req).Identifier()
+	}
+	return owners
+}
+
+func BenchmarkConsistentHashing(b *testing.B) {
+	reqs := benchKeys()
+
+	for _, impl := range _implementations {
+		b.Run(impl.name+"/Choose", func(b *testing.B) {
+			list := impl.new()
+			addBenchPeers(list)
+			list.Choose(reqs[0])
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				list.Choose(reqs[i%len(reqs)])
+			}
+		})
+
+		b.Run(impl.name+"/Membership", func(b *testing.B) {
+			list := impl.new()
+			peers := addBenchPeers(list)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bp := peers[i%len(peers)]
+				list.Remove(bp.p, bp.id, bp.sub)
+				bp.sub = list.Add(bp.p, bp.id)
+				list.Choose(reqs[i%len(reqs)])
+			}
+		})
+
+		b.Run(impl.name+"/Spread", func(b *testing.B) {
+			list := impl.new()
+			addBenchPeers(list)
+
+			var maxOverMean float64
+			for i := 0; i < b.N; i++ {
+				load := make(map[string]int)
+				for _, owner := range owners(list, reqs) {
+					load[owner]++
+				}
+				var max int
+				for _, n := range load {
+					if n > max {
+						max = n
+					}
+				}
+				maxOverMean = float64(max) / (float64(len(reqs)) / _benchPeers)
+			}
+			b.ReportMetric(maxOverMean, "max/mean")
+		})
+
+		b.Run(impl.name+"/Disruption", func(b *testing.B) {
+			var moved float64
+			for i := 0; i < b.N; i++ {
+				list := impl.new()
+				peers := addBenchPeers(list)
+				before := owners(list, reqs)
+
+				removed := peers[i%len(peers)]
+				list.Remove(removed.p, removed.id, removed.sub)
+				after := owners(list, reqs)
+
+				var others, changed int
+				for k, owner := range before {
+					if owner == removed.id.Identifier() {
+						continue
+					}
+					others++
+					if after[k] != owner {
+						changed++
+					}
+				}
+				moved = 100 * float64(changed) / float64(others)
+			}
+			b.ReportMetric(moved, "%moved")
+		})
+	}
+}
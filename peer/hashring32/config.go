@@ -25,8 +25,10 @@ import (
 
 	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hashring32/internal/farmhashring"
 	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpcerrors"
 	"go.uber.org/zap"
 )
 
@@ -79,6 +81,23 @@ type Config struct {
 	// present. This enables calls without deadlines, ie streaming, to choose
 	// peers without waiting indefinitely.
 	DefaultChooseTimeout *time.Duration `config:"defaultChooseTimeout"`
+
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	// Requests for shards owned by an ejected peer go to the next peer on
+	// the ring.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
+
+	// BoundedLoadFactor caps the pending requests of every peer at this
+	// factor of the average number of pending requests per peer. Requests
+	// for shard keys whose peer is over capacity go to the next peer on the
+	// ring that is not.
+	//
+	// Must be at least 1. Bounded loads are disabled by default.
+	BoundedLoadFactor float64 `config:"boundedLoadFactor"`
 }
 
 // Spec returns a configuration specification for the hashed peer list
@@ -102,6 +121,32 @@ func Spec(logger *zap.Logger, meter *metrics.Scope) yarpcconfig.PeerListSpec {
 				opts = append(opts, DefaultChooseTimeout(*c.DefaultChooseTimeout))
 			}
 
+			if c.CircuitBreaker != nil {
+				if err := c.CircuitBreaker.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, CircuitBreaker(*c.CircuitBreaker))
+			}
+			if c.HealthCheck != nil {
+				if err := c.HealthCheck.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, HealthCheck(*c.HealthCheck))
+			}
+
+			if c.BoundedLoadFactor != 0 {
+				if c.BoundedLoadFactor < 1 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"BoundedLoadFactor must be at least 1. Got: %v.", c.BoundedLoadFactor)
+				}
+				// Tell the metrics of lists for different outbounds apart.
+				listMeter := meter
+				if k != nil && k.OutboundServiceName() != "" {
+					listMeter = meter.Tagged(metrics.Tags{"service": k.OutboundServiceName()})
+				}
+				opts = append(opts, BoundedLoad(c.BoundedLoadFactor), Meter(listMeter))
+			}
+
 			if c.NumReplicas != 0 {
 				opts = append(opts, NumReplicas(c.NumReplicas))
 			}
@@ -25,7 +25,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hostport"
 	"go.uber.org/yarpc/yarpcconfig"
 	"go.uber.org/yarpc/yarpctest"
@@ -42,6 +45,12 @@ func TestPendingHeapConfig(t *testing.T) {
 		NumPeersEstimate:        1000,
 		AlternateShardKeyHeader: "test-header",
 		DefaultChooseTimeout:    &duration,
+		CircuitBreaker: &abstractlist.CircuitBreakerConfig{
+			ConsecutiveFailures: 3,
+		},
+		HealthCheck: &abstractlist.HealthCheckConfig{
+			Service: "their-service",
+		},
 	}
 	build := s.BuildPeerList.(func(c Config, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error))
 	pl, err := build(c, yarpctest.NewFakeTransport(), nil)
@@ -66,3 +75,16 @@ func TestOffsetGeneratorValueConfig(t *testing.T) {
 	assert.NoError(t, err, "must construct a peer list")
 	pl.Update(peer.ListUpdates{Additions: []peer.Identifier{hostport.PeerIdentifier("127.0.0.1:8080")}})
 }
+
+func TestBoundedLoadConfig(t *testing.T) {
+	root := metrics.New()
+	build := Spec(nil, root.Scope()).BuildPeerList.(func(c Config, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error))
+
+	pl, err := build(Config{BoundedLoadFactor: 1.25}, yarpctest.NewFakeTransport(), nil)
+	require.NoError(t, err, "must construct a peer list")
+	assert.Equal(t, 1.25, pl.(*List).ring.loadFactor)
+
+	_, err = build(Config{BoundedLoadFactor: 0.5}, yarpctest.NewFakeTransport(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BoundedLoadFactor must be at least 1")
+}
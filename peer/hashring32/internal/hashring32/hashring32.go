@@ -280,6 +280,60 @@ func (r *Hashring32) ChooseNth(shard Shard) (string, error) {
 	return *last, nil
 }
 
+// Walk calls visit with every member of the hash ring once, in ring order,
+// starting from the member that owns the shard key, until visit returns
+// false.
+func (r *Hashring32) Walk(key string, visit func(member string) bool) error {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	if len(r.membersSet) == 0 {
+		return ErrEmptyPool
+	}
+
+	var ix int
+	if key == "" {
+		// Random index to get hash value
+		ix = rand.Intn(len(r.hashesArray))
+	} else {
+		// Binary search to find hash value
+		ix = indexOf(r.hashesArray, r.hash(key))
+	}
+
+	// Most walks stop at the first member, so the set of visited members is
+	// only allocated when the walk goes on.
+	var first string
+	var visited map[string]struct{}
+	for n := 0; n < len(r.membersSet); {
+		hash := r.hashesArray[ix]
+		ix++
+		// reach end of ring , start from the beginning
+		if ix == len(r.hashesArray) {
+			ix = 0
+		}
+		// same hash can contains different members (collisions)
+		for member := range r.membersMapByHash[hash] {
+			// different hashes can point to same server (replicas)
+			if n == 0 {
+				first = member
+			} else {
+				if visited == nil {
+					visited = map[string]struct{}{first: {}}
+				}
+				if _, ok := visited[member]; ok {
+					continue
+				}
+				visited[member] = struct{}{}
+			}
+			n++
+			if !visit(member) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
 // Add adds a member into the hash ring and returns whether it is a new member.
 func (r *Hashring32) Add(member string) (new bool) {
 	r.m.Lock()
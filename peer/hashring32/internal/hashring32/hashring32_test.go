@@ -124,6 +124,37 @@ func TestMultipleChoose(t *testing.T) {
 	assert.Equal(t, id, id3, "Choose selected a different peer")
 }
 
+func TestWalk(t *testing.T) {
+	rp := makeHashring32()
+	err := rp.Walk(key1, func(string) bool { return true })
+	assert.Equal(t, ErrEmptyPool, err, "Walk should fail on an empty ring")
+
+	rp.Add(ringpopID1)
+	rp.Add(ringpopID2)
+	rp.Add(ringpopID3)
+	rp.Add(ringpopID4)
+	rp.Add(ringpopID5)
+
+	var walked []string
+	err = rp.Walk(key1, func(member string) bool {
+		walked = append(walked, member)
+		return true
+	})
+	assert.NoError(t, err, "Walk failed")
+
+	ids, err := rp.Choose(Shard{Key: key1, N: 4})
+	assert.NoError(t, err, "Choose failed to select peers")
+	assert.Equal(t, ids, walked, "Walk should visit every member in ring order")
+
+	walked = nil
+	err = rp.Walk(key1, func(member string) bool {
+		walked = append(walked, member)
+		return len(walked) < 2
+	})
+	assert.NoError(t, err, "Walk failed")
+	assert.Equal(t, ids[:2], walked, "Walk should stop when asked")
+}
+
 func TestNoKey(t *testing.T) {
 	rp := makeHashring32()
 	rp.Add(ringpopID1)
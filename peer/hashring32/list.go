@@ -24,8 +24,10 @@ package hashring32
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc/api/peer"
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/api/x/introspection"
@@ -42,6 +44,10 @@ type options struct {
 	peerRingOptions         []hashring32.Option
 	defaultChooseTimeout    *time.Duration
 	logger                  *zap.Logger
+	circuitBreaker          *abstractlist.CircuitBreakerConfig
+	healthCheck             *abstractlist.HealthCheckConfig
+	boundedLoad             float64
+	meter                   *metrics.Scope
 }
 
 // Option customizes the behavior of hashring32 peer list.
@@ -205,6 +211,50 @@ func DefaultChooseTimeout(timeout time.Duration) Option {
 	})
 }
 
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) Option {
+	return optionFunc(func(options *options) {
+		options.circuitBreaker = &config
+	})
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) Option {
+	return optionFunc(func(options *options) {
+		options.healthCheck = &config
+	})
+}
+
+// BoundedLoad caps the pending requests of every peer at the given factor of
+// the average number of pending requests per peer, which must be at least
+// one.
+//
+// Requests for shard keys whose peer is over capacity go to the next peer on
+// the ring that is not, so keys keep their affinity to a peer unless it is
+// overloaded, and hot keys spill over to the following peers instead of
+// overloading a single one.
+// Lower factors spread load more evenly at the cost of affinity; a factor of
+// 1.25 is a reasonable start.
+//
+// Bounded loads are disabled by default.
+func BoundedLoad(factor float64) Option {
+	return optionFunc(func(options *options) {
+		options.boundedLoad = factor
+	})
+}
+
+// Meter specifies a metrics scope for the bounded-load mode to report how
+// many requests overflow their primary peer.
+func Meter(meter *metrics.Scope) Option {
+	return optionFunc(func(options *options) {
+		options.meter = meter
+	})
+}
+
 type optionFunc func(*options)
 
 func (f optionFunc) apply(options *options) { f(options) }
@@ -221,30 +271,31 @@ func New(transport peer.Transport, hashFunc hashring32.HashFunc32, opts ...Optio
 		logger = zap.NewNop()
 	}
 
-	ring := newPeerRing(
-		hashFunc,
-		options.offsetHeader,
-		options.peerOverrideHeader,
-		options.alternateShardKeyHeader,
-		options.offsetGeneratorValue,
-		logger,
-		options.peerRingOptions...,
-	)
+	options.logger = logger
+	ring := newPeerRing(hashFunc, options)
 
 	plOpts := []abstractlist.Option{abstractlist.Logger(logger)}
 
 	if options.defaultChooseTimeout != nil {
 		plOpts = append(plOpts, abstractlist.DefaultChooseTimeout(*options.defaultChooseTimeout))
 	}
+	if options.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*options.circuitBreaker))
+	}
+	if options.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*options.healthCheck))
+	}
 
 	return &List{
 		list: abstractlist.New("hashring32", transport, ring, plOpts...),
+		ring: ring,
 	}
 }
 
 // List is a PeerList which chooses peers based on a hashing function.
 type List struct {
 	list *abstractlist.List
+	ring *peerRing
 }
 
 // Start causes the peer list to start.
@@ -306,10 +357,27 @@ func (l *List) NotifyStatusChanged(pid peer.Identifier) {
 	l.list.NotifyStatusChanged(pid)
 }
 
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
 // Introspect reveals information about the list to the internal YARPC
-// introspection system.
+// introspection system, including the overflow rate in bounded-load mode.
 func (l *List) Introspect() introspection.ChooserStatus {
-	return l.list.Introspect()
+	status := l.list.Introspect()
+	if l.ring.loadFactor > 0 {
+		requests, overflows := l.ring.boundedLoad()
+		var rate float64
+		if requests > 0 {
+			rate = 100 * float64(overflows) / float64(requests)
+		}
+		status.State += fmt.Sprintf(", bounded load: %d of %d requests overflowed (%.1f%%)",
+			overflows, requests, rate)
+	}
+	return status
 }
 
 // Peers produces a slice of all retained peers.
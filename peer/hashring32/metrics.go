@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hashring32
+
+import (
+	"go.uber.org/net/metrics"
+	"go.uber.org/zap"
+)
+
+type boundedLoadMetrics struct {
+	requests  *metrics.Counter
+	overflows *metrics.Counter
+}
+
+func newBoundedLoadMetrics(meter *metrics.Scope, logger *zap.Logger) *boundedLoadMetrics {
+	m := &boundedLoadMetrics{}
+	var err error
+
+	m.requests, err = meter.Counter(metrics.Spec{
+		Name: "hashring32_bounded_load_requests",
+		Help: "Number of requests the hash ring routed with bounded loads.",
+	})
+	if err != nil {
+		logger.Error("Failed to create hashring32 bounded load requests counter.", zap.Error(err))
+	}
+	m.overflows, err = meter.Counter(metrics.Spec{
+		Name: "hashring32_bounded_load_overflows",
+		Help: "Number of requests routed past the primary peer of their shard key because it was over capacity.",
+	})
+	if err != nil {
+		logger.Error("Failed to create hashring32 bounded load overflows counter.", zap.Error(err))
+	}
+	return m
+}
+
+// record counts a request chosen with bounded loads.
+func (m *boundedLoadMetrics) record(overflow bool) {
+	if m == nil {
+		return
+	}
+	m.requests.Inc()
+	if overflow {
+		m.overflows.Inc()
+	}
+}
@@ -21,11 +21,13 @@
 package hashring32
 
 import (
+	"math"
 	"math/rand"
 	"strconv"
 	"sync"
 	"time"
 
+	"go.uber.org/atomic"
 	"go.uber.org/yarpc/api/peer"
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/peer/abstractlist"
@@ -46,36 +48,40 @@ func NewImplementation(opts ...Option) abstractlist.Implementation {
 		o.apply(&options)
 	}
 
-	return newPeerRing(
-		farmhashring.Fingerprint32,
-		options.offsetHeader,
-		options.peerOverrideHeader,
-		options.alternateShardKeyHeader,
-		options.offsetGeneratorValue,
-		options.logger,
-		options.peerRingOptions...,
-	)
+	return newPeerRing(farmhashring.Fingerprint32, options)
 }
 
 // newPeerRing creates a new peerRing with an initial capacity
-func newPeerRing(hashFunc hashring32.HashFunc32, offsetHeader, peerOverrideHeader, alternateShardKeyHeader string, offsetGeneratorValue int, logger *zap.Logger, option ...hashring32.Option) *peerRing {
-	return &peerRing{
-		ring:                    hashring32.New(hashFunc, option...),
+func newPeerRing(hashFunc hashring32.HashFunc32, options options) *peerRing {
+	pr := &peerRing{
+		ring:                    hashring32.New(hashFunc, options.peerRingOptions...),
 		subscribers:             make(map[string]*subscriber),
-		offsetHeader:            offsetHeader,
-		offsetGeneratorValue:    offsetGeneratorValue,
-		peerOverrideHeader:      peerOverrideHeader,
-		logger:                  logger,
-		alternateShardKeyHeader: alternateShardKeyHeader,
+		offsetHeader:            options.offsetHeader,
+		offsetGeneratorValue:    options.offsetGeneratorValue,
+		peerOverrideHeader:      options.peerOverrideHeader,
+		logger:                  options.logger,
+		alternateShardKeyHeader: options.alternateShardKeyHeader,
 		random:                  rand.New(rand.NewSource(time.Now().UnixNano())),
+		loadFactor:              options.boundedLoad,
 	}
+	if pr.loadFactor > 0 {
+		pr.metrics = newBoundedLoadMetrics(options.meter, options.logger)
+	}
+	return pr
 }
 
 type subscriber struct {
-	peer peer.StatusPeer
+	ring    *peerRing
+	peer    peer.StatusPeer
+	pending atomic.Int64
 }
 
-func (s *subscriber) UpdatePendingRequestCount(int) {}
+// UpdatePendingRequestCount keeps track of the pending requests of the peer,
+// and of all peers in the ring, to bound their loads.
+func (s *subscriber) UpdatePendingRequestCount(pendingRequestCount int) {
+	old := s.pending.Swap(int64(pendingRequestCount))
+	s.ring.pending.Add(int64(pendingRequestCount) - old)
+}
 
 // peerRing provides a safe way to interact (Add/Remove/Get) with a potentially
 // changing list of peer objects
@@ -89,6 +95,15 @@ type peerRing struct {
 	logger                  *zap.Logger
 	random                  *rand.Rand
 
+	// loadFactor bounds the pending requests of every peer to this factor
+	// of the average, if greater than zero.
+	loadFactor float64
+	// pending is the total of the pending requests of all peers in the ring.
+	pending   atomic.Int64
+	requests  atomic.Int64
+	overflows atomic.Int64
+	metrics   *boundedLoadMetrics
+
 	m sync.RWMutex
 }
 
@@ -106,7 +121,7 @@ func (pr *peerRing) Add(p peer.StatusPeer, pid peer.Identifier) abstractlist.Sub
 	pr.m.Lock()
 	defer pr.m.Unlock()
 
-	sub := &subscriber{peer: p}
+	sub := &subscriber{ring: pr, peer: p}
 	shardID := getShardID(pid)
 	pr.ring.Add(shardID)
 	pr.subscribers[shardID] = sub
@@ -138,6 +153,7 @@ func (pr *peerRing) Remove(p peer.StatusPeer, pid peer.Identifier, s abstractlis
 	pr.ring.Remove(shardID)
 	// Peerlist's responsibility to make sure this is thread-safe.
 	delete(pr.subscribers, shardID)
+	pr.pending.Sub(sub.pending.Swap(0))
 }
 
 func (pr *peerRing) getPeerOverride(req *transport.Request) peer.StatusPeer {
@@ -181,6 +197,10 @@ func (pr *peerRing) Choose(req *transport.Request) peer.StatusPeer {
 		shardKey, _ = req.Headers.Get(pr.alternateShardKeyHeader)
 	}
 
+	if pr.loadFactor > 0 {
+		return pr.chooseBounded(shardKey, n)
+	}
+
 	ids, err := pr.ring.Choose(hashring32.Shard{
 		Key: shardKey,
 		N:   n,
@@ -199,6 +219,65 @@ func (pr *peerRing) Choose(req *transport.Request) peer.StatusPeer {
 	return sub.peer
 }
 
+// chooseBounded walks the ring from the shard key, skipping the first n
+// peers, and returns the first peer whose pending requests are below its
+// capacity: the load factor times the average number of pending requests,
+// counting the request to be sent.
+// Keys stay with their primary peer unless it is over capacity, and
+// overflow to the peers that follow it on the ring otherwise.
+//
+// chooseBounded must be run under the ring lock.
+func (pr *peerRing) chooseBounded(shardKey string, n int) peer.StatusPeer {
+	if len(pr.subscribers) == 0 {
+		return nil
+	}
+	capacity := int64(math.Ceil(pr.loadFactor * float64(pr.pending.Load()+1) / float64(len(pr.subscribers))))
+
+	var primary, chosen *subscriber
+	var skipped int
+	err := pr.ring.Walk(shardKey, func(member string) bool {
+		if skipped < n {
+			skipped++
+			return true
+		}
+		sub, ok := pr.subscribers[member]
+		if !ok {
+			return true
+		}
+		if primary == nil {
+			primary = sub
+		}
+		if sub.pending.Load() < capacity {
+			chosen = sub
+			return false
+		}
+		return true
+	})
+	if err != nil || primary == nil {
+		return nil
+	}
+
+	overflow := chosen != primary
+	if chosen == nil {
+		// Every peer is at capacity, which only happens with load factors
+		// below one.
+		chosen = primary
+		overflow = false
+	}
+	pr.requests.Inc()
+	if overflow {
+		pr.overflows.Inc()
+	}
+	pr.metrics.record(overflow)
+	return chosen.peer
+}
+
+// boundedLoad returns the number of requests chosen with bounded loads, and
+// how many of them overflowed their primary peer.
+func (pr *peerRing) boundedLoad() (requests, overflows int64) {
+	return pr.requests.Load(), pr.overflows.Load()
+}
+
 // getShardID returns the shardID from a StatusPeer.
 func getShardID(p peer.Identifier) string {
 	sp, ok := p.(shardIdentifier)
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package maglev provides a consistent hashing peer list that uses a Maglev
+// lookup table, as an alternative to hashring32.
+//
+// The list builds a lookup table of a prime number of entries, and fills it
+// by letting every available peer claim entries in turn, in the order of a
+// permutation of the table derived from the peer's shard identifier. A
+// request goes to the peer that owns the entry for the hash of its shard key.
+//
+// Every peer owns almost exactly the same number of entries, so the list
+// spreads shard keys more evenly than a hash ring with replicas, and choosing
+// a peer takes a single table lookup regardless of the number of peers. When
+// a peer joins or leaves, most shard keys stay with their peers: keys move to
+// or from the changed peer, and only a small fraction of the others move.
+//
+// Like hashring32, the list supports a header that overrides the chosen peer
+// with the peer of a given shard identifier, and a header that supplies an
+// alternate shard key.
+//
+// The list rebuilds its table lazily, on the first request after a peer
+// becomes available or unavailable, so a burst of membership changes costs a
+// single rebuild.
+package maglev
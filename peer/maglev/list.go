@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package maglev
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/x/introspection"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/zap"
+)
+
+type options struct {
+	tableSize               int
+	peerOverrideHeader      string
+	alternateShardKeyHeader string
+	defaultChooseTimeout    *time.Duration
+	logger                  *zap.Logger
+	circuitBreaker          *abstractlist.CircuitBreakerConfig
+	healthCheck             *abstractlist.HealthCheckConfig
+}
+
+// Option customizes the behavior of a Maglev peer list.
+type Option interface {
+	apply(*options)
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(options *options) { f(options) }
+
+// TableSize specifies the number of entries in the lookup table, rounded up
+// to a prime number. The table should have at least a hundred entries for
+// every peer for an even spread. Larger tables take longer to rebuild when
+// peers join or leave.
+//
+// Defaults to 65537.
+func TableSize(size int) Option {
+	return optionFunc(func(options *options) {
+		options.tableSize = size
+	})
+}
+
+// PeerOverrideHeader allows clients to pass a header containing the shard
+// identifier for a specific peer to override the destination address for the
+// outgoing request.
+//
+// For example, if the peer list uses addresses to identify peers, the table
+// will have retained a peer for every known address.
+// Specifying an address like "127.0.0.1" in the route override header will
+// deflect the request to that exact peer.
+// If that peer is not available, the request will continue on to the peer
+// implied by the shard key.
+func PeerOverrideHeader(peerOverrideHeader string) Option {
+	return optionFunc(func(options *options) {
+		options.peerOverrideHeader = peerOverrideHeader
+	})
+}
+
+// AlternateShardKeyHeader allows clients to pass a header containing a shard
+// key to use instead of the shard key of the request.
+func AlternateShardKeyHeader(alternateShardKeyHeader string) Option {
+	return optionFunc(func(options *options) {
+		options.alternateShardKeyHeader = alternateShardKeyHeader
+	})
+}
+
+// Logger specifies a logger.
+func Logger(logger *zap.Logger) Option {
+	return optionFunc(func(options *options) {
+		options.logger = logger
+	})
+}
+
+// DefaultChooseTimeout specifies the default timeout to add to 'Choose' calls
+// without context deadlines. This prevents long-lived streams from setting
+// calling deadlines.
+//
+// Defaults to 500ms.
+func DefaultChooseTimeout(timeout time.Duration) Option {
+	return optionFunc(func(options *options) {
+		options.defaultChooseTimeout = &timeout
+	})
+}
+
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) Option {
+	return optionFunc(func(options *options) {
+		options.circuitBreaker = &config
+	})
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) Option {
+	return optionFunc(func(options *options) {
+		options.healthCheck = &config
+	})
+}
+
+// New creates a new Maglev peer list.
+func New(transport peer.Transport, opts ...Option) *List {
+	var options options
+	for _, o := range opts {
+		o.apply(&options)
+	}
+
+	logger := options.logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	plOpts := []abstractlist.Option{abstractlist.Logger(logger)}
+
+	if options.defaultChooseTimeout != nil {
+		plOpts = append(plOpts, abstractlist.DefaultChooseTimeout(*options.defaultChooseTimeout))
+	}
+	if options.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*options.circuitBreaker))
+	}
+	if options.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*options.healthCheck))
+	}
+
+	return &List{
+		list: abstractlist.New("maglev", transport, newMaglevTable(options), plOpts...),
+	}
+}
+
+// List is a PeerList which chooses peers by consistent hashing with a Maglev
+// lookup table.
+type List struct {
+	list *abstractlist.List
+}
+
+// Start causes the peer list to start.
+//
+// Starting will retain all peers that have been added but not removed
+// the first time it is called.
+//
+// Start may be called any number of times and in any order in relation to Stop
+// but will only cause the list to start the first time, and only if it has not
+// already been stopped.
+func (l *List) Start() error {
+	return l.list.Start()
+}
+
+// Stop causes the peer list to stop.
+//
+// Stopping will release all retained peers to the underlying transport.
+//
+// Stop may be called any number of times and in order in relation to Start but
+// will only cause the list to stop the first time, and only if it has
+// previously been started.
+func (l *List) Stop() error {
+	return l.list.Stop()
+}
+
+// IsRunning returns whether the list has started and not yet stopped.
+func (l *List) IsRunning() bool {
+	return l.list.IsRunning()
+}
+
+// Choose returns a peer, suitable for sending a request.
+//
+// The peer is not guaranteed to be connected and available, but the peer list
+// makes every attempt to ensure this and minimize the probability that a
+// chosen peer will fail to carry a request.
+func (l *List) Choose(ctx context.Context, req *transport.Request) (peer peer.Peer, onFinish func(error), err error) {
+	return l.list.Choose(ctx, req)
+}
+
+// Update may add and remove logical peers in the list.
+//
+// The peer list uses a transport to obtain a physical peer for each logical
+// peer.
+// The transport is responsible for informing the peer list whether the peer is
+// available or unavailable, but cannot guarantee that the peer will still be
+// available after it is chosen.
+func (l *List) Update(updates peer.ListUpdates) error {
+	return l.list.Update(updates)
+}
+
+// NotifyStatusChanged forwards a status change notification to an individual
+// peer in the list.
+//
+// This satisfies the peer.Subscriber interface and should only be used to
+// send notifications in tests.
+// The list's RetainPeer and ReleasePeer methods deal with an individual
+// peer.Subscriber instance for each peer in the list, avoiding a map lookup.
+func (l *List) NotifyStatusChanged(pid peer.Identifier) {
+	l.list.NotifyStatusChanged(pid)
+}
+
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
+// Introspect reveals information about the list to the internal YARPC
+// introspection system.
+func (l *List) Introspect() introspection.ChooserStatus {
+	return l.list.Introspect()
+}
+
+// Peers produces a slice of all retained peers.
+func (l *List) Peers() []peer.StatusPeer {
+	return l.list.Peers()
+}
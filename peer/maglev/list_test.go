@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package maglev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/yarpctest"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestList(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	pl := New(
+		fake,
+		TableSize(1000),
+		PeerOverrideHeader("x-peer"),
+		AlternateShardKeyHeader("x-shard-key"),
+		Logger(zaptest.NewLogger(t)),
+		DefaultChooseTimeout(testtime.Second),
+		CircuitBreaker(abstractlist.CircuitBreakerConfig{ConsecutiveFailures: 3}),
+	)
+	require.NoError(t, pl.Start())
+	defer func() { assert.NoError(t, pl.Stop()) }()
+	assert.True(t, pl.IsRunning())
+
+	require.NoError(t, pl.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{
+			shardID{id: "id1", shard: "shard-1"},
+			shardID{id: "id2", shard: "shard-2"},
+		},
+	}))
+	fake.Flush()
+	assert.Len(t, pl.Peers(), 2)
+	assert.Len(t, pl.Introspect().Peers, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	owner, onFinish, err := pl.Choose(ctx, &transport.Request{ShardKey: "foo"})
+	require.NoError(t, err)
+	onFinish(nil)
+
+	for i := 0; i < 10; i++ {
+		p, onFinish, err := pl.Choose(ctx, &transport.Request{ShardKey: "foo"})
+		require.NoError(t, err)
+		onFinish(nil)
+		assert.Equal(t, owner.Identifier(), p.Identifier(), "must choose the same peer for a shard key")
+	}
+
+	// The shard key moves to the remaining peer when its owner becomes
+	// unavailable.
+	other := shardID{id: "id1", shard: "shard-1"}
+	if owner.Identifier() == "id1" {
+		other = shardID{id: "id2", shard: "shard-2"}
+	}
+	fake.SimulateDisconnect(owner.(*yarpctest.FakePeer))
+	p, onFinish, err := pl.Choose(ctx, &transport.Request{ShardKey: "foo"})
+	require.NoError(t, err)
+	onFinish(nil)
+	assert.Equal(t, other.Identifier(), p.Identifier())
+
+	// Unavailable peers cannot be chosen by override.
+	p, onFinish, err = pl.Choose(ctx, &transport.Request{
+		ShardKey: "foo",
+		Headers:  transport.NewHeaders().With("x-peer", owner.Identifier()),
+	})
+	require.NoError(t, err)
+	onFinish(nil)
+	assert.Equal(t, other.Identifier(), p.Identifier())
+}
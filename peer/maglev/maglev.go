@@ -0,0 +1,238 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package maglev
+
+import (
+	"sort"
+	"sync"
+
+	farm "github.com/dgryski/go-farm"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+const (
+	_defaultTableSize = 65537
+
+	// Seeds for the hashes that derive a peer's permutation of the table.
+	_offsetSeed = 0x9e3779b97f4a7c15
+	_skipSeed   = 0xc2b2ae3d27d4eb4f
+)
+
+// NewImplementation creates a new Maglev abstractlist.Implementation.
+//
+// Use this constructor instead of New, when wanting to do custom peer
+// connection management.
+func NewImplementation(opts ...Option) abstractlist.Implementation {
+	var options options
+	for _, o := range opts {
+		o.apply(&options)
+	}
+	return newMaglevTable(options)
+}
+
+type subscriber struct {
+	peer  peer.StatusPeer
+	shard string
+}
+
+func (s *subscriber) UpdatePendingRequestCount(int) {}
+
+// maglevTable assigns every entry of a lookup table to a peer.
+type maglevTable struct {
+	size                    int
+	peerOverrideHeader      string
+	alternateShardKeyHeader string
+
+	subscribers map[string]*subscriber
+	table       []*subscriber
+	// dirty indicates that peers were added or removed since the table was
+	// last built.
+	dirty bool
+
+	m sync.Mutex
+}
+
+var _ abstractlist.Implementation = (*maglevTable)(nil)
+
+func newMaglevTable(options options) *maglevTable {
+	size := _defaultTableSize
+	if options.tableSize > 0 {
+		size = nextPrime(options.tableSize)
+	}
+	return &maglevTable{
+		size:                    size,
+		peerOverrideHeader:      options.peerOverrideHeader,
+		alternateShardKeyHeader: options.alternateShardKeyHeader,
+		subscribers:             make(map[string]*subscriber),
+	}
+}
+
+// shardIdentifier is the interface for an identifier that have a shard property
+type shardIdentifier interface {
+	Identifier() string
+	Shard() string
+}
+
+// Add adds a peer to the table, which takes effect on the next Choose.
+func (t *maglevTable) Add(p peer.StatusPeer, pid peer.Identifier) abstractlist.Subscriber {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	sub := &subscriber{peer: p, shard: getShardID(pid)}
+	t.subscribers[sub.shard] = sub
+	t.dirty = true
+	return sub
+}
+
+// Remove removes a peer from the table, which takes effect on the next
+// Choose.
+func (t *maglevTable) Remove(p peer.StatusPeer, pid peer.Identifier, s abstractlist.Subscriber) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	sub, ok := s.(*subscriber)
+	if !ok || t.subscribers[sub.shard] != sub {
+		return
+	}
+	delete(t.subscribers, sub.shard)
+	t.dirty = true
+}
+
+// Choose returns the peer that owns the table entry for the request's shard
+// key, or the peer named by the override header.
+func (t *maglevTable) Choose(req *transport.Request) peer.StatusPeer {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if t.peerOverrideHeader != "" {
+		if dest, ok := req.Headers.Get(t.peerOverrideHeader); ok {
+			if sub, ok := t.subscribers[dest]; ok {
+				return sub.peer
+			}
+		}
+	}
+
+	if t.dirty {
+		t.build()
+	}
+	if len(t.table) == 0 {
+		return nil
+	}
+
+	shardKey := req.ShardKey
+	if t.alternateShardKeyHeader != "" {
+		if key, ok := req.Headers.Get(t.alternateShardKeyHeader); ok {
+			shardKey = key
+		}
+	}
+	return t.table[farm.Fingerprint64([]byte(shardKey))%uint64(t.size)].peer
+}
+
+func (t *maglevTable) Start() error {
+	return nil
+}
+
+func (t *maglevTable) Stop() error {
+	return nil
+}
+
+func (t *maglevTable) IsRunning() bool {
+	return true
+}
+
+// build fills the lookup table, letting every peer in turn claim the next
+// unclaimed entry of its permutation until the table is full.
+//
+// build must be run under the table lock.
+func (t *maglevTable) build() {
+	t.dirty = false
+	if len(t.subscribers) == 0 {
+		t.table = nil
+		return
+	}
+
+	// Every client must visit the peers in the same order to build the same
+	// table.
+	subs := make([]*subscriber, 0, len(t.subscribers))
+	for _, sub := range t.subscribers {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].shard < subs[j].shard })
+
+	size := uint64(t.size)
+	offsets := make([]uint64, len(subs))
+	skips := make([]uint64, len(subs))
+	next := make([]uint64, len(subs))
+	for i, sub := range subs {
+		offsets[i] = farm.Hash64WithSeed([]byte(sub.shard), _offsetSeed) % size
+		skips[i] = farm.Hash64WithSeed([]byte(sub.shard), _skipSeed)%(size-1) + 1
+	}
+
+	table := make([]*subscriber, t.size)
+	for filled := 0; ; {
+		for i, sub := range subs {
+			entry := (offsets[i] + next[i]*skips[i]) % size
+			for table[entry] != nil {
+				next[i]++
+				entry = (offsets[i] + next[i]*skips[i]) % size
+			}
+			table[entry] = sub
+			next[i]++
+			filled++
+			if filled == t.size {
+				t.table = table
+				return
+			}
+		}
+	}
+}
+
+// getShardID returns the shardID from a StatusPeer.
+func getShardID(p peer.Identifier) string {
+	if sp, ok := p.(shardIdentifier); ok {
+		return sp.Shard()
+	}
+	return p.Identifier()
+}
+
+// nextPrime returns the smallest prime number greater than or equal to n.
+func nextPrime(n int) int {
+	if n <= 2 {
+		return 2
+	}
+	if n%2 == 0 {
+		n++
+	}
+	for ; ; n += 2 {
+		prime := true
+		for d := 3; d*d <= n; d += 2 {
+			if n%d == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			return n
+		}
+	}
+}
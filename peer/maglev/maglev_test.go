@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package maglev
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+type shardID struct {
+	id    string
+	shard string
+}
+
+func (p shardID) Identifier() string { return p.id }
+
+func (p shardID) Shard() string { return p.shard }
+
+func addPeers(impl *maglevTable, n int) map[string]*subscriber {
+	fake := yarpctest.NewFakeTransport()
+	subs := make(map[string]*subscriber, n)
+	for i := 0; i < n; i++ {
+		id := hostport.PeerIdentifier(fmt.Sprintf("10.0.%d.%d:4040", i/256, i%256))
+		subs[string(id)] = impl.Add(fake.Peer(id), id).(*subscriber)
+	}
+	return subs
+}
+
+func assignments(impl *maglevTable, keys int) map[string]string {
+	owners := make(map[string]string, keys)
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		owners[key] = impl.Choose(&transport.Request{ShardKey: key}).Identifier()
+	}
+	return owners
+}
+
+func TestTableIsEven(t *testing.T) {
+	impl := newMaglevTable(options{})
+	addPeers(impl, 100)
+	impl.Choose(&transport.Request{})
+
+	entries := make(map[*subscriber]int)
+	for _, sub := range impl.table {
+		entries[sub]++
+	}
+	require.Len(t, entries, 100)
+	for _, n := range entries {
+		// Maglev guarantees that entries differ by at most one per peer.
+		assert.InDelta(t, _defaultTableSize/100, n, 1)
+	}
+}
+
+func TestTableIsDeterministic(t *testing.T) {
+	a, b := newMaglevTable(options{}), newMaglevTable(options{})
+	fake := yarpctest.NewFakeTransport()
+	var ids []peer.Identifier
+	for i := 0; i < 20; i++ {
+		ids = append(ids, hostport.PeerIdentifier(fmt.Sprintf("10.0.0.%d:4040", i)))
+	}
+	for _, id := range ids {
+		a.Add(fake.Peer(id), id)
+	}
+	rand.New(rand.NewSource(0)).Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	for _, id := range ids {
+		b.Add(fake.Peer(id), id)
+	}
+	assert.Equal(t, assignments(a, 1000), assignments(b, 1000), "order of peers must not matter")
+}
+
+func TestMinimalDisruption(t *testing.T) {
+	impl := newMaglevTable(options{})
+	subs := addPeers(impl, 100)
+	before := assignments(impl, 10000)
+
+	const removed = "10.0.0.42:4040"
+	impl.Remove(subs[removed].peer, hostport.PeerIdentifier(removed), subs[removed])
+	after := assignments(impl, 10000)
+
+	var moved int
+	for key, owner := range before {
+		if owner == removed {
+			assert.NotEqual(t, removed, after[key])
+			continue
+		}
+		if after[key] != owner {
+			moved++
+		}
+	}
+	assert.True(t, moved < len(before)/100, "%d keys of other peers moved", moved)
+}
+
+func TestChoose(t *testing.T) {
+	impl := newMaglevTable(options{
+		tableSize:               100,
+		peerOverrideHeader:      "x-peer",
+		alternateShardKeyHeader: "x-shard-key",
+	})
+	assert.Equal(t, 101, impl.size, "table size must be prime")
+	assert.Nil(t, impl.Choose(&transport.Request{ShardKey: "foo"}), "empty table must choose nothing")
+
+	fake := yarpctest.NewFakeTransport()
+	id1, id2 := shardID{id: "id1", shard: "shard-1"}, shardID{id: "id2", shard: "shard-2"}
+	sub1 := impl.Add(fake.Peer(id1), id1)
+	impl.Add(fake.Peer(id2), id2)
+
+	owner := impl.Choose(&transport.Request{ShardKey: "foo"})
+	require.NotNil(t, owner)
+	assert.Equal(t, owner, impl.Choose(&transport.Request{ShardKey: "foo"}), "must be consistent")
+
+	other, otherShard := fake.Peer(id1), "shard-1"
+	if owner.Identifier() == "id1" {
+		other, otherShard = fake.Peer(id2), "shard-2"
+	}
+
+	headers := transport.NewHeaders().With("x-peer", otherShard)
+	assert.Equal(t, other, impl.Choose(&transport.Request{ShardKey: "foo", Headers: headers}), "override must win")
+
+	headers = transport.NewHeaders().With("x-peer", "shard-3")
+	assert.Equal(t, owner, impl.Choose(&transport.Request{ShardKey: "foo", Headers: headers}), "unknown override must be ignored")
+
+	headers = transport.NewHeaders().With("x-shard-key", "foo")
+	assert.Equal(t, owner, impl.Choose(&transport.Request{ShardKey: "bar", Headers: headers}), "alternate shard key must win")
+
+	impl.Remove(fake.Peer(id1), id1, sub1)
+	assert.Equal(t, fake.Peer(id2), impl.Choose(&transport.Request{ShardKey: "foo"}))
+
+	assert.NoError(t, impl.Start())
+	assert.NoError(t, impl.Stop())
+	assert.True(t, impl.IsRunning())
+}
+
+func TestNextPrime(t *testing.T) {
+	for give, want := range map[int]int{0: 2, 2: 2, 3: 3, 4: 5, 100: 101, 65536: 65537} {
+		assert.Equal(t, want, nextPrime(give), "nextPrime(%d)", give)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package peakewma
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Configuration describes how to construct a peak EWMA peer list.
+type Configuration struct {
+	Capacity *int `config:"capacity"`
+	// Decay is the time constant of the moving average of latencies.
+	// Defaults to 10s.
+	Decay *time.Duration `config:"decay"`
+	// InitialLatency is the average latency peers start with. Defaults to
+	// 10ms.
+	InitialLatency *time.Duration `config:"initialLatency"`
+	FailFast       bool           `config:"failFast"`
+	// DefaultChooseTimeout specifies the deadline to add to Choose calls if not
+	// present. This enables calls without deadlines, ie streaming, to choose
+	// peers without waiting indefinitely.
+	DefaultChooseTimeout *time.Duration `config:"defaultChooseTimeout"`
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
+}
+
+// Spec returns a configuration specification for the peak EWMA peer list
+// implementation, making it possible to choose peers by latency with
+// transports that use outbound peer list configuration (like HTTP).
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(peakewma.Spec())
+//
+// This enables the peak EWMA peer list:
+//
+//	outbounds:
+//	  otherservice:
+//	    unary:
+//	      http:
+//	        url: https://host:port/rpc
+//	        peak-ewma:
+//	          decay: 10s
+//	          peers:
+//	            - 127.0.0.1:8080
+//	            - 127.0.0.1:8081
+func Spec() yarpcconfig.PeerListSpec {
+	return SpecWithOptions()
+}
+
+// SpecWithOptions accepts additional list constructor options.
+func SpecWithOptions(options ...ListOption) yarpcconfig.PeerListSpec {
+	return yarpcconfig.PeerListSpec{
+		Name: "peak-ewma",
+		BuildPeerList: func(cfg Configuration, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error) {
+			opts := make([]ListOption, 0, len(options)+7)
+
+			opts = append(opts, options...)
+
+			if cfg.Capacity != nil {
+				if *cfg.Capacity <= 0 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"Capacity must be greater than 0. Got: %d.", *cfg.Capacity)
+				}
+				opts = append(opts, Capacity(*cfg.Capacity))
+			}
+
+			if cfg.Decay != nil {
+				if *cfg.Decay <= 0 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"Decay must be greater than 0. Got: %v.", *cfg.Decay)
+				}
+				opts = append(opts, Decay(*cfg.Decay))
+			}
+			if cfg.InitialLatency != nil {
+				if *cfg.InitialLatency < 0 {
+					return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+						"InitialLatency must not be negative. Got: %v.", *cfg.InitialLatency)
+				}
+				opts = append(opts, InitialLatency(*cfg.InitialLatency))
+			}
+			if cfg.FailFast {
+				opts = append(opts, FailFast())
+			}
+			if cfg.DefaultChooseTimeout != nil {
+				opts = append(opts, DefaultChooseTimeout(*cfg.DefaultChooseTimeout))
+			}
+			if cfg.CircuitBreaker != nil {
+				if err := cfg.CircuitBreaker.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, CircuitBreaker(*cfg.CircuitBreaker))
+			}
+			if cfg.HealthCheck != nil {
+				if err := cfg.HealthCheck.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, HealthCheck(*cfg.HealthCheck))
+			}
+
+			return New(t, opts...), nil
+		},
+	}
+}
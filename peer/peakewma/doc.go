@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package peakewma provides a peer list that sends requests to the peers with
+// the lowest expected latency.
+//
+// The list scores every peer by a peak-sensitive exponentially weighted moving
+// average (EWMA) of the latency of the requests it sent to the peer,
+// multiplied by the number of the peer's pending requests, plus one. It
+// chooses the lower scoring of two random available peers.
+//
+// The average is peak-sensitive: a request slower than the average replaces
+// it outright, so the list backs away from a peer as soon as it slows down,
+// and only returns to it as faster requests bring the average down again.
+// Without requests, the average of a peer decays toward zero, so the list
+// eventually tries peers that it avoided in the past. The Decay option sets
+// the time constant of both.
+//
+// Peers start with the average given by the InitialLatency option until the
+// first request to them finishes.
+//
+// The list measures the latency of a request from the moment it chooses the
+// peer until the caller calls the onFinish callback, whether the request
+// succeeds or fails. Combine the list with a circuit breaker to avoid peers
+// that fail fast.
+package peakewma
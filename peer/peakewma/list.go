@@ -0,0 +1,282 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package peakewma
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/x/introspection"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/zap"
+)
+
+type listOptions struct {
+	capacity             int
+	source               rand.Source
+	decay                time.Duration
+	initialLatency       time.Duration
+	failFast             bool
+	defaultChooseTimeout *time.Duration
+	logger               *zap.Logger
+	circuitBreaker       *abstractlist.CircuitBreakerConfig
+	healthCheck          *abstractlist.HealthCheckConfig
+
+	now func() time.Time // for tests
+}
+
+var defaultListOptions = listOptions{
+	capacity:       10,
+	decay:          10 * time.Second,
+	initialLatency: 10 * time.Millisecond,
+	now:            time.Now,
+}
+
+// ListOption customizes the behavior of a peak EWMA peer list.
+type ListOption interface {
+	apply(*listOptions)
+}
+
+type listOptionFunc func(*listOptions)
+
+func (f listOptionFunc) apply(options *listOptions) { f(options) }
+
+// Capacity specifies the default capacity of the underlying
+// data structures for this list.
+//
+// Defaults to 10.
+func Capacity(capacity int) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.capacity = capacity
+	})
+}
+
+// Seed specifies the seed for generating random choices.
+func Seed(seed int64) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.source = rand.NewSource(seed)
+	})
+}
+
+// Decay specifies the time constant of the moving average of latencies. The
+// weight of a latency in the average falls to about a third after each
+// period of this length.
+//
+// Defaults to 10 seconds.
+func Decay(decay time.Duration) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.decay = decay
+	})
+}
+
+// InitialLatency specifies the average latency that peers start with, before
+// the list observes any of their requests.
+//
+// Defaults to 10ms.
+func InitialLatency(latency time.Duration) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.initialLatency = latency
+	})
+}
+
+// FailFast indicates that the peer list should not wait for a peer to become
+// available when choosing a peer.
+//
+// This option is preferrable when the better failure mode is to retry from the
+// origin, since another proxy instance might already have a connection.
+func FailFast() ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.failFast = true
+	})
+}
+
+// Logger specifies a logger.
+func Logger(logger *zap.Logger) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.logger = logger
+	})
+}
+
+// DefaultChooseTimeout specifies the default timeout to add to 'Choose' calls
+// without context deadlines. This prevents long-lived streams from setting
+// calling deadlines.
+//
+// Defaults to 500ms.
+func DefaultChooseTimeout(timeout time.Duration) ListOption {
+	return listOptionFunc(func(c *listOptions) {
+		c.defaultChooseTimeout = &timeout
+	})
+}
+
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.circuitBreaker = &config
+	})
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.healthCheck = &config
+	})
+}
+
+// New creates a new peak EWMA peer list.
+func New(transport peer.Transport, opts ...ListOption) *List {
+	options := defaultListOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	if options.source == nil {
+		options.source = rand.NewSource(time.Now().UnixNano())
+	}
+	if options.decay <= 0 {
+		options.decay = defaultListOptions.decay
+	}
+
+	plOpts := []abstractlist.Option{
+		abstractlist.Capacity(options.capacity),
+		abstractlist.NoShuffle(),
+	}
+	if options.logger != nil {
+		plOpts = append(plOpts, abstractlist.Logger(options.logger))
+	}
+	if options.failFast {
+		plOpts = append(plOpts, abstractlist.FailFast())
+	}
+	if options.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*options.circuitBreaker))
+	}
+	if options.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*options.healthCheck))
+	}
+	if options.defaultChooseTimeout != nil {
+		plOpts = append(plOpts, abstractlist.DefaultChooseTimeout(*options.defaultChooseTimeout))
+	}
+
+	impl := newPeakEWMAList(options)
+	return &List{
+		list: abstractlist.New("peak-ewma", transport, impl, plOpts...),
+		impl: impl,
+	}
+}
+
+// List is a PeerList that chooses the peer with the lower expected latency
+// of two random peers.
+type List struct {
+	list *abstractlist.List
+	impl *peakEWMAList
+}
+
+// Start causes the peer list to start.
+//
+// Starting will retain all peers that have been added but not removed
+// the first time it is called.
+//
+// Start may be called any number of times and in any order in relation to Stop
+// but will only cause the list to start the first time, and only if it has not
+// already been stopped.
+func (l *List) Start() error {
+	return l.list.Start()
+}
+
+// Stop causes the peer list to stop.
+//
+// Stopping will release all retained peers to the underlying transport.
+//
+// Stop may be called any number of times and in order in relation to Start but
+// will only cause the list to stop the first time, and only if it has
+// previously been started.
+func (l *List) Stop() error {
+	return l.list.Stop()
+}
+
+// IsRunning returns whether the list has started and not yet stopped.
+func (l *List) IsRunning() bool {
+	return l.list.IsRunning()
+}
+
+// Choose returns a peer, suitable for sending a request.
+//
+// The peer is not guaranteed to be connected and available, but the peer list
+// makes every attempt to ensure this and minimize the probability that a
+// chosen peer will fail to carry a request.
+//
+// The list measures the latency of the request until onFinish is called.
+func (l *List) Choose(ctx context.Context, req *transport.Request) (peer peer.Peer, onFinish func(error), err error) {
+	return l.list.Choose(ctx, req)
+}
+
+// Update may add and remove logical peers in the list.
+//
+// The peer list uses a transport to obtain a physical peer for each logical
+// peer.
+// The transport is responsible for informing the peer list whether the peer is
+// available or unavailable, but cannot guarantee that the peer will still be
+// available after it is chosen.
+func (l *List) Update(updates peer.ListUpdates) error {
+	return l.list.Update(updates)
+}
+
+// NotifyStatusChanged forwards a status change notification to an individual
+// peer in the list.
+//
+// This satisfies the peer.Subscriber interface and should only be used to
+// send notifications in tests.
+// The list's RetainPeer and ReleasePeer methods deal with an individual
+// peer.Subscriber instance for each peer in the list, avoiding a map lookup.
+func (l *List) NotifyStatusChanged(pid peer.Identifier) {
+	l.list.NotifyStatusChanged(pid)
+}
+
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
+// Introspect reveals information about the list to the internal YARPC
+// introspection system, including the average latency of available peers.
+func (l *List) Introspect() introspection.ChooserStatus {
+	status := l.list.Introspect()
+	latencies := l.impl.latencies()
+	for i, ps := range status.Peers {
+		if latency, ok := latencies[ps.Identifier]; ok {
+			status.Peers[i].State += ", latency " + latency.String()
+		}
+	}
+	return status
+}
+
+// Peers produces a slice of all retained peers.
+func (l *List) Peers() []peer.StatusPeer {
+	return l.list.Peers()
+}
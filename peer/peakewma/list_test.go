@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package peakewma
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/abstractpeer"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+func TestListAvoidsSlowPeer(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	list := New(fake, Seed(0), FailFast(), InitialLatency(time.Millisecond))
+	require.NoError(t, list.Start())
+	defer func() { assert.NoError(t, list.Stop()) }()
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{
+			abstractpeer.PeerIdentifier("1.1.1.1:1111"),
+			abstractpeer.PeerIdentifier("2.2.2.2:2222"),
+		},
+	}))
+	fake.Flush()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	slow, onFinish, err := list.Choose(ctx, &transport.Request{})
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	onFinish(nil)
+
+	for i := 0; i < 10; i++ {
+		p, onFinish, err := list.Choose(ctx, &transport.Request{})
+		require.NoError(t, err)
+		onFinish(nil)
+		assert.NotEqual(t, slow.Identifier(), p.Identifier(), "must avoid the slow peer")
+	}
+
+	status := list.Introspect()
+	require.Len(t, status.Peers, 2)
+	for _, ps := range status.Peers {
+		assert.Contains(t, ps.State, ", latency ")
+	}
+	assert.Len(t, list.Peers(), 2)
+	assert.True(t, list.IsRunning())
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package peakewma
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+type peakEWMAList struct {
+	subscribers    []*subscriber
+	random         *rand.Rand
+	decay          float64
+	initialLatency float64
+	now            func() time.Time
+
+	m sync.Mutex
+}
+
+func newPeakEWMAList(options listOptions) *peakEWMAList {
+	return &peakEWMAList{
+		subscribers:    make([]*subscriber, 0, options.capacity),
+		random:         rand.New(options.source),
+		decay:          float64(options.decay),
+		initialLatency: float64(options.initialLatency),
+		now:            options.now,
+	}
+}
+
+func (l *peakEWMAList) Add(peer peer.StatusPeer, _ peer.Identifier) abstractlist.Subscriber {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	index := len(l.subscribers)
+	l.subscribers = append(l.subscribers, &subscriber{
+		list:  l,
+		index: index,
+		peer:  peer,
+		ewma:  l.initialLatency,
+		stamp: l.now(),
+	})
+	return l.subscribers[index]
+}
+
+func (l *peakEWMAList) Remove(peer peer.StatusPeer, _ peer.Identifier, ps abstractlist.Subscriber) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	sub, ok := ps.(*subscriber)
+	if !ok || len(l.subscribers) == 0 {
+		return
+	}
+	index := sub.index
+	last := len(l.subscribers) - 1
+	l.subscribers[index] = l.subscribers[last]
+	l.subscribers[index].index = index
+	l.subscribers = l.subscribers[0:last]
+}
+
+func (l *peakEWMAList) Choose(_ *transport.Request) peer.StatusPeer {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	numSubs := len(l.subscribers)
+	if numSubs == 0 {
+		return nil
+	}
+	if numSubs == 1 {
+		return l.subscribers[0].peer
+	}
+	i := l.random.Intn(numSubs)
+	j := i + 1 + l.random.Intn(numSubs-1)
+	if j >= numSubs {
+		j -= numSubs
+	}
+	now := l.now()
+	if l.subscribers[i].cost(now) > l.subscribers[j].cost(now) {
+		i = j
+	}
+	return l.subscribers[i].peer
+}
+
+func (l *peakEWMAList) Start() error {
+	return nil
+}
+
+func (l *peakEWMAList) Stop() error {
+	return nil
+}
+
+func (l *peakEWMAList) IsRunning() bool {
+	return true
+}
+
+// latencies returns the current average latency of every available peer.
+func (l *peakEWMAList) latencies() map[string]time.Duration {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	now := l.now()
+	latencies := make(map[string]time.Duration, len(l.subscribers))
+	for _, s := range l.subscribers {
+		s.observe(now, 0)
+		latencies[s.peer.Identifier()] = time.Duration(s.ewma)
+	}
+	return latencies
+}
+
+// subscriber tracks the pending requests and the average latency of a peer.
+// All of its fields are guarded by the list lock.
+type subscriber struct {
+	list    *peakEWMAList
+	index   int
+	peer    peer.StatusPeer
+	pending int
+	// ewma is the average latency in nanoseconds as of stamp.
+	ewma  float64
+	stamp time.Time
+}
+
+var _ abstractlist.LatencySubscriber = (*subscriber)(nil)
+
+func (s *subscriber) UpdatePendingRequestCount(pendingRequestCount int) {
+	s.list.m.Lock()
+	defer s.list.m.Unlock()
+
+	s.pending = pendingRequestCount
+}
+
+func (s *subscriber) ObserveLatency(latency time.Duration) {
+	s.list.m.Lock()
+	defer s.list.m.Unlock()
+
+	s.observe(s.list.now(), float64(latency))
+}
+
+// observe folds a latency into the average. Latencies above the average
+// replace it, while lower latencies pull the average toward them in
+// proportion to the time since the last observation.
+func (s *subscriber) observe(now time.Time, latency float64) {
+	if latency > s.ewma {
+		s.ewma = latency
+	} else {
+		elapsed := now.Sub(s.stamp)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		w := math.Exp(-float64(elapsed) / s.list.decay)
+		s.ewma = s.ewma*w + latency*(1-w)
+	}
+	s.stamp = now
+}
+
+// cost decays the average toward zero for the time since the last
+// observation, and weighs it by the number of pending requests.
+func (s *subscriber) cost(now time.Time) float64 {
+	s.observe(now, 0)
+	return s.ewma * float64(s.pending+1)
+}
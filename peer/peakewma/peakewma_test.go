@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package peakewma
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/peer/abstractpeer"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Add(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestImplementation(clock *fakeClock) *peakEWMAList {
+	options := defaultListOptions
+	options.source = rand.NewSource(0)
+	options.now = clock.Now
+	return newPeakEWMAList(options)
+}
+
+func newPeer(addr string) peer.StatusPeer {
+	return yarpctest.NewFakeTransport().Peer(abstractpeer.PeerIdentifier(addr))
+}
+
+func TestObserve(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := newTestImplementation(clock)
+	sub := l.Add(newPeer("1.1.1.1:1111"), nil).(*subscriber)
+	assert.Equal(t, float64(10*time.Millisecond), sub.ewma, "peers must start at the initial latency")
+
+	sub.ObserveLatency(50 * time.Millisecond)
+	assert.Equal(t, float64(50*time.Millisecond), sub.ewma, "peaks must replace the average")
+
+	clock.Add(10 * time.Second)
+	sub.ObserveLatency(10 * time.Millisecond)
+	want := 50*math.Exp(-1) + 10*(1-math.Exp(-1))
+	assert.InDelta(t, want, sub.ewma/float64(time.Millisecond), 1e-9, "lower latencies must blend in")
+
+	clock.Add(10 * time.Second)
+	assert.InDelta(t, want*math.Exp(-1), sub.cost(clock.Now())/float64(time.Millisecond), 1e-9,
+		"average must decay without requests")
+}
+
+func TestCost(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := newTestImplementation(clock)
+	sub := l.Add(newPeer("1.1.1.1:1111"), nil).(*subscriber)
+
+	sub.ObserveLatency(20 * time.Millisecond)
+	assert.Equal(t, float64(20*time.Millisecond), sub.cost(clock.Now()))
+
+	sub.UpdatePendingRequestCount(2)
+	assert.Equal(t, float64(60*time.Millisecond), sub.cost(clock.Now()))
+}
+
+func TestChooseLowestCost(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := newTestImplementation(clock)
+	assert.Nil(t, l.Choose(&transport.Request{}), "empty list must choose nothing")
+
+	fast, slow := newPeer("1.1.1.1:1111"), newPeer("2.2.2.2:2222")
+	fastSub := l.Add(fast, nil)
+	slowSub := l.Add(slow, nil).(abstractlist.LatencySubscriber)
+	assert.Equal(t, fast, l.Choose(&transport.Request{}), "single peer must be chosen")
+
+	slowSub.ObserveLatency(100 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, fast, l.Choose(&transport.Request{}))
+	}
+
+	// Enough pending requests outweigh the latency.
+	fastSub.UpdatePendingRequestCount(20)
+	assert.Equal(t, slow, l.Choose(&transport.Request{}))
+
+	l.Remove(fast, nil, fastSub)
+	assert.Equal(t, slow, l.Choose(&transport.Request{}))
+	assert.Equal(t, map[string]time.Duration{
+		"2.2.2.2:2222": 100 * time.Millisecond,
+	}, l.latencies())
+
+	require.True(t, l.IsRunning())
+	assert.NoError(t, l.Start())
+	assert.NoError(t, l.Stop())
+}
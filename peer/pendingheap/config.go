@@ -22,6 +22,7 @@ package pendingheap
 
 import (
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/yarpcconfig"
 	"go.uber.org/yarpc/yarpcerrors"
 )
@@ -30,6 +31,12 @@ import (
 type Configuration struct {
 	Capacity *int `config:"capacity"`
 	FailFast bool `config:"failFast"`
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
 }
 
 // Spec returns a configuration specification for the pending heap peer list
@@ -63,6 +70,26 @@ type Configuration struct {
 //	    - 127.0.0.1:8080
 //	  capacity: 1
 //	  failFast: true
+//
+// The circuit breaker stops choosing a peer for a cool-down period after it
+// fails too many requests.
+//
+//	fewest-pending-requests:
+//	  peers:
+//	    - 127.0.0.1:8080
+//	  circuitBreaker:
+//	    consecutiveFailures: 5
+//	    coolDown: 10s
+//
+// Active health checks call a health check procedure on every peer and stop
+// choosing peers that fail them, even while their connections remain open.
+//
+//	fewest-pending-requests:
+//	  peers:
+//	    - 127.0.0.1:8080
+//	  healthCheck:
+//	    service: otherservice
+//	    interval: 5s
 func Spec() yarpcconfig.PeerListSpec {
 	return SpecWithOptions()
 }
@@ -87,6 +114,18 @@ func SpecWithOptions(options ...ListOption) yarpcconfig.PeerListSpec {
 			if cfg.FailFast {
 				opts = append(opts, FailFast())
 			}
+			if cfg.CircuitBreaker != nil {
+				if err := cfg.CircuitBreaker.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, CircuitBreaker(*cfg.CircuitBreaker))
+			}
+			if cfg.HealthCheck != nil {
+				if err := cfg.HealthCheck.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, HealthCheck(*cfg.HealthCheck))
+			}
 
 			return New(t, opts...), nil
 		},
@@ -22,9 +22,11 @@ package pendingheap
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hostport"
 	"go.uber.org/yarpc/yarpcconfig"
 	"go.uber.org/yarpc/yarpctest"
@@ -60,6 +62,42 @@ func TestPendingHeapConfig(t *testing.T) {
 				Capacity: &twenty,
 			},
 		},
+		{
+			name: "circuit breaker",
+			cfg: Configuration{
+				CircuitBreaker: &abstractlist.CircuitBreakerConfig{
+					ConsecutiveFailures: 3,
+					CoolDown:            time.Second,
+				},
+			},
+		},
+		{
+			name: "invalid circuit breaker",
+			cfg: Configuration{
+				CircuitBreaker: &abstractlist.CircuitBreakerConfig{
+					FailureRate: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check",
+			cfg: Configuration{
+				HealthCheck: &abstractlist.HealthCheckConfig{
+					Service:  "their-service",
+					Interval: time.Second,
+				},
+			},
+		},
+		{
+			name: "invalid health check",
+			cfg: Configuration{
+				HealthCheck: &abstractlist.HealthCheckConfig{
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	s := Spec()
@@ -39,6 +39,9 @@ type listConfig struct {
 	seed     int64
 	nextRand func(int) int
 	logger   *zap.Logger
+
+	circuitBreaker *abstractlist.CircuitBreakerConfig
+	healthCheck    *abstractlist.HealthCheckConfig
 }
 
 var defaultListConfig = listConfig{
@@ -87,6 +90,24 @@ func FailFast() ListOption {
 	}
 }
 
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) ListOption {
+	return func(c *listConfig) {
+		c.circuitBreaker = &config
+	}
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) ListOption {
+	return func(c *listConfig) {
+		c.healthCheck = &config
+	}
+}
+
 // New creates a new pending heap.
 func New(transport peer.Transport, opts ...ListOption) *List {
 	cfg := defaultListConfig
@@ -104,6 +125,12 @@ func New(transport peer.Transport, opts ...ListOption) *List {
 	if cfg.failFast {
 		plOpts = append(plOpts, abstractlist.FailFast())
 	}
+	if cfg.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*cfg.circuitBreaker))
+	}
+	if cfg.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*cfg.healthCheck))
+	}
 
 	nextRandFn := nextRand(cfg.seed)
 	if cfg.nextRand != nil {
@@ -198,6 +225,13 @@ func (l *List) NotifyStatusChanged(pid peer.Identifier) {
 	l.list.NotifyStatusChanged(pid)
 }
 
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
 // Introspect reveals information about the list to the internal YARPC
 // introspection system.
 func (l *List) Introspect() introspection.ChooserStatus {
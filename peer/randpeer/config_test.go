@@ -76,3 +76,108 @@ func TestConfigInvalidCapacity(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "Capacity must be greater than 0")
 }
+
+func TestConfigCircuitBreaker(t *testing.T) {
+	cfg := yarpcconfig.New()
+	cfg.RegisterPeerList(Spec())
+	cfg.RegisterTransport(yarpctest.FakeTransportSpec())
+	config, err := cfg.LoadConfig("our-service", attrs{
+		"outbounds": attrs{
+			"their-service": attrs{
+				"fake-transport": attrs{
+					"random": attrs{
+						"circuitBreaker": attrs{
+							"consecutiveFailures": 3,
+							"failureRate":         0.5,
+							"window":              10,
+							"coolDown":            "5s",
+						},
+						"peers": []string{
+							"1.1.1.1:1111",
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, config.Outbounds["their-service"].Unary)
+}
+
+func TestConfigInvalidCircuitBreaker(t *testing.T) {
+	cfg := yarpcconfig.New()
+	cfg.RegisterPeerList(Spec())
+	cfg.RegisterTransport(yarpctest.FakeTransportSpec())
+	_, err := cfg.LoadConfig("our-service", attrs{
+		"outbounds": attrs{
+			"their-service": attrs{
+				"fake-transport": attrs{
+					"random": attrs{
+						"circuitBreaker": attrs{
+							"failureRate": 2,
+						},
+						"peers": []string{
+							"1.1.1.1:1111",
+						},
+					},
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failureRate must be between 0 and 1")
+}
+
+func TestConfigHealthCheck(t *testing.T) {
+	cfg := yarpcconfig.New()
+	cfg.RegisterPeerList(Spec())
+	cfg.RegisterTransport(yarpctest.FakeTransportSpec())
+	config, err := cfg.LoadConfig("our-service", attrs{
+		"outbounds": attrs{
+			"their-service": attrs{
+				"fake-transport": attrs{
+					"random": attrs{
+						"healthCheck": attrs{
+							"service":            "their-service",
+							"procedure":          "health",
+							"encoding":           "raw",
+							"interval":           "10s",
+							"timeout":            "2s",
+							"unhealthyThreshold": 3,
+							"healthyThreshold":   2,
+						},
+						"peers": []string{
+							"1.1.1.1:1111",
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, config.Outbounds["their-service"].Unary)
+}
+
+func TestConfigInvalidHealthCheck(t *testing.T) {
+	cfg := yarpcconfig.New()
+	cfg.RegisterPeerList(Spec())
+	cfg.RegisterTransport(yarpctest.FakeTransportSpec())
+	_, err := cfg.LoadConfig("our-service", attrs{
+		"outbounds": attrs{
+			"their-service": attrs{
+				"fake-transport": attrs{
+					"random": attrs{
+						"healthCheck": attrs{
+							"interval": "10s",
+						},
+						"peers": []string{
+							"1.1.1.1:1111",
+						},
+					},
+				},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "health check service is required")
+}
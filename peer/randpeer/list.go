@@ -38,6 +38,8 @@ type listOptions struct {
 	failFast             bool
 	defaultChooseTimeout *time.Duration
 	logger               *zap.Logger
+	circuitBreaker       *abstractlist.CircuitBreakerConfig
+	healthCheck          *abstractlist.HealthCheckConfig
 }
 
 var defaultListOptions = listOptions{
@@ -106,6 +108,24 @@ func DefaultChooseTimeout(timeout time.Duration) ListOption {
 	})
 }
 
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.circuitBreaker = &config
+	})
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) ListOption {
+	return listOptionFunc(func(options *listOptions) {
+		options.healthCheck = &config
+	})
+}
+
 // New creates a new random peer list.
 func New(transport peer.Transport, opts ...ListOption) *List {
 	options := defaultListOptions
@@ -128,6 +148,12 @@ func New(transport peer.Transport, opts ...ListOption) *List {
 	if options.failFast {
 		plOpts = append(plOpts, abstractlist.FailFast())
 	}
+	if options.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*options.circuitBreaker))
+	}
+	if options.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*options.healthCheck))
+	}
 	if options.defaultChooseTimeout != nil {
 		plOpts = append(plOpts, abstractlist.DefaultChooseTimeout(*options.defaultChooseTimeout))
 	}
@@ -206,6 +232,13 @@ func (l *List) NotifyStatusChanged(pid peer.Identifier) {
 	l.list.NotifyStatusChanged(pid)
 }
 
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
 // Introspect reveals information about the list to the internal YARPC
 // introspection system.
 func (l *List) Introspect() introspection.ChooserStatus {
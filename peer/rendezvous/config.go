@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rendezvous
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/zap"
+)
+
+// Config is the configuration object for a rendezvous hashing peer list.
+type Config struct {
+	// PeerOverrideHeader allows clients to pass a header containing the shard
+	// identifier for a specific peer to override the destination address for
+	// the outgoing request.
+	//
+	// If that peer is not available, the request will continue on to the peer
+	// implied by the shard key.
+	PeerOverrideHeader string `config:"peerOverrideHeader"`
+
+	// AlternateShardKeyHeader allows clients to pass a header containing a
+	// shard key to use instead of the shard key of the request.
+	AlternateShardKeyHeader string `config:"alternateShardKeyHeader"`
+
+	// DefaultChooseTimeout specifies the deadline to add to Choose calls if not
+	// present. This enables calls without deadlines, ie streaming, to choose
+	// peers without waiting indefinitely.
+	DefaultChooseTimeout *time.Duration `config:"defaultChooseTimeout"`
+
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	// Requests for shards owned by an ejected peer spread over the
+	// remaining peers.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
+}
+
+// Spec returns a configuration specification for the rendezvous hashing peer
+// list implementation, making it possible to select peers by shard key.
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(rendezvous.Spec(logger))
+//
+// This enables the rendezvous hashing peer list:
+//
+//	outbounds:
+//	  otherservice:
+//	    unary:
+//	      http:
+//	        url: https://host:port/rpc
+//	        rendezvous:
+//	          peerOverrideHeader: x-peer-override
+//	          peers:
+//	            - 127.0.0.1:8080
+//	            - 127.0.0.1:8081
+func Spec(logger *zap.Logger) yarpcconfig.PeerListSpec {
+	return yarpcconfig.PeerListSpec{
+		Name: "rendezvous",
+		BuildPeerList: func(c Config, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error) {
+			opts := []Option{
+				PeerOverrideHeader(c.PeerOverrideHeader),
+				AlternateShardKeyHeader(c.AlternateShardKeyHeader),
+				Logger(logger),
+			}
+
+			if c.DefaultChooseTimeout != nil {
+				opts = append(opts, DefaultChooseTimeout(*c.DefaultChooseTimeout))
+			}
+
+			if c.CircuitBreaker != nil {
+				if err := c.CircuitBreaker.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, CircuitBreaker(*c.CircuitBreaker))
+			}
+			if c.HealthCheck != nil {
+				if err := c.HealthCheck.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, HealthCheck(*c.HealthCheck))
+			}
+
+			return New(t, opts...), nil
+		},
+	}
+}
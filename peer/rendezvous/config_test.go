@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rendezvous
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+func TestConfig(t *testing.T) {
+	s := Spec(nil)
+	duration := time.Second
+
+	c := Config{
+		PeerOverrideHeader:      "x-peer",
+		AlternateShardKeyHeader: "x-shard-key",
+		DefaultChooseTimeout:    &duration,
+		CircuitBreaker: &abstractlist.CircuitBreakerConfig{
+			ConsecutiveFailures: 3,
+		},
+		HealthCheck: &abstractlist.HealthCheckConfig{
+			Service: "their-service",
+		},
+	}
+	build := s.BuildPeerList.(func(c Config, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error))
+	pl, err := build(c, yarpctest.NewFakeTransport(), nil)
+	require.NoError(t, err, "must construct a peer list")
+	assert.NoError(t, pl.Update(peer.ListUpdates{Additions: []peer.Identifier{hostport.PeerIdentifier("127.0.0.1:8080")}}))
+}
+
+func TestConfigErrors(t *testing.T) {
+	build := Spec(nil).BuildPeerList.(func(c Config, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error))
+
+	_, err := build(Config{
+		CircuitBreaker: &abstractlist.CircuitBreakerConfig{FailureRate: 2},
+	}, yarpctest.NewFakeTransport(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failureRate must be between 0 and 1")
+
+	_, err = build(Config{
+		HealthCheck: &abstractlist.HealthCheckConfig{Interval: time.Second},
+	}, yarpctest.NewFakeTransport(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "health check service is required")
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package rendezvous provides a consistent hashing peer list that uses
+// rendezvous, or highest random weight, hashing, as an alternative to
+// hashring32.
+//
+// For every request, the list scores each available peer by a hash of the
+// peer's shard identifier and the request's shard key, and chooses the peer
+// with the highest score.
+//
+// Shard keys spread over peers as evenly as the hash allows, without the
+// replicas a hash ring needs, and membership changes cause the least possible
+// disruption: when a peer leaves, only its own shard keys move, spreading
+// evenly over the remaining peers, and when a peer joins, it only takes
+// shard keys from other peers. Choosing a peer takes time in proportion to
+// the number of available peers, so the list suits sharded services with up
+// to a few hundred peers.
+//
+// Like hashring32, the list supports a header that overrides the chosen peer
+// with the peer of a given shard identifier, and a header that supplies an
+// alternate shard key.
+package rendezvous
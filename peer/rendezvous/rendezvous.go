@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rendezvous
+
+import (
+	"sync"
+
+	farm "github.com/dgryski/go-farm"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+// NewImplementation creates a new rendezvous hashing
+// abstractlist.Implementation.
+//
+// Use this constructor instead of New, when wanting to do custom peer
+// connection management.
+func NewImplementation(opts ...Option) abstractlist.Implementation {
+	var options options
+	for _, o := range opts {
+		o.apply(&options)
+	}
+	return newRendezvousList(options)
+}
+
+type subscriber struct {
+	index int
+	peer  peer.StatusPeer
+	shard string
+	hash  uint64
+}
+
+func (s *subscriber) UpdatePendingRequestCount(int) {}
+
+type rendezvousList struct {
+	peerOverrideHeader      string
+	alternateShardKeyHeader string
+
+	subscribers []*subscriber
+	shards      map[string]*subscriber
+
+	m sync.RWMutex
+}
+
+var _ abstractlist.Implementation = (*rendezvousList)(nil)
+
+func newRendezvousList(options options) *rendezvousList {
+	return &rendezvousList{
+		peerOverrideHeader:      options.peerOverrideHeader,
+		alternateShardKeyHeader: options.alternateShardKeyHeader,
+		shards:                  make(map[string]*subscriber),
+	}
+}
+
+// shardIdentifier is the interface for an identifier that have a shard property
+type shardIdentifier interface {
+	Identifier() string
+	Shard() string
+}
+
+func (l *rendezvousList) Add(p peer.StatusPeer, pid peer.Identifier) abstractlist.Subscriber {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	shard := getShardID(pid)
+	sub := &subscriber{
+		index: len(l.subscribers),
+		peer:  p,
+		shard: shard,
+		hash:  farm.Fingerprint64([]byte(shard)),
+	}
+	l.subscribers = append(l.subscribers, sub)
+	l.shards[shard] = sub
+	return sub
+}
+
+func (l *rendezvousList) Remove(p peer.StatusPeer, pid peer.Identifier, s abstractlist.Subscriber) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	sub, ok := s.(*subscriber)
+	if !ok || sub.index >= len(l.subscribers) || l.subscribers[sub.index] != sub {
+		return
+	}
+	last := len(l.subscribers) - 1
+	l.subscribers[sub.index] = l.subscribers[last]
+	l.subscribers[sub.index].index = sub.index
+	l.subscribers = l.subscribers[:last]
+	if l.shards[sub.shard] == sub {
+		delete(l.shards, sub.shard)
+	}
+}
+
+// Choose returns the peer with the highest score for the request's shard
+// key, or the peer named by the override header.
+func (l *rendezvousList) Choose(req *transport.Request) peer.StatusPeer {
+	l.m.RLock()
+	defer l.m.RUnlock()
+
+	if l.peerOverrideHeader != "" {
+		if dest, ok := req.Headers.Get(l.peerOverrideHeader); ok {
+			if sub, ok := l.shards[dest]; ok {
+				return sub.peer
+			}
+		}
+	}
+
+	shardKey := req.ShardKey
+	if l.alternateShardKeyHeader != "" {
+		if key, ok := req.Headers.Get(l.alternateShardKeyHeader); ok {
+			shardKey = key
+		}
+	}
+
+	keyHash := farm.Fingerprint64([]byte(shardKey))
+	var (
+		best      *subscriber
+		bestScore uint64
+	)
+	for _, sub := range l.subscribers {
+		score := mix(keyHash ^ sub.hash)
+		// Break ties by shard identifier, so that every client makes the
+		// same choice regardless of the order of its peers.
+		if best == nil || score > bestScore || (score == bestScore && sub.shard < best.shard) {
+			best, bestScore = sub, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.peer
+}
+
+func (l *rendezvousList) Start() error {
+	return nil
+}
+
+func (l *rendezvousList) Stop() error {
+	return nil
+}
+
+func (l *rendezvousList) IsRunning() bool {
+	return true
+}
+
+// mix scrambles the bits of a combined key and peer hash into a uniformly
+// distributed score, using the finalizer of SplitMix64.
+func mix(z uint64) uint64 {
+	z ^= z >> 30
+	z *= 0xbf58476d1ce4e5b9
+	z ^= z >> 27
+	z *= 0x94d049bb133111eb
+	z ^= z >> 31
+	return z
+}
+
+// getShardID returns the shardID from a StatusPeer.
+func getShardID(p peer.Identifier) string {
+	if sp, ok := p.(shardIdentifier); ok {
+		return sp.Shard()
+	}
+	return p.Identifier()
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/yarpcconfig"
 	"go.uber.org/yarpc/yarpcerrors"
 )
@@ -36,6 +37,12 @@ type Configuration struct {
 	// present. This enables calls without deadlines, ie streaming, to choose
 	// peers without waiting indefinitely.
 	DefaultChooseTimeout *time.Duration `config:"defaultChooseTimeout"`
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
 }
 
 // Spec returns a configuration specification for the round-robin peer list
@@ -72,6 +79,26 @@ type Configuration struct {
 //	  capacity: 1
 //	  failFast: true
 //	  defaultChooseTimeout: 1s
+//
+// The circuit breaker stops choosing a peer for a cool-down period after it
+// fails too many requests.
+//
+//	round-robin:
+//	  peers:
+//	    - 127.0.0.1:8080
+//	  circuitBreaker:
+//	    consecutiveFailures: 5
+//	    coolDown: 10s
+//
+// Active health checks call a health check procedure on every peer and stop
+// choosing peers that fail them, even while their connections remain open.
+//
+//	round-robin:
+//	  peers:
+//	    - 127.0.0.1:8080
+//	  healthCheck:
+//	    service: otherservice
+//	    interval: 5s
 func Spec() yarpcconfig.PeerListSpec {
 	return SpecWithOptions()
 }
@@ -98,6 +125,18 @@ func SpecWithOptions(options ...ListOption) yarpcconfig.PeerListSpec {
 			if cfg.DefaultChooseTimeout != nil {
 				opts = append(opts, DefaultChooseTimeout(*cfg.DefaultChooseTimeout))
 			}
+			if cfg.CircuitBreaker != nil {
+				if err := cfg.CircuitBreaker.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, CircuitBreaker(*cfg.CircuitBreaker))
+			}
+			if cfg.HealthCheck != nil {
+				if err := cfg.HealthCheck.Validate(); err != nil {
+					return nil, err
+				}
+				opts = append(opts, HealthCheck(*cfg.HealthCheck))
+			}
 			return New(t, opts...), nil
 		},
 	}
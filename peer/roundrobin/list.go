@@ -38,6 +38,8 @@ type listConfig struct {
 	defaultChooseTimeout *time.Duration
 	seed                 int64
 	logger               *zap.Logger
+	circuitBreaker       *abstractlist.CircuitBreakerConfig
+	healthCheck          *abstractlist.HealthCheckConfig
 }
 
 var defaultListConfig = listConfig{
@@ -88,6 +90,24 @@ func DefaultChooseTimeout(timeout time.Duration) ListOption {
 	}
 }
 
+// CircuitBreaker stops choosing a peer for a cool-down period after it fails
+// too many requests.
+// See "go.uber.org/yarpc/peer/abstractlist".CircuitBreakerConfig for details.
+func CircuitBreaker(config abstractlist.CircuitBreakerConfig) ListOption {
+	return func(c *listConfig) {
+		c.circuitBreaker = &config
+	}
+}
+
+// HealthCheck periodically calls a health check procedure on every peer and
+// stops choosing peers that fail health checks, even while connected.
+// See "go.uber.org/yarpc/peer/abstractlist".HealthCheckConfig for details.
+func HealthCheck(config abstractlist.HealthCheckConfig) ListOption {
+	return func(c *listConfig) {
+		c.healthCheck = &config
+	}
+}
+
 // New creates a new round robin peer list.
 func New(transport peer.Transport, opts ...ListOption) *List {
 	cfg := defaultListConfig
@@ -111,6 +131,12 @@ func New(transport peer.Transport, opts ...ListOption) *List {
 	if cfg.defaultChooseTimeout != nil {
 		plOpts = append(plOpts, abstractlist.DefaultChooseTimeout(*cfg.defaultChooseTimeout))
 	}
+	if cfg.circuitBreaker != nil {
+		plOpts = append(plOpts, abstractlist.CircuitBreaker(*cfg.circuitBreaker))
+	}
+	if cfg.healthCheck != nil {
+		plOpts = append(plOpts, abstractlist.HealthCheck(*cfg.healthCheck))
+	}
 
 	return &List{
 		list: abstractlist.New(
@@ -190,6 +216,13 @@ func (l *List) NotifyStatusChanged(pid peer.Identifier) {
 	l.list.NotifyStatusChanged(pid)
 }
 
+// SetProber sets the Prober through which the list sends health checks, if
+// they are enabled. Outbounds that own the list call SetProber so that
+// health checks reach peers the same way requests do.
+func (l *List) SetProber(prober abstractlist.Prober) {
+	l.list.SetProber(prober)
+}
+
 // Introspect reveals information about the list to the internal YARPC
 // introspection system.
 func (l *List) Introspect() introspection.ChooserStatus {
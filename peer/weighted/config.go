@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Configuration describes how to build a weighted peer list.
+type Configuration struct {
+	Capacity *int `config:"capacity"`
+	FailFast bool `config:"failFast"`
+	// DefaultChooseTimeout specifies the deadline to add to Choose calls if not
+	// present. This enables calls without deadlines, ie streaming, to choose
+	// peers without waiting indefinitely.
+	DefaultChooseTimeout *time.Duration `config:"defaultChooseTimeout"`
+	// CircuitBreaker enables a per-peer circuit breaker that temporarily
+	// stops choosing peers that fail too many requests.
+	CircuitBreaker *abstractlist.CircuitBreakerConfig `config:"circuitBreaker"`
+	// HealthCheck enables active health checks that stop choosing peers
+	// that fail them, even while connected.
+	HealthCheck *abstractlist.HealthCheckConfig `config:"healthCheck"`
+}
+
+// RoundRobinSpec returns a configuration specification for the weighted
+// round robin peer list implementation.
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(weighted.RoundRobinSpec())
+//
+// The list takes its weights from the identifiers a peer list updater
+// provides, so it is most useful with an updater that attaches weights, like
+// the file updater:
+//
+//	outbounds:
+//	  otherservice:
+//	    unary:
+//	      http:
+//	        url: https://host:port/rpc
+//	        weighted-round-robin:
+//	          file:
+//	            path: /etc/otherservice/peers.yaml
+//
+// Peers from updaters that do not attach weights all have a weight of 1.
+func RoundRobinSpec() yarpcconfig.PeerListSpec {
+	return RoundRobinSpecWithOptions()
+}
+
+// RoundRobinSpecWithOptions accepts additional list constructor options.
+func RoundRobinSpecWithOptions(options ...ListOption) yarpcconfig.PeerListSpec {
+	return yarpcconfig.PeerListSpec{
+		Name:          "weighted-round-robin",
+		BuildPeerList: buildPeerList(NewRoundRobin, options),
+	}
+}
+
+// RandomSpec returns a configuration specification for the weighted random
+// peer list implementation.
+//
+//	cfg := yarpcconfig.New()
+//	cfg.MustRegisterPeerList(weighted.RandomSpec())
+//
+// This enables the weighted random peer list:
+//
+//	weighted-random:
+//	  file:
+//	    path: /etc/otherservice/peers.yaml
+func RandomSpec() yarpcconfig.PeerListSpec {
+	return RandomSpecWithOptions()
+}
+
+// RandomSpecWithOptions accepts additional list constructor options.
+func RandomSpecWithOptions(options ...ListOption) yarpcconfig.PeerListSpec {
+	return yarpcconfig.PeerListSpec{
+		Name:          "weighted-random",
+		BuildPeerList: buildPeerList(NewRandom, options),
+	}
+}
+
+type listConstructor func(peer.Transport, ...ListOption) *List
+
+func buildPeerList(newList listConstructor, options []ListOption) func(Configuration, peer.Transport, *yarpcconfig.Kit) (peer.ChooserList, error) {
+	return func(cfg Configuration, t peer.Transport, k *yarpcconfig.Kit) (peer.ChooserList, error) {
+		opts := make([]ListOption, 0, len(options)+4)
+
+		opts = append(opts, options...)
+
+		if cfg.Capacity != nil {
+			if *cfg.Capacity <= 0 {
+				return nil, yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument,
+					"Capacity must be greater than 0. Got: %d.", *cfg.Capacity)
+			}
+			opts = append(opts, Capacity(*cfg.Capacity))
+		}
+
+		if cfg.FailFast {
+			opts = append(opts, FailFast())
+		}
+		if cfg.DefaultChooseTimeout != nil {
+			opts = append(opts, DefaultChooseTimeout(*cfg.DefaultChooseTimeout))
+		}
+		if cfg.CircuitBreaker != nil {
+			if err := cfg.CircuitBreaker.Validate(); err != nil {
+				return nil, err
+			}
+			opts = append(opts, CircuitBreaker(*cfg.CircuitBreaker))
+		}
+		if cfg.HealthCheck != nil {
+			if err := cfg.HealthCheck.Validate(); err != nil {
+				return nil, err
+			}
+			opts = append(opts, HealthCheck(*cfg.HealthCheck))
+		}
+		return newList(t, opts...), nil
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package weighted provides peer lists that choose peers in proportion to
+// weights that peer list updaters attach to peer identifiers.
+//
+// The round robin list spreads requests evenly over time using the smooth
+// weighted round robin algorithm, and the random list chooses each peer at
+// random with a probability proportional to its weight.
+//
+//	list := weighted.NewRoundRobin(transport)
+//	list.Update(peer.ListUpdates{
+//		Additions: []peer.Identifier{
+//			weighted.Identify("10.0.0.1:4040", 10),
+//			weighted.Identify("10.0.0.2:4040", 1), // canary
+//		},
+//	})
+//
+// Peers with identifiers that carry no weight have a weight of 1. A peer with
+// a weight of 0 is retained, keeping its connections warm, but never chosen,
+// which makes it possible to drain a host without removing it.
+//
+// To change the weight of a peer, remove its old identifier and add the new
+// one in the same update. The list replaces the identifier in place without
+// releasing the peer.
+package weighted
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import "go.uber.org/yarpc/api/peer"
+
+// Identifier is a peer identifier that carries a weight.
+type Identifier interface {
+	peer.Identifier
+
+	// Weight is the share of requests the peer should receive relative to the
+	// other peers in the list.
+	Weight() int
+}
+
+type identifier struct {
+	address string
+	weight  int
+}
+
+// Identify returns an identifier for the peer at the given address with the
+// given weight.
+func Identify(address string, weight int) Identifier {
+	return identifier{address: address, weight: weight}
+}
+
+func (i identifier) Identifier() string { return i.address }
+
+func (i identifier) Weight() int { return i.weight }
+
+func (i identifier) String() string { return i.address }
+
+// Weight returns the weight of a peer identifier.
+//
+// Identifiers that do not implement Identifier have a weight of 1, and
+// negative weights are treated as 0.
+func Weight(pid peer.Identifier) int {
+	w, ok := pid.(interface{ Weight() int })
+	if !ok {
+		return 1
+	}
+	if weight := w.Weight(); weight > 0 {
+		return weight
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/yarpc/peer/hostport"
+)
+
+func TestWeight(t *testing.T) {
+	tests := []struct {
+		desc string
+		give Identifier
+		want int
+	}{
+		{desc: "positive", give: Identify("1.1.1.1:80", 3), want: 3},
+		{desc: "zero", give: Identify("1.1.1.1:80", 0), want: 0},
+		{desc: "negative", give: Identify("1.1.1.1:80", -2), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, "1.1.1.1:80", tt.give.Identifier())
+			assert.Equal(t, tt.want, Weight(tt.give))
+		})
+	}
+
+	t.Run("unweighted", func(t *testing.T) {
+		assert.Equal(t, 1, Weight(hostport.PeerIdentifier("1.1.1.1:80")))
+	})
+}
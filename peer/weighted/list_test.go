@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+func choose(t *testing.T, list *List, n int) map[string]int {
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		p, onFinish, err := list.Choose(ctx, &transport.Request{})
+		require.NoError(t, err)
+		onFinish(nil)
+		counts[p.Identifier()]++
+	}
+	return counts
+}
+
+func TestListDrain(t *testing.T) {
+	const (
+		a = "1.1.1.1:4040"
+		b = "2.2.2.2:4040"
+	)
+
+	for _, tt := range []struct {
+		desc    string
+		newList func(peer.Transport, ...ListOption) *List
+	}{
+		{desc: "round robin", newList: NewRoundRobin},
+		{desc: "random", newList: NewRandom},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Releasing a peer would fail, so changing weights must not
+			// release peers.
+			fake := yarpctest.NewFakeTransport(
+				yarpctest.InitialConnectionStatus(peer.Available),
+				yarpctest.ReleaseErrors(errors.New("released"), []string{a, b}),
+			)
+			list := tt.newList(fake, Seed(0), FailFast())
+			require.NoError(t, list.Start())
+
+			require.NoError(t, list.Update(peer.ListUpdates{
+				Additions: []peer.Identifier{Identify(a, 3), Identify(b, 1)},
+			}))
+			fake.Flush()
+
+			counts := choose(t, list, 400)
+			assert.InDelta(t, 300, counts[a], 40)
+			assert.InDelta(t, 100, counts[b], 40)
+
+			// Drain a.
+			require.NoError(t, list.Update(peer.ListUpdates{
+				Additions: []peer.Identifier{Identify(a, 0)},
+				Removals:  []peer.Identifier{Identify(a, 3)},
+			}))
+			assert.Len(t, list.Peers(), 2, "drained peers must stay retained")
+			assert.Equal(t, map[string]int{b: 10}, choose(t, list, 10))
+
+			// Drain b too.
+			require.NoError(t, list.Update(peer.ListUpdates{
+				Additions: []peer.Identifier{Identify(b, 0)},
+				Removals:  []peer.Identifier{Identify(b, 1)},
+			}))
+			_, _, err := list.Choose(context.Background(), &transport.Request{})
+			assert.Error(t, err, "a list of drained peers must not choose any")
+
+			// Bring a back.
+			require.NoError(t, list.Update(peer.ListUpdates{
+				Additions: []peer.Identifier{Identify(a, 1)},
+				Removals:  []peer.Identifier{Identify(a, 0)},
+			}))
+			assert.Equal(t, map[string]int{a: 10}, choose(t, list, 10))
+		})
+	}
+}
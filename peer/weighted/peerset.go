@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"sync"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+// peerSet tracks the available peers of a weighted list and their weights.
+type peerSet struct {
+	subscribers []*subscriber
+	total       int
+}
+
+func newPeerSet(cap int) peerSet {
+	return peerSet{subscribers: make([]*subscriber, 0, cap)}
+}
+
+func (s *peerSet) add(p peer.StatusPeer, pid peer.Identifier) *subscriber {
+	sub := &subscriber{
+		index:  len(s.subscribers),
+		peer:   p,
+		weight: Weight(pid),
+	}
+	s.subscribers = append(s.subscribers, sub)
+	s.total += sub.weight
+	return sub
+}
+
+func (s *peerSet) remove(ps abstractlist.Subscriber) {
+	sub, ok := ps.(*subscriber)
+	if !ok || len(s.subscribers) == 0 {
+		return
+	}
+	index := sub.index
+	last := len(s.subscribers) - 1
+	s.subscribers[index] = s.subscribers[last]
+	s.subscribers[index].index = index
+	s.subscribers = s.subscribers[0:last]
+	s.total -= sub.weight
+}
+
+type subscriber struct {
+	index  int
+	peer   peer.StatusPeer
+	weight int
+
+	// current is the running weight of the peer for smooth weighted round
+	// robin.
+	current int
+}
+
+var _ abstractlist.Subscriber = (*subscriber)(nil)
+
+func (*subscriber) UpdatePendingRequestCount(int) {}
+
+// lockedPeerSet guards a peer set with a mutex.
+type lockedPeerSet struct {
+	peerSet
+
+	m sync.Mutex
+}
+
+func (s *lockedPeerSet) Add(p peer.StatusPeer, pid peer.Identifier) abstractlist.Subscriber {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.add(p, pid)
+}
+
+func (s *lockedPeerSet) Remove(_ peer.StatusPeer, _ peer.Identifier, ps abstractlist.Subscriber) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.remove(ps)
+}
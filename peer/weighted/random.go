@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"math/rand"
+
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+// randomList chooses each peer at random with a probability proportional to
+// its weight.
+type randomList struct {
+	lockedPeerSet
+
+	random *rand.Rand
+}
+
+var _ abstractlist.Implementation = (*randomList)(nil)
+
+func newRandomList(cap int, source rand.Source) *randomList {
+	return &randomList{
+		lockedPeerSet: lockedPeerSet{peerSet: newPeerSet(cap)},
+		random:        rand.New(source),
+	}
+}
+
+func (r *randomList) Choose(_ *transport.Request) peer.StatusPeer {
+	// The lock also guards r.random, which is not thread safe.
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.total == 0 {
+		return nil
+	}
+	n := r.random.Intn(r.total)
+	for _, sub := range r.subscribers {
+		if n < sub.weight {
+			return sub.peer
+		}
+		n -= sub.weight
+	}
+	return nil
+}
+
+func (r *randomList) Start() error { return nil }
+
+func (r *randomList) Stop() error { return nil }
+
+func (r *randomList) IsRunning() bool { return true }
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom(t *testing.T) {
+	r := newRandomList(10, rand.NewSource(0))
+	assert.Equal(t, "--", chooseN(r, 2), "empty list")
+
+	a, b, c := &testPeer{"a"}, &testPeer{"b"}, &testPeer{"c"}
+	r.Add(a, Identify("a", 3))
+	subB := r.Add(b, Identify("b", 1))
+	r.Add(c, Identify("c", 0))
+
+	choices := chooseN(r, 4000)
+	assert.InDelta(t, 3000, strings.Count(choices, "a"), 150)
+	assert.InDelta(t, 1000, strings.Count(choices, "b"), 150)
+	assert.Zero(t, strings.Count(choices, "c"), "peers without weight must not be chosen")
+
+	r.Remove(b, Identify("b", 1), subB)
+	assert.Equal(t, "aaaa", chooseN(r, 4))
+}
+
+func TestRandomZeroWeight(t *testing.T) {
+	r := newRandomList(10, rand.NewSource(0))
+	r.Add(&testPeer{"a"}, Identify("a", 0))
+	assert.Equal(t, "--", chooseN(r, 2))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+// roundRobinList chooses peers with the smooth weighted round robin algorithm.
+//
+// On every choice, each peer's running weight grows by its weight, the peer
+// with the highest running weight is chosen, and the chosen peer's running
+// weight shrinks by the total weight. Over any window of total weight choices,
+// each peer is chosen as many times as its weight, and heavy peers are
+// interleaved with light peers rather than chosen in bursts.
+type roundRobinList struct {
+	lockedPeerSet
+}
+
+var _ abstractlist.Implementation = (*roundRobinList)(nil)
+
+func newRoundRobinList(cap int) *roundRobinList {
+	return &roundRobinList{
+		lockedPeerSet: lockedPeerSet{peerSet: newPeerSet(cap)},
+	}
+}
+
+func (r *roundRobinList) Choose(_ *transport.Request) peer.StatusPeer {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var best *subscriber
+	for _, sub := range r.subscribers {
+		if sub.weight == 0 {
+			continue
+		}
+		sub.current += sub.weight
+		if best == nil || sub.current > best.current {
+			best = sub
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	best.current -= r.total
+	return best.peer
+}
+
+func (r *roundRobinList) Start() error { return nil }
+
+func (r *roundRobinList) Stop() error { return nil }
+
+func (r *roundRobinList) IsRunning() bool { return true }
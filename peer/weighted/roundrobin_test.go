@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package weighted
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+type testPeer struct {
+	id string
+}
+
+func (p *testPeer) Identifier() string { return p.id }
+
+func (p *testPeer) Status() peer.Status { return peer.Status{ConnectionStatus: peer.Available} }
+
+func (p *testPeer) StartRequest() {}
+
+func (p *testPeer) EndRequest() {}
+
+// chooseN returns the identifiers of n consecutive choices, using "-" for
+// no choice.
+func chooseN(impl abstractlist.Implementation, n int) string {
+	var ids []string
+	for i := 0; i < n; i++ {
+		p := impl.Choose(&transport.Request{})
+		if p == nil {
+			ids = append(ids, "-")
+			continue
+		}
+		ids = append(ids, p.Identifier())
+	}
+	return strings.Join(ids, "")
+}
+
+func TestRoundRobin(t *testing.T) {
+	r := newRoundRobinList(10)
+	assert.Equal(t, "--", chooseN(r, 2), "empty list")
+
+	a, b, c := &testPeer{"a"}, &testPeer{"b"}, &testPeer{"c"}
+	r.Add(a, Identify("a", 5))
+	subB := r.Add(b, Identify("b", 1))
+	r.Add(c, Identify("c", 1))
+
+	assert.Equal(t, "aabacaa", chooseN(r, 7), "heavy peers must be interleaved")
+	assert.Equal(t, "aabacaa", chooseN(r, 7), "choices must repeat every total weight")
+
+	r.Remove(b, Identify("b", 1), subB)
+	choices := chooseN(r, 6)
+	assert.Equal(t, 5, strings.Count(choices, "a"))
+	assert.Equal(t, 1, strings.Count(choices, "c"))
+}
+
+func TestRoundRobinZeroWeight(t *testing.T) {
+	r := newRoundRobinList(10)
+
+	a, b := &testPeer{"a"}, &testPeer{"b"}
+	subA := r.Add(a, Identify("a", 0))
+	assert.Equal(t, "--", chooseN(r, 2), "peers without weight must not be chosen")
+
+	r.Add(b, Identify("b", 1))
+	assert.Equal(t, "bbb", chooseN(r, 3))
+
+	// Restore the peer's weight, the way abstractlist replaces identifiers.
+	r.Remove(a, Identify("a", 0), subA)
+	r.Add(a, Identify("a", 1))
+	assert.Len(t, strings.Replace(chooseN(r, 4), "b", "", -1), 2)
+}
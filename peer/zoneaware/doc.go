@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zoneaware provides a peer list that prefers peers in the caller's
+// own zone and region, and spills over to other zones when too few local
+// peers are available.
+//
+// The list learns where peers live from the identifiers that peer list
+// updaters provide:
+//
+//	list := zoneaware.New(transport, zoneaware.Zone("us-east-1a"), zoneaware.Region("us-east-1"))
+//	list.Update(peer.ListUpdates{
+//		Additions: []peer.Identifier{
+//			zoneaware.Identify("10.0.0.1:4040", "us-east-1a", "us-east-1"),
+//			zoneaware.Identify("10.0.1.1:4040", "us-east-1b", "us-east-1"),
+//			zoneaware.Identify("10.1.0.1:4040", "us-west-2a", "us-west-2"),
+//		},
+//	})
+//
+// The list chooses among the available peers of the local zone in turn, as
+// long as the fraction of local peers that are available is at least the
+// spillover threshold. Otherwise it spills over to the available peers of the
+// local region, under the same condition, and failing that, to all available
+// peers. Peers whose identifiers carry no zone or region are only chosen
+// once the list spills over to all peers.
+//
+// Requests tagged with yarpc.WithCrossZoneRoutingGRPC or
+// yarpc.WithCrossRegionRoutingGRPC prefer the zone or region they name over
+// the local one.
+//
+// Introspection reports the availability of peers in each zone, and whether
+// the list is spilling over from the local zone.
+package zoneaware
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zoneaware
+
+import "go.uber.org/yarpc/api/peer"
+
+// Identifier is a peer identifier that carries the zone and region of the
+// peer.
+type Identifier interface {
+	peer.Identifier
+
+	// Zone is the name of the zone, or availability zone, the peer runs in.
+	Zone() string
+
+	// Region is the name of the region the peer runs in.
+	Region() string
+}
+
+type identifier struct {
+	address string
+	zone    string
+	region  string
+}
+
+// Identify returns an identifier for the peer at the given address in the
+// given zone and region.
+func Identify(address, zone, region string) Identifier {
+	return identifier{address: address, zone: zone, region: region}
+}
+
+func (i identifier) Identifier() string { return i.address }
+
+func (i identifier) Zone() string { return i.zone }
+
+func (i identifier) Region() string { return i.region }
+
+func (i identifier) String() string { return i.address }
+
+// locality is where a peer lives.
+type locality struct {
+	zone   string
+	region string
+}
+
+// localityOf returns the zone and region of a peer identifier. Either is
+// empty if the identifier does not carry it.
+func localityOf(pid peer.Identifier) locality {
+	var l locality
+	if z, ok := pid.(interface{ Zone() string }); ok {
+		l.zone = z.Zone()
+	}
+	if r, ok := pid.(interface{ Region() string }); ok {
+		l.region = r.Region()
+	}
+	return l
+}
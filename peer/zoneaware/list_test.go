@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zoneaware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/yarpctest"
+)
+
+const (
+	a1 = "10.0.0.1:4040" // us-east-1a
+	a2 = "10.0.0.2:4040" // us-east-1a
+	b1 = "10.0.1.1:4040" // us-east-1b
+	b2 = "10.0.1.2:4040" // us-east-1b
+	w1 = "10.1.0.1:4040" // us-west-2a
+	x1 = "10.2.0.1:4040" // unknown
+)
+
+var (
+	_peers = []peer.Identifier{
+		Identify(a1, "us-east-1a", "us-east-1"),
+		Identify(a2, "us-east-1a", "us-east-1"),
+		Identify(b1, "us-east-1b", "us-east-1"),
+		Identify(b2, "us-east-1b", "us-east-1"),
+		Identify(w1, "us-west-2a", "us-west-2"),
+		hostport.PeerIdentifier(x1),
+	}
+)
+
+func newTestList(t *testing.T, opts ...ListOption) (*List, *yarpctest.FakeTransport) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	opts = append([]ListOption{Zone("us-east-1a"), Region("us-east-1"), FailFast()}, opts...)
+	list := New(fake, opts...)
+	require.NoError(t, list.Start())
+	t.Cleanup(func() { assert.NoError(t, list.Stop()) })
+
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: _peers}))
+	fake.Flush()
+	return list, fake
+}
+
+func choose(t *testing.T, list *List, req *transport.Request, n int) map[string]int {
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		p, onFinish, err := list.Choose(ctx, req)
+		require.NoError(t, err)
+		onFinish(nil)
+		counts[p.Identifier()]++
+	}
+	return counts
+}
+
+func disconnect(fake *yarpctest.FakeTransport, addrs ...string) {
+	for _, addr := range addrs {
+		fake.SimulateDisconnect(hostport.PeerIdentifier(addr))
+	}
+	fake.Flush()
+}
+
+func connect(fake *yarpctest.FakeTransport, addrs ...string) {
+	for _, addr := range addrs {
+		fake.SimulateConnect(hostport.PeerIdentifier(addr))
+	}
+	fake.Flush()
+}
+
+func TestListPrefersLocalZone(t *testing.T) {
+	list, _ := newTestList(t)
+	assert.Equal(t, map[string]int{a1: 5, a2: 5}, choose(t, list, &transport.Request{}, 10))
+}
+
+func TestListSpillover(t *testing.T) {
+	list, fake := newTestList(t)
+	req := &transport.Request{}
+
+	// Half of the local zone is still enough with the default threshold.
+	disconnect(fake, a2)
+	assert.Equal(t, map[string]int{a1: 10}, choose(t, list, req, 10))
+	assert.Contains(t, list.Introspect().State, "us-east-1a 1/2 available (local)")
+
+	disconnect(fake, a1)
+	assert.Equal(t, map[string]int{b1: 5, b2: 5}, choose(t, list, req, 10),
+		"requests must spill over to the local region first")
+	assert.Contains(t, list.Introspect().State, "us-east-1a 0/2 available (local, spilling over to region us-east-1)")
+
+	disconnect(fake, b1)
+	assert.Equal(t, map[string]int{b2: 3, w1: 3, x1: 3}, choose(t, list, req, 9),
+		"requests must spill over to all peers last")
+	assert.Contains(t, list.Introspect().State, "(local, spilling over to all zones)")
+
+	connect(fake, a1, a2, b1)
+	assert.Equal(t, map[string]int{a1: 5, a2: 5}, choose(t, list, req, 10),
+		"requests must return to the local zone once it recovers")
+}
+
+func TestListSpilloverThreshold(t *testing.T) {
+	list, fake := newTestList(t, SpilloverThreshold(0.75))
+	disconnect(fake, a2)
+	assert.Equal(t, map[string]int{a1: 3, b1: 3, b2: 3}, choose(t, list, &transport.Request{}, 9),
+		"requests must spill over when fewer local peers than the threshold are available")
+}
+
+func TestListRoutingHeaders(t *testing.T) {
+	list, _ := newTestList(t)
+
+	tests := []struct {
+		desc    string
+		headers transport.Headers
+		want    map[string]int
+	}{
+		{
+			desc:    "gRPC zone",
+			headers: transport.NewHeaders().With(encoding.RoutingZoneGRPCHeaderKey, "us-east-1b"),
+			want:    map[string]int{b1: 5, b2: 5},
+		},
+		{
+			desc:    "HTTP zone",
+			headers: transport.NewHeaders().With(_httpRoutingZoneHeader, "us-west-2a"),
+			want:    map[string]int{w1: 10},
+		},
+		{
+			desc:    "unknown zone, region",
+			headers: transport.NewHeaders().With(encoding.RoutingZoneGRPCHeaderKey, "us-west-2c").With(encoding.RoutingRegionGRPCHeaderKey, "us-west-2"),
+			want:    map[string]int{w1: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, choose(t, list, &transport.Request{Headers: tt.headers}, 10))
+		})
+	}
+}
+
+func TestListMovePeer(t *testing.T) {
+	list, fake := newTestList(t)
+	disconnect(fake, a2)
+
+	// Moving a1 out of the local zone must not release it, and must leave
+	// the local zone without available peers.
+	fake.SimulateReleaseError(hostport.PeerIdentifier(a1), errors.New("released"))
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Removals:  []peer.Identifier{Identify(a1, "us-east-1a", "us-east-1")},
+		Additions: []peer.Identifier{Identify(a1, "us-east-1b", "us-east-1")},
+	}))
+	fake.SimulateReleaseError(hostport.PeerIdentifier(a1), nil)
+	assert.Equal(t, map[string]int{a1: 3, b1: 3, b2: 3}, choose(t, list, &transport.Request{}, 9))
+	assert.Contains(t, list.Introspect().State, "us-east-1a 0/1 available (local, spilling over to region us-east-1)")
+	assert.Contains(t, list.Introspect().State, "us-east-1b 3/3 available")
+
+	require.NoError(t, list.Update(peer.ListUpdates{
+		Removals: []peer.Identifier{Identify(a2, "us-east-1a", "us-east-1")},
+	}))
+	assert.NotContains(t, list.Introspect().State, "us-east-1a", "empty zones must not be reported")
+}
+
+func TestListRejectedUpdate(t *testing.T) {
+	list, _ := newTestList(t)
+
+	// The list rejects a1 because it already retains it, so a1 must stay
+	// in the local zone.
+	assert.Error(t, list.Update(peer.ListUpdates{
+		Additions: []peer.Identifier{Identify(a1, "us-east-1b", "us-east-1")},
+	}))
+	assert.Error(t, list.Update(peer.ListUpdates{
+		Removals: []peer.Identifier{Identify("10.0.0.9:4040", "us-east-1a", "us-east-1")},
+	}))
+	assert.Contains(t, list.Introspect().State,
+		"zones: us-east-1a 2/2 available (local), us-east-1b 2/2 available, us-west-2a 1/1 available")
+	assert.Equal(t, map[string]int{a1: 5, a2: 5}, choose(t, list, &transport.Request{}, 10))
+}
+
+func TestListIntrospect(t *testing.T) {
+	list, _ := newTestList(t)
+
+	status := list.Introspect()
+	assert.Equal(t, "zone-aware", status.Name)
+	assert.Contains(t, status.State,
+		"zones: us-east-1a 2/2 available (local), us-east-1b 2/2 available, us-west-2a 1/1 available")
+
+	states := make(map[string]string, len(status.Peers))
+	for _, ps := range status.Peers {
+		states[ps.Identifier] = ps.State
+	}
+	assert.Contains(t, states[a1], ", zone us-east-1a")
+	assert.Contains(t, states[w1], ", zone us-west-2a")
+	assert.NotContains(t, states[x1], "zone")
+}
+
+func TestListWithoutLocality(t *testing.T) {
+	fake := yarpctest.NewFakeTransport(yarpctest.InitialConnectionStatus(peer.Available))
+	list := New(fake, FailFast())
+	require.NoError(t, list.Start())
+	defer list.Stop()
+
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: _peers}))
+	fake.Flush()
+	assert.Len(t, choose(t, list, &transport.Request{}, 12), 6,
+		"lists without a zone must choose among all peers")
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zoneaware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
+)
+
+// Requests over HTTP carry the cross-zone routing headers under these names.
+const (
+	_httpRoutingZoneHeader   = "rpc-routing-zone"
+	_httpRoutingRegionHeader = "rpc-routing-region"
+)
+
+// group holds the peers of a zone or a region, or all peers of the list.
+type group struct {
+	// total counts the peers retained by the list, available or not.
+	total int
+
+	// available peers, chosen in turn.
+	peers []*subscriber
+	next  int
+}
+
+func (g *group) add(sub *subscriber, index *int) {
+	*index = len(g.peers)
+	g.peers = append(g.peers, sub)
+}
+
+func (g *group) remove(index *int) {
+	i := *index
+	last := len(g.peers) - 1
+	g.peers[i] = g.peers[last]
+	g.peers[i].indices[g.peers[i].slot(g)] = i
+	g.peers = g.peers[:last]
+}
+
+func (g *group) choose() peer.StatusPeer {
+	if len(g.peers) == 0 {
+		return nil
+	}
+	g.next = (g.next + 1) % len(g.peers)
+	return g.peers[g.next].peer
+}
+
+// healthy reports whether enough of the group's peers are available for the
+// group to take all of its traffic.
+func (g *group) healthy(threshold float64) bool {
+	return g != nil && len(g.peers) > 0 && float64(len(g.peers)) >= threshold*float64(g.total)
+}
+
+// Slots of a subscriber in the groups it belongs to.
+const (
+	_zoneSlot = iota
+	_regionSlot
+	_allSlot
+)
+
+type subscriber struct {
+	peer     peer.StatusPeer
+	locality locality
+
+	// groups the peer is available in, and its index in each.
+	groups  [3]*group
+	indices [3]int
+}
+
+var _ abstractlist.Subscriber = (*subscriber)(nil)
+
+func (*subscriber) UpdatePendingRequestCount(int) {}
+
+func (s *subscriber) slot(g *group) int {
+	for i, sg := range s.groups {
+		if sg == g {
+			return i
+		}
+	}
+	panic("zoneaware: peer is not in group")
+}
+
+// zoneList is the abstractlist.Implementation of the zone-aware list.
+type zoneList struct {
+	m sync.Mutex
+
+	zone      string
+	region    string
+	threshold float64
+
+	// localities of all retained peers by address, as reported by the
+	// list's updates.
+	localities map[string]locality
+	zones      map[string]*group
+	regions    map[string]*group
+	all        group
+}
+
+var _ abstractlist.Implementation = (*zoneList)(nil)
+
+func newZoneList(options listOptions) *zoneList {
+	return &zoneList{
+		zone:       options.zone,
+		region:     options.region,
+		threshold:  options.spilloverThreshold,
+		localities: make(map[string]locality, options.capacity),
+		zones:      make(map[string]*group),
+		regions:    make(map[string]*group),
+	}
+}
+
+// groups returns the zone and region groups of a locality, creating them if
+// they do not exist. Either is nil if the locality does not name it.
+//
+// groups must be run under a lock.
+func (l *zoneList) groups(loc locality) (zone, region *group) {
+	if loc.zone != "" {
+		if zone = l.zones[loc.zone]; zone == nil {
+			zone = new(group)
+			l.zones[loc.zone] = zone
+		}
+	}
+	if loc.region != "" {
+		if region = l.regions[loc.region]; region == nil {
+			region = new(group)
+			l.regions[loc.region] = region
+		}
+	}
+	return zone, region
+}
+
+// update tracks the localities of the peers that the list retains, whether
+// or not they are available.
+func (l *zoneList) update(updates peer.ListUpdates) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	for _, pid := range updates.Removals {
+		l.release(pid.Identifier())
+	}
+	for _, pid := range updates.Additions {
+		addr := pid.Identifier()
+		if _, ok := l.localities[addr]; ok {
+			// The list rejects peers that it already retains, unless the
+			// same update removes them.
+			continue
+		}
+
+		loc := localityOf(pid)
+		l.localities[addr] = loc
+		zone, region := l.groups(loc)
+		for _, g := range []*group{zone, region, &l.all} {
+			if g != nil {
+				g.total++
+			}
+		}
+	}
+}
+
+// release must be run under a lock.
+func (l *zoneList) release(addr string) {
+	loc, ok := l.localities[addr]
+	if !ok {
+		return
+	}
+	delete(l.localities, addr)
+	zone, region := l.groups(loc)
+	for _, g := range []*group{zone, region, &l.all} {
+		if g != nil {
+			g.total--
+		}
+	}
+}
+
+func (l *zoneList) Add(p peer.StatusPeer, pid peer.Identifier) abstractlist.Subscriber {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	sub := &subscriber{peer: p, locality: localityOf(pid)}
+	zone, region := l.groups(sub.locality)
+	sub.groups = [3]*group{zone, region, &l.all}
+	for i, g := range sub.groups {
+		if g != nil {
+			g.add(sub, &sub.indices[i])
+		}
+	}
+	return sub
+}
+
+func (l *zoneList) Remove(_ peer.StatusPeer, _ peer.Identifier, ps abstractlist.Subscriber) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	sub, ok := ps.(*subscriber)
+	if !ok {
+		return
+	}
+	for i, g := range sub.groups {
+		if g != nil {
+			g.remove(&sub.indices[i])
+		}
+	}
+}
+
+// Choose returns an available peer from the preferred zone if it is healthy,
+// from the preferred region if that is, and from all peers otherwise.
+func (l *zoneList) Choose(req *transport.Request) peer.StatusPeer {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	zone, region := l.zone, l.region
+	if req != nil {
+		if z := routingHeader(req, encoding.RoutingZoneGRPCHeaderKey, _httpRoutingZoneHeader); z != "" {
+			zone = z
+		}
+		if r := routingHeader(req, encoding.RoutingRegionGRPCHeaderKey, _httpRoutingRegionHeader); r != "" {
+			region = r
+		}
+	}
+
+	if g := l.zones[zone]; g.healthy(l.threshold) {
+		return g.choose()
+	}
+	if g := l.regions[region]; g.healthy(l.threshold) {
+		return g.choose()
+	}
+	return l.all.choose()
+}
+
+func routingHeader(req *transport.Request, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := req.Headers.Get(k); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (l *zoneList) Start() error { return nil }
+
+func (l *zoneList) Stop() error { return nil }
+
+func (l *zoneList) IsRunning() bool { return true }
+
+// introspect describes the availability of each zone, and the zone of each
+// peer by address.
+func (l *zoneList) introspect() (string, map[string]string) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	names := make([]string, 0, len(l.zones))
+	for name, g := range l.zones {
+		if g.total > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	zones := make([]string, 0, len(names))
+	for _, name := range names {
+		g := l.zones[name]
+		desc := fmt.Sprintf("%s %d/%d available", name, len(g.peers), g.total)
+		if name == l.zone {
+			desc += " (local"
+			if !g.healthy(l.threshold) {
+				if l.regions[l.region].healthy(l.threshold) {
+					desc += ", spilling over to region " + l.region
+				} else {
+					desc += ", spilling over to all zones"
+				}
+			}
+			desc += ")"
+		}
+		zones = append(zones, desc)
+	}
+
+	peerZones := make(map[string]string, len(l.localities))
+	for addr, loc := range l.localities {
+		peerZones[addr] = loc.zone
+	}
+	return strings.Join(zones, ", "), peerZones
+}
@@ -0,0 +1,510 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shadow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// _absent stands in for values that are present in only one of two
+// responses.
+const _absent = "<absent>"
+
+// _maxRenderedBytes limits how much of an opaque body is shown in a
+// difference.
+const _maxRenderedBytes = 64
+
+// Difference is a value that differs between the bodies of a primary and a
+// shadow response.
+type Difference struct {
+	// Path locates the value in the response, starting at "$". Thrift and
+	// Protobuf fields are identified by their field numbers, so "$.1[2].3"
+	// is field 3 of the third element of field 1.
+	Path string
+
+	// Primary and Shadow render the two values, or are "<absent>" if the
+	// value is missing from a response.
+	Primary string
+	Shadow  string
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (d Difference) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("path", d.Path)
+	enc.AddString("primary", d.Primary)
+	enc.AddString("shadow", d.Shadow)
+	return nil
+}
+
+type differences []Difference
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (ds differences) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, d := range ds {
+		if err := enc.AppendObject(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff describes how the response to a shadow request differed from the
+// response to its primary request.
+type Diff struct {
+	// Service, Procedure and Encoding of the primary request.
+	Service   string
+	Procedure string
+	Encoding  transport.Encoding
+
+	// PrimaryOutcome and ShadowOutcome name how each request completed, as
+	// reported by the shadow_requests metric. Bodies are only compared if
+	// the outcomes match.
+	PrimaryOutcome string
+	ShadowOutcome  string
+
+	// Differences between the response bodies, if any.
+	Differences []Difference
+}
+
+// Comparer compares the bodies of a primary and a shadow response of the
+// same encoding, returning their differences.
+type Comparer interface {
+	Compare(primary, shadow []byte) []Difference
+}
+
+// ComparerFunc is a function that implements Comparer.
+type ComparerFunc func(primary, shadow []byte) []Difference
+
+// Compare implements Comparer.
+func (f ComparerFunc) Compare(primary, shadow []byte) []Difference {
+	return f(primary, shadow)
+}
+
+var (
+	// BytesComparer compares bodies byte for byte.
+	BytesComparer Comparer = ComparerFunc(compareBytes)
+
+	// JSONComparer compares JSON bodies by value, ignoring the order of
+	// object keys and the formatting of numbers.
+	JSONComparer Comparer = ComparerFunc(compareJSON)
+
+	// ThriftComparer compares Thrift bodies encoded with the binary
+	// protocol, with or without an envelope, field by field. The order of
+	// fields, set elements and map items is ignored.
+	ThriftComparer Comparer = ComparerFunc(compareThrift)
+
+	// ProtobufComparer compares Protobuf bodies field by field without
+	// knowledge of their schema. The order of fields with different
+	// numbers is ignored, but the order of repeated fields is not, so maps
+	// whose entries are marshaled in a different order are reported as
+	// different.
+	ProtobufComparer Comparer = ComparerFunc(compareProtobuf)
+)
+
+// _defaultComparers are the comparers used for the encodings built into
+// YARPC.
+var _defaultComparers = map[transport.Encoding]Comparer{
+	"json":   JSONComparer,
+	"thrift": ThriftComparer,
+	"proto":  ProtobufComparer,
+}
+
+func compareBytes(primary, shadow []byte) []Difference {
+	if bytes.Equal(primary, shadow) {
+		return nil
+	}
+	return []Difference{{Path: "$", Primary: renderBytes(primary), Shadow: renderBytes(shadow)}}
+}
+
+func compareJSON(primary, shadow []byte) []Difference {
+	return compareDecoded(primary, shadow, decodeJSON)
+}
+
+func compareThrift(primary, shadow []byte) []Difference {
+	return compareDecoded(primary, shadow, decodeThrift)
+}
+
+func compareProtobuf(primary, shadow []byte) []Difference {
+	return compareDecoded(primary, shadow, decodeProtobuf)
+}
+
+// compareDecoded decodes both bodies into generic values and compares them.
+// Bodies that fail to decode are compared byte for byte.
+func compareDecoded(primary, shadow []byte, decode func([]byte) (interface{}, error)) []Difference {
+	p, perr := decode(primary)
+	s, serr := decode(shadow)
+	switch {
+	case perr != nil && serr != nil:
+		return compareBytes(primary, shadow)
+	case perr != nil:
+		return []Difference{{Path: "$", Primary: fmt.Sprintf("<invalid: %v>", perr), Shadow: render(s)}}
+	case serr != nil:
+		return []Difference{{Path: "$", Primary: render(p), Shadow: fmt.Sprintf("<invalid: %v>", serr)}}
+	}
+
+	var d differ
+	d.diff("$", p, s)
+	return d.diffs
+}
+
+// differ walks two generic values built of maps, slices and scalars,
+// collecting their differences.
+type differ struct {
+	diffs []Difference
+}
+
+func (d *differ) diff(path string, primary, shadow interface{}) {
+	switch p := primary.(type) {
+	case map[string]interface{}:
+		s, ok := shadow.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(p)+len(s))
+		for k := range p {
+			keys = append(keys, k)
+		}
+		for k := range s {
+			if _, ok := p[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			d.diffOptional(path+"."+k, p, s, k)
+		}
+		return
+
+	case []interface{}:
+		s, ok := shadow.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(p) || i < len(s); i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(s):
+				d.add(elemPath, render(p[i]), _absent)
+			case i >= len(p):
+				d.add(elemPath, _absent, render(s[i]))
+			default:
+				d.diff(elemPath, p[i], s[i])
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(primary, shadow) {
+		d.add(path, render(primary), render(shadow))
+	}
+}
+
+func (d *differ) diffOptional(path string, primary, shadow map[string]interface{}, key string) {
+	p, pok := primary[key]
+	s, sok := shadow[key]
+	switch {
+	case !sok:
+		d.add(path, render(p), _absent)
+	case !pok:
+		d.add(path, _absent, render(s))
+	default:
+		d.diff(path, p, s)
+	}
+}
+
+func (d *differ) add(path, primary, shadow string) {
+	d.diffs = append(d.diffs, Difference{Path: path, Primary: primary, Shadow: shadow})
+}
+
+// render formats a generic value for a difference.
+func render(v interface{}) string {
+	if b, ok := v.([]byte); ok {
+		return renderBytes(b)
+	}
+	if s, err := json.Marshal(v); err == nil {
+		return string(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func renderBytes(b []byte) string {
+	if len(b) > _maxRenderedBytes {
+		return fmt.Sprintf("%q... (%d bytes)", b[:_maxRenderedBytes], len(b))
+	}
+	return fmt.Sprintf("%q", b)
+}
+
+func decodeJSON(body []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return normalizeJSON(v), nil
+}
+
+// normalizeJSON converts numbers into int64 or float64 values so that
+// numbers that are formatted differently compare equal.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+				return int64(f)
+			}
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+	}
+	return v
+}
+
+func decodeThrift(body []byte) (interface{}, error) {
+	// Strict envelopes start with a version number with the high bit set,
+	// which is never the case for the type of a struct's first field.
+	if len(body) > 0 && body[0]&0x80 != 0 {
+		e, err := protocol.Binary.DecodeEnveloped(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		return thriftValue(e.Value)
+	}
+
+	v, err := protocol.Binary.Decode(bytes.NewReader(body), wire.TStruct)
+	if err != nil {
+		return nil, err
+	}
+	return thriftValue(v)
+}
+
+// thriftValue converts a Thrift value into a generic value. Structs become
+// maps keyed by field ID, sets are sorted and maps are keyed by their
+// rendered keys.
+func thriftValue(v wire.Value) (interface{}, error) {
+	switch v.Type() {
+	case wire.TBool:
+		return v.GetBool(), nil
+	case wire.TI8:
+		return int64(v.GetI8()), nil
+	case wire.TI16:
+		return int64(v.GetI16()), nil
+	case wire.TI32:
+		return int64(v.GetI32()), nil
+	case wire.TI64:
+		return v.GetI64(), nil
+	case wire.TDouble:
+		return v.GetDouble(), nil
+	case wire.TBinary:
+		return opaque(v.GetBinary()), nil
+	case wire.TStruct:
+		fields := make(map[string]interface{}, len(v.GetStruct().Fields))
+		for _, f := range v.GetStruct().Fields {
+			fv, err := thriftValue(f.Value)
+			if err != nil {
+				return nil, err
+			}
+			fields[strconv.Itoa(int(f.ID))] = fv
+		}
+		return fields, nil
+	case wire.TList, wire.TSet:
+		l := v.GetList()
+		if v.Type() == wire.TSet {
+			l = v.GetSet()
+		}
+		items := make([]interface{}, 0, l.Size())
+		err := l.ForEach(func(e wire.Value) error {
+			ev, err := thriftValue(e)
+			items = append(items, ev)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if v.Type() == wire.TSet {
+			sort.Slice(items, func(i, j int) bool { return render(items[i]) < render(items[j]) })
+		}
+		return items, nil
+	case wire.TMap:
+		m := v.GetMap()
+		items := make(map[string]interface{}, m.Size())
+		err := m.ForEach(func(item wire.MapItem) error {
+			k, err := thriftValue(item.Key)
+			if err != nil {
+				return err
+			}
+			ev, err := thriftValue(item.Value)
+			if err != nil {
+				return err
+			}
+			if s, ok := k.(string); ok {
+				items[s] = ev
+			} else {
+				items[render(k)] = ev
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown Thrift type %v", v.Type())
+	}
+}
+
+func decodeProtobuf(body []byte) (interface{}, error) {
+	m, err := protobufMessage(body)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// protobufMessage converts a Protobuf message into a map keyed by field
+// number. Fields that occur more than once become slices.
+//
+// Without a schema, length-delimited fields are ambiguous: they are treated
+// as strings if they hold printable text, as messages if they parse as
+// such, and as bytes otherwise.
+func protobufMessage(b []byte) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var v interface{}
+		switch typ {
+		case protowire.VarintType:
+			var x uint64
+			x, n = protowire.ConsumeVarint(b)
+			v = x
+		case protowire.Fixed32Type:
+			var x uint32
+			x, n = protowire.ConsumeFixed32(b)
+			v = x
+		case protowire.Fixed64Type:
+			var x uint64
+			x, n = protowire.ConsumeFixed64(b)
+			v = x
+		case protowire.BytesType:
+			var x []byte
+			x, n = protowire.ConsumeBytes(b)
+			v = protobufBytes(x)
+		case protowire.StartGroupType:
+			var x []byte
+			x, n = protowire.ConsumeGroup(num, b)
+			if n >= 0 {
+				group, err := protobufMessage(x)
+				if err != nil {
+					return nil, err
+				}
+				v = group
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		key := strconv.Itoa(int(num))
+		switch prev := fields[key].(type) {
+		case nil:
+			fields[key] = v
+		case repeated:
+			fields[key] = append(prev, v)
+		default:
+			fields[key] = repeated{prev, v}
+		}
+	}
+
+	// Repeated fields are plain slices to the differ.
+	for k, v := range fields {
+		if r, ok := v.(repeated); ok {
+			fields[k] = []interface{}(r)
+		}
+	}
+	return fields, nil
+}
+
+// repeated holds the values of a field that occurs more than once while a
+// message is parsed.
+type repeated []interface{}
+
+func protobufBytes(b []byte) interface{} {
+	if isPrintable(b) {
+		return string(b)
+	}
+	if m, err := protobufMessage(b); err == nil {
+		return m
+	}
+	return b
+}
+
+// opaque returns binary data as a string if it is printable text.
+func opaque(b []byte) interface{} {
+	if isPrintable(b) {
+		return string(b)
+	}
+	return b
+}
+
+func isPrintable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
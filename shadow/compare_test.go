@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shadow
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestCompareBytes(t *testing.T) {
+	assert.Empty(t, BytesComparer.Compare([]byte("hello"), []byte("hello")))
+	assert.Equal(t, []Difference{
+		{Path: "$", Primary: `"hello"`, Shadow: `"world"`},
+	}, BytesComparer.Compare([]byte("hello"), []byte("world")))
+
+	long := bytes.Repeat([]byte("a"), 100)
+	assert.Equal(t, []Difference{
+		{Path: "$", Primary: `"` + string(long[:64]) + `"... (100 bytes)`, Shadow: `""`},
+	}, BytesComparer.Compare(long, nil))
+}
+
+func TestCompareJSON(t *testing.T) {
+	tests := []struct {
+		desc    string
+		primary string
+		shadow  string
+		want    []Difference
+	}{
+		{
+			desc:    "equal, with different key order and number formatting",
+			primary: `{"a": 1, "b": [1.5, "x"]}`,
+			shadow:  `{"b":[1.50,"x"],"a":1.0}`,
+		},
+		{
+			desc:    "changed, added and removed values",
+			primary: `{"a": 1, "b": {"c": true}, "d": [1, 2]}`,
+			shadow:  `{"a": 2, "b": {}, "d": [1, 2, 3], "e": null}`,
+			want: []Difference{
+				{Path: "$.a", Primary: "1", Shadow: "2"},
+				{Path: "$.b.c", Primary: "true", Shadow: _absent},
+				{Path: "$.d[2]", Primary: _absent, Shadow: "3"},
+				{Path: "$.e", Primary: _absent, Shadow: "null"},
+			},
+		},
+		{
+			desc:    "changed type",
+			primary: `{"a": [1]}`,
+			shadow:  `{"a": {"0": 1}}`,
+			want:    []Difference{{Path: "$.a", Primary: "[1]", Shadow: `{"0":1}`}},
+		},
+		{
+			desc:    "invalid shadow",
+			primary: `{}`,
+			shadow:  `{`,
+			want:    []Difference{{Path: "$", Primary: "{}", Shadow: "<invalid: unexpected EOF>"}},
+		},
+		{
+			desc:    "both invalid",
+			primary: `{`,
+			shadow:  `[`,
+			want:    []Difference{{Path: "$", Primary: `"{"`, Shadow: `"["`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, JSONComparer.Compare([]byte(tt.primary), []byte(tt.shadow)))
+		})
+	}
+}
+
+func encodeThrift(t *testing.T, fields ...wire.Field) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, protocol.Binary.Encode(wire.NewValueStruct(wire.Struct{Fields: fields}), &buf))
+	return buf.Bytes()
+}
+
+func TestCompareThrift(t *testing.T) {
+	set := func(values ...wire.Value) wire.Value {
+		return wire.NewValueSet(wire.ValueListFromSlice(wire.TI32, values))
+	}
+	strMap := func(items ...wire.MapItem) wire.Value {
+		return wire.NewValueMap(wire.MapItemListFromSlice(wire.TBinary, wire.TI64, items))
+	}
+
+	primary := encodeThrift(t,
+		wire.Field{ID: 1, Value: wire.NewValueString("hello")},
+		wire.Field{ID: 2, Value: set(wire.NewValueI32(1), wire.NewValueI32(2))},
+		wire.Field{ID: 3, Value: strMap(
+			wire.MapItem{Key: wire.NewValueString("a"), Value: wire.NewValueI64(1)},
+			wire.MapItem{Key: wire.NewValueString("b"), Value: wire.NewValueI64(2)},
+		)},
+	)
+	reordered := encodeThrift(t,
+		wire.Field{ID: 3, Value: strMap(
+			wire.MapItem{Key: wire.NewValueString("b"), Value: wire.NewValueI64(2)},
+			wire.MapItem{Key: wire.NewValueString("a"), Value: wire.NewValueI64(1)},
+		)},
+		wire.Field{ID: 2, Value: set(wire.NewValueI32(2), wire.NewValueI32(1))},
+		wire.Field{ID: 1, Value: wire.NewValueString("hello")},
+	)
+	assert.Empty(t, ThriftComparer.Compare(primary, reordered))
+
+	changed := encodeThrift(t,
+		wire.Field{ID: 1, Value: wire.NewValueString("world")},
+		wire.Field{ID: 3, Value: strMap(
+			wire.MapItem{Key: wire.NewValueString("a"), Value: wire.NewValueI64(3)},
+		)},
+		wire.Field{ID: 4, Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 1, Value: wire.NewValueBool(true)},
+		}})},
+	)
+	assert.Equal(t, []Difference{
+		{Path: "$.1", Primary: `"hello"`, Shadow: `"world"`},
+		{Path: "$.2", Primary: "[1,2]", Shadow: _absent},
+		{Path: "$.3.a", Primary: "1", Shadow: "3"},
+		{Path: "$.3.b", Primary: "2", Shadow: _absent},
+		{Path: "$.4", Primary: _absent, Shadow: `{"1":true}`},
+	}, ThriftComparer.Compare(primary, changed))
+
+	t.Run("enveloped", func(t *testing.T) {
+		envelope := func(v wire.Value) []byte {
+			var buf bytes.Buffer
+			require.NoError(t, protocol.Binary.EncodeEnveloped(wire.Envelope{
+				Name:  "getValue",
+				Type:  wire.Reply,
+				Value: v,
+			}, &buf))
+			return buf.Bytes()
+		}
+		result := func(v string) wire.Value {
+			return wire.NewValueStruct(wire.Struct{Fields: []wire.Field{{ID: 0, Value: wire.NewValueString(v)}}})
+		}
+		assert.Equal(t, []Difference{
+			{Path: "$.0", Primary: `"a"`, Shadow: `"b"`},
+		}, ThriftComparer.Compare(envelope(result("a")), envelope(result("b"))))
+	})
+}
+
+func TestCompareProtobuf(t *testing.T) {
+	nested := func(n uint64) []byte {
+		var b []byte
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		return protowire.AppendVarint(b, n)
+	}
+	message := func(name string, ids []uint64, child []byte) []byte {
+		var b []byte
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, name)
+		for _, id := range ids {
+			b = protowire.AppendTag(b, 2, protowire.VarintType)
+			b = protowire.AppendVarint(b, id)
+		}
+		if child != nil {
+			b = protowire.AppendTag(b, 3, protowire.BytesType)
+			b = protowire.AppendBytes(b, child)
+		}
+		return b
+	}
+
+	assert.Empty(t, ProtobufComparer.Compare(
+		message("hello", []uint64{1, 2}, nested(7)),
+		message("hello", []uint64{1, 2}, nested(7)),
+	))
+
+	// Fields with different numbers may be marshaled in any order.
+	var reordered []byte
+	reordered = protowire.AppendTag(reordered, 3, protowire.BytesType)
+	reordered = protowire.AppendBytes(reordered, nested(7))
+	reordered = append(reordered, message("hello", []uint64{1, 2}, nil)...)
+	assert.Empty(t, ProtobufComparer.Compare(message("hello", []uint64{1, 2}, nested(7)), reordered))
+
+	assert.Equal(t, []Difference{
+		{Path: "$.1", Primary: `"hello"`, Shadow: `"world"`},
+		{Path: "$.2[1]", Primary: "2", Shadow: "3"},
+		{Path: "$.2[2]", Primary: _absent, Shadow: "4"},
+		{Path: "$.3.1", Primary: "7", Shadow: "8"},
+	}, ProtobufComparer.Compare(
+		message("hello", []uint64{1, 2}, nested(7)),
+		message("world", []uint64{1, 3, 4}, nested(8)),
+	))
+
+	assert.Equal(t, []Difference{
+		{Path: "$", Primary: "{}", Shadow: "<invalid: unexpected EOF>"},
+	}, ProtobufComparer.Compare(nil, []byte{0xff}))
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package shadow provides outbound middleware that mirrors a sample of unary
+// requests to a second outbound, so that a new version of a service can be
+// exercised with production traffic before it serves any of it.
+//
+//	mw, err := shadow.New(shadow.Params{
+//		Outbound:   canaryOutbound,
+//		Percentage: 10,
+//		Meter:      meter,
+//	})
+//	out := middleware.ApplyUnaryOutbound(primaryOutbound, mw)
+//
+// Shadow requests are sent in the background and their responses are
+// discarded, so callers only ever see the response of the primary outbound.
+// Once both have completed, the middleware compares the latency and the
+// outcome of the two requests and reports them as metrics:
+//
+//	shadow_requests          by procedure, primary_code and shadow_code
+//	shadow_divergences       by procedure, when the outcomes differ
+//	shadow_latency_ms        by procedure and source (primary or shadow)
+//	shadow_latency_delta_ms  by procedure and direction (slower or faster)
+//	shadow_skipped           by reason, for sampled requests not mirrored
+//
+// Outcomes are reported as "ok", as the name of a yarpcerrors code, or as
+// "application-error" if an application error carries no code of its own.
+//
+// # Comparing responses
+//
+// With Compare set, the middleware also compares the responses to sampled
+// requests, to validate a rewrite of a service against the original. If the
+// outcomes match, the response bodies are decoded according to the encoding
+// of the request and compared value by value, so that JSON objects whose keys
+// are ordered differently, or Thrift sets and maps whose items are, compare
+// equal. Responses whose outcomes or bodies differ are logged with the paths
+// of their differences, counted by the shadow_response_diffs metric, and
+// passed to OnDiff:
+//
+//	mw, err := shadow.New(shadow.Params{
+//		Outbound:   rewriteOutbound,
+//		Percentage: 1,
+//		Compare:    true,
+//		OnDiff: func(d shadow.Diff) {
+//			for _, diff := range d.Differences {
+//				fmt.Println(d.Procedure, diff.Path, diff.Primary, diff.Shadow)
+//			}
+//		},
+//	})
+//
+// JSON, Thrift and Protobuf bodies are compared by default, and other
+// encodings byte for byte. Use Comparers to compare other encodings, or to
+// replace the schemaless Protobuf comparison with one that knows the
+// response types.
+//
+// The shadow outbound is not started or stopped by the middleware; it must
+// be running for as long as the middleware is in use.
+package shadow
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shadow
+
+import (
+	"time"
+
+	"go.uber.org/net/metrics"
+	"go.uber.org/net/metrics/bucket"
+	"go.uber.org/zap"
+)
+
+const (
+	_procedure   = "procedure"
+	_primaryCode = "primary_code"
+	_shadowCode  = "shadow_code"
+	_source      = "source"
+	_direction   = "direction"
+	_reason      = "reason"
+
+	_reasonMaxInFlight = "max_in_flight"
+	_reasonBody        = "body"
+)
+
+var _bucketsMs = bucket.NewRPCLatency()
+
+type shadowMetrics struct {
+	logger *zap.Logger
+
+	requests      *metrics.CounterVector
+	divergences   *metrics.CounterVector
+	latencies     *metrics.HistogramVector
+	latencyDeltas *metrics.HistogramVector
+	skipped       *metrics.CounterVector
+	diffs         *metrics.CounterVector
+}
+
+func newShadowMetrics(meter *metrics.Scope, logger *zap.Logger) *shadowMetrics {
+	m := &shadowMetrics{logger: logger}
+	var err error
+
+	m.requests, err = meter.CounterVector(metrics.Spec{
+		Name:    "shadow_requests",
+		Help:    "Number of requests mirrored to the shadow outbound.",
+		VarTags: []string{_procedure, _primaryCode, _shadowCode},
+	})
+	if err != nil {
+		logger.Error("Failed to create shadow requests counter.", zap.Error(err))
+	}
+	m.divergences, err = meter.CounterVector(metrics.Spec{
+		Name:    "shadow_divergences",
+		Help:    "Number of mirrored requests whose shadow outcome differed from the primary outcome.",
+		VarTags: []string{_procedure},
+	})
+	if err != nil {
+		logger.Error("Failed to create shadow divergences counter.", zap.Error(err))
+	}
+	m.latencies, err = meter.HistogramVector(metrics.HistogramSpec{
+		Spec: metrics.Spec{
+			Name:    "shadow_latency_ms",
+			Help:    "Latency distribution of mirrored requests and of their primary requests.",
+			VarTags: []string{_procedure, _source},
+		},
+		Unit:    time.Millisecond,
+		Buckets: _bucketsMs,
+	})
+	if err != nil {
+		logger.Error("Failed to create shadow latency histogram.", zap.Error(err))
+	}
+	m.latencyDeltas, err = meter.HistogramVector(metrics.HistogramSpec{
+		Spec: metrics.Spec{
+			Name:    "shadow_latency_delta_ms",
+			Help:    "Distribution of how much slower or faster shadow requests were than their primary requests.",
+			VarTags: []string{_procedure, _direction},
+		},
+		Unit:    time.Millisecond,
+		Buckets: _bucketsMs,
+	})
+	if err != nil {
+		logger.Error("Failed to create shadow latency delta histogram.", zap.Error(err))
+	}
+	m.skipped, err = meter.CounterVector(metrics.Spec{
+		Name:    "shadow_skipped",
+		Help:    "Number of sampled requests that were not mirrored.",
+		VarTags: []string{_reason},
+	})
+	if err != nil {
+		logger.Error("Failed to create shadow skipped counter.", zap.Error(err))
+	}
+	m.diffs, err = meter.CounterVector(metrics.Spec{
+		Name:    "shadow_response_diffs",
+		Help:    "Number of compared requests whose shadow response differed from the primary response.",
+		VarTags: []string{_procedure},
+	})
+	if err != nil {
+		logger.Error("Failed to create shadow response diffs counter.", zap.Error(err))
+	}
+	return m
+}
+
+// record reports how a shadow request compared to its primary request.
+func (m *shadowMetrics) record(procedure string, primary, shadow result) {
+	if c, err := m.requests.Get(_procedure, procedure, _primaryCode, primary.code, _shadowCode, shadow.code); err == nil {
+		c.Inc()
+	}
+	if primary.code != shadow.code {
+		if c, err := m.divergences.Get(_procedure, procedure); err == nil {
+			c.Inc()
+		}
+	}
+
+	if h, err := m.latencies.Get(_procedure, procedure, _source, "primary"); err == nil {
+		h.Observe(primary.latency)
+	}
+	if h, err := m.latencies.Get(_procedure, procedure, _source, "shadow"); err == nil {
+		h.Observe(shadow.latency)
+	}
+
+	direction, delta := "slower", shadow.latency-primary.latency
+	if delta < 0 {
+		direction, delta = "faster", -delta
+	}
+	if h, err := m.latencyDeltas.Get(_procedure, procedure, _direction, direction); err == nil {
+		h.Observe(delta)
+	}
+}
+
+func (m *shadowMetrics) skip(reason string) {
+	if c, err := m.skipped.Get(_reason, reason); err == nil {
+		c.Inc()
+	}
+}
+
+func (m *shadowMetrics) diff(procedure string) {
+	if c, err := m.diffs.Get(_procedure, procedure); err == nil {
+		c.Inc()
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shadow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/zap"
+)
+
+const (
+	_defaultTimeout        = time.Second
+	_defaultMaxInFlight    = 100
+	_defaultMaxDifferences = 10
+)
+
+var _ middleware.UnaryOutbound = (*Middleware)(nil)
+
+// Params defines the parameters for creating the Middleware.
+type Params struct {
+	// Outbound receives copies of the sampled requests.
+	Outbound transport.UnaryOutbound
+
+	// Service, if set, replaces the service name of the copies.
+	Service string
+
+	// Percentage of unary requests that are mirrored, greater than 0 and at
+	// most 100.
+	Percentage float64
+
+	// Timeout bounds shadow requests for primary requests without a
+	// deadline. Shadow requests otherwise share the deadline of their
+	// primary request, but are not cancelled when it completes. Defaults to
+	// one second.
+	Timeout time.Duration
+
+	// MaxInFlight limits the number of shadow requests in flight at once.
+	// Sampled requests are not mirrored while this many are outstanding, so
+	// a slow shadow cannot pile up work. Defaults to 100.
+	MaxInFlight int
+
+	// Compare enables comparing the responses to the primary and shadow
+	// requests. The body of a sampled primary response is then read into
+	// memory before it is returned, and differences are logged, counted and
+	// passed to OnDiff.
+	Compare bool
+
+	// Comparers compare response bodies by encoding, replacing or adding to
+	// the defaults for JSON, Thrift and Protobuf. Bodies of other encodings
+	// are compared with BytesComparer.
+	Comparers map[transport.Encoding]Comparer
+
+	// OnDiff, if set, is called with every diff found when comparing
+	// responses.
+	OnDiff func(Diff)
+
+	// MaxDifferences limits the number of differences reported per diff.
+	// Defaults to 10.
+	MaxDifferences int
+
+	// Meter is used to report the comparison between primary and shadow
+	// requests. Metrics are not reported if this is nil.
+	Meter  *metrics.Scope
+	Logger *zap.Logger
+}
+
+// Middleware is a unary outbound middleware that mirrors a sample of
+// requests to a shadow outbound.
+type Middleware struct {
+	outbound    transport.UnaryOutbound
+	service     string
+	percentage  float64
+	timeout     time.Duration
+	maxInFlight int64
+
+	compare        bool
+	comparers      map[transport.Encoding]Comparer
+	onDiff         func(Diff)
+	maxDifferences int
+
+	logger  *zap.Logger
+	metrics *shadowMetrics
+
+	inFlight atomic.Int64
+	wg       sync.WaitGroup // tracks shadow requests, for tests
+
+	// random returns a number in [0, 100).
+	random func() float64
+}
+
+// New constructs a shadowing middleware.
+func New(p Params) (*Middleware, error) {
+	if p.Outbound == nil {
+		return nil, errors.New("a shadow outbound is required")
+	}
+	if p.Percentage <= 0 || p.Percentage > 100 {
+		return nil, fmt.Errorf("percentage must be greater than 0 and at most 100, got %v", p.Percentage)
+	}
+	if p.Timeout < 0 {
+		return nil, fmt.Errorf("timeout must not be negative, got %v", p.Timeout)
+	}
+	if p.MaxInFlight < 0 {
+		return nil, fmt.Errorf("maxInFlight must not be negative, got %v", p.MaxInFlight)
+	}
+	if p.MaxDifferences < 0 {
+		return nil, fmt.Errorf("maxDifferences must not be negative, got %v", p.MaxDifferences)
+	}
+
+	logger := p.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	m := &Middleware{
+		outbound:    p.Outbound,
+		service:     p.Service,
+		percentage:  p.Percentage,
+		timeout:     p.Timeout,
+		maxInFlight: int64(p.MaxInFlight),
+
+		compare:        p.Compare,
+		comparers:      make(map[transport.Encoding]Comparer, len(_defaultComparers)+len(p.Comparers)),
+		onDiff:         p.OnDiff,
+		maxDifferences: p.MaxDifferences,
+
+		logger:  logger,
+		metrics: newShadowMetrics(p.Meter, logger),
+		random:  func() float64 { return rand.Float64() * 100 },
+	}
+	for encoding, c := range _defaultComparers {
+		m.comparers[encoding] = c
+	}
+	for encoding, c := range p.Comparers {
+		m.comparers[encoding] = c
+	}
+	if m.timeout == 0 {
+		m.timeout = _defaultTimeout
+	}
+	if m.maxInFlight == 0 {
+		m.maxInFlight = _defaultMaxInFlight
+	}
+	if m.maxDifferences == 0 {
+		m.maxDifferences = _defaultMaxDifferences
+	}
+	return m, nil
+}
+
+// Call implements middleware.UnaryOutbound.
+//
+// The body of a sampled request is read into memory so that the primary and
+// the shadow request may each read their own copy. The request passed in is
+// not modified, and the primary request never waits on the shadow.
+func (m *Middleware) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	if m.random() >= m.percentage {
+		return out.Call(ctx, req)
+	}
+	if m.inFlight.Add(1) > m.maxInFlight {
+		m.inFlight.Add(-1)
+		m.metrics.skip(_reasonMaxInFlight)
+		return out.Call(ctx, req)
+	}
+
+	primaryReq := *req
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			// Let the primary request observe the same failure.
+			m.inFlight.Add(-1)
+			m.metrics.skip(_reasonBody)
+			primaryReq.Body = io.MultiReader(bytes.NewReader(body), req.Body)
+			return out.Call(ctx, &primaryReq)
+		}
+		primaryReq.Body = bytes.NewReader(body)
+	}
+
+	primaryDone := make(chan result, 1)
+	m.wg.Add(1)
+	go m.shadow(ctx, req, m.mirror(req, body), primaryDone)
+
+	start := time.Now()
+	res, err := out.Call(ctx, &primaryReq)
+	primary := result{latency: time.Since(start), code: outcomeCode(res, err)}
+	if m.compare && res != nil && res.Body != nil {
+		primary.body, res.Body, primary.bodyErr = bufferBody(res.Body)
+	}
+	primaryDone <- primary
+	return res, err
+}
+
+// bufferBody reads a response body into memory, returning its contents and a
+// body to replace it with. If the body cannot be read, the replacement fails
+// the same way after returning what was read.
+func bufferBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, readCloser{io.MultiReader(bytes.NewReader(b), body), body}, err
+	}
+	_ = body.Close()
+	return b, io.NopCloser(bytes.NewReader(b)), nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// mirror returns a copy of the request with its own headers and body.
+func (m *Middleware) mirror(req *transport.Request, body []byte) *transport.Request {
+	shadowReq := *req
+	shadowReq.Headers = transport.NewHeadersWithCapacity(req.Headers.Len())
+	for k, v := range req.Headers.OriginalItemsAll() {
+		shadowReq.Headers = shadowReq.Headers.With(k, v)
+	}
+	if req.Body != nil {
+		shadowReq.Body = bytes.NewReader(body)
+	}
+	if m.service != "" {
+		shadowReq.Service = m.service
+	}
+	return &shadowReq
+}
+
+// shadow sends the shadow request and, once the primary request has also
+// completed, records how the two compare.
+func (m *Middleware) shadow(ctx context.Context, primaryReq, req *transport.Request, primaryDone <-chan result) {
+	defer m.wg.Done()
+	defer m.inFlight.Add(-1)
+
+	ctx, cancel := m.shadowContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	res, err := m.outbound.Call(ctx, req)
+	shadow := result{latency: time.Since(start), code: outcomeCode(res, err)}
+	if res != nil && res.Body != nil {
+		if m.compare {
+			shadow.body, shadow.bodyErr = io.ReadAll(res.Body)
+		} else {
+			_, _ = io.Copy(io.Discard, res.Body)
+		}
+		_ = res.Body.Close()
+	}
+
+	primary := <-primaryDone
+	m.metrics.record(req.Procedure, primary, shadow)
+	if m.compare {
+		m.diff(primaryReq, primary, shadow)
+	}
+}
+
+// diff compares the outcomes of the primary and shadow requests and, if they
+// match, their response bodies, reporting any differences. Bodies that could
+// not be read are not compared.
+func (m *Middleware) diff(req *transport.Request, primary, shadow result) {
+	d := Diff{
+		Service:        req.Service,
+		Procedure:      req.Procedure,
+		Encoding:       req.Encoding,
+		PrimaryOutcome: primary.code,
+		ShadowOutcome:  shadow.code,
+	}
+	if primary.code == shadow.code {
+		if primary.bodyErr != nil || shadow.bodyErr != nil {
+			return
+		}
+		comparer, ok := m.comparers[req.Encoding]
+		if !ok {
+			comparer = BytesComparer
+		}
+		d.Differences = comparer.Compare(primary.body, shadow.body)
+		if len(d.Differences) == 0 {
+			return
+		}
+		if len(d.Differences) > m.maxDifferences {
+			d.Differences = d.Differences[:m.maxDifferences]
+		}
+	}
+
+	m.metrics.diff(req.Procedure)
+	m.logger.Info("Shadow response differed from primary response.",
+		zap.String("service", d.Service),
+		zap.String("procedure", d.Procedure),
+		zap.String("encoding", string(d.Encoding)),
+		zap.String("primaryOutcome", d.PrimaryOutcome),
+		zap.String("shadowOutcome", d.ShadowOutcome),
+		zap.Array("differences", differences(d.Differences)),
+	)
+	if m.onDiff != nil {
+		m.onDiff(d)
+	}
+}
+
+// shadowContext detaches the shadow request from the cancellation of the
+// primary request, keeping its deadline and values.
+func (m *Middleware) shadowContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(m.timeout)
+	}
+	return context.WithDeadline(context.WithoutCancel(ctx), deadline)
+}
+
+// result describes how a primary or shadow request completed.
+type result struct {
+	latency time.Duration
+	code    string
+	body    []byte // only if responses are compared
+	bodyErr error
+}
+
+// outcomeCode names the outcome of a request: "ok" for success, the
+// yarpcerrors code for failures, and the application error code, if any,
+// for application errors.
+func outcomeCode(res *transport.Response, err error) string {
+	if err != nil {
+		return yarpcerrors.FromError(err).Code().String()
+	}
+	if res != nil && res.ApplicationError {
+		if meta := res.ApplicationErrorMeta; meta != nil && meta.Code != nil {
+			return meta.Code.String()
+		}
+		return "application-error"
+	}
+	return "ok"
+}
@@ -0,0 +1,556 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shadow
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// captured is a request as seen by an outbound.
+type captured struct {
+	service string
+	headers map[string]string
+	body    string
+}
+
+func capture(t *testing.T, into chan<- captured) func(context.Context, *transport.Request) {
+	return func(_ context.Context, req *transport.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		into <- captured{service: req.Service, headers: req.Headers.Items(), body: string(body)}
+	}
+}
+
+func counter(root *metrics.Root, name string, tags map[string]string) int64 {
+	var total int64
+	for _, c := range root.Snapshot().Counters {
+		if c.Name != name {
+			continue
+		}
+		matched := true
+		for k, v := range tags {
+			if c.Tags[k] != v {
+				matched = false
+			}
+		}
+		if matched {
+			total += c.Value
+		}
+	}
+	return total
+}
+
+func TestNew(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	tests := []struct {
+		desc    string
+		give    Params
+		wantErr string
+	}{
+		{
+			desc: "valid",
+			give: Params{Outbound: out, Percentage: 100},
+		},
+		{
+			desc:    "no outbound",
+			give:    Params{Percentage: 10},
+			wantErr: "a shadow outbound is required",
+		},
+		{
+			desc:    "no percentage",
+			give:    Params{Outbound: out},
+			wantErr: "percentage must be greater than 0 and at most 100, got 0",
+		},
+		{
+			desc:    "percentage too large",
+			give:    Params{Outbound: out, Percentage: 101},
+			wantErr: "percentage must be greater than 0 and at most 100, got 101",
+		},
+		{
+			desc:    "negative timeout",
+			give:    Params{Outbound: out, Percentage: 10, Timeout: -time.Second},
+			wantErr: "timeout must not be negative, got -1s",
+		},
+		{
+			desc:    "negative max in flight",
+			give:    Params{Outbound: out, Percentage: 10, MaxInFlight: -1},
+			wantErr: "maxInFlight must not be negative, got -1",
+		},
+		{
+			desc:    "negative max differences",
+			give:    Params{Outbound: out, Percentage: 10, MaxDifferences: -1},
+			wantErr: "maxDifferences must not be negative, got -1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mw, err := New(tt.give)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, _defaultTimeout, mw.timeout)
+			assert.Equal(t, int64(_defaultMaxInFlight), mw.maxInFlight)
+			assert.Equal(t, _defaultMaxDifferences, mw.maxDifferences)
+		})
+	}
+}
+
+func TestCall(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	root := metrics.New()
+	mw, err := New(Params{
+		Outbound:   shadowOut,
+		Service:    "keyvalue-canary",
+		Percentage: 100,
+		Meter:      root.Scope(),
+	})
+	require.NoError(t, err)
+
+	primaryReqs := make(chan captured, 1)
+	shadowReqs := make(chan captured, 1)
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).
+		Do(capture(t, primaryReqs)).
+		Return(&transport.Response{Body: io.NopCloser(strings.NewReader("primary"))}, nil)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).
+		Do(capture(t, shadowReqs)).
+		Return(nil, yarpcerrors.UnavailableErrorf("canary is down"))
+
+	body := strings.NewReader("hello")
+	req := &transport.Request{
+		Service:   "keyvalue",
+		Procedure: "getValue",
+		Headers:   transport.NewHeaders().With("foo", "bar"),
+		Body:      body,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+	res, err := mw.Call(ctx, req, primary)
+	require.NoError(t, err)
+	got, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", string(got), "callers must see the primary response")
+	mw.wg.Wait()
+
+	assert.Equal(t, captured{
+		service: "keyvalue",
+		headers: map[string]string{"foo": "bar"},
+		body:    "hello",
+	}, <-primaryReqs)
+	assert.Equal(t, captured{
+		service: "keyvalue-canary",
+		headers: map[string]string{"foo": "bar"},
+		body:    "hello",
+	}, <-shadowReqs)
+	assert.Equal(t, "keyvalue", req.Service, "request must not be modified")
+	assert.Same(t, body, req.Body, "request must not be modified")
+
+	assert.Equal(t, int64(1), counter(root, "shadow_requests", map[string]string{
+		_procedure:   "getValue",
+		_primaryCode: "ok",
+		_shadowCode:  "unavailable",
+	}))
+	assert.Equal(t, int64(1), counter(root, "shadow_divergences", nil))
+
+	var latencies int
+	for _, h := range root.Snapshot().Histograms {
+		if h.Name == "shadow_latency_ms" || h.Name == "shadow_latency_delta_ms" {
+			latencies++
+		}
+	}
+	assert.Equal(t, 3, latencies, "expected primary, shadow and delta latencies")
+}
+
+func TestCallDoesNotWaitForShadow(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	mw, err := New(Params{Outbound: shadowOut, Percentage: 100})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	shadowErr := make(chan error, 1)
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *transport.Request) (*transport.Response, error) {
+			<-release
+			shadowErr <- ctx.Err()
+			return &transport.Response{}, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	_, err = mw.Call(ctx, &transport.Request{Procedure: "hello"}, primary)
+	require.NoError(t, err)
+	cancel()
+
+	close(release)
+	mw.wg.Wait()
+	assert.NoError(t, <-shadowErr, "shadow requests must outlive their primary request")
+}
+
+func TestCallShadowDeadline(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	mw, err := New(Params{Outbound: shadowOut, Percentage: 100, Timeout: time.Minute})
+	require.NoError(t, err)
+
+	deadlines := make(chan time.Time, 2)
+	recordDeadline := func(ctx context.Context, _ *transport.Request) {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok, "shadow requests must have a deadline")
+		deadlines <- deadline
+	}
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil).Times(2)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).Do(recordDeadline).Return(&transport.Response{}, nil).Times(2)
+
+	deadline := time.Now().Add(testtime.Second)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	_, err = mw.Call(ctx, &transport.Request{}, primary)
+	require.NoError(t, err)
+	mw.wg.Wait()
+	assert.Equal(t, deadline, <-deadlines)
+
+	_, err = mw.Call(context.Background(), &transport.Request{}, primary)
+	require.NoError(t, err)
+	mw.wg.Wait()
+	assert.WithinDuration(t, time.Now().Add(time.Minute), <-deadlines, testtime.Second)
+}
+
+func TestCallSampling(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	mw, err := New(Params{Outbound: shadowOut, Percentage: 25})
+	require.NoError(t, err)
+
+	for _, roll := range []float64{25, 99.9} {
+		mw.random = func() float64 { return roll }
+		req := &transport.Request{Body: strings.NewReader("hello")}
+		primary.EXPECT().Call(gomock.Any(), req).Return(&transport.Response{}, nil)
+		_, err := mw.Call(context.Background(), req, primary)
+		require.NoError(t, err)
+	}
+
+	mw.random = func() float64 { return 24.9 }
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+	_, err = mw.Call(context.Background(), &transport.Request{}, primary)
+	require.NoError(t, err)
+	mw.wg.Wait()
+}
+
+func TestCallMaxInFlight(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	root := metrics.New()
+	mw, err := New(Params{Outbound: shadowOut, Percentage: 100, MaxInFlight: 1, Meter: root.Scope()})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil).Times(3)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *transport.Request) (*transport.Response, error) {
+			<-release
+			return &transport.Response{}, nil
+		}).Times(2)
+
+	_, err = mw.Call(context.Background(), &transport.Request{}, primary)
+	require.NoError(t, err)
+	_, err = mw.Call(context.Background(), &transport.Request{}, primary)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counter(root, "shadow_skipped", map[string]string{_reason: _reasonMaxInFlight}))
+
+	close(release)
+	mw.wg.Wait()
+
+	_, err = mw.Call(context.Background(), &transport.Request{}, primary)
+	require.NoError(t, err)
+	mw.wg.Wait()
+}
+
+func TestCallBodyReadError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	root := metrics.New()
+	mw, err := New(Params{Outbound: shadowOut, Percentage: 100, Meter: root.Scope()})
+	require.NoError(t, err)
+
+	readErr := errors.New("great sadness")
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *transport.Request) (*transport.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			assert.Equal(t, "hello", string(body))
+			return nil, err
+		})
+
+	req := &transport.Request{Body: io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(readErr))}
+	_, err = mw.Call(context.Background(), req, primary)
+	assert.Equal(t, readErr, err, "primary requests must observe body read failures")
+	assert.Equal(t, int64(1), counter(root, "shadow_skipped", map[string]string{_reason: _reasonBody}))
+}
+
+func TestCallCompare(t *testing.T) {
+	body := func(s string) io.ReadCloser { return io.NopCloser(strings.NewReader(s)) }
+
+	tests := []struct {
+		desc           string
+		encoding       transport.Encoding
+		primaryBody    string
+		shadowRes      *transport.Response
+		shadowErr      error
+		maxDifferences int
+		want           *Diff
+	}{
+		{
+			desc:        "equal",
+			encoding:    "json",
+			primaryBody: `{"a": 1, "b": 2}`,
+			shadowRes:   &transport.Response{Body: body(`{"b": 2, "a": 1}`)},
+		},
+		{
+			desc:        "different bodies",
+			encoding:    "json",
+			primaryBody: `{"a": 1, "b": 2}`,
+			shadowRes:   &transport.Response{Body: body(`{"a": 1, "b": 3}`)},
+			want: &Diff{
+				Encoding:       "json",
+				PrimaryOutcome: "ok",
+				ShadowOutcome:  "ok",
+				Differences:    []Difference{{Path: "$.b", Primary: "2", Shadow: "3"}},
+			},
+		},
+		{
+			desc:           "different bodies, truncated",
+			encoding:       "json",
+			primaryBody:    `[1, 2, 3]`,
+			shadowRes:      &transport.Response{Body: body(`[4, 5, 6]`)},
+			maxDifferences: 2,
+			want: &Diff{
+				Encoding:       "json",
+				PrimaryOutcome: "ok",
+				ShadowOutcome:  "ok",
+				Differences: []Difference{
+					{Path: "$[0]", Primary: "1", Shadow: "4"},
+					{Path: "$[1]", Primary: "2", Shadow: "5"},
+				},
+			},
+		},
+		{
+			desc:        "unknown encoding",
+			encoding:    "raw",
+			primaryBody: "hello",
+			shadowRes:   &transport.Response{Body: body("world")},
+			want: &Diff{
+				Encoding:       "raw",
+				PrimaryOutcome: "ok",
+				ShadowOutcome:  "ok",
+				Differences:    []Difference{{Path: "$", Primary: `"hello"`, Shadow: `"world"`}},
+			},
+		},
+		{
+			desc:        "application error",
+			encoding:    "json",
+			primaryBody: `{}`,
+			shadowRes:   &transport.Response{Body: body(`{}`), ApplicationError: true},
+			want: &Diff{
+				Encoding:       "json",
+				PrimaryOutcome: "ok",
+				ShadowOutcome:  "application-error",
+			},
+		},
+		{
+			desc:        "error",
+			encoding:    "json",
+			primaryBody: `{}`,
+			shadowErr:   yarpcerrors.InternalErrorf("great sadness"),
+			want: &Diff{
+				Encoding:       "json",
+				PrimaryOutcome: "ok",
+				ShadowOutcome:  "internal",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+			shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+			root := metrics.New()
+			var diffs []Diff
+			mw, err := New(Params{
+				Outbound:       shadowOut,
+				Percentage:     100,
+				Compare:        true,
+				OnDiff:         func(d Diff) { diffs = append(diffs, d) },
+				MaxDifferences: tt.maxDifferences,
+				Meter:          root.Scope(),
+			})
+			require.NoError(t, err)
+
+			primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{Body: body(tt.primaryBody)}, nil)
+			shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).Return(tt.shadowRes, tt.shadowErr)
+
+			req := &transport.Request{Service: "keyvalue", Procedure: "getValue", Encoding: tt.encoding}
+			res, err := mw.Call(context.Background(), req, primary)
+			require.NoError(t, err)
+			got, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.primaryBody, string(got), "callers must see the primary response body")
+			require.NoError(t, res.Body.Close())
+			mw.wg.Wait()
+
+			if tt.want == nil {
+				assert.Empty(t, diffs)
+				assert.Zero(t, counter(root, "shadow_response_diffs", nil))
+				return
+			}
+			tt.want.Service = "keyvalue"
+			tt.want.Procedure = "getValue"
+			assert.Equal(t, []Diff{*tt.want}, diffs)
+			assert.Equal(t, int64(1), counter(root, "shadow_response_diffs", map[string]string{_procedure: "getValue"}))
+		})
+	}
+}
+
+func TestCallCompareComparers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	var compared []string
+	diffs := make(chan Diff, 1)
+	mw, err := New(Params{
+		Outbound:   shadowOut,
+		Percentage: 100,
+		Compare:    true,
+		Comparers: map[transport.Encoding]Comparer{
+			"json": ComparerFunc(func(p, s []byte) []Difference {
+				compared = append(compared, string(p), string(s))
+				return []Difference{{Path: "$", Primary: "p", Shadow: "s"}}
+			}),
+		},
+		OnDiff: func(d Diff) { diffs <- d },
+	})
+	require.NoError(t, err)
+
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{Body: io.NopCloser(strings.NewReader("1"))}, nil)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{Body: io.NopCloser(strings.NewReader("2"))}, nil)
+
+	_, err = mw.Call(context.Background(), &transport.Request{Encoding: "json"}, primary)
+	require.NoError(t, err)
+	mw.wg.Wait()
+
+	assert.Equal(t, []string{"1", "2"}, compared)
+	assert.Equal(t, []Difference{{Path: "$", Primary: "p", Shadow: "s"}}, (<-diffs).Differences)
+	assert.Contains(t, mw.comparers, transport.Encoding("thrift"), "defaults must be kept")
+}
+
+func TestCallComparePrimaryBodyReadError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	primary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	shadowOut := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	mw, err := New(Params{
+		Outbound:   shadowOut,
+		Percentage: 100,
+		Compare:    true,
+		OnDiff:     func(d Diff) { t.Errorf("unexpected diff: %+v", d) },
+	})
+	require.NoError(t, err)
+
+	readErr := errors.New("great sadness")
+	primaryBody := io.NopCloser(io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(readErr)))
+	primary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{Body: primaryBody}, nil)
+	shadowOut.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{Body: io.NopCloser(strings.NewReader("world"))}, nil)
+
+	res, err := mw.Call(context.Background(), &transport.Request{Encoding: "raw"}, primary)
+	require.NoError(t, err)
+	got, err := io.ReadAll(res.Body)
+	assert.Equal(t, readErr, err, "callers must observe body read failures")
+	assert.Equal(t, "hello", string(got))
+	mw.wg.Wait()
+}
+
+func TestOutcomeCode(t *testing.T) {
+	code := yarpcerrors.CodeNotFound
+	tests := []struct {
+		desc string
+		res  *transport.Response
+		err  error
+		want string
+	}{
+		{desc: "success", res: &transport.Response{}, want: "ok"},
+		{desc: "yarpc error", err: yarpcerrors.InternalErrorf("oops"), want: "internal"},
+		{desc: "other error", err: errors.New("oops"), want: "unknown"},
+		{
+			desc: "application error",
+			res:  &transport.Response{ApplicationError: true},
+			want: "application-error",
+		},
+		{
+			desc: "application error with code",
+			res: &transport.Response{
+				ApplicationError:     true,
+				ApplicationErrorMeta: &transport.ApplicationErrorMeta{Code: &code},
+			},
+			want: "not-found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, outcomeCode(tt.res, tt.err))
+		})
+	}
+}
@@ -24,6 +24,9 @@ import (
 	"runtime"
 
 	opentracing "github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/yarpc/internal/oteltracing"
 )
 
 // OpentracingTags are tags with YARPC metadata.
@@ -32,3 +35,38 @@ var OpentracingTags = opentracing.Tags{
 	"go.version":    runtime.Version(),
 	"component":     "yarpc-go",
 }
+
+// TracingConfig describes how OpenTelemetry tracing should be configured.
+//
+// When a TracerProvider is present, the dispatcher records a server span for
+// every inbound request and a client span for every outbound request, for
+// unary, oneway and streaming RPCs alike. Span contexts are propagated in
+// request headers, which every transport carries to the remote peer.
+//
+// OpenTelemetry tracing is independent of the OpenTracing tracers configured
+// on individual transports. Services migrating from OpenTracing should stop
+// configuring transport tracers once all callers and callees propagate
+// OpenTelemetry span contexts.
+type TracingConfig struct {
+	// TracerProvider is used to create spans. If nil, the dispatcher does
+	// not record OpenTelemetry spans.
+	TracerProvider trace.TracerProvider
+
+	// Propagator injects span contexts into outbound request headers and
+	// extracts them from inbound request headers.
+	//
+	// Defaults to the W3C Trace Context and Baggage propagators, which use
+	// the traceparent, tracestate and baggage headers.
+	Propagator propagation.TextMapPropagator
+}
+
+func (c TracingConfig) middleware() *oteltracing.Middleware {
+	if c.TracerProvider == nil {
+		return nil
+	}
+	return oteltracing.New(oteltracing.Params{
+		TracerProvider: c.TracerProvider,
+		Propagator:     c.Propagator,
+		Version:        Version,
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	. "go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/encoding/raw"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/transport/grpc"
+	"go.uber.org/yarpc/transport/http"
+	"go.uber.org/yarpc/transport/tchannel"
+)
+
+func TestOpenTelemetryTracing(t *testing.T) {
+	tests := []struct {
+		desc string
+		// newInbound returns an inbound and a function that, once the inbound
+		// has started, builds an outbound that reaches it.
+		newInbound func(t *testing.T) (transport.Inbound, func() transport.UnaryOutbound)
+	}{
+		{
+			desc: "http",
+			newInbound: func(t *testing.T) (transport.Inbound, func() transport.UnaryOutbound) {
+				inbound := http.NewTransport().NewInbound("127.0.0.1:0")
+				return inbound, func() transport.UnaryOutbound {
+					return http.NewTransport().NewSingleOutbound("http://" + inbound.Addr().String())
+				}
+			},
+		},
+		{
+			desc: "grpc",
+			newInbound: func(t *testing.T) (transport.Inbound, func() transport.UnaryOutbound) {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				require.NoError(t, err)
+				inbound := grpc.NewTransport().NewInbound(listener)
+				return inbound, func() transport.UnaryOutbound {
+					return grpc.NewTransport().NewSingleOutbound(listener.Addr().String())
+				}
+			},
+		},
+		{
+			desc: "tchannel",
+			newInbound: func(t *testing.T) (transport.Inbound, func() transport.UnaryOutbound) {
+				serverTransport, err := tchannel.NewTransport(tchannel.ServiceName("server"), tchannel.ListenAddr("127.0.0.1:0"))
+				require.NoError(t, err)
+				clientTransport, err := tchannel.NewTransport(tchannel.ServiceName("client"))
+				require.NoError(t, err)
+				return serverTransport.NewInbound(), func() transport.UnaryOutbound {
+					return clientTransport.NewSingleOutbound(serverTransport.ListenAddr())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			serverSpans := tracetest.NewSpanRecorder()
+			clientSpans := tracetest.NewSpanRecorder()
+
+			inbound, newOutbound := tt.newInbound(t)
+			server := NewDispatcher(Config{
+				Name:     "server",
+				Inbounds: Inbounds{inbound},
+				Tracing: TracingConfig{
+					TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(serverSpans)),
+				},
+			})
+
+			var (
+				traceparent string
+				handlerSpan trace.SpanContext
+			)
+			server.Register(raw.Procedure("hello", func(ctx context.Context, body []byte) ([]byte, error) {
+				traceparent = CallFromContext(ctx).Header("traceparent")
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return body, nil
+			}))
+			require.NoError(t, server.Start())
+			defer func() { assert.NoError(t, server.Stop()) }()
+
+			client := NewDispatcher(Config{
+				Name:      "client",
+				Outbounds: Outbounds{"server": {Unary: newOutbound()}},
+				Tracing: TracingConfig{
+					TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(clientSpans)),
+				},
+			})
+			require.NoError(t, client.Start())
+			defer func() { assert.NoError(t, client.Stop()) }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+			defer cancel()
+			res, err := raw.New(client.ClientConfig("server")).Call(ctx, "hello", []byte("world"))
+			require.NoError(t, err)
+			assert.Equal(t, "world", string(res))
+
+			require.Len(t, clientSpans.Ended(), 1)
+			require.Len(t, serverSpans.Ended(), 1)
+			clientSpan, serverSpan := clientSpans.Ended()[0], serverSpans.Ended()[0]
+
+			assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+			assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+			assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+			assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+			assert.Equal(t, serverSpan.SpanContext(), handlerSpan)
+			assert.Equal(t,
+				"00-"+clientSpan.SpanContext().TraceID().String()+"-"+clientSpan.SpanContext().SpanID().String()+"-01",
+				traceparent)
+		})
+	}
+}
@@ -21,7 +21,11 @@
 package grpc
 
 import (
+	"context"
+
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
 )
 
 // NewDialer creates a transport that is decorated to retain peers with
@@ -43,7 +47,10 @@ type Dialer struct {
 	connectionScope *connectionScope
 }
 
-var _ peer.Transport = (*Dialer)(nil)
+var (
+	_ peer.Transport      = (*Dialer)(nil)
+	_ abstractlist.Prober = (*Dialer)(nil)
+)
 
 // WithConnectionIsolation returns a copy of the Dialer whose peers, and
 // therefore connections or connection pools, are not shared with other
@@ -66,3 +73,9 @@ func (d *Dialer) RetainPeer(id peer.Identifier, ps peer.Subscriber) (peer.Peer,
 func (d *Dialer) ReleasePeer(id peer.Identifier, ps peer.Subscriber) error {
 	return d.trans.releasePeer(id, d.connectionScope, ps)
 }
+
+// Probe sends a unary request directly to a peer retained from the dialer.
+// See Transport.Probe.
+func (d *Dialer) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	return d.trans.Probe(ctx, p, req)
+}
@@ -36,6 +36,7 @@ import (
 	"go.uber.org/yarpc/api/middleware"
 	"go.uber.org/yarpc/api/peer"
 	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/health"
 	"go.uber.org/yarpc/internal/prototest/example"
 	"go.uber.org/yarpc/internal/prototest/examplepb"
 	yarpcpeer "go.uber.org/yarpc/peer"
@@ -46,6 +47,11 @@ import (
 	"go.uber.org/yarpc/x/yarpctest/api"
 	"go.uber.org/yarpc/x/yarpctest/types"
 	"go.uber.org/zap"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 func TestStreamingWithNoCtxDeadline(t *testing.T) {
@@ -376,3 +382,66 @@ func TestConnectionPoolMaxConnectionsCapRespected(t *testing.T) {
 		assert.Equal(t, int64(1), gauges["conn_pool_active_connections"])
 	})
 }
+
+func TestHealthCheck(t *testing.T) {
+	const serviceName = "service-name"
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "could not start listener")
+
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name:     serviceName,
+		Inbounds: yarpc.Inbounds{grpc.NewTransport().NewInbound(listener)},
+	})
+	require.NoError(t, dispatcher.Start(), "could not start dispatcher")
+	stopped := false
+	defer func() {
+		if !stopped {
+			assert.NoError(t, dispatcher.Stop(), "could not stop dispatcher")
+		}
+	}()
+
+	// Call the health service the way standard health checkers do, without
+	// YARPC headers.
+	conn, err := gogrpc.NewClient(listener.Addr().String(), gogrpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, service := range []string{"", serviceName} {
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, "could not check service %q", service)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+	}
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	watch, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: serviceName})
+	require.NoError(t, err)
+	res, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	dispatcher.Health().SetServingStatus(serviceName, health.NotServing)
+	res, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.Status)
+	dispatcher.Health().SetServingStatus(serviceName, health.Serving)
+	res, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	// Stopping the dispatcher reports the service as not serving and ends
+	// the watch rather than waiting for it.
+	stopped = true
+	require.NoError(t, dispatcher.Stop(), "could not stop dispatcher")
+	res, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.Status)
+	_, err = watch.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -31,12 +31,15 @@ import (
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/api/middleware"
 	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/health"
 	"go.uber.org/yarpc/internal/grpcerrorcodes"
 	"go.uber.org/yarpc/yarpcerrors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -46,6 +49,11 @@ var (
 	errInvalidGRPCMethod = yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument, "invalid stream method name for request")
 )
 
+const (
+	_healthCheckCaller                    = "grpc-health-check"
+	_protoEncoding     transport.Encoding = "proto"
+)
+
 type handler struct {
 	i      *Inbound
 	logger *zap.Logger
@@ -114,6 +122,11 @@ func (h *handler) getBasicTransportRequest(ctx context.Context, streamMethod str
 		return nil, err
 	}
 	transportRequest.Transport = TransportName
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			transportRequest.PeerIdentity = transport.PeerIdentityFromTLS(&info.State)
+		}
+	}
 
 	procedure, err := procedureFromStreamMethod(streamMethod)
 	if err != nil {
@@ -121,12 +134,35 @@ func (h *handler) getBasicTransportRequest(ctx context.Context, streamMethod str
 	}
 
 	transportRequest.Procedure = procedure
+	if health.IsProcedure(procedure) {
+		h.setHealthCheckDefaults(transportRequest)
+	}
 	if err := transport.ValidateRequest(transportRequest); err != nil {
 		return nil, err
 	}
 	return transportRequest, nil
 }
 
+// setHealthCheckDefaults fills in the request metadata that standard gRPC
+// health checkers, like grpc-health-probe, do not send.
+func (h *handler) setHealthCheckDefaults(req *transport.Request) {
+	if req.Caller == "" {
+		req.Caller = _healthCheckCaller
+	}
+	if req.Encoding == "" {
+		// A content-type without a subtype implies Protobuf.
+		req.Encoding = _protoEncoding
+	}
+	if req.Service == "" {
+		for _, p := range h.i.router.Procedures() {
+			if p.Name == req.Procedure {
+				req.Service = p.Service
+				break
+			}
+		}
+	}
+}
+
 // procedureFromStreamMethod converts a GRPC stream method into a yarpc
 // procedure name.  This is mostly copied from the GRPC-go server processing
 // logic here:
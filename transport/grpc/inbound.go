@@ -40,6 +40,7 @@ var (
 
 	_ introspection.IntrospectableInbound = (*Inbound)(nil)
 	_ transport.Inbound                   = (*Inbound)(nil)
+	_ transport.DrainableInbound          = (*Inbound)(nil)
 )
 
 // Inbound is a grpc transport.Inbound.
@@ -51,6 +52,9 @@ type Inbound struct {
 	options  *inboundOptions
 	router   transport.Router
 	server   *grpc.Server
+	// served is the listener the server accepts connections from, which
+	// wraps listener when the inbound terminates TLS itself.
+	served net.Listener
 }
 
 // newInbound returns a new Inbound for the given listener.
@@ -139,6 +143,9 @@ func (i *Inbound) start() error {
 			TransportName: TransportName,
 			Mode:          i.options.tlsMode,
 		})
+		// The listener terminates TLS itself. These credentials only expose
+		// the TLS connection state of those connections to handlers.
+		serverOptions = append(serverOptions, grpc.Creds(muxTLSCredentials{}))
 	}
 
 	if i.t.options.serverMaxHeaderListSize != nil {
@@ -173,9 +180,28 @@ func (i *Inbound) start() error {
 		_ = server.Serve(listener)
 	}()
 	i.server = server
+	i.served = listener
 	return nil
 }
 
+// Drain stops accepting new connections. Existing connections keep serving
+// requests until the inbound stops, which sends GOAWAY on them and waits for
+// requests in flight to complete.
+//
+// GOAWAY is not sent while draining, because gRPC servers refuse new streams
+// on connections they have sent GOAWAY on, which would cut the grace period
+// short for clients that are already connected.
+func (i *Inbound) Drain() {
+	i.lock.RLock()
+	served := i.served
+	i.lock.RUnlock()
+	if served != nil {
+		// Closing the listener ends Serve, but not the connections it
+		// accepted.
+		_ = served.Close()
+	}
+}
+
 func (i *Inbound) stop() error {
 	i.lock.Lock()
 	defer i.lock.Unlock()
@@ -183,6 +209,7 @@ func (i *Inbound) stop() error {
 		i.server.GracefulStop()
 	}
 	i.server = nil
+	i.served = nil
 	return nil
 }
 
@@ -21,11 +21,18 @@
 package grpc
 
 import (
+	"context"
 	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/testtime"
+	"golang.org/x/net/http2"
 )
 
 func TestInboundMechanics(t *testing.T) {
@@ -66,6 +73,91 @@ func TestInboundIntrospection(t *testing.T) {
 	assert.Empty(t, inbound.Introspect().Endpoint, "unexpected endpoint")
 }
 
+func TestInboundDrain(t *testing.T) {
+	trans := NewTransport()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	inbound := trans.NewInbound(listener)
+	inbound.SetRouter(newTestRouter([]transport.Procedure{{
+		Name:        "echo",
+		HandlerSpec: transport.NewUnaryHandlerSpec(transporttest.EchoHandler{}),
+	}}))
+	outbound := trans.NewSingleOutbound(listener.Addr().String())
+	require.NoError(t, trans.Start())
+	defer func() { assert.NoError(t, trans.Stop()) }()
+	require.NoError(t, inbound.Start())
+	defer inbound.Stop()
+	require.NoError(t, outbound.Start())
+	defer func() { assert.NoError(t, outbound.Stop()) }()
+
+	call := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+		defer cancel()
+		res, err := outbound.Call(ctx, &transport.Request{
+			Caller:    "caller",
+			Service:   "service",
+			Encoding:  "raw",
+			Procedure: "echo",
+			Body:      strings.NewReader("hello"),
+		})
+		if err != nil {
+			return err
+		}
+		return res.Body.Close()
+	}
+	require.NoError(t, call(), "call before draining failed")
+
+	// A raw HTTP/2 connection, established before draining, observes the
+	// GOAWAY that the inbound sends when it stops.
+	addr := inbound.Addr().String()
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(testtime.Second)))
+	_, err = conn.Write([]byte(http2.ClientPreface))
+	require.NoError(t, err)
+	framer := http2.NewFramer(conn, conn)
+	require.NoError(t, framer.WriteSettings())
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err)
+		if f, ok := frame.(*http2.SettingsFrame); ok && !f.IsAck() {
+			require.NoError(t, framer.WriteSettingsAck())
+			break
+		}
+	}
+
+	inbound.Drain()
+
+	assert.Eventually(t, func() bool {
+		c, err := net.Dial("tcp", addr)
+		if err == nil {
+			c.Close()
+		}
+		return err != nil
+	}, testtime.Second, testtime.Millisecond, "new connections must be refused after draining")
+	assert.NoError(t, call(), "existing connections must serve requests during the grace period")
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- inbound.Stop() }()
+
+	var goAway bool
+	for !goAway {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err, "expected GOAWAY on existing connections when stopping")
+		switch f := frame.(type) {
+		case *http2.PingFrame:
+			if !f.IsAck() {
+				require.NoError(t, framer.WritePing(true, f.Data))
+			}
+		case *http2.GoAwayFrame:
+			goAway = true
+		}
+	}
+	require.NoError(t, conn.Close())
+	assert.NoError(t, <-stopped)
+}
+
 func TestInboundStartWithNumStreamWorkers(t *testing.T) {
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	require.NoError(t, err)
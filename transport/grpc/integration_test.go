@@ -646,6 +646,76 @@ func TestOutboundTLS(t *testing.T) {
 	}
 }
 
+func TestInboundTLSPeerIdentity(t *testing.T) {
+	scenario := testscenario.Create(t, time.Minute, time.Minute)
+	muxOptions := []InboundOption{InboundTLSConfiguration(scenario.ServerTLSConfig()), InboundTLSMode(yarpctls.Permissive)}
+
+	tests := []struct {
+		desc           string
+		inboundOptions []InboundOption
+		dialOptions    []DialOption
+		want           *transport.PeerIdentity
+	}{
+		{
+			desc:           "tls_listener",
+			inboundOptions: muxOptions,
+			dialOptions:    []DialOption{DialerTLSConfig(scenario.ClientTLSConfig())},
+			want:           transport.PeerIdentityFromCertificate(scenario.ClientCert),
+		},
+		{
+			desc:           "tls_credentials",
+			inboundOptions: []InboundOption{InboundCredentials(credentials.NewTLS(scenario.ServerTLSConfig()))},
+			dialOptions:    []DialOption{DialerCredentials(credentials.NewTLS(scenario.ClientTLSConfig()))},
+			want:           transport.PeerIdentityFromCertificate(scenario.ClientCert),
+		},
+		{
+			desc:           "plaintext",
+			inboundOptions: muxOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got *transport.PeerIdentity
+			handler := peerIdentityHandler(func(id *transport.PeerIdentity) { got = id })
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			trans := NewTransport()
+			inbound := trans.NewInbound(listener, tt.inboundOptions...)
+			inbound.SetRouter(newTestRouter([]transport.Procedure{
+				{Name: "identity", Service: "test-svc", HandlerSpec: transport.NewUnaryHandlerSpec(handler)},
+			}))
+			chooser := peer.NewSingle(hostport.Identify(listener.Addr().String()), trans.NewDialer(tt.dialOptions...))
+			outbound := trans.NewOutbound(chooser)
+			require.NoError(t, trans.Start())
+			defer func() { assert.NoError(t, trans.Stop()) }()
+			require.NoError(t, inbound.Start())
+			defer func() { assert.NoError(t, inbound.Stop()) }()
+			require.NoError(t, outbound.Start())
+			defer func() { assert.NoError(t, outbound.Stop()) }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+			defer cancel()
+			_, err = outbound.Call(ctx, &transport.Request{
+				Caller:    "test-client",
+				Service:   "test-svc",
+				Encoding:  "raw",
+				Procedure: "identity",
+				Body:      bytes.NewReader(nil),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type peerIdentityHandler func(*transport.PeerIdentity)
+
+func (h peerIdentityHandler) Handle(_ context.Context, req *transport.Request, _ transport.ResponseWriter) error {
+	h(req.PeerIdentity)
+	return nil
+}
+
 type metricCollection struct {
 	metrics []metric
 }
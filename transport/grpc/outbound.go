@@ -38,6 +38,7 @@ import (
 	"go.uber.org/yarpc/internal/interceptor/outboundinterceptor"
 	intyarpcerrors "go.uber.org/yarpc/internal/yarpcerrors"
 	peerchooser "go.uber.org/yarpc/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hostport"
 	"go.uber.org/yarpc/pkg/lifecycle"
 	"go.uber.org/yarpc/yarpcerrors"
@@ -53,6 +54,7 @@ const UserAgent = "yarpc-go/" + yarpc.Version
 var (
 	_                         transport.UnaryOutbound              = (*Outbound)(nil)
 	_                         introspection.IntrospectableOutbound = (*Outbound)(nil)
+	_                         abstractlist.Prober                  = (*Outbound)(nil)
 	invalidHeaderValueCharSet                                      = "\r\n" + string('\x00') // NUL
 )
 
@@ -80,9 +82,21 @@ func newOutbound(t *Transport, peerChooser peer.Chooser, options ...OutboundOpti
 	}
 	o.unaryCallWithInterceptor = outboundinterceptor.NewUnaryChain(o, t.options.unaryOutboundInterceptor)
 	o.streamCallWithInterceptor = outboundinterceptor.NewStreamChain(o, t.options.streamOutboundInterceptor)
+
+	// Peer lists that send health checks send them through the outbound,
+	// so that they reach peers the same way requests do.
+	if l, ok := peerChooser.(proberSetter); ok {
+		l.SetProber(o)
+	}
 	return o
 }
 
+// proberSetter is implemented by peer lists that send health checks to their
+// peers, like those built on "go.uber.org/yarpc/peer/abstractlist".
+type proberSetter interface {
+	SetProber(abstractlist.Prober)
+}
+
 // TransportName is the transport name that will be set on `transport.Request`
 // struct.
 func (o *Outbound) TransportName() string {
@@ -130,6 +144,26 @@ func (o *Outbound) DirectCall(ctx context.Context, request *transport.Request) (
 	if err := o.once.WaitUntilRunning(ctx); err != nil {
 		return nil, intyarpcerrors.AnnotateWithInfo(yarpcerrors.FromError(err), "error waiting for grpc outbound to start for service: %s", request.Service)
 	}
+	return o.call(ctx, request)
+}
+
+// Probe sends a unary request directly to the given peer, without consulting
+// the outbound's peer chooser. The request is sent with the outbound's
+// settings, like its compressor.
+//
+// Peer lists owned by the outbound use Probe to send health checks to each
+// of their peers.
+func (o *Outbound) Probe(ctx context.Context, p peer.Peer, request *transport.Request) (*transport.Response, error) {
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+	probe := *o
+	probe.peerChooser = probeChooser{p}
+	return probe.call(ctx, request)
+}
+
+// call sends a unary request to the peer that the outbound's chooser picks.
+func (o *Outbound) call(ctx context.Context, request *transport.Request) (*transport.Response, error) {
 	start := time.Now()
 	var responseBody []byte
 	var responseMD metadata.MD
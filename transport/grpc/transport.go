@@ -21,15 +21,20 @@
 package grpc
 
 import (
+	"context"
 	"net"
 	"sync"
 
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/pkg/lifecycle"
 )
 
 var emptyDialOpts = &dialOptions{}
 
+var _ abstractlist.Prober = (*Transport)(nil)
+
 // Transport is a grpc transport.Transport.
 //
 // This currently does not have any additional functionality over creating
@@ -49,6 +54,9 @@ type Transport struct {
 	// while calling it, and monitorConnWrapper needs list.lock to exit cleanly
 	// (deadlock). Instead we wait asynchronously and join in Transport.Stop().
 	releasedCleanupWg sync.WaitGroup
+
+	probeOutboundOnce sync.Once
+	probeOutbound     *Outbound
 }
 
 // peerKey identifies a peer in the transport's peer map.
@@ -169,6 +177,32 @@ func (t *Transport) ReleasePeer(pid peer.Identifier, ps peer.Subscriber) error {
 	return t.releasePeer(pid, nil, ps)
 }
 
+// Probe sends a unary request directly to a peer retained from this
+// transport, or from one of its dialers, without consulting a peer chooser.
+// Peer lists use Probe to send health checks to each of their peers, unless
+// they are owned by an outbound, which probes peers with its own settings.
+func (t *Transport) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	t.probeOutboundOnce.Do(func() {
+		// The outbound only lends its settings to probes, which choose
+		// their own peer, so it never has to start.
+		t.probeOutbound = newOutbound(t, probeChooser{})
+	})
+	return t.probeOutbound.Probe(ctx, p, req)
+}
+
+// probeChooser always chooses the peer that a probe is addressed to.
+type probeChooser struct {
+	peer peer.Peer
+}
+
+func (c probeChooser) Choose(context.Context, *transport.Request) (peer.Peer, func(error), error) {
+	return c.peer, func(error) {}, nil
+}
+
+func (probeChooser) Start() error    { return nil }
+func (probeChooser) Stop() error     { return nil }
+func (probeChooser) IsRunning() bool { return true }
+
 func (t *Transport) releasePeer(
 	pid peer.Identifier,
 	connectionScope *connectionScope,
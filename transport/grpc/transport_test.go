@@ -21,6 +21,7 @@
 package grpc
 
 import (
+	"context"
 	"net"
 	"sync"
 	"testing"
@@ -30,6 +31,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/health"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/peer/roundrobin"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
@@ -44,6 +51,85 @@ func TestTransportLifecycle(t *testing.T) {
 	assert.False(t, transport.IsRunning())
 }
 
+// healthChecks records the health checks that an inbound serves.
+type healthChecks struct {
+	handler transport.UnaryHandler
+	checks  chan struct{}
+}
+
+func (h healthChecks) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	select {
+	case h.checks <- struct{}{}:
+	default:
+	}
+	return h.handler.Handle(ctx, req, resw)
+}
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		desc string
+		// start starts sending health checks to the peers of the list.
+		start func(t *testing.T, trans *Transport, list *roundrobin.List) func()
+	}{
+		{
+			desc: "transport",
+			start: func(t *testing.T, trans *Transport, list *roundrobin.List) func() {
+				require.NoError(t, list.Start())
+				return func() { assert.NoError(t, list.Stop()) }
+			},
+		},
+		{
+			desc: "outbound",
+			start: func(t *testing.T, trans *Transport, list *roundrobin.List) func() {
+				outbound := trans.NewOutbound(list)
+				require.NoError(t, outbound.Start())
+				return func() { assert.NoError(t, outbound.Stop()) }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			checks := make(chan struct{}, 1)
+			server := health.NewServer()
+			server.SetServingStatus("service", health.Serving)
+			var procedures []transport.Procedure
+			for _, p := range server.Procedures() {
+				if p.Name == health.CheckProcedure {
+					p.HandlerSpec = transport.NewUnaryHandlerSpec(healthChecks{p.HandlerSpec.Unary(), checks})
+				}
+				procedures = append(procedures, p)
+			}
+
+			trans := NewTransport()
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			inbound := trans.NewInbound(listener)
+			inbound.SetRouter(newTestRouter(procedures))
+			require.NoError(t, trans.Start())
+			defer func() { assert.NoError(t, trans.Stop()) }()
+			require.NoError(t, inbound.Start())
+			defer func() { assert.NoError(t, inbound.Stop()) }()
+
+			list := roundrobin.New(trans.NewDialer(), roundrobin.HealthCheck(abstractlist.HealthCheckConfig{
+				Service:  "service",
+				Interval: 10 * time.Millisecond,
+			}))
+			stop := tt.start(t, trans, list)
+			defer stop()
+			require.NoError(t, list.Update(peer.ListUpdates{
+				Additions: []peer.Identifier{hostport.Identify(listener.Addr().String())},
+			}))
+
+			select {
+			case <-checks:
+			case <-time.After(testtime.Second):
+				t.Fatal("timed out waiting for a health check")
+			}
+		})
+	}
+}
+
 func TestRetainReleasePeerSuccess(t *testing.T) {
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	require.NoError(t, err)
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const (
+	contentEncodingHeader = "Content-Encoding"
+	acceptEncodingHeader  = "Accept-Encoding"
+)
+
+// compressionMetrics records how well request and response bodies compress.
+//
+// The metrics are nil if the transport has no meter, and every method is a
+// no-op in that case.
+type compressionMetrics struct {
+	uncompressedBytes *metrics.CounterVector
+	compressedBytes   *metrics.CounterVector
+	ratio             *metrics.HistogramVector
+}
+
+func newCompressionMetrics(meter *metrics.Scope) *compressionMetrics {
+	if meter == nil {
+		return nil
+	}
+	tags := []string{"compressor", "body"}
+	uncompressed, err := meter.CounterVector(metrics.Spec{
+		Name:    "yarpc_http_uncompressed_bytes",
+		Help:    "Total size of HTTP bodies before compression.",
+		VarTags: tags,
+	})
+	if err != nil {
+		return nil
+	}
+	compressed, err := meter.CounterVector(metrics.Spec{
+		Name:    "yarpc_http_compressed_bytes",
+		Help:    "Total size of HTTP bodies after compression.",
+		VarTags: tags,
+	})
+	if err != nil {
+		return nil
+	}
+	ratio, err := meter.HistogramVector(metrics.HistogramSpec{
+		Spec: metrics.Spec{
+			Name:    "yarpc_http_compression_ratio_percent",
+			Help:    "Size of compressed HTTP bodies as a percentage of their uncompressed size.",
+			VarTags: tags,
+		},
+		Unit:    1,
+		Buckets: []int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100},
+	})
+	if err != nil {
+		return nil
+	}
+	return &compressionMetrics{
+		uncompressedBytes: uncompressed,
+		compressedBytes:   compressed,
+		ratio:             ratio,
+	}
+}
+
+// observe records the sizes of a request or response body, as indicated by
+// body, before and after compression.
+func (m *compressionMetrics) observe(compressor, body string, uncompressed, compressed int64) {
+	if m == nil {
+		return
+	}
+	m.uncompressedBytes.MustGet("compressor", compressor, "body", body).Add(uncompressed)
+	m.compressedBytes.MustGet("compressor", compressor, "body", body).Add(compressed)
+	if uncompressed > 0 {
+		m.ratio.MustGet("compressor", compressor, "body", body).IncBucket(compressed * 100 / uncompressed)
+	}
+}
+
+// compress returns the contents of the reader, compressed with the
+// compressor.
+func compress(compressor transport.Compressor, r io.Reader) (*bytes.Buffer, int64, error) {
+	var buf bytes.Buffer
+	w, err := compressor.Compress(&buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	n, err := io.Copy(w, r)
+	if err != nil {
+		_ = w.Close()
+		return nil, 0, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, 0, err
+	}
+	return &buf, n, nil
+}
+
+// decompress wraps a compressed body so that reading it produces the
+// uncompressed contents, and closing it closes the compressed body and
+// records the compression ratio.
+func decompress(compressor transport.Compressor, body io.ReadCloser, onClose func(uncompressed, compressed int64)) (io.ReadCloser, error) {
+	counted := &countingReader{r: body}
+	r, err := compressor.Decompress(counted)
+	if err != nil {
+		return nil, err
+	}
+	return &decompressedBody{
+		ReadCloser: r,
+		body:       body,
+		compressed: counted,
+		onClose:    onClose,
+	}, nil
+}
+
+type decompressedBody struct {
+	io.ReadCloser
+
+	body         io.Closer
+	compressed   *countingReader
+	uncompressed int64
+	onClose      func(uncompressed, compressed int64)
+	closed       bool
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.uncompressed += int64(n)
+	return n, err
+}
+
+func (b *decompressedBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.onClose(b.uncompressed, b.compressed.n)
+	err := b.ReadCloser.Close()
+	if cerr := b.body.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// acceptedCompressor returns the compressor named in an Accept-Encoding
+// header with the highest quality value among the given compressors,
+// preferring the first one named on ties. Codings with a quality value of 0
+// are not acceptable.
+func acceptedCompressor(header http.Header, compressors map[string]transport.Compressor) transport.Compressor {
+	if len(compressors) == 0 {
+		return nil
+	}
+	var (
+		best  transport.Compressor
+		bestQ float64
+	)
+	for _, value := range header.Values(acceptEncodingHeader) {
+		for _, coding := range strings.Split(value, ",") {
+			name, q, ok := parseCoding(coding)
+			if !ok || q <= 0 {
+				continue
+			}
+			if c, found := compressors[name]; found && q > bestQ {
+				best, bestQ = c, q
+			}
+		}
+	}
+	return best
+}
+
+// parseCoding parses a coding from an Accept-Encoding header, like
+// "gzip;q=0.5", into its name and quality value, which defaults to 1.
+func parseCoding(coding string) (name string, q float64, ok bool) {
+	params := strings.Split(coding, ";")
+	name, q = strings.TrimSpace(params[0]), 1
+	for _, param := range params[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "q") {
+			continue
+		}
+		var err error
+		if q, err = strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil || q > 1 {
+			return "", 0, false
+		}
+	}
+	return name, q, true
+}
+
+// requestCompressor returns the compressor for the Content-Encoding of a
+// request, or an error if the inbound does not support the encoding.
+func requestCompressor(header http.Header, compressors map[string]transport.Compressor) (transport.Compressor, error) {
+	coding := header.Get(contentEncodingHeader)
+	if coding == "" || coding == "identity" {
+		return nil, nil
+	}
+	c, ok := compressors[coding]
+	if !ok {
+		return nil, yarpcerrors.InvalidArgumentErrorf("unsupported content encoding %q", coding)
+	}
+	return c, nil
+}
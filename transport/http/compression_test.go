@@ -0,0 +1,251 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/net/metrics"
+	"go.uber.org/yarpc/api/transport"
+	yarpcgzip "go.uber.org/yarpc/compressor/gzip"
+	yarpcsnappy "go.uber.org/yarpc/compressor/snappy"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/internal/yarpctest"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// echoHandler writes the request body back, and records the size of the
+// request body if bodySize is set.
+type echoHandler struct{ bodySize *int }
+
+func (h echoHandler) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	if h.bodySize != nil {
+		*h.bodySize = req.BodySize
+	}
+	_, err := io.Copy(resw, req.Body)
+	return err
+}
+
+func TestAcceptedCompressor(t *testing.T) {
+	gzip := yarpcgzip.New()
+	snappy := yarpcsnappy.New()
+	compressors := map[string]transport.Compressor{"gzip": gzip, "snappy": snappy}
+
+	tests := []struct {
+		desc   string
+		accept []string
+		want   transport.Compressor
+	}{
+		{desc: "none"},
+		{desc: "exact", accept: []string{"gzip"}, want: gzip},
+		{desc: "list", accept: []string{"br, gzip"}, want: gzip},
+		{desc: "quality", accept: []string{"gzip;q=0.5"}, want: gzip},
+		{desc: "not acceptable", accept: []string{"gzip;q=0"}},
+		{desc: "not acceptable with spaces", accept: []string{"gzip ; Q=0.000"}},
+		{desc: "highest quality", accept: []string{"gzip;q=0.5, snappy;q=0.8"}, want: snappy},
+		{desc: "first on ties", accept: []string{"snappy, gzip"}, want: snappy},
+		{desc: "acceptable fallback", accept: []string{"snappy;q=0, gzip;q=0.1"}, want: gzip},
+		{desc: "invalid quality", accept: []string{"snappy;q=high, gzip"}, want: gzip},
+		{desc: "multiple headers", accept: []string{"br", "gzip"}, want: gzip},
+		{desc: "unknown", accept: []string{"br, deflate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			header := make(http.Header)
+			for _, v := range tt.accept {
+				header.Add(acceptEncodingHeader, v)
+			}
+			assert.Equal(t, tt.want, acceptedCompressor(header, compressors))
+		})
+	}
+}
+
+func TestRequestCompressor(t *testing.T) {
+	gzip := yarpcgzip.New()
+	compressors := map[string]transport.Compressor{"gzip": gzip}
+
+	c, err := requestCompressor(http.Header{}, compressors)
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	c, err = requestCompressor(http.Header{contentEncodingHeader: {"identity"}}, compressors)
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	c, err = requestCompressor(http.Header{contentEncodingHeader: {"gzip"}}, compressors)
+	require.NoError(t, err)
+	assert.Equal(t, gzip, c)
+
+	_, err = requestCompressor(http.Header{contentEncodingHeader: {"snappy"}}, compressors)
+	assert.True(t, yarpcerrors.IsInvalidArgument(err))
+	assert.Contains(t, err.Error(), `unsupported content encoding "snappy"`)
+}
+
+func TestCompression(t *testing.T) {
+	payload := strings.Repeat("compress me ", 100)
+
+	tests := []struct {
+		desc                string
+		inboundCompressors  []transport.Compressor
+		outboundCompressor  transport.Compressor
+		wantRequestEncoding string
+		wantResponseEncode  string
+		wantCode            yarpcerrors.Code
+	}{
+		{
+			desc: "no compression",
+		},
+		{
+			desc:                "gzip",
+			inboundCompressors:  []transport.Compressor{yarpcgzip.New(), yarpcsnappy.New()},
+			outboundCompressor:  yarpcgzip.New(),
+			wantRequestEncoding: "gzip",
+			wantResponseEncode:  "gzip",
+		},
+		{
+			desc:                "snappy",
+			inboundCompressors:  []transport.Compressor{yarpcgzip.New(), yarpcsnappy.New()},
+			outboundCompressor:  yarpcsnappy.New(),
+			wantRequestEncoding: "snappy",
+			wantResponseEncode:  "snappy",
+		},
+		{
+			// The Go HTTP client asks for gzip responses and decompresses
+			// them transparently.
+			desc:               "inbound only",
+			inboundCompressors: []transport.Compressor{yarpcgzip.New()},
+			wantResponseEncode: "gzip",
+		},
+		{
+			desc:                "unsupported compressor",
+			inboundCompressors:  []transport.Compressor{yarpcgzip.New()},
+			outboundCompressor:  yarpcsnappy.New(),
+			wantRequestEncoding: "snappy",
+			wantCode:            yarpcerrors.CodeInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := metrics.New()
+			trans := NewTransport(Meter(root.Scope()))
+
+			var gotRequestEncoding, gotResponseEncoding string
+			var gotRequestBodySize int
+			intercept := func(h http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotRequestEncoding = r.Header.Get("Content-Encoding")
+					h.ServeHTTP(w, r)
+					gotResponseEncoding = w.Header().Get("Content-Encoding")
+				})
+			}
+			inbound := trans.NewInbound("127.0.0.1:0",
+				InboundCompressors(tt.inboundCompressors...),
+				Interceptor(intercept))
+			inbound.SetRouter(newTestRouter([]transport.Procedure{{
+				Name:        "echo",
+				HandlerSpec: transport.NewUnaryHandlerSpec(echoHandler{bodySize: &gotRequestBodySize}),
+			}}))
+			require.NoError(t, trans.Start())
+			defer trans.Stop()
+			require.NoError(t, inbound.Start())
+			defer inbound.Stop()
+
+			var opts []OutboundOption
+			if tt.outboundCompressor != nil {
+				opts = append(opts, OutboundCompressor(tt.outboundCompressor))
+			}
+			outbound := trans.NewSingleOutbound(
+				fmt.Sprintf("http://%v", yarpctest.ZeroAddrToHostPort(inbound.Addr())), opts...)
+			require.NoError(t, outbound.Start())
+			defer outbound.Stop()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+			defer cancel()
+			res, err := outbound.Call(ctx, &transport.Request{
+				Caller:    "caller",
+				Service:   "service",
+				Procedure: "echo",
+				Encoding:  "raw",
+				Body:      bytes.NewReader([]byte(payload)),
+			})
+			assert.Equal(t, tt.wantRequestEncoding, gotRequestEncoding, "request Content-Encoding")
+			if tt.wantCode != yarpcerrors.CodeOK {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, yarpcerrors.FromError(err).Code())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResponseEncode, gotResponseEncoding, "response Content-Encoding")
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, payload, string(body))
+
+			// Decompressed bodies have an unknown size, rather than the size
+			// of the compressed body.
+			wantRequestBodySize, wantResponseBodySize := len(payload), len(payload)
+			if tt.wantRequestEncoding != "" {
+				wantRequestBodySize = -1
+			}
+			if tt.wantResponseEncode != "" {
+				wantResponseBodySize = -1
+			}
+			assert.Equal(t, wantRequestBodySize, gotRequestBodySize, "request body size")
+			assert.Equal(t, wantResponseBodySize, res.BodySize, "response body size")
+
+			if len(tt.inboundCompressors) == 0 {
+				assert.Empty(t, root.Snapshot().Counters, "uncompressed calls must not emit compression metrics")
+				return
+			}
+			if tt.outboundCompressor == nil {
+				return
+			}
+
+			// The request and response bodies are observed on both sides.
+			name := tt.outboundCompressor.Name()
+			snapshot := root.Snapshot()
+			for _, c := range snapshot.Counters {
+				assert.Equal(t, name, c.Tags["compressor"])
+				switch c.Name {
+				case "yarpc_http_uncompressed_bytes":
+					assert.Equal(t, int64(2*len(payload)), c.Value, "%v uncompressed bytes", c.Tags)
+				case "yarpc_http_compressed_bytes":
+					assert.True(t, c.Value < int64(len(payload)), "%v compressed bytes", c.Tags)
+				}
+			}
+			require.Len(t, snapshot.Histograms, 2)
+			for _, h := range snapshot.Histograms {
+				assert.Equal(t, "yarpc_http_compression_ratio_percent", h.Name)
+				assert.Equal(t, []int64{10, 10}, h.Values, "%v compression ratio", h.Tags)
+			}
+		})
+	}
+}
@@ -74,6 +74,7 @@ func (ts *transportSpec) Spec() yarpcconfig.TransportSpec {
 		BuildInbound:        ts.buildInbound,
 		BuildUnaryOutbound:  ts.buildUnaryOutbound,
 		BuildOnewayOutbound: ts.buildOnewayOutbound,
+		BuildStreamOutbound: ts.buildStreamOutbound,
 	}
 }
 
@@ -175,6 +176,8 @@ func (ts *transportSpec) buildTransport(tc *TransportConfig, k *yarpcconfig.Kit)
 //	    readTimeout: 10s
 //	    writeTimeout: 10s
 //	    idleTimeout: 60s
+//	    compressors:
+//	      - gzip
 type InboundConfig struct {
 	// Address to listen on. This field is required.
 	Address string `config:"address,interpolate"`
@@ -205,6 +208,10 @@ type InboundConfig struct {
 	// Keys must be lowercase. Values are the desired original casings.
 	// Ignored if CanonicalizeHeaderKeys is true.
 	HeaderCaseMapping map[string][]string `config:"headerCaseMapping"`
+	// Compressors are the names of the compressors, registered with the
+	// configurator, that callers may use to compress request bodies and to
+	// ask for compressed response bodies.
+	Compressors []string `config:"compressors"`
 }
 
 // TLSConfig specifies the TLS configuration of the HTTP inbound.
@@ -275,6 +282,18 @@ func (ts *transportSpec) buildInbound(ic *InboundConfig, t transport.Transport,
 		}
 	}
 
+	if len(ic.Compressors) > 0 {
+		compressors := make([]transport.Compressor, 0, len(ic.Compressors))
+		for _, name := range ic.Compressors {
+			c := k.Compressor(name)
+			if c == nil {
+				return nil, fmt.Errorf("unknown compressor %q", name)
+			}
+			compressors = append(compressors, c)
+		}
+		inboundOptions = append(inboundOptions, InboundCompressors(compressors...))
+	}
+
 	inboundOptions = append(inboundOptions, DisableHTTP2(ic.DisableHTTP2))
 
 	return t.(*Transport).NewInbound(ic.Address, inboundOptions...), nil
@@ -335,6 +354,14 @@ type OutboundConfig struct {
 	//      spiffe-ids:
 	//        - destination-id
 	TLS OutboundTLSConfig `config:"tls"`
+	// Compressor is the name of a compressor, registered with the
+	// configurator, that compresses request bodies and that the inbound is
+	// asked to compress response bodies with.
+	//
+	//  http:
+	//    url: "http://localhost:8080/yarpc"
+	//    compressor: gzip
+	Compressor string `config:"compressor"`
 }
 
 // OutboundTLSConfig configures TLS for the HTTP outbound.
@@ -380,6 +407,14 @@ func (ts *transportSpec) buildOutbound(oc *OutboundConfig, t transport.Transport
 		}
 	}
 
+	if oc.Compressor != "" {
+		c := k.Compressor(oc.Compressor)
+		if c == nil {
+			return nil, fmt.Errorf("unknown compressor %q", oc.Compressor)
+		}
+		opts = append(opts, OutboundCompressor(c))
+	}
+
 	option, err := oc.TLS.options(x.ouboundTLSConfigProvider)
 	if err != nil {
 		return nil, err
@@ -409,3 +444,7 @@ func (ts *transportSpec) buildUnaryOutbound(oc *OutboundConfig, t transport.Tran
 func (ts *transportSpec) buildOnewayOutbound(oc *OutboundConfig, t transport.Transport, k *yarpcconfig.Kit) (transport.OnewayOutbound, error) {
 	return ts.buildOutbound(oc, t, k)
 }
+
+func (ts *transportSpec) buildStreamOutbound(oc *OutboundConfig, t transport.Transport, k *yarpcconfig.Kit) (transport.StreamOutbound, error) {
+	return ts.buildOutbound(oc, t, k)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	yarpctls "go.uber.org/yarpc/api/transport/tls"
+	yarpcgzip "go.uber.org/yarpc/compressor/gzip"
 	"go.uber.org/yarpc/yarpcconfig"
 )
 
@@ -88,6 +89,7 @@ func TestTransportSpec(t *testing.T) {
 		IdleTimeout            time.Duration
 		CanonicalizeHeaderKeys bool
 		HeaderCaseMapping      map[string][]string
+		Compressors            []string
 	}
 
 	type inboundTest struct {
@@ -107,6 +109,7 @@ func TestTransportSpec(t *testing.T) {
 		Headers     http.Header
 		TLSConfig   bool
 		UseHTTP2    bool
+		Compressor  string
 	}
 
 	type outboundTest struct {
@@ -247,6 +250,20 @@ func TestTransportSpec(t *testing.T) {
 			cfg:        attrs{"address": ":8080", "shutdownTimeout": "-1s"},
 			wantErrors: []string{`shutdownTimeout must not be negative, got: "-1s"`},
 		},
+		{
+			desc: "inbound with compressors",
+			cfg:  attrs{"address": ":8080", "compressors": []string{"gzip"}},
+			wantInbound: &wantInbound{
+				Address:         ":8080",
+				ShutdownTimeout: defaultShutdownTimeout,
+				Compressors:     []string{"gzip"},
+			},
+		},
+		{
+			desc:       "inbound with unknown compressor",
+			cfg:        attrs{"address": ":8080", "compressors": []string{"brotli"}},
+			wantErrors: []string{`unknown compressor "brotli"`},
+		},
 		{
 			desc:        "disableHTTP2 - true",
 			cfg:         attrs{"address": ":8080", "disableHTTP2": true},
@@ -353,6 +370,29 @@ func TestTransportSpec(t *testing.T) {
 
 	outboundTests := []outboundTest{
 		{desc: "no outbound", empty: true},
+		{
+			desc: "outbound with compressor",
+			cfg: attrs{
+				"myservice": attrs{
+					"http": attrs{"url": "http://localhost:4040/yarpc", "compressor": "gzip"},
+				},
+			},
+			wantOutbounds: map[string]wantOutbound{
+				"myservice": {
+					URLTemplate: "http://localhost:4040/yarpc",
+					Compressor:  "gzip",
+				},
+			},
+		},
+		{
+			desc: "outbound with unknown compressor",
+			cfg: attrs{
+				"myservice": attrs{
+					"http": attrs{"url": "http://localhost:4040/yarpc", "compressor": "brotli"},
+				},
+			},
+			wantErrors: []string{`unknown compressor "brotli"`},
+		},
 		{
 			desc: "simple outbound",
 			cfg: attrs{
@@ -640,6 +680,7 @@ func TestTransportSpec(t *testing.T) {
 			env[k] = v
 		}
 		configurator := yarpcconfig.New(yarpcconfig.InterpolationResolver(mapResolver(env)))
+		configurator.MustRegisterCompressor(yarpcgzip.New())
 
 		opts := append(append(trans.opts, inbound.opts...), outbound.opts...)
 		if trans.wantClient != nil {
@@ -701,6 +742,11 @@ func TestTransportSpec(t *testing.T) {
 				} else {
 					assert.Empty(t, ib.headerCaseMapping)
 				}
+				var compressors []string
+				for name := range ib.compressors {
+					compressors = append(compressors, name)
+				}
+				assert.ElementsMatch(t, want.Compressors, compressors, "compressors should match")
 			}
 		}
 
@@ -710,12 +756,19 @@ func TestTransportSpec(t *testing.T) {
 				// Verify that we install a oneway too
 				_, ok := cfg.Outbounds[svc].Oneway.(*Outbound)
 				assert.True(t, ok, "expected *Outbound for %q oneway, got %T", svc, cfg.Outbounds[svc].Oneway)
+				_, ok = cfg.Outbounds[svc].Stream.(*Outbound)
+				assert.True(t, ok, "expected *Outbound for %q stream, got %T", svc, cfg.Outbounds[svc].Stream)
 
 				assert.Equal(t, want.URLTemplate, ob.urlTemplate.String(), "outbound URLTemplate should match")
 				assert.Equal(t, want.Headers, ob.headers, "outbound headers should match")
 				assert.Equal(t, svc, ob.destServiceName, "outbound destination service name must match")
 				assert.Equal(t, want.TLSConfig, ob.tlsConfig != nil, "unexpected outbound tls config")
 				assert.Equal(t, want.UseHTTP2, ob.useHTTP2, "UseHTTP2 should match")
+				var compressor string
+				if ob.compressor != nil {
+					compressor = ob.compressor.Name()
+				}
+				assert.Equal(t, want.Compressor, compressor, "compressor should match")
 			}
 
 		}
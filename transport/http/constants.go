@@ -117,6 +117,11 @@ const (
 	// feature is supported on the server. If any non-empty value is set,
 	// this indicates true.
 	BothResponseErrorHeader = "Rpc-Both-Response-Error"
+
+	// StreamHeader marks a request as a stream. If the value is "true", the
+	// request and response bodies are sequences of length-prefixed messages
+	// and errors are reported in trailers.
+	StreamHeader = "Rpc-Stream"
 )
 
 const (
@@ -168,6 +173,11 @@ var (
 const (
 	UberTraceContextHeaderKey  = "uber-trace-id"
 	UberBaggageHeaderKeyPrefix = "uberctx-"
+
+	// W3C Trace Context and Baggage headers, used by OpenTelemetry.
+	TraceParentHeaderKey = "traceparent"
+	TraceStateHeaderKey  = "tracestate"
+	BaggageHeaderKey     = "baggage"
 )
 
 // Proxy-managed header keys that must travel over the wire unprefixed
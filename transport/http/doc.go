@@ -20,7 +20,7 @@
 
 // Package http implements a YARPC transport based on the HTTP/1.1 protocol.
 // The HTTP transport provides first class support for Unary RPCs and
-// experimental support for Oneway RPCs.
+// experimental support for Oneway and Streaming RPCs.
 //
 // # Usage
 //
@@ -66,6 +66,14 @@
 // the names of these headers. The request and response bodies are sent as-is
 // in the HTTP request or response body.
 //
+// Streams are sent as a single HTTP request with the "Rpc-Stream: true"
+// header. Each message in the request and response bodies is prefixed by its
+// length as a 4-byte big-endian integer. Errors that occur after the server
+// has started the response are sent in HTTP trailers, using the same header
+// names as the error headers of unary responses. Streams are bidirectional
+// over HTTP/2; over HTTP/1.1, they rely on chunked encoding in both
+// directions.
+//
 // # See Also
 //
 // YARPC Properties: https://github.com/yarpc/yarpc/blob/master/properties.md
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	opentracinglog "github.com/opentracing/opentracing-go/log"
+	"go.uber.org/multierr"
 	"go.uber.org/net/metrics"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/api/middleware"
@@ -60,6 +62,7 @@ type handler struct {
 	headerCaseMapping                        map[string][]string
 	// duplicate header counter vector
 	duplicateHeaderCounterVec *metrics.CounterVector
+	compressors               map[string]transport.Compressor
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -67,13 +70,21 @@ func (h handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	service := popHeader(req.Header, ServiceHeader)
 	procedure := popHeader(req.Header, ProcedureHeader)
 	bothResponseError := popHeader(req.Header, AcceptsBothResponseErrorHeader) == AcceptTrue
+	stream := popHeader(req.Header, StreamHeader) == AcceptTrue
 	// add response header to echo accepted rpc-service
 	responseWriter.AddSystemHeader(ServiceHeader, service)
-	status := yarpcerrors.FromError(errors.WrapHandlerError(h.callHandler(responseWriter, req, service, procedure), service, procedure))
+	status := yarpcerrors.FromError(errors.WrapHandlerError(h.callHandler(responseWriter, req, service, procedure, stream), service, procedure))
 	if status == nil {
+		if compressor := acceptedCompressor(req.Header, h.compressors); compressor != nil {
+			responseWriter.compress(compressor, h.transport.getCompressionMetrics())
+		}
 		responseWriter.Close(http.StatusOK)
 		return
 	}
+	if responseWriter.streamed {
+		// The stream already reported the error in its trailers.
+		return
+	}
 	if statusCodeText, marshalErr := status.Code().MarshalText(); marshalErr != nil {
 		status = yarpcerrors.Newf(yarpcerrors.CodeInternal, "error %s had code %v which is unknown", status.Error(), status.Code())
 		responseWriter.AddSystemHeader(ErrorCodeHeader, "internal")
@@ -103,7 +114,7 @@ func (h handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	responseWriter.Close(httpStatusCode)
 }
 
-func (h handler) callHandler(responseWriter *responseWriter, req *http.Request, service string, procedure string) (retErr error) {
+func (h handler) callHandler(responseWriter *responseWriter, req *http.Request, service string, procedure string, stream bool) (retErr error) {
 	start := time.Now()
 	defer req.Body.Close()
 	if req.Method != http.MethodPost {
@@ -141,6 +152,7 @@ func (h handler) callHandler(responseWriter *responseWriter, req *http.Request,
 		RoutingKey:      routingKey,
 		RoutingDelegate: routingDelegate,
 		CallerProcedure: callerProcedure,
+		PeerIdentity:    transport.PeerIdentityFromTLS(req.TLS),
 		Headers:         applicationHeaders.FromHTTPHeaders(req.Header, transportHeader),
 		Body:            req.Body,
 		BodySize:        int(req.ContentLength),
@@ -184,12 +196,25 @@ func (h handler) callHandler(responseWriter *responseWriter, req *http.Request,
 		return err
 	}
 
+	if spec.Type() == transport.Streaming || stream {
+		defer span.Finish()
+		err = h.handleStream(ctx, req, responseWriter, treq, spec, stream, parseTTLErr)
+		updateSpanWithErr(span, err)
+		return err
+	}
+
 	if parseTTLErr != nil {
 		return parseTTLErr
 	}
 	if err := transport.ValidateRequestContext(ctx); err != nil {
 		return err
 	}
+	if body, err := h.decompressRequest(req, treq); err != nil {
+		updateSpanWithErr(span, err)
+		return err
+	} else if body != nil {
+		defer body.Close()
+	}
 	switch spec.Type() {
 	case transport.Unary:
 		defer span.Finish()
@@ -225,6 +250,80 @@ func (h handler) callHandler(responseWriter *responseWriter, req *http.Request,
 	return err
 }
 
+// decompressRequest replaces the body of the request with its decompressed
+// contents if the caller compressed it, and returns the decompressed body,
+// which the caller must close.
+func (h handler) decompressRequest(req *http.Request, treq *transport.Request) (io.ReadCloser, error) {
+	compressor, err := requestCompressor(req.Header, h.compressors)
+	if err != nil || compressor == nil {
+		return nil, err
+	}
+	name := compressor.Name()
+	body, err := decompress(compressor, req.Body, func(uncompressed, compressed int64) {
+		h.transport.getCompressionMetrics().observe(name, "request", uncompressed, compressed)
+	})
+	if err != nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("failed to decompress request body with %q: %v", name, err)
+	}
+	treq.Body = body
+	// The decompressed size is unknown until the body is read.
+	treq.BodySize = -1
+	return body, nil
+}
+
+// handleStream runs a stream handler over the request and response bodies.
+//
+// Unlike unary and oneway requests, streams do not require a TTL.
+func (h handler) handleStream(
+	ctx context.Context,
+	req *http.Request,
+	responseWriter *responseWriter,
+	treq *transport.Request,
+	spec transport.HandlerSpec,
+	stream bool,
+	parseTTLErr error,
+) error {
+	if spec.Type() != transport.Streaming {
+		return yarpcerrors.Newf(yarpcerrors.CodeUnimplemented,
+			"procedure %q of service %q is a %s procedure, not a stream", treq.Procedure, treq.Service, spec.Type().String())
+	}
+	if !stream {
+		return yarpcerrors.Newf(yarpcerrors.CodeUnimplemented,
+			"procedure %q of service %q is a stream procedure but the request was not a stream", treq.Procedure, treq.Service)
+	}
+	if parseTTLErr != nil {
+		return parseTTLErr
+	}
+
+	if req.ProtoMajor == 1 {
+		// HTTP/1.1 servers otherwise stop reading the request body once the
+		// response starts, which would prevent bidirectional streams.
+		_ = http.NewResponseController(responseWriter.w).EnableFullDuplex()
+	}
+	if contentType := getContentType(treq.Encoding); contentType != "" {
+		responseWriter.AddSystemHeader("Content-Type", contentType)
+	}
+
+	ss := newServerStream(ctx, &transport.StreamRequest{Meta: treq.ToRequestMeta()}, responseWriter.w, req.Body)
+	tServerStream, err := transport.NewServerStream(ss)
+	if err != nil {
+		return err
+	}
+	err = transport.InvokeStreamHandler(transport.StreamInvokeRequest{
+		Stream: tServerStream,
+		Handler: middleware.ApplyStreamInbound(
+			spec.Stream(),
+			h.transport.streamInboundInterceptor,
+		),
+		Logger: h.logger,
+	})
+	if finishErr := ss.finish(err); finishErr != nil {
+		return finishErr
+	}
+	responseWriter.streamed = true
+	return err
+}
+
 func handleOnewayRequest(
 	span opentracing.Span,
 	treq *transport.Request,
@@ -305,6 +404,8 @@ type responseWriter struct {
 	isApplicationError bool
 	appErrorMeta       *transport.ApplicationErrorMeta
 	responseSize       int
+	// streamed indicates that a stream handler wrote the response.
+	streamed bool
 }
 
 func newResponseWriter(w http.ResponseWriter) *responseWriter {
@@ -385,7 +486,34 @@ func (rw *responseWriter) ResetBuffer() {
 	}
 }
 
+// compress replaces the buffered response body with its contents compressed
+// with the compressor.
+// The response remains uncompressed if compression fails.
+func (rw *responseWriter) compress(compressor transport.Compressor, m *compressionMetrics) {
+	if rw.buffer == nil || rw.buffer.Len() == 0 {
+		return
+	}
+	uncompressed := rw.buffer.Len()
+	compressed := bufferpool.Get()
+	w, err := compressor.Compress(compressed)
+	if err == nil {
+		_, err = w.Write(rw.buffer.Bytes())
+		err = multierr.Append(err, w.Close())
+	}
+	if err != nil {
+		bufferpool.Put(compressed)
+		return
+	}
+	m.observe(compressor.Name(), "response", int64(uncompressed), int64(compressed.Len()))
+	bufferpool.Put(rw.buffer)
+	rw.buffer = compressed
+	rw.AddSystemHeader(contentEncodingHeader, compressor.Name())
+}
+
 func (rw *responseWriter) Close(httpStatusCode int) {
+	if rw.streamed {
+		return
+	}
 	rw.w.WriteHeader(httpStatusCode)
 	if rw.buffer != nil {
 		// TODO: what to do with error?
@@ -109,10 +109,14 @@ func hasPrefixFold(s, prefix string) bool {
 	return strings.EqualFold(s[:len(prefix)], prefix)
 }
 
-// isTracingHeader returns true for the handful of YARPC/OpenTracing headers
-// that must go over the wire unprefixed.
+// isTracingHeader returns true for the handful of YARPC/OpenTracing and W3C
+// Trace Context headers that must go over the wire unprefixed.
 func isTracingHeader(k string) bool {
-	if strings.EqualFold(k, UberTraceContextHeaderKey) {
+	switch {
+	case strings.EqualFold(k, UberTraceContextHeaderKey),
+		strings.EqualFold(k, TraceParentHeaderKey),
+		strings.EqualFold(k, TraceStateHeaderKey),
+		strings.EqualFold(k, BaggageHeaderKey):
 		return true
 	}
 	if hasPrefixFold(k, UberBaggageHeaderKeyPrefix) {
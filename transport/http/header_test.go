@@ -76,6 +76,29 @@ func TestHTTPHeaders(t *testing.T) {
 				"uberctx-foo":   "ctxval",
 			}),
 		},
+		{
+			name: "w3c trace context",
+			transHeaders: transport.HeadersFromMap(map[string]string{
+				"traceparent": "00-tid-sid-01",
+				"tracestate":  "k=v",
+				"baggage":     "foo=bar",
+			}),
+			expectedHTTP: http.Header{
+				"Traceparent": []string{"00-tid-sid-01"},
+				"Tracestate":  []string{"k=v"},
+				"Baggage":     []string{"foo=bar"},
+			},
+			httpHeaders: http.Header{
+				"Traceparent": []string{"00-tid-sid-01"},
+				"Tracestate":  []string{"k=v"},
+				"Baggage":     []string{"foo=bar"},
+			},
+			expectedTransHeaders: transport.HeadersFromMap(map[string]string{
+				"traceparent": "00-tid-sid-01",
+				"tracestate":  "k=v",
+				"baggage":     "foo=bar",
+			}),
+		},
 		{
 			name: "mixed headers",
 			transHeaders: transport.HeadersFromMap(map[string]string{
@@ -184,6 +184,29 @@ func IdleTimeout(timeout time.Duration) InboundOption {
 	}
 }
 
+// InboundCompressors returns an InboundOption that decompresses the bodies of
+// requests compressed with any of the given compressors, and compresses the
+// bodies of responses to callers that accept one of them.
+//
+// Callers name the compressor of a request body in the Content-Encoding
+// header and the compressors they accept for the response body in the
+// Accept-Encoding header.
+// The inbound rejects requests compressed with any other compressor.
+//
+// Since Go HTTP clients ask for gzip by default, an inbound with the gzip
+// compressor compresses responses to them, and they decompress the responses
+// transparently.
+func InboundCompressors(compressors ...transport.Compressor) InboundOption {
+	return func(i *Inbound) {
+		if i.compressors == nil {
+			i.compressors = make(map[string]transport.Compressor, len(compressors))
+		}
+		for _, c := range compressors {
+			i.compressors[c.Name()] = c
+		}
+	}
+}
+
 // NewInbound builds a new HTTP inbound that listens on the given address and
 // sharing this transport.
 func (t *Transport) NewInbound(addr string, opts ...InboundOption) *Inbound {
@@ -235,6 +258,7 @@ type Inbound struct {
 	disableHTTP2                             bool
 	overrideOriginalItemWithCanonicalizedKey bool
 	headerCaseMapping                        map[string][]string
+	compressors                              map[string]transport.Compressor
 }
 
 // Tracer configures a tracer on this inbound.
@@ -298,6 +322,7 @@ func (i *Inbound) start() error {
 		overrideOriginalItemWithCanonicalizedKey: i.overrideOriginalItemWithCanonicalizedKey,
 		headerCaseMapping:                        i.headerCaseMapping,
 		duplicateHeaderCounterVec:                duplicateHeaderCounterVec,
+		compressors:                              i.compressors,
 	}
 
 	// reverse iterating because we want the last from options to wrap the
@@ -359,6 +384,14 @@ func (i *Inbound) start() error {
 	return nil
 }
 
+// Drain stops accepting new connections and stops keeping the existing ones
+// alive, closing idle connections and asking clients to close the others
+// after their current requests, so that new requests reach other instances
+// while this one stops.
+func (i *Inbound) Drain() {
+	i.server.Drain()
+}
+
 // Stop the inbound using Shutdown.
 func (i *Inbound) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), i.shutdownTimeout)
@@ -198,6 +198,58 @@ func TestInboundMux(t *testing.T) {
 	}
 }
 
+func TestInboundDrain(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	var _ transport.DrainableInbound = (*Inbound)(nil)
+	i := NewTransport().NewInbound("127.0.0.1:0", Mux("/rpc/v1", mux))
+	reg := transporttest.NewMockRouter(mockCtrl)
+	reg.EXPECT().Procedures()
+	i.SetRouter(reg)
+	require.NoError(t, i.Start())
+	defer i.Stop()
+
+	url := fmt.Sprintf("http://%v/slow", yarpctest.ZeroAddrToHostPort(i.Addr()))
+	type result struct {
+		resp *http.Response
+		body string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		done <- result{resp: resp, body: string(body), err: err}
+	}()
+
+	<-started
+	i.Drain()
+
+	_, err := http.Get(url)
+	assert.Error(t, err, "new connections must be refused after draining")
+
+	close(release)
+	res := <-done
+	require.NoError(t, res.err, "requests in flight must complete")
+	assert.Equal(t, "done", res.body)
+	assert.True(t, res.resp.Close, "connections must be closed after draining")
+	assert.NoError(t, i.Stop())
+}
+
 func TestMuxWithInterceptor(t *testing.T) {
 	tests := []struct {
 		path string
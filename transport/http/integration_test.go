@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 	"go.uber.org/multierr"
+	"go.uber.org/yarpc/api/encoding"
 	"go.uber.org/yarpc/api/transport"
 	yarpctls "go.uber.org/yarpc/api/transport/tls"
 	"go.uber.org/yarpc/encoding/json"
@@ -92,6 +93,53 @@ func TestInboundTLS(t *testing.T) {
 	}
 }
 
+func TestInboundTLSPeerIdentity(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	scenario := testscenario.Create(t, time.Minute, time.Minute)
+	tests := []struct {
+		desc            string
+		outboundOptions []OutboundOption
+		want            string
+	}{
+		{
+			desc:            "tls_client",
+			outboundOptions: []OutboundOption{OutboundTLSConfiguration(scenario.ClientTLSConfig())},
+			want:            testscenario.ClientSPIFFEID,
+		},
+		{
+			desc: "plaintext_client",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			handler := func(ctx context.Context, _ *testFooRequest) (*testFooResponse, error) {
+				var res testFooResponse
+				if id := encoding.CallFromContext(ctx).PeerIdentity(); id != nil {
+					res.One = id.SPIFFEID
+				}
+				return &res, nil
+			}
+			doWithTestEnv(t, testEnvOptions{
+				Procedures: json.Procedure("testFoo", handler),
+				InboundOptions: []InboundOption{
+					InboundTLSConfiguration(scenario.ServerTLSConfig()),
+					InboundTLSMode(yarpctls.Permissive),
+				},
+				OutboundOptions: tt.outboundOptions,
+			}, func(t *testing.T, testEnv *testEnv) {
+				client := json.New(testEnv.ClientConfig)
+				var response testFooResponse
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+
+				require.NoError(t, client.Call(ctx, "testFoo", &testFooRequest{}, &response))
+				assert.Equal(t, tt.want, response.One)
+			})
+		})
+	}
+}
+
 func TestOutboundTLS(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
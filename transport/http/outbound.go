@@ -48,6 +48,7 @@ import (
 	"go.uber.org/yarpc/internal/interceptor/outboundinterceptor"
 	intyarpcerrors "go.uber.org/yarpc/internal/yarpcerrors"
 	peerchooser "go.uber.org/yarpc/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hostport"
 	"go.uber.org/yarpc/pkg/lifecycle"
 	"go.uber.org/yarpc/transport/internal/tls/dialer"
@@ -59,6 +60,7 @@ var (
 	_ transport.Namer                      = (*Outbound)(nil)
 	_ transport.UnaryOutbound              = (*Outbound)(nil)
 	_ transport.OnewayOutbound             = (*Outbound)(nil)
+	_ transport.StreamOutbound             = (*Outbound)(nil)
 	_ introspection.IntrospectableOutbound = (*Outbound)(nil)
 )
 
@@ -109,6 +111,22 @@ func OutboundTLSConfiguration(config *tls.Config) OutboundOption {
 	}
 }
 
+// OutboundCompressor returns an OutboundOption that compresses the bodies of
+// unary and oneway requests with the given compressor, and asks the inbound
+// to compress response bodies with the same compressor.
+//
+// The outbound sends the name of the compressor in the Content-Encoding and
+// Accept-Encoding headers.
+// Inbounds reject requests compressed with a compressor they were not
+// configured with, so the inbounds of a service must support the compressor
+// before its callers enable it.
+// Inbounds that do not support the compressor send uncompressed responses.
+func OutboundCompressor(compressor transport.Compressor) OutboundOption {
+	return func(o *Outbound) {
+		o.compressor = compressor
+	}
+}
+
 // OutboundDestinationServiceName returns a OutboundOption which provides the
 // name of the destination service. Mostly used in outbound TLS dialer metrics.
 func OutboundDestinationServiceName(name string) OutboundOption {
@@ -165,9 +183,22 @@ func (t *Transport) NewOutbound(chooser peer.Chooser, opts ...OutboundOption) *O
 	o.sender = &transportSender{Client: client}
 	o.unaryCallWithInterceptor = outboundinterceptor.NewUnaryChain(o, t.unaryOutboundInterceptor)
 	o.onewayCallWithInterceptor = outboundinterceptor.NewOnewayChain(o, t.onewayOutboundInterceptor)
+	o.streamCallWithInterceptor = outboundinterceptor.NewStreamChain(o, t.streamOutboundInterceptor)
+
+	// Peer lists that send health checks send them through the outbound,
+	// so that they reach peers the same way requests do.
+	if l, ok := chooser.(proberSetter); ok {
+		l.SetProber(o)
+	}
 	return o
 }
 
+// proberSetter is implemented by peer lists that send health checks to their
+// peers, like those built on "go.uber.org/yarpc/peer/abstractlist".
+type proberSetter interface {
+	SetProber(abstractlist.Prober)
+}
+
 func createHTTP1Client(o *Outbound) *http.Client {
 	if o.tlsConfig != nil {
 		return createHTTP1TLSClient(o)
@@ -244,6 +275,7 @@ func (t *Transport) NewSingleOutbound(uri string, opts ...OutboundOption) *Outbo
 	o := t.NewOutbound(chooser, opts...)
 	o.unaryCallWithInterceptor = outboundinterceptor.NewUnaryChain(o, t.unaryOutboundInterceptor)
 	o.onewayCallWithInterceptor = outboundinterceptor.NewOnewayChain(o, t.onewayOutboundInterceptor)
+	o.streamCallWithInterceptor = outboundinterceptor.NewStreamChain(o, t.streamOutboundInterceptor)
 	return o
 }
 
@@ -271,7 +303,9 @@ type Outbound struct {
 	tlsConfig                 *tls.Config
 	unaryCallWithInterceptor  interceptor.UnaryOutboundChain
 	onewayCallWithInterceptor interceptor.OnewayOutboundChain
+	streamCallWithInterceptor interceptor.StreamOutboundChain
 	useHTTP2                  bool
+	compressor                transport.Compressor
 }
 
 // TransportName is the transport name that will be set on `transport.Request` struct.
@@ -354,6 +388,107 @@ func (o *Outbound) DirectCallOneway(ctx context.Context, treq *transport.Request
 	return time.Now(), nil
 }
 
+// CallStream implements transport.StreamOutbound.
+//
+// Streams are bidirectional over HTTP/2.
+// Over HTTP/1.1, messages are sent with chunked encoding in both directions,
+// which supports server streaming through any proxy, but client and
+// bidirectional streaming only through proxies that forward request bodies
+// without buffering them.
+func (o *Outbound) CallStream(ctx context.Context, req *transport.StreamRequest) (*transport.ClientStream, error) {
+	return o.streamCallWithInterceptor.Next(ctx, req)
+}
+
+// DirectCallStream starts a stream over HTTP.
+func (o *Outbound) DirectCallStream(ctx context.Context, req *transport.StreamRequest) (*transport.ClientStream, error) {
+	if req == nil || req.Meta == nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("stream request for http outbound requires request metadata")
+	}
+	if err := o.once.WaitUntilRunning(ctx); err != nil {
+		return nil, intyarpcerrors.AnnotateWithInfo(
+			yarpcerrors.FromError(err),
+			"error waiting for HTTP outbound to start for service: %s",
+			req.Meta.Service)
+	}
+	return o.stream(ctx, req, time.Now())
+}
+
+func (o *Outbound) stream(ctx context.Context, req *transport.StreamRequest, start time.Time) (*transport.ClientStream, error) {
+	treq := req.Meta.ToRequest()
+	if err := transport.ValidateRequest(treq); err != nil {
+		return nil, err
+	}
+
+	// Unlike unary requests, a stream does not require a deadline.
+	var ttl time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		ttl = deadline.Sub(start)
+	}
+
+	p, onFinish, err := o.getPeerForRequest(ctx, treq)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	newURL := *o.urlTemplate
+	hreq, err := http.NewRequest("POST", newURL.String(), pr)
+	if err != nil {
+		onFinish(err)
+		return nil, err
+	}
+	// The body has an unknown length, so it must be sent in chunks.
+	hreq.ContentLength = -1
+	headers := applicationHeaders.deleteHTTP2PseudoHeadersIfNeeded(treq.Headers)
+	hreq.Header = applicationHeaders.ToHTTPHeaders(headers, nil)
+
+	ctx, hreq, span, err := o.withOpentracingSpan(ctx, hreq, treq, start)
+	if err != nil {
+		span.Finish()
+		onFinish(err)
+		return nil, err
+	}
+	hreq = o.withCoreHeaders(hreq, treq, ttl)
+	hreq.Header.Set(StreamHeader, AcceptTrue)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := newClientStream(ctx, cancel, req, pw, span, onFinish)
+	go func() {
+		// The request body is written as the stream sends messages, so the
+		// response may not arrive until the stream has sent them all.
+		hres, err := o.doWithPeer(ctx, hreq, treq, start, ttl, p, o.sender)
+		if err == nil {
+			err = streamResponseError(treq, hres)
+		}
+		stream.respond(hres, err)
+	}()
+
+	tClientStream, err := transport.NewClientStream(stream)
+	if err != nil {
+		_ = stream.closeWithErr(err)
+		return nil, err
+	}
+	return tClientStream, nil
+}
+
+// streamResponseError returns the error for a stream response that failed
+// before the server started the stream, closing the response body.
+func streamResponseError(treq *transport.Request, response *http.Response) error {
+	if match, resSvcName := checkServiceMatch(treq.Service, response.Header); !match {
+		_ = response.Body.Close()
+		return yarpcerrors.InternalErrorf("service name sent from the request "+
+			"does not match the service name received in the response, sent %q, got: %q", treq.Service, resSvcName)
+	}
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	bothResponseError := response.Header.Get(BothResponseErrorHeader) == AcceptTrue
+	_, err := getYARPCErrorFromResponse(&transport.Response{}, response, bothResponseError)
+	_ = response.Body.Close()
+	return err
+}
+
 func (o *Outbound) call(ctx context.Context, treq *transport.Request) (*transport.Response, error) {
 	start := time.Now()
 	deadline, ok := ctx.Deadline()
@@ -362,7 +497,7 @@ func (o *Outbound) call(ctx context.Context, treq *transport.Request) (*transpor
 	}
 	ttl := deadline.Sub(start)
 
-	hreq, err := o.createRequest(treq)
+	hreq, err := o.createCompressedRequest(treq)
 	if err != nil {
 		return nil, err
 	}
@@ -394,9 +529,15 @@ func (o *Outbound) call(ctx context.Context, treq *transport.Request) (*transpor
 				"does not match the service name received in the response, sent %q, got: %q", treq.Service, resSvcName))
 	}
 
+	body, err := o.responseBody(response)
+	if err != nil {
+		_ = response.Body.Close()
+		return nil, transport.UpdateSpanWithErr(span, err)
+	}
+
 	tres := &transport.Response{
 		Headers:          applicationHeaders.FromHTTPHeaders(response.Header, transport.NewHeaders()),
-		Body:             response.Body,
+		Body:             body,
 		BodySize:         int(response.ContentLength),
 		ApplicationError: response.Header.Get(ApplicationStatusHeader) == ApplicationErrorStatus,
 		ApplicationErrorMeta: &transport.ApplicationErrorMeta{
@@ -433,6 +574,18 @@ func getYARPCApplicationErrorCode(code string) *yarpcerrors.Code {
 	return &yarpcCode
 }
 
+// Probe sends a unary request directly to the given peer, without consulting
+// the outbound's peer chooser. The request is sent with the outbound's
+// settings, like its URL template, TLS configuration and headers.
+//
+// Peer lists owned by the outbound use Probe to send health checks to each
+// of their peers.
+func (o *Outbound) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	probe := *o
+	probe.chooser = probeChooser{p}
+	return probe.call(ctx, req)
+}
+
 func (o *Outbound) getPeerForRequest(ctx context.Context, treq *transport.Request) (*httpPeer, func(error), error) {
 	p, onFinish, err := o.chooser.Choose(ctx, treq)
 	if err != nil {
@@ -450,6 +603,54 @@ func (o *Outbound) getPeerForRequest(ctx context.Context, treq *transport.Reques
 	return hpPeer, onFinish, nil
 }
 
+// createCompressedRequest creates an HTTP request with a compressed body if
+// the outbound has a compressor.
+func (o *Outbound) createCompressedRequest(treq *transport.Request) (*http.Request, error) {
+	if o.compressor == nil {
+		return o.createRequest(treq)
+	}
+
+	compressed := *treq
+	if treq.Body != nil {
+		buf, n, err := compress(o.compressor, treq.Body)
+		if err != nil {
+			return nil, yarpcerrors.InternalErrorf("failed to compress request body with %q: %v", o.compressor.Name(), err)
+		}
+		o.transport.getCompressionMetrics().observe(o.compressor.Name(), "request", n, int64(buf.Len()))
+		compressed.Body = bytes.NewReader(buf.Bytes())
+		compressed.BodySize = buf.Len()
+	}
+
+	hreq, err := o.createRequest(&compressed)
+	if err != nil {
+		return nil, err
+	}
+	if treq.Body != nil {
+		hreq.Header.Set(contentEncodingHeader, o.compressor.Name())
+	}
+	hreq.Header.Set(acceptEncodingHeader, o.compressor.Name())
+	return hreq, nil
+}
+
+// responseBody returns the body of the response, decompressed if the inbound
+// compressed it with the outbound's compressor.
+func (o *Outbound) responseBody(response *http.Response) (io.ReadCloser, error) {
+	coding := response.Header.Get(contentEncodingHeader)
+	if coding == "" || o.compressor == nil || coding != o.compressor.Name() {
+		return response.Body, nil
+	}
+	body, err := decompress(o.compressor, response.Body, func(uncompressed, compressed int64) {
+		o.transport.getCompressionMetrics().observe(coding, "response", uncompressed, compressed)
+	})
+	if err != nil {
+		return nil, yarpcerrors.InternalErrorf("failed to decompress response body with %q: %v", coding, err)
+	}
+	// Like net/http's transparent decompression, the decompressed size is
+	// unknown until the body is read.
+	response.ContentLength = -1
+	return body, nil
+}
+
 func (o *Outbound) createRequest(treq *transport.Request) (*http.Request, error) {
 	newURL := *o.urlTemplate
 
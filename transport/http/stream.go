@@ -0,0 +1,447 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/atomic"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/iopool"
+	intyarpcerrors "go.uber.org/yarpc/internal/yarpcerrors"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Messages on HTTP streams are framed with a 4-byte big-endian length prefix.
+const (
+	_streamFrameHeaderSize  = 4
+	_maxStreamMessageSize   = 64 * 1024 * 1024
+	_streamTrailerSeparator = ", "
+)
+
+// _streamTrailers are the trailers a server declares for every stream so
+// that it can report an error after it has started sending messages.
+var _streamTrailers = strings.Join([]string{
+	ErrorCodeHeader,
+	ErrorNameHeader,
+	ErrorMessageHeader,
+	ErrorDetailsHeader,
+}, _streamTrailerSeparator)
+
+var (
+	_ transport.StreamHeadersSender = (*serverStream)(nil)
+	_ transport.StreamHeadersReader = (*clientStream)(nil)
+)
+
+// writeStreamMessage writes a length-prefixed message and releases its body.
+func writeStreamMessage(w io.Writer, msg *transport.StreamMessage) error {
+	body, err := readStreamMessage(msg)
+	if err != nil {
+		return err
+	}
+	if len(body) > _maxStreamMessageSize {
+		return yarpcerrors.ResourceExhaustedErrorf(
+			"stream message of %d bytes exceeds the limit of %d bytes", len(body), _maxStreamMessageSize)
+	}
+
+	var header [_streamFrameHeaderSize]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(body)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+func readStreamMessage(msg *transport.StreamMessage) ([]byte, error) {
+	if msg == nil || msg.Body == nil {
+		return nil, nil
+	}
+	defer msg.Body.Close()
+
+	var buf bytes.Buffer
+	if msg.BodySize > 0 {
+		buf.Grow(msg.BodySize)
+	}
+	if _, err := iopool.Copy(&buf, msg.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readStreamFrame reads a length-prefixed message.
+// It returns io.EOF if the stream ended cleanly between messages.
+func readStreamFrame(r io.Reader) (*transport.StreamMessage, error) {
+	var header [_streamFrameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, yarpcerrors.InternalErrorf("stream ended in the middle of a message header")
+		}
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > _maxStreamMessageSize {
+		return nil, yarpcerrors.ResourceExhaustedErrorf(
+			"stream message of %d bytes exceeds the limit of %d bytes", size, _maxStreamMessageSize)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, yarpcerrors.InternalErrorf("stream ended in the middle of a message")
+		}
+		return nil, err
+	}
+	return &transport.StreamMessage{
+		Body:     io.NopCloser(bytes.NewReader(body)),
+		BodySize: len(body),
+	}, nil
+}
+
+// serverStream implements transport.Stream over an HTTP request and its
+// response.
+type serverStream struct {
+	ctx  context.Context
+	req  *transport.StreamRequest
+	body io.Reader
+	w    http.ResponseWriter
+	rc   *http.ResponseController
+
+	recvLock sync.Mutex
+
+	sendLock    sync.Mutex
+	wroteHeader bool
+}
+
+func newServerStream(ctx context.Context, req *transport.StreamRequest, w http.ResponseWriter, body io.Reader) *serverStream {
+	return &serverStream{
+		ctx:  ctx,
+		req:  req,
+		body: body,
+		w:    w,
+		rc:   http.NewResponseController(w),
+	}
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
+
+func (ss *serverStream) Request() *transport.StreamRequest {
+	return ss.req
+}
+
+func (ss *serverStream) SendHeaders(headers transport.Headers) error {
+	ss.sendLock.Lock()
+	defer ss.sendLock.Unlock()
+
+	if ss.wroteHeader {
+		return yarpcerrors.FailedPreconditionErrorf("stream headers have already been sent")
+	}
+	applicationHeaders.ToHTTPHeaders(headers, ss.w.Header())
+	return ss.writeHeader()
+}
+
+func (ss *serverStream) SendMessage(_ context.Context, msg *transport.StreamMessage) error {
+	ss.sendLock.Lock()
+	defer ss.sendLock.Unlock()
+
+	if !ss.wroteHeader {
+		if err := ss.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if err := writeStreamMessage(ss.w, msg); err != nil {
+		return toStreamError(ss.ctx, err)
+	}
+	return toStreamError(ss.ctx, ss.rc.Flush())
+}
+
+func (ss *serverStream) ReceiveMessage(context.Context) (*transport.StreamMessage, error) {
+	ss.recvLock.Lock()
+	defer ss.recvLock.Unlock()
+
+	msg, err := readStreamFrame(ss.body)
+	if err != nil {
+		return nil, toStreamError(ss.ctx, err)
+	}
+	return msg, nil
+}
+
+// writeHeader must be called under the send lock.
+func (ss *serverStream) writeHeader() error {
+	ss.wroteHeader = true
+	ss.w.Header().Set("Trailer", _streamTrailers)
+	ss.w.WriteHeader(http.StatusOK)
+	return toStreamError(ss.ctx, ss.rc.Flush())
+}
+
+// finish ends the stream with the handler's error.
+//
+// If the stream has not sent its headers yet, finish returns the error so it
+// can be sent as an ordinary HTTP error response.
+// Otherwise, the error is sent in the stream's trailers.
+func (ss *serverStream) finish(err error) error {
+	ss.sendLock.Lock()
+	defer ss.sendLock.Unlock()
+
+	if !ss.wroteHeader {
+		if err != nil {
+			return err
+		}
+		if err := ss.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if err == nil {
+		return nil
+	}
+
+	status := yarpcerrors.FromError(err)
+	code, marshalErr := status.Code().MarshalText()
+	if marshalErr != nil {
+		code = []byte(yarpcerrors.CodeInternal.String())
+	}
+	header := ss.w.Header()
+	header.Set(ErrorCodeHeader, string(code))
+	if status.Name() != "" {
+		header.Set(ErrorNameHeader, status.Name())
+	}
+	header.Set(ErrorMessageHeader, status.Message())
+	if details := status.Details(); details != nil {
+		header.Set(ErrorDetailsHeader, base64.StdEncoding.EncodeToString(details))
+	}
+	return nil
+}
+
+// clientStream implements transport.StreamCloser over an HTTP request whose
+// body is written while its response is read.
+type clientStream struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	req     *transport.StreamRequest
+	span    opentracing.Span
+	release func(error)
+	closed  atomic.Bool
+	done    chan struct{}
+
+	sendLock sync.Mutex
+	pw       *io.PipeWriter
+
+	// ready is closed once the response headers arrive or the request fails.
+	ready    chan struct{}
+	res      *http.Response
+	resErr   error
+	recvLock sync.Mutex
+}
+
+func newClientStream(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	req *transport.StreamRequest,
+	pw *io.PipeWriter,
+	span opentracing.Span,
+	release func(error),
+) *clientStream {
+	cs := &clientStream{
+		ctx:     ctx,
+		cancel:  cancel,
+		req:     req,
+		pw:      pw,
+		span:    span,
+		release: release,
+		done:    make(chan struct{}),
+		ready:   make(chan struct{}),
+	}
+	go cs.watch()
+	return cs
+}
+
+// watch ends the stream if its context finishes first.
+func (cs *clientStream) watch() {
+	select {
+	case <-cs.ctx.Done():
+		_ = cs.closeWithErr(toStreamError(cs.ctx, cs.ctx.Err()))
+	case <-cs.done:
+	}
+}
+
+// respond records the outcome of the HTTP request.
+func (cs *clientStream) respond(res *http.Response, err error) {
+	cs.res = res
+	cs.resErr = err
+	close(cs.ready)
+	if err != nil {
+		_ = cs.closeWithErr(err)
+	} else if cs.closed.Load() {
+		// The stream ended before the response arrived.
+		_ = res.Body.Close()
+	}
+}
+
+func (cs *clientStream) Context() context.Context {
+	return cs.ctx
+}
+
+func (cs *clientStream) Request() *transport.StreamRequest {
+	return cs.req
+}
+
+func (cs *clientStream) SendMessage(_ context.Context, msg *transport.StreamMessage) error {
+	if cs.closed.Load() {
+		return io.EOF
+	}
+
+	cs.sendLock.Lock()
+	defer cs.sendLock.Unlock()
+
+	if err := writeStreamMessage(cs.pw, msg); err != nil {
+		if cs.closed.Load() {
+			return io.EOF
+		}
+		return toStreamError(cs.ctx, err)
+	}
+	return nil
+}
+
+func (cs *clientStream) ReceiveMessage(ctx context.Context) (*transport.StreamMessage, error) {
+	res, err := cs.response(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.recvLock.Lock()
+	defer cs.recvLock.Unlock()
+
+	msg, err := readStreamFrame(res.Body)
+	if err == io.EOF {
+		// The trailers are only available once the body has been read to
+		// the end.
+		return nil, cs.closeWithErr(trailerError(res.Trailer))
+	}
+	if err != nil {
+		return nil, cs.closeWithErr(toStreamError(cs.ctx, err))
+	}
+	return msg, nil
+}
+
+// Close closes the sending side of the stream, signaling to the server that
+// there are no more messages.
+func (cs *clientStream) Close(context.Context) error {
+	cs.sendLock.Lock()
+	defer cs.sendLock.Unlock()
+
+	return cs.pw.Close()
+}
+
+func (cs *clientStream) Headers() (transport.Headers, error) {
+	res, err := cs.response(cs.ctx)
+	if err != nil {
+		return transport.NewHeaders(), err
+	}
+	return applicationHeaders.FromHTTPHeaders(res.Header, transport.NewHeaders()), nil
+}
+
+// response waits for the response headers.
+func (cs *clientStream) response(ctx context.Context) (*http.Response, error) {
+	// Prefer a response that has already arrived: the stream context is
+	// cancelled once the stream ends, and the outcome must not be masked.
+	select {
+	case <-cs.ready:
+	default:
+		select {
+		case <-cs.ready:
+		case <-ctx.Done():
+			return nil, toStreamError(ctx, ctx.Err())
+		}
+	}
+	if cs.resErr != nil {
+		return nil, cs.resErr
+	}
+	return cs.res, nil
+}
+
+// closeWithErr ends the stream, returning io.EOF in place of a nil error.
+func (cs *clientStream) closeWithErr(err error) error {
+	if !cs.closed.Swap(true) {
+		close(cs.done)
+		_ = cs.pw.CloseWithError(io.EOF)
+		cs.cancel()
+		select {
+		case <-cs.ready:
+			if cs.res != nil {
+				_ = cs.res.Body.Close()
+			}
+		default:
+			// respond closes the body if it arrives later.
+		}
+		err = transport.UpdateSpanWithErr(cs.span, err)
+		cs.span.Finish()
+		cs.release(err)
+	}
+	if err == nil {
+		return io.EOF
+	}
+	return err
+}
+
+// trailerError returns the error a server sent in stream trailers, if any.
+func trailerError(trailer http.Header) error {
+	codeText := trailer.Get(ErrorCodeHeader)
+	if codeText == "" {
+		return nil
+	}
+
+	var code yarpcerrors.Code
+	if err := code.UnmarshalText([]byte(codeText)); err != nil {
+		code = yarpcerrors.CodeUnknown
+	}
+	err := intyarpcerrors.NewWithNamef(code, trailer.Get(ErrorNameHeader), "%s", trailer.Get(ErrorMessageHeader))
+	if encoded := trailer.Get(ErrorDetailsHeader); encoded != "" {
+		if details, decodeErr := base64.StdEncoding.DecodeString(encoded); decodeErr == nil {
+			err = err.WithDetails(details)
+		}
+	}
+	return err
+}
+
+// toStreamError converts errors from reading and writing stream bodies into
+// YARPC errors.
+func toStreamError(ctx context.Context, err error) error {
+	if err == nil || err == io.EOF || yarpcerrors.IsStatus(err) {
+		return err
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return yarpcerrors.DeadlineExceededErrorf("stream deadline exceeded: %v", err)
+	case context.Canceled:
+		return yarpcerrors.CancelledErrorf("stream cancelled: %v", err)
+	}
+	return yarpcerrors.UnavailableErrorf("stream failed: %v", err)
+}
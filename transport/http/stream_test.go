@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+type streamHandlerFunc func(*transport.ServerStream) error
+
+func (f streamHandlerFunc) HandleStream(s *transport.ServerStream) error { return f(s) }
+
+func newStreamMessage(body string) *transport.StreamMessage {
+	return &transport.StreamMessage{
+		Body:     io.NopCloser(bytes.NewReader([]byte(body))),
+		BodySize: len(body),
+	}
+}
+
+func readStreamBody(t *testing.T, msg *transport.StreamMessage) string {
+	b, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	require.NoError(t, msg.Body.Close())
+	return string(b)
+}
+
+func echoStreamHandler(s *transport.ServerStream) error {
+	if err := s.SendHeaders(transport.NewHeaders().With("echo", "true")); err != nil {
+		return err
+	}
+	for {
+		msg, err := s.ReceiveMessage(s.Context())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.SendMessage(s.Context(), msg); err != nil {
+			return err
+		}
+	}
+}
+
+// startStreamServer starts an inbound serving the given stream procedures and
+// an outbound connected to it.
+func startStreamServer(t *testing.T, useHTTP2 bool, procedures ...transport.Procedure) *Outbound {
+	trans := NewTransport()
+	require.NoError(t, trans.Start())
+	t.Cleanup(func() { assert.NoError(t, trans.Stop()) })
+
+	inbound := trans.NewInbound("127.0.0.1:0")
+	inbound.SetRouter(newTestRouter(procedures))
+	require.NoError(t, inbound.Start())
+	t.Cleanup(func() { assert.NoError(t, inbound.Stop()) })
+
+	opts := []OutboundOption{}
+	if useHTTP2 {
+		opts = append(opts, UseHTTP2())
+	}
+	outbound := trans.NewSingleOutbound("http://"+inbound.Addr().String(), opts...)
+	require.NoError(t, outbound.Start())
+	t.Cleanup(func() { assert.NoError(t, outbound.Stop()) })
+	return outbound
+}
+
+func newStreamRequest(procedure string) *transport.StreamRequest {
+	return &transport.StreamRequest{
+		Meta: &transport.RequestMeta{
+			Caller:    "caller",
+			Service:   "service",
+			Encoding:  "raw",
+			Procedure: procedure,
+		},
+	}
+}
+
+func TestStreamEcho(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		useHTTP2 bool
+	}{
+		{name: "http1", useHTTP2: false},
+		{name: "http2", useHTTP2: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			outbound := startStreamServer(t, tt.useHTTP2, transport.Procedure{
+				Name:        "echo",
+				HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(echoStreamHandler)),
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+			defer cancel()
+
+			stream, err := outbound.CallStream(ctx, newStreamRequest("echo"))
+			require.NoError(t, err)
+
+			headers, err := stream.Headers()
+			require.NoError(t, err)
+			echo, ok := headers.Get("echo")
+			assert.True(t, ok)
+			assert.Equal(t, "true", echo)
+
+			for _, body := range []string{"foo", "", "bar"} {
+				require.NoError(t, stream.SendMessage(ctx, newStreamMessage(body)))
+				msg, err := stream.ReceiveMessage(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, body, readStreamBody(t, msg))
+			}
+
+			require.NoError(t, stream.Close(ctx))
+			_, err = stream.ReceiveMessage(ctx)
+			assert.Equal(t, io.EOF, err)
+			assert.Equal(t, io.EOF, stream.SendMessage(ctx, newStreamMessage("late")))
+		})
+	}
+}
+
+func TestStreamServerStreaming(t *testing.T) {
+	outbound := startStreamServer(t, false, transport.Procedure{
+		Name: "count",
+		HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(func(s *transport.ServerStream) error {
+			msg, err := s.ReceiveMessage(s.Context())
+			if err != nil {
+				return err
+			}
+			body, err := io.ReadAll(msg.Body)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < 3; i++ {
+				if err := s.SendMessage(s.Context(), newStreamMessage(string(body))); err != nil {
+					return err
+				}
+			}
+			return nil
+		})),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	stream, err := outbound.CallStream(ctx, newStreamRequest("count"))
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMessage(ctx, newStreamMessage("hi")))
+	require.NoError(t, stream.Close(ctx))
+
+	for i := 0; i < 3; i++ {
+		msg, err := stream.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "hi", readStreamBody(t, msg))
+	}
+	_, err = stream.ReceiveMessage(ctx)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestStreamErrors(t *testing.T) {
+	outbound := startStreamServer(t, true,
+		transport.Procedure{
+			Name: "fail-after-message",
+			HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(func(s *transport.ServerStream) error {
+				if err := s.SendMessage(s.Context(), newStreamMessage("first")); err != nil {
+					return err
+				}
+				return yarpcerrors.Newf(yarpcerrors.CodeInvalidArgument, "bad stream").WithDetails([]byte{0, 1, 2})
+			})),
+		},
+		transport.Procedure{
+			Name: "fail-immediately",
+			HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(func(s *transport.ServerStream) error {
+				return yarpcerrors.PermissionDeniedErrorf("denied")
+			})),
+		},
+		transport.Procedure{
+			Name: "unary",
+			// The handler must not be called for streams.
+			HandlerSpec: transport.NewUnaryHandlerSpec(panickedHandler{}),
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	t.Run("error in trailers", func(t *testing.T) {
+		stream, err := outbound.CallStream(ctx, newStreamRequest("fail-after-message"))
+		require.NoError(t, err)
+
+		msg, err := stream.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "first", readStreamBody(t, msg))
+
+		_, err = stream.ReceiveMessage(ctx)
+		require.Error(t, err)
+		status := yarpcerrors.FromError(err)
+		assert.Equal(t, yarpcerrors.CodeInvalidArgument, status.Code())
+		assert.Equal(t, "bad stream", status.Message())
+		assert.Equal(t, []byte{0, 1, 2}, status.Details())
+	})
+
+	t.Run("error before the stream starts", func(t *testing.T) {
+		stream, err := outbound.CallStream(ctx, newStreamRequest("fail-immediately"))
+		require.NoError(t, err)
+
+		_, err = stream.ReceiveMessage(ctx)
+		assert.Equal(t, yarpcerrors.CodePermissionDenied, yarpcerrors.FromError(err).Code())
+		_, err = stream.Headers()
+		assert.Equal(t, yarpcerrors.CodePermissionDenied, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("unary procedure", func(t *testing.T) {
+		stream, err := outbound.CallStream(ctx, newStreamRequest("unary"))
+		require.NoError(t, err)
+
+		_, err = stream.ReceiveMessage(ctx)
+		assert.Equal(t, yarpcerrors.CodeUnimplemented, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("unary request to stream procedure", func(t *testing.T) {
+		_, err := outbound.Call(ctx, &transport.Request{
+			Caller:    "caller",
+			Service:   "service",
+			Encoding:  "raw",
+			Procedure: "fail-immediately",
+			Body:      bytes.NewReader(nil),
+		})
+		assert.Equal(t, yarpcerrors.CodeUnimplemented, yarpcerrors.FromError(err).Code())
+	})
+}
+
+func TestStreamContextCancelled(t *testing.T) {
+	outbound := startStreamServer(t, true, transport.Procedure{
+		Name:        "echo",
+		HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(echoStreamHandler)),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := outbound.CallStream(ctx, newStreamRequest("echo"))
+	require.NoError(t, err)
+	_, err = stream.Headers()
+	require.NoError(t, err)
+
+	cancel()
+	_, err = stream.ReceiveMessage(context.Background())
+	assert.Equal(t, yarpcerrors.CodeCancelled, yarpcerrors.FromError(err).Code())
+}
+
+func TestReadStreamFrame(t *testing.T) {
+	frame := func(size uint32, body string) io.Reader {
+		var buf bytes.Buffer
+		var header [_streamFrameHeaderSize]byte
+		binary.BigEndian.PutUint32(header[:], size)
+		buf.Write(header[:])
+		buf.WriteString(body)
+		return &buf
+	}
+
+	t.Run("clean end", func(t *testing.T) {
+		_, err := readStreamFrame(bytes.NewReader(nil))
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("truncated header", func(t *testing.T) {
+		_, err := readStreamFrame(bytes.NewReader([]byte{0, 0}))
+		assert.Equal(t, yarpcerrors.CodeInternal, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		_, err := readStreamFrame(frame(10, "short"))
+		assert.Equal(t, yarpcerrors.CodeInternal, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := readStreamFrame(frame(_maxStreamMessageSize+1, ""))
+		assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeStreamMessage(&buf, newStreamMessage("hello")))
+		msg, err := readStreamFrame(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, 5, msg.BodySize)
+		assert.Equal(t, "hello", readStreamBody(t, msg))
+	})
+}
@@ -287,6 +287,8 @@ func (o *transportOptions) newTransport() *Transport {
 		unaryOutbounds  []interceptor.UnaryOutbound
 		onewayInbounds  []interceptor.OnewayInbound
 		onewayOutbounds []interceptor.OnewayOutbound
+		streamInbounds  []interceptor.StreamInbound
+		streamOutbounds []interceptor.StreamOutbound
 	)
 	tracer := o.tracer
 	if o.tracingInterceptorEnabled {
@@ -298,6 +300,8 @@ func (o *transportOptions) newTransport() *Transport {
 		unaryOutbounds = append(unaryOutbounds, ti)
 		onewayInbounds = append(onewayInbounds, ti)
 		onewayOutbounds = append(onewayOutbounds, ti)
+		streamInbounds = append(streamInbounds, ti)
+		streamOutbounds = append(streamOutbounds, ti)
 
 		tracer = opentracing.NoopTracer{}
 	}
@@ -317,6 +321,8 @@ func (o *transportOptions) newTransport() *Transport {
 		unaryOutboundInterceptor:  unaryOutbounds,
 		onewayInboundInterceptor:  inboundmiddleware.OnewayChain(onewayInbounds...),
 		onewayOutboundInterceptor: onewayOutbounds,
+		streamInboundInterceptor:  inboundmiddleware.StreamChain(streamInbounds...),
+		streamOutboundInterceptor: streamOutbounds,
 		h1Transport:               buildH1Transport(o),
 		h2Transport:               buildH2Transport(o),
 	}
@@ -397,13 +403,31 @@ type Transport struct {
 	unaryOutboundInterceptor  []interceptor.UnaryOutbound
 	onewayInboundInterceptor  interceptor.OnewayInbound
 	onewayOutboundInterceptor []interceptor.OnewayOutbound
+	streamInboundInterceptor  interceptor.StreamInbound
+	streamOutboundInterceptor []interceptor.StreamOutbound
 
 	h1Transport *http.Transport
 	h2Transport *http2.Transport
+
+	compressionMetricsOnce sync.Once
+	compressionMetrics     *compressionMetrics
+
+	probeOutboundOnce sync.Once
+	probeOutbound     *Outbound
 }
 
 var _ transport.Transport = (*Transport)(nil)
 
+// getCompressionMetrics returns the compression metrics of the transport,
+// registering them with the meter the first time an inbound or outbound
+// uses compression.
+func (a *Transport) getCompressionMetrics() *compressionMetrics {
+	a.compressionMetricsOnce.Do(func() {
+		a.compressionMetrics = newCompressionMetrics(a.meter)
+	})
+	return a.compressionMetrics
+}
+
 // Start starts the HTTP transport.
 func (a *Transport) Start() error {
 	return a.once.Start(func() error {
@@ -474,3 +498,32 @@ func (a *Transport) ReleasePeer(pid peer.Identifier, sub peer.Subscriber) error
 
 	return nil
 }
+
+// Probe sends a unary request directly to a peer retained from this
+// transport, without consulting a peer chooser.
+// Peer lists use Probe to send health checks to each of their peers, unless
+// they are owned by an outbound, which probes peers with its own settings.
+//
+// Probe sends requests over HTTP/1.1 without TLS, to the root path.
+func (a *Transport) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	a.probeOutboundOnce.Do(func() {
+		// The outbound only lends its settings and client to probes, which
+		// choose their own peer, so it never has to stop.
+		a.probeOutbound = a.NewOutbound(probeChooser{})
+		_ = a.probeOutbound.Start()
+	})
+	return a.probeOutbound.Probe(ctx, p, req)
+}
+
+// probeChooser always chooses the peer that a probe is addressed to.
+type probeChooser struct {
+	peer peer.Peer
+}
+
+func (c probeChooser) Choose(context.Context, *transport.Request) (peer.Peer, func(error), error) {
+	return c.peer, func(error) {}, nil
+}
+
+func (probeChooser) Start() error    { return nil }
+func (probeChooser) Stop() error     { return nil }
+func (probeChooser) IsRunning() bool { return true }
@@ -21,10 +21,14 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,9 +37,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/yarpc/api/peer"
 	. "go.uber.org/yarpc/api/peer/peertest"
+	ytransport "go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/internal/testtime"
 	ypeer "go.uber.org/yarpc/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/peer/roundrobin"
+	"go.uber.org/yarpc/yarpcerrors"
 )
 
 // NoJitter is a transport option only available in tests, to disable jitter
@@ -326,6 +334,104 @@ func TestDialContext(t *testing.T) {
 	assert.Contains(t, err.Error(), errMsg)
 }
 
+func TestTransportProbe(t *testing.T) {
+	var procedures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		procedures = append(procedures, r.Header.Get(ProcedureHeader))
+		if r.Header.Get(ProcedureHeader) == "hung" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport := NewTransport()
+	require.NoError(t, transport.Start())
+	defer func() { assert.NoError(t, transport.Stop()) }()
+
+	var _ abstractlist.Prober = transport
+
+	pid := hostport.Identify(strings.TrimPrefix(server.URL, "http://"))
+	sub := &testSubscriber{}
+	p, err := transport.RetainPeer(pid, sub)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, transport.ReleasePeer(pid, sub)) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	req := func(procedure string) *ytransport.Request {
+		return &ytransport.Request{
+			Caller:    "caller",
+			Service:   "service",
+			Procedure: procedure,
+			Encoding:  "raw",
+			Body:      bytes.NewReader(nil),
+		}
+	}
+
+	res, err := transport.Probe(ctx, p, req("health"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	require.NoError(t, res.Body.Close())
+	probeOutbound := transport.probeOutbound
+
+	_, err = transport.Probe(ctx, p, req("hung"))
+	assert.True(t, yarpcerrors.IsUnavailable(err), "unexpected error: %v", err)
+	assert.Same(t, probeOutbound, transport.probeOutbound, "probes must share an outbound")
+
+	assert.Equal(t, []string{"health", "hung"}, procedures)
+}
+
+func TestOutboundProbe(t *testing.T) {
+	type probe struct{ path, header string }
+	probes := make(chan probe, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(ProcedureHeader) == "health" {
+			select {
+			case probes <- probe{path: r.URL.Path, header: r.Header.Get("X-Foo")}:
+			default:
+			}
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport := NewTransport()
+	list := roundrobin.New(transport, roundrobin.HealthCheck(abstractlist.HealthCheckConfig{
+		Service:   "service",
+		Procedure: "health",
+		Encoding:  "raw",
+		Interval:  10 * time.Millisecond,
+	}))
+	outbound := transport.NewOutbound(list,
+		URLTemplate("http://host/yarpc"),
+		AddHeader("X-Foo", "bar"),
+	)
+	require.NoError(t, transport.Start())
+	defer func() { assert.NoError(t, transport.Stop()) }()
+	require.NoError(t, outbound.Start())
+	defer func() { assert.NoError(t, outbound.Stop()) }()
+
+	pid := hostport.Identify(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, list.Update(peer.ListUpdates{Additions: []peer.Identifier{pid}}))
+
+	select {
+	case p := <-probes:
+		assert.Equal(t, probe{path: "/yarpc", header: "bar"}, p,
+			"health checks must be sent with the outbound's settings")
+	case <-time.After(testtime.Second):
+		t.Fatal("timed out waiting for a health check")
+	}
+}
+
+type testSubscriber struct{}
+
+func (testSubscriber) NotifyStatusChanged(peer.Identifier) {}
+
 type testIdentifier struct {
 	id string
 }
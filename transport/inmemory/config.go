@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"fmt"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcconfig"
+)
+
+// TransportSpec returns a TransportSpec for the given in-memory Transport.
+// Register the same spec, or specs for the same Transport, with the
+// configurators of all dispatchers that should reach each other.
+//
+// See InboundConfig and OutboundConfig for details on the different
+// configuration parameters supported by this Transport.
+//
+// Any InboundOption or OutboundOption may be passed to this function. These
+// options will be applied BEFORE configuration parameters are interpreted.
+func TransportSpec(t *Transport, opts ...Option) yarpcconfig.TransportSpec {
+	if t == nil {
+		panic("an inmemory Transport is required")
+	}
+	ts := transportSpec{Transport: t}
+	for _, o := range opts {
+		switch opt := o.(type) {
+		case InboundOption:
+			ts.InboundOptions = append(ts.InboundOptions, opt)
+		case OutboundOption:
+			ts.OutboundOptions = append(ts.OutboundOptions, opt)
+		default:
+			panic(fmt.Sprintf("unknown option of type %T: %v", o, o))
+		}
+	}
+	return ts.Spec()
+}
+
+// transportSpec holds the configurable parts of the in-memory TransportSpec.
+type transportSpec struct {
+	Transport       *Transport
+	InboundOptions  []InboundOption
+	OutboundOptions []OutboundOption
+}
+
+func (ts *transportSpec) Spec() yarpcconfig.TransportSpec {
+	return yarpcconfig.TransportSpec{
+		Name:                TransportName,
+		BuildTransport:      ts.buildTransport,
+		BuildInbound:        ts.buildInbound,
+		BuildUnaryOutbound:  ts.buildUnaryOutbound,
+		BuildOnewayOutbound: ts.buildOnewayOutbound,
+		BuildStreamOutbound: ts.buildStreamOutbound,
+	}
+}
+
+// TransportConfig configures the in-memory Transport. It accepts no
+// parameters, and the section may be omitted from the transports section.
+type TransportConfig struct{}
+
+func (ts *transportSpec) buildTransport(*TransportConfig, *yarpcconfig.Kit) (transport.Transport, error) {
+	return ts.Transport, nil
+}
+
+// InboundConfig configures an in-memory Inbound.
+//
+//	inbounds:
+//	  inmemory:
+//	    address: keyvalue
+//	    compressors: [gzip]
+type InboundConfig struct {
+	// Address the inbound receives requests at. Defaults to the name of the
+	// service.
+	Address string `config:"address,interpolate"`
+
+	// Compressors are the names of the compressors, registered with the
+	// Configurator, that the inbound accepts for request bodies.
+	Compressors []string `config:"compressors"`
+}
+
+func (ts *transportSpec) buildInbound(ic *InboundConfig, t transport.Transport, k *yarpcconfig.Kit) (transport.Inbound, error) {
+	address := ic.Address
+	if address == "" {
+		address = k.ServiceName()
+	}
+
+	opts := append([]InboundOption(nil), ts.InboundOptions...)
+	if len(ic.Compressors) > 0 {
+		compressors := make([]transport.Compressor, 0, len(ic.Compressors))
+		for _, name := range ic.Compressors {
+			c := k.Compressor(name)
+			if c == nil {
+				return nil, fmt.Errorf("unknown compressor %q", name)
+			}
+			compressors = append(compressors, c)
+		}
+		opts = append(opts, InboundCompressors(compressors...))
+	}
+	return t.(*Transport).NewInbound(address, opts...), nil
+}
+
+// OutboundConfig configures an in-memory Outbound.
+//
+//	outbounds:
+//	  keyvalue:
+//	    inmemory:
+//	      address: keyvalue
+//	      compressor: gzip
+type OutboundConfig struct {
+	// Address of the inbound to send requests to. Defaults to the name of
+	// the service the outbound sends requests to.
+	Address string `config:"address,interpolate"`
+
+	// Compressor is the name of a compressor, registered with the
+	// Configurator, used to compress the bodies of Unary and Oneway
+	// requests.
+	Compressor string `config:"compressor"`
+}
+
+func (ts *transportSpec) buildOutbound(oc *OutboundConfig, t transport.Transport, k *yarpcconfig.Kit) (*Outbound, error) {
+	address := oc.Address
+	if address == "" {
+		address = k.OutboundServiceName()
+	}
+
+	opts := append([]OutboundOption(nil), ts.OutboundOptions...)
+	if oc.Compressor != "" {
+		c := k.Compressor(oc.Compressor)
+		if c == nil {
+			return nil, fmt.Errorf("unknown compressor %q", oc.Compressor)
+		}
+		opts = append(opts, OutboundCompressor(c))
+	}
+	return t.(*Transport).NewOutbound(address, opts...), nil
+}
+
+func (ts *transportSpec) buildUnaryOutbound(oc *OutboundConfig, t transport.Transport, k *yarpcconfig.Kit) (transport.UnaryOutbound, error) {
+	return ts.buildOutbound(oc, t, k)
+}
+
+func (ts *transportSpec) buildOnewayOutbound(oc *OutboundConfig, t transport.Transport, k *yarpcconfig.Kit) (transport.OnewayOutbound, error) {
+	return ts.buildOutbound(oc, t, k)
+}
+
+func (ts *transportSpec) buildStreamOutbound(oc *OutboundConfig, t transport.Transport, k *yarpcconfig.Kit) (transport.StreamOutbound, error) {
+	return ts.buildOutbound(oc, t, k)
+}
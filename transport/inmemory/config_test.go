@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc"
+	yarpcgzip "go.uber.org/yarpc/compressor/gzip"
+	"go.uber.org/yarpc/encoding/json"
+	"go.uber.org/yarpc/internal/whitespace"
+	"go.uber.org/yarpc/yarpcconfig"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+type echoBody struct {
+	Message string `json:"message"`
+}
+
+func TestTransportSpec(t *testing.T) {
+	tr := NewTransport()
+	cfg := yarpcconfig.New()
+	require.NoError(t, cfg.RegisterTransport(TransportSpec(tr)))
+	require.NoError(t, cfg.RegisterCompressor(yarpcgzip.New()))
+
+	server, err := cfg.NewDispatcherFromYAML("server", strings.NewReader(whitespace.Expand(`
+		inbounds:
+			inmemory:
+				compressors: [gzip]
+	`)))
+	require.NoError(t, err)
+	server.Register(json.Procedure("echo", func(ctx context.Context, req *echoBody) (*echoBody, error) {
+		assert.Equal(t, "client", yarpc.CallFromContext(ctx).Caller())
+		assert.Equal(t, "bar", yarpc.CallFromContext(ctx).Header("foo"))
+		if req.Message == "" {
+			return nil, yarpcerrors.InvalidArgumentErrorf("message is required")
+		}
+		return req, nil
+	}))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := cfg.NewDispatcherFromYAML("client", strings.NewReader(whitespace.Expand(`
+		outbounds:
+			server:
+				inmemory:
+					compressor: gzip
+	`)))
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	defer client.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	jsonClient := json.New(client.ClientConfig("server"))
+	var res echoBody
+	require.NoError(t, jsonClient.Call(ctx, "echo", &echoBody{Message: "hello"}, &res, yarpc.WithHeader("foo", "bar")))
+	assert.Equal(t, "hello", res.Message)
+
+	err = jsonClient.Call(ctx, "echo", &echoBody{}, &res, yarpc.WithHeader("foo", "bar"))
+	assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), "message is required")
+}
+
+func TestTransportSpecErrors(t *testing.T) {
+	assert.Panics(t, func() { TransportSpec(nil) })
+	assert.Panics(t, func() { TransportSpec(NewTransport(), Logger(nil)) })
+
+	cfg := yarpcconfig.New()
+	require.NoError(t, cfg.RegisterTransport(TransportSpec(NewTransport())))
+
+	_, err := cfg.LoadConfigFromYAML("server", strings.NewReader(whitespace.Expand(`
+		inbounds:
+			inmemory:
+				compressors: [snappy]
+	`)))
+	assert.ErrorContains(t, err, `unknown compressor "snappy"`)
+
+	_, err = cfg.LoadConfigFromYAML("client", strings.NewReader(whitespace.Expand(`
+		outbounds:
+			server:
+				inmemory:
+					compressor: snappy
+	`)))
+	assert.ErrorContains(t, err, `unknown compressor "snappy"`)
+}
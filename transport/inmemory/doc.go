@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package inmemory implements a YARPC transport that connects dispatchers
+// within the same process without opening sockets. It supports Unary, Oneway
+// and Streaming RPCs and is intended for hermetic integration tests of
+// services that call each other.
+//
+// # Usage
+//
+// All inbounds and outbounds that must reach each other have to be created
+// from the same Transport.
+//
+//	network := inmemory.NewTransport()
+//
+// An inbound listens at an address, which is an arbitrary name unique to the
+// Transport.
+//
+//	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+//		Name:     "myservice",
+//		Inbounds: yarpc.Inbounds{network.NewInbound("myservice")},
+//	})
+//
+// Outbounds send requests to the inbound listening at their address.
+//
+//	myserviceOutbound := network.NewOutbound("myservice")
+//	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+//		Name: "myclient",
+//		Outbounds: yarpc.Outbounds{
+//			"myservice": {
+//				Unary:  myserviceOutbound,
+//				Oneway: myserviceOutbound,
+//				Stream: myserviceOutbound,
+//			},
+//		},
+//	})
+//
+// # Semantics
+//
+// Requests are copied as they would be by a network transport: handlers
+// receive their own copy of the headers and body, and callers only see the
+// headers, body, application error metadata and error status of the
+// response. Unary and Oneway requests require a deadline, which becomes the
+// deadline of the handler, and callers stop waiting for handlers that outlive
+// it. Context values are not propagated from callers to handlers.
+//
+// Request and response bodies of Unary and Oneway requests may be compressed
+// with a Compressor to exercise compression without a network transport. See
+// OutboundCompressor and InboundCompressors.
+//
+// Stopping an Inbound waits for the requests it is handling to finish.
+//
+// # Configuration
+//
+// An in-memory Transport may be configured using YARPC's configuration
+// system by registering the TransportSpec for a Transport shared by all
+// dispatchers that should reach each other. See InboundConfig and
+// OutboundConfig for details.
+package inmemory
@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/pkg/lifecycle"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var _ transport.Inbound = (*Inbound)(nil)
+
+// Inbound receives requests made through Outbounds of the same Transport
+// to its address.
+type Inbound struct {
+	once        *lifecycle.Once
+	transport   *Transport
+	address     string
+	router      transport.Router
+	compressors map[string]transport.Compressor
+
+	// mu guards stopped so that no requests are started once Stop waits
+	// for the requests being handled.
+	mu       sync.RWMutex
+	stopped  bool
+	requests sync.WaitGroup
+}
+
+// NewInbound builds a new in-memory Inbound that receives requests made to
+// the given address.
+func (t *Transport) NewInbound(address string, opts ...InboundOption) *Inbound {
+	i := &Inbound{
+		once:        lifecycle.NewOnce(),
+		transport:   t,
+		address:     address,
+		compressors: make(map[string]transport.Compressor),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Address returns the address the Inbound receives requests at.
+func (i *Inbound) Address() string {
+	return i.address
+}
+
+// SetRouter configures a router to handle incoming requests.
+// This satisfies the transport.Inbound interface, and would be called
+// by a dispatcher when it starts.
+func (i *Inbound) SetRouter(router transport.Router) {
+	i.router = router
+}
+
+// Transports returns the transport the Inbound belongs to.
+func (i *Inbound) Transports() []transport.Transport {
+	return []transport.Transport{i.transport}
+}
+
+// Start starts receiving requests.
+func (i *Inbound) Start() error {
+	return i.once.Start(i.start)
+}
+
+func (i *Inbound) start() error {
+	if i.router == nil {
+		return yarpcerrors.InternalErrorf("no router configured for inmemory inbound at %q", i.address)
+	}
+	return i.transport.listen(i)
+}
+
+// Stop stops receiving requests and waits for the requests being handled,
+// including Oneway requests and streams, to finish.
+func (i *Inbound) Stop() error {
+	return i.once.Stop(i.stop)
+}
+
+func (i *Inbound) stop() error {
+	i.transport.unlisten(i)
+
+	i.mu.Lock()
+	i.stopped = true
+	i.mu.Unlock()
+
+	i.requests.Wait()
+	return nil
+}
+
+// IsRunning returns whether the Inbound is running.
+func (i *Inbound) IsRunning() bool {
+	return i.once.IsRunning()
+}
+
+// begin records the start of a request, which must be followed by a call to
+// i.requests.Done.
+func (i *Inbound) begin(meta *transport.RequestMeta) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if i.stopped {
+		return yarpcerrors.UnavailableErrorf(
+			"inmemory inbound at %q is stopped and cannot handle requests for service %q", i.address, meta.Service)
+	}
+	i.requests.Add(1)
+	return nil
+}
+
+// request builds the request received by handlers from the given wire
+// request.
+func (i *Inbound) request(wreq *wireRequest) (*transport.Request, transport.Compressor, error) {
+	treq := wreq.Meta.ToRequest()
+	treq.Transport = TransportName
+	if err := transport.ValidateRequest(treq); err != nil {
+		return nil, nil, err
+	}
+
+	body := wreq.Body
+	var compressor transport.Compressor
+	if name := wreq.Compressor; name != "" {
+		var ok bool
+		compressor, ok = i.compressors[name]
+		if !ok {
+			return nil, nil, yarpcerrors.InvalidArgumentErrorf("unsupported compressor %q", name)
+		}
+		var err error
+		body, err = decompress(compressor, body)
+		if err != nil {
+			return nil, nil, yarpcerrors.InvalidArgumentErrorf(
+				"failed to decompress request body with %q: %v", name, err)
+		}
+	}
+	treq.Body = bytes.NewReader(body)
+	treq.BodySize = len(body)
+	return treq, compressor, nil
+}
+
+// choose returns the handler for the request, which must be of the given
+// type.
+func (i *Inbound) choose(ctx context.Context, treq *transport.Request, rpcType transport.Type) (transport.HandlerSpec, error) {
+	spec, err := i.router.Choose(ctx, treq)
+	if err != nil {
+		return spec, err
+	}
+	if spec.Type() != rpcType {
+		return spec, yarpcerrors.UnimplementedErrorf(
+			"procedure %q of service %q is a %s procedure, not a %s procedure",
+			treq.Procedure, treq.Service, spec.Type(), rpcType)
+	}
+	return spec, nil
+}
+
+// handleUnary handles a unary request made with the given context. It
+// returns an error only if the request did not reach a handler.
+func (i *Inbound) handleUnary(ctx context.Context, wreq *wireRequest, start time.Time) (*wireResponse, error) {
+	if err := i.begin(wreq.Meta); err != nil {
+		return nil, err
+	}
+	defer i.requests.Done()
+
+	treq, compressor, err := i.request(wreq)
+	if err != nil {
+		return nil, wireError(err)
+	}
+
+	ctx, cancel := handlerContext(ctx)
+	defer cancel()
+
+	spec, err := i.choose(ctx, treq, transport.Unary)
+	if err != nil {
+		return nil, wireError(err)
+	}
+
+	rw := newResponseWriter()
+	err = transport.InvokeUnaryHandler(transport.UnaryInvokeRequest{
+		Context:        ctx,
+		StartTime:      start,
+		Request:        treq,
+		Handler:        spec.Unary(),
+		ResponseWriter: rw,
+		Logger:         i.transport.logger,
+	})
+
+	wres := &wireResponse{
+		Headers:              copyHeaders(rw.headers),
+		Body:                 bytes.Clone(rw.buffer.Bytes()),
+		ApplicationError:     rw.isApplicationError,
+		ApplicationErrorMeta: copyApplicationErrorMeta(rw.appErrorMeta),
+		Err:                  wireError(err),
+	}
+	if compressor != nil && len(wres.Body) > 0 {
+		if wres.Body, err = compress(compressor, wres.Body); err != nil {
+			return nil, yarpcerrors.InternalErrorf(
+				"failed to compress response body with %q: %v", compressor.Name(), err)
+		}
+	}
+	return wres, nil
+}
+
+// handleOneway starts handling a oneway request in the background, and
+// returns once the request has been accepted.
+func (i *Inbound) handleOneway(wreq *wireRequest) error {
+	if err := i.begin(wreq.Meta); err != nil {
+		return err
+	}
+
+	treq, _, err := i.request(wreq)
+	if err != nil {
+		i.requests.Done()
+		return wireError(err)
+	}
+
+	// Like network transports, oneway handlers are not bound by the
+	// lifetime of the request.
+	ctx := context.Background()
+	spec, err := i.choose(ctx, treq, transport.Oneway)
+	if err != nil {
+		i.requests.Done()
+		return wireError(err)
+	}
+
+	go func() {
+		defer i.requests.Done()
+		_ = transport.InvokeOnewayHandler(transport.OnewayInvokeRequest{
+			Context: ctx,
+			Request: treq,
+			Handler: spec.Oneway(),
+			Logger:  i.transport.logger,
+		})
+	}()
+	return nil
+}
+
+// handleStream starts a stream handler for a stream opened with the given
+// context, and returns the pipe to the handler. The stream ends when the
+// context is done.
+func (i *Inbound) handleStream(ctx context.Context, meta *transport.RequestMeta) (*streamPipe, error) {
+	if err := i.begin(meta); err != nil {
+		return nil, err
+	}
+
+	treq := meta.ToRequest()
+	treq.Transport = TransportName
+	if err := transport.ValidateRequest(treq); err != nil {
+		i.requests.Done()
+		return nil, wireError(err)
+	}
+
+	sctx, cancel := handlerContext(ctx)
+	spec, err := i.choose(sctx, treq, transport.Streaming)
+	if err != nil {
+		cancel()
+		i.requests.Done()
+		return nil, wireError(err)
+	}
+
+	pipe := newStreamPipe()
+	stream, err := transport.NewServerStream(&serverStream{
+		ctx:  sctx,
+		req:  &transport.StreamRequest{Meta: treq.ToRequestMeta()},
+		pipe: pipe,
+	})
+	if err != nil {
+		cancel()
+		i.requests.Done()
+		return nil, err
+	}
+
+	go func() {
+		defer i.requests.Done()
+		defer cancel()
+
+		err := transport.InvokeStreamHandler(transport.StreamInvokeRequest{
+			Stream:  stream,
+			Handler: spec.Stream(),
+			Logger:  i.transport.logger,
+		})
+		pipe.finish(wireError(err))
+	}()
+	return pipe, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/zap"
+)
+
+// Option allows customizing the in-memory transport. Any InboundOption,
+// OutboundOption, or TransportOption is a valid Option.
+type Option interface {
+	inmemoryOption()
+}
+
+var _ Option = (InboundOption)(nil)
+var _ Option = (OutboundOption)(nil)
+var _ Option = (TransportOption)(nil)
+
+// TransportOption customizes the behavior of an in-memory Transport.
+type TransportOption func(*Transport)
+
+func (TransportOption) inmemoryOption() {}
+
+// Logger sets the logger used to report handlers that panic.
+func Logger(logger *zap.Logger) TransportOption {
+	return func(t *Transport) {
+		t.logger = logger
+	}
+}
+
+// InboundOption customizes the behavior of an in-memory Inbound.
+type InboundOption func(*Inbound)
+
+func (InboundOption) inmemoryOption() {}
+
+// InboundCompressors sets the compressors an Inbound accepts for request
+// bodies. Responses to requests compressed with one of them are compressed
+// with the same compressor.
+func InboundCompressors(compressors ...transport.Compressor) InboundOption {
+	return func(i *Inbound) {
+		for _, c := range compressors {
+			i.compressors[c.Name()] = c
+		}
+	}
+}
+
+// OutboundOption customizes the behavior of an in-memory Outbound.
+type OutboundOption func(*Outbound)
+
+func (OutboundOption) inmemoryOption() {}
+
+// OutboundCompressor sets the compressor for the bodies of Unary and Oneway
+// requests made through an Outbound. Requests fail if the inbound does not
+// accept the compressor.
+func OutboundCompressor(compressor transport.Compressor) OutboundOption {
+	return func(o *Outbound) {
+		o.compressor = compressor
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"go.uber.org/yarpc/api/transport"
+	intyarpcerrors "go.uber.org/yarpc/internal/yarpcerrors"
+	"go.uber.org/yarpc/pkg/lifecycle"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ transport.UnaryOutbound  = (*Outbound)(nil)
+	_ transport.OnewayOutbound = (*Outbound)(nil)
+	_ transport.StreamOutbound = (*Outbound)(nil)
+	_ transport.Namer          = (*Outbound)(nil)
+)
+
+// Outbound sends requests to the Inbound of the same Transport listening at
+// its address.
+type Outbound struct {
+	once       *lifecycle.Once
+	transport  *Transport
+	address    string
+	compressor transport.Compressor
+}
+
+// NewOutbound builds a new in-memory Outbound that sends requests to the
+// given address.
+func (t *Transport) NewOutbound(address string, opts ...OutboundOption) *Outbound {
+	o := &Outbound{
+		once:      lifecycle.NewOnce(),
+		transport: t,
+		address:   address,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// TransportName is the transport name that will be set on `transport.Request`
+// struct.
+func (o *Outbound) TransportName() string {
+	return TransportName
+}
+
+// Transports returns the transport the Outbound belongs to.
+func (o *Outbound) Transports() []transport.Transport {
+	return []transport.Transport{o.transport}
+}
+
+// Start starts the Outbound.
+func (o *Outbound) Start() error {
+	return o.once.Start(nil)
+}
+
+// Stop stops the Outbound.
+func (o *Outbound) Stop() error {
+	return o.once.Stop(nil)
+}
+
+// IsRunning returns whether the Outbound is running.
+func (o *Outbound) IsRunning() bool {
+	return o.once.IsRunning()
+}
+
+func (o *Outbound) waitUntilRunning(ctx context.Context, service string) error {
+	if err := o.once.WaitUntilRunning(ctx); err != nil {
+		return intyarpcerrors.AnnotateWithInfo(
+			yarpcerrors.FromError(err),
+			"error waiting for inmemory outbound to start for service: %s",
+			service)
+	}
+	return nil
+}
+
+// Call sends a unary request to the inbound and waits for its response, or
+// for the deadline of the request.
+func (o *Outbound) Call(ctx context.Context, treq *transport.Request) (*transport.Response, error) {
+	if treq == nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("request for inmemory unary outbound was nil")
+	}
+	if err := o.waitUntilRunning(ctx, treq.Service); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, yarpcerrors.InvalidArgumentErrorf("missing context deadline")
+	}
+	i, err := o.transport.inbound(o.address, treq.Service)
+	if err != nil {
+		return nil, err
+	}
+	wreq, err := o.wireRequest(treq)
+	if err != nil {
+		return nil, err
+	}
+
+	// The handler runs in the background so that callers stop waiting for
+	// it at their deadline, as they would with a network transport.
+	type result struct {
+		wres *wireResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		wres, err := i.handleUnary(ctx, wreq, start)
+		done <- result{wres, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return o.response(r.wres)
+	case <-ctx.Done():
+		return nil, contextError(ctx, wreq.Meta, start)
+	}
+}
+
+// CallOneway sends a oneway request to the inbound and returns once the
+// inbound has accepted it.
+func (o *Outbound) CallOneway(ctx context.Context, treq *transport.Request) (transport.Ack, error) {
+	if treq == nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("request for inmemory oneway outbound was nil")
+	}
+	if err := o.waitUntilRunning(ctx, treq.Service); err != nil {
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, yarpcerrors.InvalidArgumentErrorf("missing context deadline")
+	}
+	i, err := o.transport.inbound(o.address, treq.Service)
+	if err != nil {
+		return nil, err
+	}
+	wreq, err := o.wireRequest(treq)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.handleOneway(wreq); err != nil {
+		return nil, err
+	}
+	return time.Now(), nil
+}
+
+// CallStream opens a stream to the inbound. The stream ends when the given
+// context is done.
+func (o *Outbound) CallStream(ctx context.Context, req *transport.StreamRequest) (*transport.ClientStream, error) {
+	if req == nil || req.Meta == nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("stream request for inmemory outbound requires request metadata")
+	}
+	if err := o.waitUntilRunning(ctx, req.Meta.Service); err != nil {
+		return nil, err
+	}
+
+	i, err := o.transport.inbound(o.address, req.Meta.Service)
+	if err != nil {
+		return nil, err
+	}
+	pipe, err := i.handleStream(ctx, wireMeta(req.Meta))
+	if err != nil {
+		return nil, err
+	}
+	return transport.NewClientStream(&clientStream{ctx: ctx, req: req, pipe: pipe})
+}
+
+// wireMeta returns a copy of the given request metadata.
+func wireMeta(meta *transport.RequestMeta) *transport.RequestMeta {
+	cp := *meta
+	cp.Headers = copyHeaders(meta.Headers)
+	cp.PeerIdentity = nil
+	return &cp
+}
+
+func (o *Outbound) wireRequest(treq *transport.Request) (*wireRequest, error) {
+	body, err := readBody(treq.Body)
+	if err != nil {
+		return nil, yarpcerrors.InternalErrorf(
+			"failed to read body of request to procedure %q of service %q: %v",
+			treq.Procedure, treq.Service, err)
+	}
+
+	wreq := &wireRequest{Meta: wireMeta(treq.ToRequestMeta()), Body: body}
+	if c := o.compressor; c != nil {
+		if wreq.Body, err = compress(c, body); err != nil {
+			return nil, yarpcerrors.InternalErrorf(
+				"failed to compress request body with %q: %v", c.Name(), err)
+		}
+		wreq.Compressor = c.Name()
+	}
+	return wreq, nil
+}
+
+func (o *Outbound) response(wres *wireResponse) (*transport.Response, error) {
+	body := wres.Body
+	if c := o.compressor; c != nil && len(body) > 0 {
+		var err error
+		if body, err = decompress(c, body); err != nil {
+			return nil, yarpcerrors.InternalErrorf(
+				"failed to decompress response body with %q: %v", c.Name(), err)
+		}
+	}
+
+	return &transport.Response{
+		Headers:              wres.Headers,
+		Body:                 io.NopCloser(bytes.NewReader(body)),
+		BodySize:             len(body),
+		ApplicationError:     wres.ApplicationError,
+		ApplicationErrorMeta: wres.ApplicationErrorMeta,
+	}, wres.Err
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	yarpcgzip "go.uber.org/yarpc/compressor/gzip"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const testTimeout = 5 * time.Second
+
+func newRequest(procedure, body string) *transport.Request {
+	return &transport.Request{
+		Caller:    "caller",
+		Service:   "service",
+		Encoding:  "raw",
+		Procedure: procedure,
+		ShardKey:  "shard",
+		Headers:   transport.NewHeaders().With("Foo", "bar"),
+		Body:      bytes.NewReader([]byte(body)),
+	}
+}
+
+func TestCall(t *testing.T) {
+	type ctxKey struct{}
+
+	tr := NewTransport()
+	startInbound(t, tr, "server", []transport.Procedure{{
+		Name: "echo",
+		HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+			func(ctx context.Context, req *transport.Request, rw transport.ResponseWriter) error {
+				assert.Nil(t, ctx.Value(ctxKey{}), "context values must not be propagated")
+				_, ok := ctx.Deadline()
+				assert.True(t, ok, "handler must have a deadline")
+
+				assert.Equal(t, TransportName, req.Transport)
+				assert.Equal(t, "caller", req.Caller)
+				assert.Equal(t, "shard", req.ShardKey)
+				assert.Equal(t, 5, req.BodySize)
+
+				foo, _ := req.Headers.Get("foo")
+				rw.AddHeaders(transport.NewHeaders().With("Echo", foo))
+				req.Headers.Del("foo")
+
+				_, err := io.Copy(rw, req.Body)
+				return err
+			})),
+	}})
+	o := startOutbound(t, tr, "server")
+
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), ctxKey{}, "value"), testTimeout)
+	defer cancel()
+
+	req := newRequest("echo", "hello")
+	res, err := o.Call(ctx, req)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, 5, res.BodySize)
+	assert.Equal(t, map[string]string{"echo": "bar"}, res.Headers.Items())
+	assert.False(t, res.ApplicationError)
+
+	_, ok := req.Headers.Get("foo")
+	assert.True(t, ok, "handler must not modify the caller's headers")
+}
+
+func TestCallErrors(t *testing.T) {
+	appErrCode := yarpcerrors.CodeNotFound
+	tr := NewTransport()
+	startInbound(t, tr, "server", []transport.Procedure{
+		{
+			Name: "appError",
+			HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+				func(ctx context.Context, req *transport.Request, rw transport.ResponseWriter) error {
+					rw.SetApplicationError()
+					rw.(transport.ApplicationErrorMetaSetter).SetApplicationErrorMeta(&transport.ApplicationErrorMeta{
+						Name:    "KeyNotFound",
+						Details: "no such key",
+						Code:    &appErrCode,
+					})
+					rw.AddHeaders(transport.NewHeaders().With("foo", "bar"))
+					_, err := rw.Write([]byte("details"))
+					return err
+				})),
+		},
+		{
+			Name: "yarpcError",
+			HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+				func(context.Context, *transport.Request, transport.ResponseWriter) error {
+					return yarpcerrors.Newf(yarpcerrors.CodeResourceExhausted, "slow down").
+						WithName("overloaded").
+						WithDetails([]byte{1, 2})
+				})),
+		},
+		{
+			Name: "error",
+			HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+				func(context.Context, *transport.Request, transport.ResponseWriter) error {
+					return errors.New("great sadness")
+				})),
+		},
+		{
+			Name: "panic",
+			HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+				func(context.Context, *transport.Request, transport.ResponseWriter) error {
+					panic("oops")
+				})),
+		},
+		{
+			Name: "oneway",
+			HandlerSpec: transport.NewOnewayHandlerSpec(onewayHandlerFunc(
+				func(context.Context, *transport.Request) error { return nil })),
+		},
+	})
+	o := startOutbound(t, tr, "server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	t.Run("application error", func(t *testing.T) {
+		res, err := o.Call(ctx, newRequest("appError", ""))
+		require.NoError(t, err)
+		assert.True(t, res.ApplicationError)
+		assert.Equal(t, &transport.ApplicationErrorMeta{
+			Name:    "KeyNotFound",
+			Details: "no such key",
+			Code:    &appErrCode,
+		}, res.ApplicationErrorMeta)
+		assert.Equal(t, map[string]string{"foo": "bar"}, res.Headers.Items())
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "details", string(body))
+	})
+
+	t.Run("yarpc error", func(t *testing.T) {
+		_, err := o.Call(ctx, newRequest("yarpcError", ""))
+		require.Error(t, err)
+		st := yarpcerrors.FromError(err)
+		assert.Equal(t, yarpcerrors.CodeResourceExhausted, st.Code())
+		assert.Equal(t, "overloaded", st.Name())
+		assert.Equal(t, "slow down", st.Message())
+		assert.Equal(t, []byte{1, 2}, st.Details())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := o.Call(ctx, newRequest("error", ""))
+		require.Error(t, err)
+		st := yarpcerrors.FromError(err)
+		assert.Equal(t, yarpcerrors.CodeUnknown, st.Code())
+		assert.Equal(t, "great sadness", st.Message())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		_, err := o.Call(ctx, newRequest("panic", ""))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "panic: oops")
+	})
+
+	t.Run("unknown procedure", func(t *testing.T) {
+		_, err := o.Call(ctx, newRequest("missing", ""))
+		assert.Equal(t, yarpcerrors.CodeUnimplemented, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("wrong rpc type", func(t *testing.T) {
+		_, err := o.Call(ctx, newRequest("oneway", ""))
+		assert.Equal(t, yarpcerrors.CodeUnimplemented, yarpcerrors.FromError(err).Code())
+		assert.Contains(t, err.Error(), `procedure "oneway" of service "service" is a Oneway procedure, not a Unary procedure`)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := o.Call(ctx, &transport.Request{Service: "service", Procedure: "error"})
+		assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		_, err := o.Call(ctx, nil)
+		assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("missing deadline", func(t *testing.T) {
+		_, err := o.Call(context.Background(), newRequest("error", ""))
+		assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+		assert.Contains(t, err.Error(), "missing context deadline")
+	})
+
+	t.Run("no inbound", func(t *testing.T) {
+		_, err := startOutbound(t, tr, "nobody").Call(ctx, newRequest("error", ""))
+		assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+		assert.Contains(t, err.Error(), `no inmemory inbound is listening at "nobody" for service "service"`)
+	})
+}
+
+func TestCallTimeout(t *testing.T) {
+	handlerDone := make(chan error, 1)
+	tr := NewTransport()
+	startInbound(t, tr, "server", []transport.Procedure{{
+		Name: "sleep",
+		HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+			func(ctx context.Context, req *transport.Request, rw transport.ResponseWriter) error {
+				<-ctx.Done()
+				handlerDone <- ctx.Err()
+				// Handlers that ignore their deadline do not hold up the
+				// caller.
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})),
+	}})
+	o := startOutbound(t, tr, "server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := o.Call(ctx, newRequest("sleep", ""))
+	assert.Equal(t, yarpcerrors.CodeDeadlineExceeded, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `client timeout for procedure "sleep" of service "service"`)
+	assert.Equal(t, context.DeadlineExceeded, <-handlerDone, "handler deadline must match the caller's")
+}
+
+func TestCallCancelled(t *testing.T) {
+	handlerStarted := make(chan struct{})
+	handlerDone := make(chan error, 1)
+	tr := NewTransport()
+	startInbound(t, tr, "server", []transport.Procedure{{
+		Name: "block",
+		HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+			func(ctx context.Context, req *transport.Request, rw transport.ResponseWriter) error {
+				close(handlerStarted)
+				<-ctx.Done()
+				handlerDone <- ctx.Err()
+				return ctx.Err()
+			})),
+	}})
+	o := startOutbound(t, tr, "server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	go func() {
+		<-handlerStarted
+		cancel()
+	}()
+
+	_, err := o.Call(ctx, newRequest("block", ""))
+	assert.Equal(t, yarpcerrors.CodeCancelled, yarpcerrors.FromError(err).Code())
+	assert.Equal(t, context.Canceled, <-handlerDone, "cancellation must reach the handler")
+}
+
+func TestCallCompression(t *testing.T) {
+	tr := NewTransport()
+	startInbound(t, tr, "server", []transport.Procedure{{
+		Name: "echo",
+		HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+			func(ctx context.Context, req *transport.Request, rw transport.ResponseWriter) error {
+				_, err := io.Copy(rw, req.Body)
+				return err
+			})),
+	}}, InboundCompressors(yarpcgzip.New()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	t.Run("accepted", func(t *testing.T) {
+		o := startOutbound(t, tr, "server", OutboundCompressor(yarpcgzip.New()))
+		res, err := o.Call(ctx, newRequest("echo", "hello hello hello"))
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello hello hello", string(body))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		o := startOutbound(t, tr, "server", OutboundCompressor(fakeCompressor{}))
+		_, err := o.Call(ctx, newRequest("echo", "hello"))
+		assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+		assert.Contains(t, err.Error(), `unsupported compressor "fake"`)
+	})
+}
+
+// fakeCompressor passes bodies through unchanged.
+type fakeCompressor struct{}
+
+func (fakeCompressor) Name() string { return "fake" }
+
+func (fakeCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (fakeCompressor) Decompress(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestCallOneway(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	release := make(chan struct{})
+
+	tr := NewTransport()
+	router := []transport.Procedure{{
+		Name: "record",
+		HandlerSpec: transport.NewOnewayHandlerSpec(onewayHandlerFunc(
+			func(ctx context.Context, req *transport.Request) error {
+				<-release
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				received = append(received, string(body))
+				mu.Unlock()
+				return nil
+			})),
+	}}
+	i := tr.NewInbound("server", InboundCompressors(yarpcgzip.New()))
+	i.SetRouter(newTestRouter(router))
+	require.NoError(t, i.Start())
+	o := startOutbound(t, tr, "server", OutboundCompressor(yarpcgzip.New()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	ack, err := o.CallOneway(ctx, newRequest("record", "hello"))
+	require.NoError(t, err)
+	assert.NotNil(t, ack)
+
+	_, err = o.CallOneway(ctx, newRequest("missing", "hello"))
+	assert.Equal(t, yarpcerrors.CodeUnimplemented, yarpcerrors.FromError(err).Code())
+
+	_, err = o.CallOneway(context.Background(), newRequest("record", "hello"))
+	assert.Contains(t, err.Error(), "missing context deadline")
+
+	_, err = o.CallOneway(ctx, nil)
+	assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+
+	stopped := make(chan error)
+	go func() { stopped <- i.Stop() }()
+	select {
+	case <-stopped:
+		t.Fatal("Stop must wait for oneway handlers")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-stopped)
+	assert.Equal(t, []string{"hello"}, received)
+
+	_, err = o.CallOneway(ctx, newRequest("record", "hello"))
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+}
+
+func TestOutboundNotRunning(t *testing.T) {
+	o := NewTransport().NewOutbound("server")
+	assert.Equal(t, TransportName, o.TransportName())
+	assert.Len(t, o.Transports(), 1)
+	assert.False(t, o.IsRunning())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := o.Call(ctx, newRequest("echo", ""))
+	assert.Contains(t, err.Error(), "error waiting for inmemory outbound to start for service: service")
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+
+	"go.uber.org/yarpc/api/transport"
+)
+
+var (
+	_ transport.ExtendedResponseWriter     = (*responseWriter)(nil)
+	_ transport.ApplicationErrorMetaSetter = (*responseWriter)(nil)
+)
+
+// responseWriter buffers the response of a unary handler.
+type responseWriter struct {
+	buffer             bytes.Buffer
+	headers            transport.Headers
+	isApplicationError bool
+	appErrorMeta       *transport.ApplicationErrorMeta
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{headers: transport.NewHeaders()}
+}
+
+func (r *responseWriter) Write(p []byte) (int, error) {
+	return r.buffer.Write(p)
+}
+
+func (r *responseWriter) ResponseSize() int {
+	return r.buffer.Len()
+}
+
+func (r *responseWriter) AddHeaders(headers transport.Headers) {
+	for k, v := range headers.OriginalItems() {
+		r.headers = r.headers.With(k, v)
+	}
+}
+
+func (r *responseWriter) SetApplicationError() {
+	r.isApplicationError = true
+}
+
+func (r *responseWriter) SetApplicationErrorMeta(meta *transport.ApplicationErrorMeta) {
+	if meta == nil {
+		return
+	}
+	r.appErrorMeta = meta
+}
+
+func (r *responseWriter) IsApplicationError() bool {
+	return r.isApplicationError
+}
+
+func (r *responseWriter) ApplicationErrorMeta() *transport.ApplicationErrorMeta {
+	return r.appErrorMeta
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var (
+	_ transport.StreamCloser        = (*clientStream)(nil)
+	_ transport.StreamHeadersReader = (*clientStream)(nil)
+	_ transport.StreamHeadersSender = (*serverStream)(nil)
+)
+
+// streamPipe connects a client stream to the server stream handling it.
+//
+// Messages are passed over unbuffered channels so that, as with network
+// transports, a sender blocks until the receiver is ready.
+type streamPipe struct {
+	toServer  chan []byte
+	toClient  chan []byte
+	closeSend chan struct{} // closed when the client stops sending
+	closeOnce sync.Once
+
+	headersMu   sync.Mutex
+	headers     transport.Headers
+	headersSent chan struct{}
+
+	done chan struct{} // closed when the handler returns
+	err  error         // error returned by the handler
+}
+
+func newStreamPipe() *streamPipe {
+	return &streamPipe{
+		toServer:    make(chan []byte),
+		toClient:    make(chan []byte),
+		closeSend:   make(chan struct{}),
+		headers:     transport.NewHeaders(),
+		headersSent: make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// sendHeaders sends the response headers of the stream, reporting whether
+// they were sent by this call.
+func (p *streamPipe) sendHeaders(headers transport.Headers) bool {
+	p.headersMu.Lock()
+	defer p.headersMu.Unlock()
+
+	select {
+	case <-p.headersSent:
+		return false
+	default:
+	}
+	p.headers = copyHeaders(headers)
+	close(p.headersSent)
+	return true
+}
+
+// finish records the result of the handler.
+func (p *streamPipe) finish(err error) {
+	p.sendHeaders(transport.NewHeaders())
+	p.err = err
+	close(p.done)
+}
+
+// readMessage reads and closes the body of a message.
+func readMessage(msg *transport.StreamMessage) ([]byte, error) {
+	if msg == nil || msg.Body == nil {
+		return nil, nil
+	}
+	defer msg.Body.Close()
+	b, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return nil, yarpcerrors.InternalErrorf("failed to read stream message: %v", err)
+	}
+	return b, nil
+}
+
+func newMessage(b []byte) *transport.StreamMessage {
+	return &transport.StreamMessage{
+		Body:     io.NopCloser(bytes.NewReader(b)),
+		BodySize: len(b),
+	}
+}
+
+// streamContextError returns the error for a stream operation abandoned
+// because the given context is done.
+func streamContextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return yarpcerrors.DeadlineExceededErrorf("%v", ctx.Err())
+	}
+	return yarpcerrors.CancelledErrorf("%v", ctx.Err())
+}
+
+// clientStream is the caller's end of a stream.
+type clientStream struct {
+	ctx  context.Context
+	req  *transport.StreamRequest
+	pipe *streamPipe
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *clientStream) Request() *transport.StreamRequest {
+	return s.req
+}
+
+func (s *clientStream) SendMessage(ctx context.Context, msg *transport.StreamMessage) error {
+	b, err := readMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-s.pipe.closeSend:
+		return yarpcerrors.FailedPreconditionErrorf("cannot send messages after closing the stream")
+	default:
+	}
+
+	select {
+	case s.pipe.toServer <- b:
+		return nil
+	case <-s.pipe.done:
+		// As with gRPC, the status of the stream is returned by
+		// ReceiveMessage.
+		return io.EOF
+	case <-ctx.Done():
+		return streamContextError(ctx)
+	case <-s.ctx.Done():
+		return streamContextError(s.ctx)
+	}
+}
+
+func (s *clientStream) ReceiveMessage(ctx context.Context) (*transport.StreamMessage, error) {
+	select {
+	case b := <-s.pipe.toClient:
+		return newMessage(b), nil
+	case <-s.pipe.done:
+		if s.pipe.err != nil {
+			return nil, s.pipe.err
+		}
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, streamContextError(ctx)
+	case <-s.ctx.Done():
+		return nil, streamContextError(s.ctx)
+	}
+}
+
+// Close signals to the handler that the client will send no more messages.
+func (s *clientStream) Close(context.Context) error {
+	s.pipe.closeOnce.Do(func() { close(s.pipe.closeSend) })
+	return nil
+}
+
+// Headers blocks until the handler sends headers, sends a message, or
+// returns.
+func (s *clientStream) Headers() (transport.Headers, error) {
+	select {
+	case <-s.pipe.headersSent:
+		return copyHeaders(s.pipe.headers), nil
+	case <-s.ctx.Done():
+		return transport.NewHeaders(), streamContextError(s.ctx)
+	}
+}
+
+// serverStream is the handler's end of a stream.
+type serverStream struct {
+	ctx  context.Context
+	req  *transport.StreamRequest
+	pipe *streamPipe
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) Request() *transport.StreamRequest {
+	return s.req
+}
+
+func (s *serverStream) SendHeaders(headers transport.Headers) error {
+	if !s.pipe.sendHeaders(headers) {
+		return yarpcerrors.FailedPreconditionErrorf("stream headers have already been sent")
+	}
+	return nil
+}
+
+func (s *serverStream) SendMessage(ctx context.Context, msg *transport.StreamMessage) error {
+	b, err := readMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	// Messages implicitly send empty headers if none were sent.
+	s.pipe.sendHeaders(transport.NewHeaders())
+
+	select {
+	case s.pipe.toClient <- b:
+		return nil
+	case <-ctx.Done():
+		return streamContextError(ctx)
+	case <-s.ctx.Done():
+		return streamContextError(s.ctx)
+	}
+}
+
+func (s *serverStream) ReceiveMessage(ctx context.Context) (*transport.StreamMessage, error) {
+	select {
+	case b := <-s.pipe.toServer:
+		return newMessage(b), nil
+	case <-s.pipe.closeSend:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, streamContextError(ctx)
+	case <-s.ctx.Done():
+		return nil, streamContextError(s.ctx)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func sendString(ctx context.Context, s interface {
+	SendMessage(context.Context, *transport.StreamMessage) error
+}, msg string) error {
+	return s.SendMessage(ctx, &transport.StreamMessage{Body: io.NopCloser(bytes.NewReader([]byte(msg)))})
+}
+
+func receiveString(ctx context.Context, s interface {
+	ReceiveMessage(context.Context) (*transport.StreamMessage, error)
+}) (string, error) {
+	msg, err := s.ReceiveMessage(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer msg.Body.Close()
+	b, err := io.ReadAll(msg.Body)
+	return string(b), err
+}
+
+func newStreamRequest(procedure string) *transport.StreamRequest {
+	return &transport.StreamRequest{Meta: &transport.RequestMeta{
+		Caller:    "caller",
+		Service:   "service",
+		Encoding:  "raw",
+		Procedure: procedure,
+		Headers:   transport.NewHeaders().With("foo", "bar"),
+	}}
+}
+
+func TestCallStream(t *testing.T) {
+	tr := NewTransport()
+	startInbound(t, tr, "server", []transport.Procedure{
+		{
+			Name: "echo",
+			HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(func(s *transport.ServerStream) error {
+				meta := s.Request().Meta
+				assert.Equal(t, TransportName, meta.Transport)
+				foo, _ := meta.Headers.Get("foo")
+				if err := s.SendHeaders(transport.NewHeaders().With("echo", foo)); err != nil {
+					return err
+				}
+				assert.Error(t, s.SendHeaders(transport.NewHeaders()), "headers can be sent only once")
+
+				ctx := s.Context()
+				for {
+					msg, err := receiveString(ctx, s)
+					if err == io.EOF {
+						return nil
+					}
+					if err != nil {
+						return err
+					}
+					if err := sendString(ctx, s, msg); err != nil {
+						return err
+					}
+				}
+			})),
+		},
+		{
+			Name: "fail",
+			HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(func(s *transport.ServerStream) error {
+				if err := sendString(s.Context(), s, "last"); err != nil {
+					return err
+				}
+				return yarpcerrors.AbortedErrorf("done")
+			})),
+		},
+		{
+			Name: "unary",
+			HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+				func(context.Context, *transport.Request, transport.ResponseWriter) error { return nil })),
+		},
+	})
+	o := startOutbound(t, tr, "server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	t.Run("echo", func(t *testing.T) {
+		stream, err := o.CallStream(ctx, newStreamRequest("echo"))
+		require.NoError(t, err)
+
+		headers, err := stream.Headers()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"echo": "bar"}, headers.Items())
+
+		for _, msg := range []string{"foo", "bar", ""} {
+			require.NoError(t, sendString(ctx, stream, msg))
+			got, err := receiveString(ctx, stream)
+			require.NoError(t, err)
+			assert.Equal(t, msg, got)
+		}
+
+		require.NoError(t, stream.Close(ctx))
+		_, err = receiveString(ctx, stream)
+		assert.Equal(t, io.EOF, err)
+		assert.Error(t, sendString(ctx, stream, "closed"), "must not send after closing")
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		stream, err := o.CallStream(ctx, newStreamRequest("fail"))
+		require.NoError(t, err)
+
+		msg, err := receiveString(ctx, stream)
+		require.NoError(t, err)
+		assert.Equal(t, "last", msg)
+
+		headers, err := stream.Headers()
+		require.NoError(t, err)
+		assert.Equal(t, 0, headers.Len())
+
+		_, err = receiveString(ctx, stream)
+		assert.Equal(t, yarpcerrors.CodeAborted, yarpcerrors.FromError(err).Code())
+		assert.Equal(t, io.EOF, sendString(ctx, stream, "late"))
+	})
+
+	t.Run("not a stream", func(t *testing.T) {
+		_, err := o.CallStream(ctx, newStreamRequest("unary"))
+		assert.Equal(t, yarpcerrors.CodeUnimplemented, yarpcerrors.FromError(err).Code())
+	})
+
+	t.Run("missing metadata", func(t *testing.T) {
+		_, err := o.CallStream(ctx, &transport.StreamRequest{})
+		assert.Equal(t, yarpcerrors.CodeInvalidArgument, yarpcerrors.FromError(err).Code())
+	})
+}
+
+func TestCallStreamCancelled(t *testing.T) {
+	handlerErr := make(chan error, 1)
+	tr := NewTransport()
+	i := startInbound(t, tr, "server", []transport.Procedure{{
+		Name: "block",
+		HandlerSpec: transport.NewStreamHandlerSpec(streamHandlerFunc(func(s *transport.ServerStream) error {
+			_, err := s.ReceiveMessage(context.Background())
+			handlerErr <- err
+			return err
+		})),
+	}})
+	o := startOutbound(t, tr, "server")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := o.CallStream(ctx, newStreamRequest("block"))
+	require.NoError(t, err)
+	assert.Equal(t, ctx, stream.Context())
+
+	cancel()
+	err = <-handlerErr
+	assert.Equal(t, yarpcerrors.CodeCancelled, yarpcerrors.FromError(err).Code())
+
+	_, err = stream.ReceiveMessage(context.Background())
+	assert.Error(t, err)
+
+	// The stream no longer holds up the inbound.
+	stopped := make(chan error)
+	go func() { stopped <- i.Stop() }()
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(testTimeout):
+		t.Fatal("inbound did not stop")
+	}
+}
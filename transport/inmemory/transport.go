@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"sync"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/pkg/lifecycle"
+	"go.uber.org/yarpc/yarpcerrors"
+	"go.uber.org/zap"
+)
+
+// TransportName is the name of the transport.
+//
+// This value is what will be populated in the Request.Transport field of
+// requests handled by inbounds of this transport.
+const TransportName = "inmemory"
+
+var _ transport.Transport = (*Transport)(nil)
+
+// Transport connects the inbounds and outbounds created from it.
+//
+// A Transport holds no resources of its own, so it may be shared by any
+// number of dispatchers.
+type Transport struct {
+	once   *lifecycle.Once
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	inbounds map[string]*Inbound
+}
+
+// NewTransport builds a new in-memory Transport.
+func NewTransport(opts ...TransportOption) *Transport {
+	t := &Transport{
+		once:     lifecycle.NewOnce(),
+		logger:   zap.NewNop(),
+		inbounds: make(map[string]*Inbound),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Start starts the Transport.
+func (t *Transport) Start() error {
+	return t.once.Start(nil)
+}
+
+// Stop stops the Transport. Inbounds that are still running continue to
+// receive requests.
+func (t *Transport) Stop() error {
+	return t.once.Stop(nil)
+}
+
+// IsRunning returns whether the Transport is running.
+func (t *Transport) IsRunning() bool {
+	return t.once.IsRunning()
+}
+
+func (t *Transport) listen(i *Inbound) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.inbounds[i.address]; ok {
+		return yarpcerrors.FailedPreconditionErrorf("inmemory address %q is already in use", i.address)
+	}
+	t.inbounds[i.address] = i
+	return nil
+}
+
+func (t *Transport) unlisten(i *Inbound) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.inbounds[i.address] == i {
+		delete(t.inbounds, i.address)
+	}
+}
+
+// inbound returns the inbound listening at the given address.
+func (t *Transport) inbound(address, service string) (*Inbound, error) {
+	t.mu.RLock()
+	i, ok := t.inbounds[address]
+	t.mu.RUnlock()
+	if !ok {
+		return nil, yarpcerrors.UnavailableErrorf(
+			"no inmemory inbound is listening at %q for service %q", address, service)
+	}
+	return i, nil
+}
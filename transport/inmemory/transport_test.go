@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+type unaryHandlerFunc func(context.Context, *transport.Request, transport.ResponseWriter) error
+
+func (f unaryHandlerFunc) Handle(ctx context.Context, req *transport.Request, rw transport.ResponseWriter) error {
+	return f(ctx, req, rw)
+}
+
+type onewayHandlerFunc func(context.Context, *transport.Request) error
+
+func (f onewayHandlerFunc) HandleOneway(ctx context.Context, req *transport.Request) error {
+	return f(ctx, req)
+}
+
+type streamHandlerFunc func(*transport.ServerStream) error
+
+func (f streamHandlerFunc) HandleStream(s *transport.ServerStream) error {
+	return f(s)
+}
+
+func newTestRouter(procedures []transport.Procedure) transport.Router {
+	router := yarpc.NewMapRouter("service")
+	router.Register(procedures)
+	return router
+}
+
+// startInbound starts an inbound at the given address that handles the
+// given procedures of the service "service".
+func startInbound(t *testing.T, tr *Transport, address string, procedures []transport.Procedure, opts ...InboundOption) *Inbound {
+	i := tr.NewInbound(address, opts...)
+	i.SetRouter(newTestRouter(procedures))
+	require.NoError(t, i.Start())
+	t.Cleanup(func() { assert.NoError(t, i.Stop()) })
+	return i
+}
+
+func startOutbound(t *testing.T, tr *Transport, address string, opts ...OutboundOption) *Outbound {
+	o := tr.NewOutbound(address, opts...)
+	require.NoError(t, o.Start())
+	t.Cleanup(func() { assert.NoError(t, o.Stop()) })
+	return o
+}
+
+func TestTransportLifecycle(t *testing.T) {
+	tr := NewTransport()
+	require.NoError(t, tr.Start())
+	assert.True(t, tr.IsRunning())
+	require.NoError(t, tr.Stop())
+	assert.False(t, tr.IsRunning())
+}
+
+func TestInboundAddressInUse(t *testing.T) {
+	tr := NewTransport()
+	startInbound(t, tr, "foo", nil)
+
+	i := tr.NewInbound("foo")
+	i.SetRouter(yarpc.NewMapRouter("service"))
+	err := i.Start()
+	require.Error(t, err)
+	assert.Equal(t, yarpcerrors.CodeFailedPrecondition, yarpcerrors.FromError(err).Code())
+	assert.Contains(t, err.Error(), `inmemory address "foo" is already in use`)
+}
+
+func TestInboundRequiresRouter(t *testing.T) {
+	i := NewTransport().NewInbound("foo")
+	assert.Equal(t, "foo", i.Address())
+	assert.Equal(t, []transport.Transport{i.transport}, i.Transports())
+	err := i.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no router configured for inmemory inbound at "foo"`)
+}
+
+func TestInboundStopReleasesAddress(t *testing.T) {
+	tr := NewTransport()
+	router := yarpc.NewMapRouter("service")
+	router.Register([]transport.Procedure{{
+		Name: "echo",
+		HandlerSpec: transport.NewUnaryHandlerSpec(unaryHandlerFunc(
+			func(context.Context, *transport.Request, transport.ResponseWriter) error { return nil })),
+	}})
+
+	first := tr.NewInbound("foo")
+	first.SetRouter(router)
+	require.NoError(t, first.Start())
+	assert.True(t, first.IsRunning())
+	require.NoError(t, first.Stop())
+
+	o := startOutbound(t, tr, "foo")
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	_, err := o.Call(ctx, &transport.Request{Caller: "caller", Service: "service", Procedure: "echo", Encoding: "raw"})
+	assert.Equal(t, yarpcerrors.CodeUnavailable, yarpcerrors.FromError(err).Code())
+
+	second := tr.NewInbound("foo")
+	second.SetRouter(router)
+	require.NoError(t, second.Start(), "address must be released by Stop")
+	defer second.Stop()
+
+	res, err := o.Call(ctx, &transport.Request{Caller: "caller", Service: "service", Procedure: "echo", Encoding: "raw"})
+	require.NoError(t, err)
+	_, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// wireRequest is a request as it crosses from an Outbound to an Inbound.
+// Like a request sent over a network, it shares no memory with the request
+// made by the caller.
+type wireRequest struct {
+	Meta *transport.RequestMeta
+	Body []byte
+
+	// Compressor is the name of the compressor the body was compressed
+	// with, if any. The response is compressed with the same compressor.
+	Compressor string
+}
+
+// wireResponse is a response as it crosses from an Inbound to an Outbound.
+type wireResponse struct {
+	Headers              transport.Headers
+	Body                 []byte
+	ApplicationError     bool
+	ApplicationErrorMeta *transport.ApplicationErrorMeta
+	Err                  error
+}
+
+// copyHeaders returns a copy of the given headers.
+func copyHeaders(src transport.Headers) transport.Headers {
+	dst := transport.NewHeadersWithCapacity(src.OriginalItemsLen())
+	for k, v := range src.OriginalItems() {
+		dst = dst.With(k, v)
+	}
+	return dst
+}
+
+func copyApplicationErrorMeta(meta *transport.ApplicationErrorMeta) *transport.ApplicationErrorMeta {
+	if meta == nil {
+		return nil
+	}
+	cp := *meta
+	if meta.Code != nil {
+		code := *meta.Code
+		cp.Code = &code
+	}
+	return &cp
+}
+
+// wireError returns an error with the code, name, message and details of the
+// given error, as a caller would receive it over a network.
+func wireError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st := yarpcerrors.FromError(err)
+	return yarpcerrors.Newf(st.Code(), "%s", st.Message()).
+		WithName(st.Name()).
+		WithDetails(st.Details())
+}
+
+// contextError returns the error for a request whose caller stopped waiting
+// for the response.
+func contextError(ctx context.Context, meta *transport.RequestMeta, start time.Time) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return yarpcerrors.DeadlineExceededErrorf(
+			"client timeout for procedure %q of service %q after %v",
+			meta.Procedure, meta.Service, time.Since(start))
+	}
+	return yarpcerrors.CancelledErrorf(
+		"client canceled request for procedure %q of service %q after %v",
+		meta.Procedure, meta.Service, time.Since(start))
+}
+
+// handlerContext returns the context for a handler of a request made with
+// the given context. The handler context has the same deadline and is
+// cancelled with it, but carries none of its values.
+//
+// Only explicit cancellation is forwarded to the handler context. When the
+// deadline passes, the handler context expires on its own timer, so that
+// handlers see context.DeadlineExceeded rather than context.Canceled.
+func handlerContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	var (
+		hctx   context.Context
+		cancel context.CancelFunc
+	)
+	if deadline, ok := ctx.Deadline(); ok {
+		hctx, cancel = context.WithDeadline(context.Background(), deadline)
+	} else {
+		hctx, cancel = context.WithCancel(context.Background())
+	}
+	stop := context.AfterFunc(ctx, func() {
+		if ctx.Err() == context.Canceled {
+			cancel()
+		}
+	})
+	return hctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func readBody(body io.Reader) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	return io.ReadAll(body)
+}
+
+func compress(c transport.Compressor, body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := c.Compress(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(body); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(c transport.Compressor, body []byte) ([]byte, error) {
+	r, err := c.Decompress(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
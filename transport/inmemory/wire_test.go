@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerContext(t *testing.T) {
+	type ctxKey struct{}
+
+	tests := []struct {
+		desc string
+		// caller builds the caller's context from the given parent context.
+		caller func(parent context.Context) (context.Context, context.CancelFunc)
+		// cancel is whether the caller cancels its context while the
+		// handler runs.
+		cancel bool
+		want   error
+	}{
+		{
+			desc: "deadline",
+			caller: func(parent context.Context) (context.Context, context.CancelFunc) {
+				return context.WithTimeout(parent, 5*time.Millisecond)
+			},
+			want: context.DeadlineExceeded,
+		},
+		{
+			desc: "cancelled before deadline",
+			caller: func(parent context.Context) (context.Context, context.CancelFunc) {
+				return context.WithTimeout(parent, testTimeout)
+			},
+			cancel: true,
+			want:   context.Canceled,
+		},
+		{
+			desc: "cancelled without deadline",
+			caller: func(parent context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(parent)
+			},
+			cancel: true,
+			want:   context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// The caller's deadline usually passes just before the handler's
+			// does, so a single run may not catch the handler seeing it as
+			// cancellation.
+			for i := 0; i < 10; i++ {
+				parent := context.WithValue(context.Background(), ctxKey{}, "value")
+				ctx, cancelCaller := tt.caller(parent)
+				hctx, cancel := handlerContext(ctx)
+				assert.Nil(t, hctx.Value(ctxKey{}), "handler context must not carry the caller's values")
+				if tt.cancel {
+					cancelCaller()
+				}
+
+				select {
+				case <-hctx.Done():
+				case <-time.After(testTimeout):
+					t.Fatal("handler context must end with the caller's")
+				}
+				assert.Equal(t, tt.want, hctx.Err())
+				cancel()
+				cancelCaller()
+			}
+		})
+	}
+
+	t.Run("released", func(t *testing.T) {
+		hctx, cancel := handlerContext(context.Background())
+		cancel()
+		assert.Equal(t, context.Canceled, hctx.Err(), "releasing the handler context must cancel it")
+	})
+}
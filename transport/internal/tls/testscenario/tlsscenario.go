@@ -29,12 +29,16 @@ import (
 	"crypto/x509/pkix"
 	"math/big"
 	"net"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+// ClientSPIFFEID is the SPIFFE ID carried by the client certificate.
+const ClientSPIFFEID = "spiffe://yarpc.test/client"
+
 // TLSScenario holds client & server tls credentials.
 type TLSScenario struct {
 	CAs        *x509.CertPool
@@ -133,6 +137,7 @@ func Create(t *testing.T, clientValidity time.Duration, serverValidity time.Dura
 			Subject: pkix.Name{
 				CommonName: "client",
 			},
+			URIs:         []*url.URL{{Scheme: "spiffe", Host: "yarpc.test", Path: "/client"}},
 			NotAfter:     now.Add(clientValidity),
 			SerialNumber: big.NewInt(3),
 			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
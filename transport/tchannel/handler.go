@@ -174,6 +174,7 @@ func (h handler) callHandler(ctx context.Context, call inboundCall, responseWrit
 		ShardKey:        call.ShardKey(),
 		RoutingKey:      call.RoutingKey(),
 		RoutingDelegate: call.RoutingDelegate(),
+		PeerIdentity:    peerIdentityFromContext(ctx),
 	}
 
 	ctx, headers, err := readRequestHeaders(ctx, call.Format(), call.Arg2Reader)
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tchannel
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"go.uber.org/yarpc/api/transport"
+)
+
+type peerIdentityKey struct{} // context key for *transport.PeerIdentity
+
+// peerIdentityConnContext is a tchannel ConnContext which records the
+// verified identity of the remote peer of TLS connections on the context
+// that inbound calls over that connection inherit.
+func peerIdentityConnContext(ctx context.Context, conn net.Conn) context.Context {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return ctx
+	}
+	state := tlsConn.ConnectionState()
+	if id := transport.PeerIdentityFromTLS(&state); id != nil {
+		return context.WithValue(ctx, peerIdentityKey{}, id)
+	}
+	return ctx
+}
+
+// peerIdentityFromContext returns the peer identity recorded by
+// peerIdentityConnContext, if any.
+func peerIdentityFromContext(ctx context.Context) *transport.PeerIdentity {
+	id, _ := ctx.Value(peerIdentityKey{}).(*transport.PeerIdentity)
+	return id
+}
@@ -77,6 +77,14 @@ func (i *Inbound) Stop() error {
 	return i.once.Stop(nil)
 }
 
+// Drain stops accepting new connections, so that new clients connect to other
+// instances while this one stops. TChannel has no way to ask clients to move
+// off existing connections, so requests made on them are served until the
+// transport stops.
+func (i *Inbound) Drain() {
+	i.transport.drain()
+}
+
 // IsRunning returns whether the Inbound is running.
 func (i *Inbound) IsRunning() bool {
 	return i.once.IsRunning()
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net"
 	"strings"
 	"testing"
 
@@ -145,6 +146,53 @@ func TestInboundSubServices(t *testing.T) {
 	require.NoError(t, ot.Stop())
 }
 
+func TestInboundDrain(t *testing.T) {
+	it, err := NewTransport(ServiceName("myservice"), ListenAddr("127.0.0.1:0"))
+	require.NoError(t, err)
+
+	router := yarpc.NewMapRouter("myservice")
+	router.Register([]transport.Procedure{
+		{Name: "hello", HandlerSpec: transport.NewUnaryHandlerSpec(nophandler{})},
+	})
+	var _ transport.DrainableInbound = (*Inbound)(nil)
+	i := it.NewInbound()
+	i.SetRouter(router)
+	require.NoError(t, i.Start())
+	require.NoError(t, it.Start())
+
+	ot, err := NewTransport(ServiceName("caller"))
+	require.NoError(t, err)
+	o := ot.NewSingleOutbound(it.ListenAddr())
+	require.NoError(t, o.Start())
+	require.NoError(t, ot.Start())
+
+	call := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*testtime.Millisecond)
+		defer cancel()
+		_, err := o.Call(ctx, &transport.Request{
+			Caller:    "caller",
+			Service:   "myservice",
+			Procedure: "hello",
+			Encoding:  raw.Encoding,
+			Body:      bytes.NewReader(nil),
+		})
+		return err
+	}
+	require.NoError(t, call())
+
+	i.Drain()
+	i.Drain() // must be a no-op
+
+	_, err = net.Dial("tcp", it.ListenAddr())
+	assert.Error(t, err, "new connections must be refused after draining")
+	assert.NoError(t, call(), "existing connections must be served after draining")
+
+	require.NoError(t, i.Stop())
+	require.NoError(t, it.Stop())
+	require.NoError(t, o.Stop())
+	require.NoError(t, ot.Stop())
+}
+
 type nopNativehandler struct{}
 
 func (nopNativehandler) Handle(ctx context.Context, call *tchannel.InboundCall) {
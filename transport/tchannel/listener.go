@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tchannel
+
+import (
+	"net"
+	"sync"
+)
+
+// drainListener is a net.Listener that can stop accepting connections before
+// it is closed.
+//
+// TChannel treats errors from Accept as fatal unless its channel is closing,
+// so once the listener is drained, Accept blocks until it is closed.
+type drainListener struct {
+	net.Listener
+
+	drainOnce sync.Once
+	closeOnce sync.Once
+	drained   chan struct{}
+	closed    chan struct{}
+}
+
+func newDrainListener(l net.Listener) *drainListener {
+	return &drainListener{
+		Listener: l,
+		drained:  make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (l *drainListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		select {
+		case <-l.drained:
+			<-l.closed
+		default:
+		}
+	}
+	return conn, err
+}
+
+// Drain closes the underlying listener so that new connections are refused.
+func (l *drainListener) Drain() {
+	l.drainOnce.Do(func() {
+		close(l.drained)
+		_ = l.Listener.Close()
+	})
+}
+
+func (l *drainListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		select {
+		case <-l.drained:
+			// The underlying listener was closed already.
+		default:
+			err = l.Listener.Close()
+		}
+		close(l.closed)
+	})
+	return err
+}
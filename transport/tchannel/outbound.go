@@ -36,6 +36,7 @@ import (
 	"go.uber.org/yarpc/internal/iopool"
 	intyarpcerrors "go.uber.org/yarpc/internal/yarpcerrors"
 	peerchooser "go.uber.org/yarpc/peer"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/peer/hostport"
 	"go.uber.org/yarpc/pkg/errors"
 	"go.uber.org/yarpc/pkg/lifecycle"
@@ -47,6 +48,7 @@ var (
 
 	_ transport.UnaryOutbound              = (*Outbound)(nil)
 	_ introspection.IntrospectableOutbound = (*Outbound)(nil)
+	_ abstractlist.Prober                  = (*Outbound)(nil)
 )
 
 // Outbound sends YARPC requests over TChannel.
@@ -83,9 +85,21 @@ func (t *Transport) NewOutbound(chooser peer.Chooser, opts ...OutboundOption) *O
 	for _, opt := range opts {
 		opt(o)
 	}
+
+	// Peer lists that send health checks send them through the outbound,
+	// so that they reach peers the same way requests do.
+	if l, ok := chooser.(proberSetter); ok {
+		l.SetProber(o)
+	}
 	return o
 }
 
+// proberSetter is implemented by peer lists that send health checks to their
+// peers, like those built on "go.uber.org/yarpc/peer/abstractlist".
+type proberSetter interface {
+	SetProber(abstractlist.Prober)
+}
+
 // NewSingleOutbound builds a new TChannel outbound always using the peer with
 // the given address.
 func (t *Transport) NewSingleOutbound(addr string, opts ...OutboundOption) *Outbound {
@@ -128,6 +142,15 @@ func (o *Outbound) DirectCall(ctx context.Context, req *transport.Request) (*tra
 	return res, toYARPCError(req, err)
 }
 
+// Probe sends a unary request directly to the given peer, without consulting
+// the outbound's peer chooser.
+//
+// Peer lists owned by the outbound use Probe to send health checks to each
+// of their peers.
+func (o *Outbound) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	return probe(ctx, p, req, o.reuseBuffer)
+}
+
 // Call sends an RPC to this specific peer.
 func (p *tchannelPeer) Call(ctx context.Context, req *transport.Request, reuseBuffer bool) (*transport.Response, error) {
 	return callWithPeer(ctx, req, p.getPeer(), p.transport.headerCase, reuseBuffer)
@@ -27,9 +27,14 @@ import (
 
 	"github.com/uber/tchannel-go"
 	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/peer/abstractlist"
 )
 
-var _ peer.Transport = (*outboundChannel)(nil)
+var (
+	_ peer.Transport      = (*outboundChannel)(nil)
+	_ abstractlist.Prober = (*outboundChannel)(nil)
+)
 
 type dialerFunc = func(ctx context.Context, network, hostPort string) (net.Conn, error)
 
@@ -60,6 +65,11 @@ func (o *outboundChannel) ReleasePeer(pid peer.Identifier, sub peer.Subscriber)
 	return o.t.ReleasePeer(pid, sub)
 }
 
+// Probe delegates to the transport Probe, reaching the peer over its channel.
+func (o *outboundChannel) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	return o.t.Probe(ctx, p, req)
+}
+
 // start creates channel used for managing outbound peers.
 // This is invoked by the transport when it is started.
 func (o *outboundChannel) start() (err error) {
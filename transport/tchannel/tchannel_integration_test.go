@@ -32,6 +32,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	peerapi "go.uber.org/yarpc/api/peer"
 	"go.uber.org/yarpc/api/peer/peertest"
 	"go.uber.org/yarpc/api/transport"
 	yarpctls "go.uber.org/yarpc/api/transport/tls"
@@ -201,6 +202,61 @@ func TestTLSOutbound(t *testing.T) {
 	assert.Equal(t, "hello", string(resBody))
 }
 
+func TestInboundTLSPeerIdentity(t *testing.T) {
+	scenario := testscenario.Create(t, time.Minute, time.Minute)
+	serverTransport, err := tchannel.NewTransport(
+		tchannel.InboundTLSConfiguration(scenario.ServerTLSConfig()),
+		tchannel.InboundTLSMode(yarpctls.Permissive),
+		tchannel.ServiceName("test-svc"),
+		tchannel.ListenAddr("127.0.0.1:0"),
+	)
+	require.NoError(t, err)
+
+	inbound := serverTransport.NewInbound()
+	inbound.SetRouter(testRouter{proc: transport.Procedure{HandlerSpec: transport.NewUnaryHandlerSpec(identityServer{})}})
+	require.NoError(t, serverTransport.Start())
+	defer serverTransport.Stop()
+	require.NoError(t, inbound.Start())
+	defer inbound.Stop()
+
+	clientTransport, err := tchannel.NewTransport(tchannel.ServiceName("test-client-svc"))
+	require.NoError(t, err)
+	tlsTransport, err := clientTransport.CreateTLSOutboundChannel(scenario.ClientTLSConfig(), "test-svc")
+	require.NoError(t, err)
+	require.NoError(t, clientTransport.Start())
+	defer clientTransport.Stop()
+
+	tests := []struct {
+		desc      string
+		transport peerapi.Transport
+		want      string
+	}{
+		{desc: "tls", transport: tlsTransport, want: testscenario.ClientSPIFFEID},
+		{desc: "plaintext", transport: clientTransport, want: "<none>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			outbound := clientTransport.NewOutbound(peer.NewSingle(hostport.Identify(serverTransport.ListenAddr()), tt.transport))
+			require.NoError(t, outbound.Start())
+			defer outbound.Stop()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			res, err := outbound.Call(ctx, &transport.Request{
+				Service:   "test-svc",
+				Procedure: "test-proc",
+				Body:      strings.NewReader("hello"),
+			})
+			require.NoError(t, err)
+
+			resBody, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(resBody))
+		})
+	}
+}
+
 type testRouter struct {
 	proc transport.Procedure
 }
@@ -223,3 +279,15 @@ func (testServer) Handle(ctx context.Context, req *transport.Request, resw trans
 	resw.Write(data)
 	return nil
 }
+
+// identityServer responds with the SPIFFE ID of the caller.
+type identityServer struct{}
+
+func (identityServer) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	if req.PeerIdentity == nil {
+		_, err := io.WriteString(resw, "<none>")
+		return err
+	}
+	_, err := io.WriteString(resw, req.PeerIdentity.SPIFFEID)
+	return err
+}
@@ -39,6 +39,7 @@ import (
 	"go.uber.org/yarpc/internal/inboundmiddleware"
 	"go.uber.org/yarpc/internal/interceptor"
 	"go.uber.org/yarpc/internal/tracinginterceptor"
+	"go.uber.org/yarpc/peer/abstractlist"
 	"go.uber.org/yarpc/pkg/lifecycle"
 	"go.uber.org/yarpc/transport/internal/tls/dialer"
 	"go.uber.org/yarpc/transport/internal/tls/muxlistener"
@@ -52,6 +53,8 @@ const (
 	originalHeaderCase
 )
 
+var _ abstractlist.Prober = (*Transport)(nil)
+
 // Transport is a TChannel transport suitable for use with YARPC's peer
 // selection system.
 // The transport implements peer.Transport so multiple peer.List
@@ -70,6 +73,7 @@ type Transport struct {
 	name              string
 	addr              string
 	listener          net.Listener
+	inboundListener   *drainListener
 	dialer            func(ctx context.Context, network, hostPort string) (net.Conn, error)
 	newResponseWriter func(inboundCallResponse, tchannel.Format, headerCase) responseWriter
 
@@ -231,6 +235,28 @@ func (t *Transport) ReleasePeer(pid peer.Identifier, sub peer.Subscriber) error
 	return nil
 }
 
+// Probe sends a unary request directly to a peer retained from this
+// transport, or from one of its outbound channels, without consulting a peer
+// chooser.
+// Peer lists use Probe to send health checks to each of their peers, unless
+// they are owned by an outbound, which probes peers with its own settings.
+func (t *Transport) Probe(ctx context.Context, p peer.Peer, req *transport.Request) (*transport.Response, error) {
+	return probe(ctx, p, req, false)
+}
+
+// probe sends a request to the given peer, which must be a TChannel peer.
+func probe(ctx context.Context, p peer.Peer, req *transport.Request, reuseBuffer bool) (*transport.Response, error) {
+	tp, ok := p.(*tchannelPeer)
+	if !ok {
+		return nil, peer.ErrInvalidPeerConversion{
+			Peer:         p,
+			ExpectedType: "*tchannelPeer",
+		}
+	}
+	res, err := tp.Call(ctx, req, reuseBuffer)
+	return res, toYARPCError(req, err)
+}
+
 // Start starts the TChannel transport. This starts making connections and
 // accepting inbound requests. All inbounds must have been assigned a router
 // to accept inbound requests before this is called.
@@ -260,6 +286,7 @@ func (t *Transport) start() error {
 			unaryInboundInterceptor:        t.unaryInboundInterceptor,
 		},
 		OnPeerStatusChanged: t.onPeerStatusChanged,
+		ConnContext:         peerIdentityConnContext,
 		Dialer:              t.dialer,
 		SkipHandlerMethods:  skipHandlerMethods,
 	}
@@ -313,7 +340,8 @@ func (t *Transport) start() error {
 		})
 	}
 
-	if err := t.ch.Serve(listener); err != nil {
+	t.inboundListener = newDrainListener(listener)
+	if err := t.ch.Serve(t.inboundListener); err != nil {
 		return err
 	}
 	t.addr = t.ch.PeerInfo().HostPort
@@ -350,6 +378,16 @@ func (t *Transport) stop() error {
 	return nil
 }
 
+// drain stops accepting new connections. Connections that were already
+// accepted continue to serve requests until the transport stops.
+func (t *Transport) drain() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.inboundListener != nil {
+		t.inboundListener.Drain()
+	}
+}
+
 // IsRunning returns whether the TChannel transport is running.
 func (t *Transport) IsRunning() bool {
 	return t.once.IsRunning()
@@ -21,10 +21,20 @@
 package tchannel
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/peer"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/health"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/peer/abstractlist"
+	"go.uber.org/yarpc/peer/hostport"
+	"go.uber.org/yarpc/peer/roundrobin"
 )
 
 func TestTransportCancellationOptions(t *testing.T) {
@@ -74,3 +84,87 @@ func TestTransportCancellationOptions(t *testing.T) {
 		})
 	}
 }
+
+// healthChecks records the health checks that an inbound serves.
+type healthChecks struct {
+	handler transport.UnaryHandler
+	checks  chan struct{}
+}
+
+func (h healthChecks) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	select {
+	case h.checks <- struct{}{}:
+	default:
+	}
+	return h.handler.Handle(ctx, req, resw)
+}
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		desc string
+		// start starts sending health checks to the peers of the list.
+		start func(t *testing.T, trans *Transport, list *roundrobin.List) func()
+	}{
+		{
+			desc: "transport",
+			start: func(t *testing.T, trans *Transport, list *roundrobin.List) func() {
+				require.NoError(t, list.Start())
+				return func() { assert.NoError(t, list.Stop()) }
+			},
+		},
+		{
+			desc: "outbound",
+			start: func(t *testing.T, trans *Transport, list *roundrobin.List) func() {
+				outbound := trans.NewOutbound(list)
+				require.NoError(t, outbound.Start())
+				return func() { assert.NoError(t, outbound.Stop()) }
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			checks := make(chan struct{}, 1)
+			server := health.NewServer()
+			server.SetServingStatus("myservice", health.Serving)
+			var procedures []transport.Procedure
+			for _, p := range server.Procedures() {
+				if p.Name == health.CheckProcedure {
+					p.HandlerSpec = transport.NewUnaryHandlerSpec(healthChecks{p.HandlerSpec.Unary(), checks})
+				}
+				procedures = append(procedures, p)
+			}
+			router := yarpc.NewMapRouter("myservice")
+			router.Register(procedures)
+
+			it, err := NewTransport(ServiceName("myservice"), ListenAddr("127.0.0.1:0"))
+			require.NoError(t, err)
+			inbound := it.NewInbound()
+			inbound.SetRouter(router)
+			require.NoError(t, inbound.Start())
+			require.NoError(t, it.Start())
+			defer func() { assert.NoError(t, it.Stop()) }()
+
+			ot, err := NewTransport(ServiceName("caller"))
+			require.NoError(t, err)
+			require.NoError(t, ot.Start())
+			defer func() { assert.NoError(t, ot.Stop()) }()
+
+			list := roundrobin.New(ot, roundrobin.HealthCheck(abstractlist.HealthCheckConfig{
+				Service:  "myservice",
+				Interval: 10 * time.Millisecond,
+			}))
+			stop := tt.start(t, ot, list)
+			defer stop()
+			require.NoError(t, list.Update(peer.ListUpdates{
+				Additions: []peer.Identifier{hostport.Identify(it.ListenAddr())},
+			}))
+
+			select {
+			case <-checks:
+			case <-time.After(testtime.Second):
+				t.Fatal("timed out waiting for a health check")
+			}
+		})
+	}
+}
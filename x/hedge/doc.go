@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package hedge provides a unary outbound middleware that sends additional
+// copies of a slow request to other peers and returns the first successful
+// response.
+//
+// Hedging trades extra load for lower tail latency. It is only safe for
+// idempotent procedures, so the middleware should be applied to outbounds
+// (or restricted with the Procedures option) accordingly.
+//
+// An attempt that fails with CodeUnavailable or CodeResourceExhausted starts
+// the next attempt without waiting for the delay once no other attempt is in
+// flight. Any other error is returned right away and cancels the remaining
+// attempts, since other peers would reject the request the same way.
+//
+// Every attempt carries a shared record of the peers chosen so far, so peer
+// lists built on "go.uber.org/yarpc/peer/abstractlist" send each hedged
+// attempt to a peer that is not already serving the same call. Observability
+// middleware reports hedged attempts separately from primary ones.
+//
+//	mw := hedge.NewUnaryMiddleware(
+//		hedge.Delay(20*time.Millisecond),
+//		hedge.LatencyPercentile(95),
+//		hedge.Procedures("KeyValue::getValue"),
+//	)
+package hedge
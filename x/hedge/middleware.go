@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hedge
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/attempt"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const (
+	_defaultDelay       = 50 * time.Millisecond
+	_defaultMaxAttempts = 2
+	_windowSize         = 256
+	_minSamples         = 16
+)
+
+var _ middleware.UnaryOutbound = (*OutboundMiddleware)(nil)
+
+// Option customizes the behavior of a hedging middleware.
+type Option func(*options)
+
+type options struct {
+	delay       time.Duration
+	percentile  float64
+	maxAttempts int
+	procedures  map[string]struct{}
+}
+
+// Delay is how long to wait for an attempt before sending the next one.
+// When LatencyPercentile is also specified, Delay is used until enough
+// latencies have been observed for the procedure.
+//
+// Defaults to 50ms.
+func Delay(d time.Duration) Option {
+	return func(opts *options) {
+		opts.delay = d
+	}
+}
+
+// LatencyPercentile derives the hedging delay for each procedure from the
+// given percentile (between 0 and 100) of its recently observed latencies,
+// so that only requests slower than that percentile are hedged.
+func LatencyPercentile(p float64) Option {
+	return func(opts *options) {
+		opts.percentile = p
+	}
+}
+
+// MaxAttempts is the total number of attempts, including the primary
+// attempt, that may be in flight for a single call.
+//
+// Defaults to 2.
+func MaxAttempts(n int) Option {
+	return func(opts *options) {
+		opts.maxAttempts = n
+	}
+}
+
+// Procedures restricts hedging to the named procedures. Requests for other
+// procedures are passed through unchanged.
+//
+// By default, all requests are hedged.
+func Procedures(procedures ...string) Option {
+	return func(opts *options) {
+		if opts.procedures == nil {
+			opts.procedures = make(map[string]struct{}, len(procedures))
+		}
+		for _, p := range procedures {
+			opts.procedures[p] = struct{}{}
+		}
+	}
+}
+
+// OutboundMiddleware is a unary outbound middleware that hedges requests.
+type OutboundMiddleware struct {
+	opts      options
+	latencies *latencyTracker
+}
+
+// NewUnaryMiddleware builds a new hedging middleware.
+func NewUnaryMiddleware(opts ...Option) *OutboundMiddleware {
+	options := options{
+		delay:       _defaultDelay,
+		maxAttempts: _defaultMaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &OutboundMiddleware{
+		opts:      options,
+		latencies: newLatencyTracker(_windowSize),
+	}
+}
+
+type result struct {
+	index  int
+	res    *transport.Response
+	err    error
+	cancel context.CancelFunc
+}
+
+// Call implements middleware.UnaryOutbound.
+func (h *OutboundMiddleware) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	if !h.hedged(req) {
+		return out.Call(ctx, req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, yarpcerrors.Newf(yarpcerrors.CodeInternal,
+				"hedging middleware failed to read request body for %q: %v", req.Procedure, err)
+		}
+	}
+
+	window := h.latencies.window(req.Procedure)
+	group := attempt.NewGroup()
+	results := make(chan result, h.opts.maxAttempts)
+	cancels := make([]context.CancelFunc, 0, h.opts.maxAttempts)
+	launch := func(index int) {
+		attemptCtx, cancel := context.WithCancel(attempt.WithAttempt(ctx, attempt.Attempt{Index: index, Group: group}))
+		cancels = append(cancels, cancel)
+		attemptReq := *req
+		if req.Body != nil {
+			attemptReq.Body = bytes.NewReader(body)
+			attemptReq.BodySize = len(body)
+		}
+		go func() {
+			start := time.Now()
+			res, err := out.Call(attemptCtx, &attemptReq)
+			if err == nil {
+				window.observe(time.Since(start))
+			}
+			results <- result{index: index, res: res, err: err, cancel: cancel}
+		}()
+	}
+
+	launch(0)
+	launched, pending := 1, 1
+
+	timer := time.NewTimer(h.delay(window))
+	defer timer.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				for i, cancel := range cancels {
+					if i != r.index {
+						cancel()
+					}
+				}
+				go drain(results, pending)
+				if r.res != nil && r.res.Body != nil {
+					// The transport may still be streaming the body under
+					// the attempt's context.
+					r.res.Body = &cancelOnClose{ReadCloser: r.res.Body, cancel: r.cancel}
+				} else {
+					r.cancel()
+				}
+				return r.res, nil
+			}
+			closeResult(r)
+			lastErr = r.err
+			if !retryable(r.err) {
+				// Other peers are expected to reject the request the same
+				// way, so there is no point in waiting for them.
+				for _, cancel := range cancels {
+					cancel()
+				}
+				go drain(results, pending)
+				return nil, lastErr
+			}
+			if pending > 0 {
+				continue
+			}
+			if launched == h.opts.maxAttempts || ctx.Err() != nil {
+				return nil, lastErr
+			}
+			// Every attempt so far failed; send the next one right away
+			// rather than waiting for the delay.
+			launch(launched)
+			launched++
+			pending++
+
+		case <-timer.C:
+			if launched < h.opts.maxAttempts {
+				launch(launched)
+				launched++
+				pending++
+				timer.Reset(h.delay(window))
+			}
+		}
+	}
+}
+
+func (h *OutboundMiddleware) hedged(req *transport.Request) bool {
+	if h.opts.maxAttempts < 2 {
+		return false
+	}
+	if h.opts.procedures == nil {
+		return true
+	}
+	_, ok := h.opts.procedures[req.Procedure]
+	return ok
+}
+
+// retryable reports whether an attempt that failed with the given error
+// may succeed on another peer.
+func retryable(err error) bool {
+	switch yarpcerrors.FromError(err).Code() {
+	case yarpcerrors.CodeUnavailable, yarpcerrors.CodeResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *OutboundMiddleware) delay(w *latencyWindow) time.Duration {
+	if h.opts.percentile > 0 {
+		if d, ok := w.percentile(h.opts.percentile, _minSamples); ok {
+			return d
+		}
+	}
+	return h.opts.delay
+}
+
+// drain releases the responses of attempts that lost the race as they come
+// in.
+func drain(results <-chan result, pending int) {
+	for i := 0; i < pending; i++ {
+		closeResult(<-results)
+	}
+}
+
+func closeResult(r result) {
+	if r.res != nil && r.res.Body != nil {
+		_ = r.res.Body.Close()
+	}
+	r.cancel()
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hedge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/attempt"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func newRequest() *transport.Request {
+	return &transport.Request{
+		Caller:    "caller",
+		Service:   "service",
+		Encoding:  "raw",
+		Procedure: "procedure",
+		Body:      bytes.NewReader([]byte("body")),
+	}
+}
+
+// attemptFunc is called for every attempt with the attempt's index.
+type attemptFunc func(ctx context.Context, index int) (*transport.Response, error)
+
+func newOutbound(t *testing.T, mockCtrl *gomock.Controller, f attemptFunc) *transporttest.MockUnaryOutbound {
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "body", string(body))
+
+			a, ok := attempt.FromContext(ctx)
+			require.True(t, ok, "attempt must be present on context")
+			return f(ctx, a.Index)
+		})
+	return out
+}
+
+func response(body string) *transport.Response {
+	return &transport.Response{Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+}
+
+func readBody(t *testing.T, res *transport.Response) string {
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	return string(b)
+}
+
+func TestFastPrimaryIsNotHedged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var mu sync.Mutex
+	var calls int
+	out := newOutbound(t, mockCtrl, func(ctx context.Context, index int) (*transport.Response, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return response("primary"), nil
+	})
+
+	mw := NewUnaryMiddleware(Delay(testtime.Second))
+	res, err := mw.Call(context.Background(), newRequest(), out)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", readBody(t, res))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, calls)
+}
+
+func TestSlowPrimaryIsHedged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	primaryCancelled := make(chan struct{})
+	out := newOutbound(t, mockCtrl, func(ctx context.Context, index int) (*transport.Response, error) {
+		if index == 0 {
+			<-ctx.Done()
+			close(primaryCancelled)
+			return nil, yarpcerrors.CancelledErrorf("cancelled")
+		}
+		return response("hedge"), nil
+	})
+
+	mw := NewUnaryMiddleware(Delay(testtime.Millisecond))
+	res, err := mw.Call(context.Background(), newRequest(), out)
+	require.NoError(t, err)
+	assert.Equal(t, "hedge", readBody(t, res))
+
+	select {
+	case <-primaryCancelled:
+	case <-time.After(testtime.Second):
+		t.Fatal("primary attempt was not cancelled after the hedge won")
+	}
+}
+
+func TestFailedAttemptHedgesImmediately(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	out := newOutbound(t, mockCtrl, func(ctx context.Context, index int) (*transport.Response, error) {
+		if index == 0 {
+			return nil, yarpcerrors.UnavailableErrorf("unavailable")
+		}
+		return response("hedge"), nil
+	})
+
+	// The delay is long enough that the test would time out if the hedge
+	// waited for it.
+	mw := NewUnaryMiddleware(Delay(time.Hour))
+	res, err := mw.Call(context.Background(), newRequest(), out)
+	require.NoError(t, err)
+	assert.Equal(t, "hedge", readBody(t, res))
+}
+
+func TestAllAttemptsFail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	out := newOutbound(t, mockCtrl, func(ctx context.Context, index int) (*transport.Response, error) {
+		if index == 0 {
+			return nil, yarpcerrors.UnavailableErrorf("first")
+		}
+		return nil, yarpcerrors.UnavailableErrorf("second")
+	})
+
+	mw := NewUnaryMiddleware(Delay(time.Hour), MaxAttempts(2))
+	_, err := mw.Call(context.Background(), newRequest(), out)
+	assert.Equal(t, yarpcerrors.UnavailableErrorf("second"), err)
+}
+
+func TestNonRetryableErrorIsNotHedged(t *testing.T) {
+	tests := []struct {
+		msg string
+		err error
+	}{
+		{"invalid argument", yarpcerrors.InvalidArgumentErrorf("bad request")},
+		{"permission denied", yarpcerrors.PermissionDeniedErrorf("denied")},
+		{"unauthenticated", yarpcerrors.UnauthenticatedErrorf("who are you")},
+		{"internal", yarpcerrors.InternalErrorf("broken")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var mu sync.Mutex
+			var calls int
+			out := newOutbound(t, mockCtrl, func(ctx context.Context, index int) (*transport.Response, error) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+				return nil, tt.err
+			})
+
+			mw := NewUnaryMiddleware(Delay(time.Hour), MaxAttempts(3))
+			_, err := mw.Call(context.Background(), newRequest(), out)
+			assert.Equal(t, tt.err, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, 1, calls, "non-retryable errors must not start another attempt")
+		})
+	}
+
+	t.Run("pending hedge is cancelled", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		hedgeStarted := make(chan struct{})
+		hedgeCancelled := make(chan struct{})
+		out := newOutbound(t, mockCtrl, func(ctx context.Context, index int) (*transport.Response, error) {
+			if index == 0 {
+				<-hedgeStarted
+				return nil, yarpcerrors.InvalidArgumentErrorf("bad request")
+			}
+			close(hedgeStarted)
+			<-ctx.Done()
+			close(hedgeCancelled)
+			return nil, yarpcerrors.CancelledErrorf("cancelled")
+		})
+
+		mw := NewUnaryMiddleware(Delay(testtime.Millisecond))
+		_, err := mw.Call(context.Background(), newRequest(), out)
+		assert.Equal(t, yarpcerrors.InvalidArgumentErrorf("bad request"), err)
+
+		select {
+		case <-hedgeCancelled:
+		case <-time.After(testtime.Second):
+			t.Fatal("hedged attempt was not cancelled after a non-retryable error")
+		}
+	})
+}
+
+func TestAttemptsShareGroup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var mu sync.Mutex
+	groups := make(map[*attempt.Group]struct{})
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+			a, ok := attempt.FromContext(ctx)
+			require.True(t, ok)
+			mu.Lock()
+			groups[a.Group] = struct{}{}
+			mu.Unlock()
+			return nil, yarpcerrors.UnavailableErrorf("unavailable")
+		})
+
+	mw := NewUnaryMiddleware(MaxAttempts(3))
+	_, err := mw.Call(context.Background(), newRequest(), out)
+	require.Error(t, err)
+	assert.Len(t, groups, 1, "all attempts of a call must share one group")
+}
+
+func TestProceduresAllowlist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	req := newRequest()
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), req).DoAndReturn(
+		func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+			_, ok := attempt.FromContext(ctx)
+			assert.False(t, ok, "requests outside the allowlist must not be hedged")
+			return nil, yarpcerrors.UnavailableErrorf("unavailable")
+		})
+
+	mw := NewUnaryMiddleware(Procedures("other"))
+	_, err := mw.Call(context.Background(), req, out)
+	assert.Error(t, err)
+}
+
+func TestBodyReadError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	req := newRequest()
+	req.Body = errReader{}
+
+	mw := NewUnaryMiddleware()
+	_, err := mw.Call(context.Background(), req, transporttest.NewMockUnaryOutbound(mockCtrl))
+	require.Error(t, err)
+	assert.Equal(t, yarpcerrors.CodeInternal, yarpcerrors.FromError(err).Code())
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	w := newLatencyWindow(4)
+
+	_, ok := w.percentile(50, 2)
+	assert.False(t, ok, "empty window")
+
+	w.observe(40 * time.Millisecond)
+	_, ok = w.percentile(50, 2)
+	assert.False(t, ok, "too few samples")
+
+	for _, d := range []time.Duration{10, 20, 30} {
+		w.observe(d * time.Millisecond)
+	}
+	d, ok := w.percentile(50, 2)
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Millisecond, d)
+
+	d, ok = w.percentile(100, 2)
+	require.True(t, ok)
+	assert.Equal(t, 40*time.Millisecond, d)
+
+	// The oldest sample is evicted once the window is full.
+	w.observe(5 * time.Millisecond)
+	d, ok = w.percentile(100, 2)
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Millisecond, d)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
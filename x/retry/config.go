@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"fmt"
+	"time"
+
+	backoffapi "go.uber.org/yarpc/api/backoff"
+	"go.uber.org/yarpc/internal/backoff"
+	"go.uber.org/yarpc/internal/config"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+// Config describes a set of named retry policies and the procedures they
+// apply to. It is decoded using the same rules as yarpcconfig, which accepts
+// it under the 'retry' key of an outbound.
+//
+//	default: fast
+//	policies:
+//	  fast:
+//	    retries: 2
+//	    maxTimeout: 50ms
+//	    codes: [unavailable, resource-exhausted]
+//	    backoff:
+//	      exponential:
+//	        first: 10ms
+//	        max: 100ms
+//	  none:
+//	    retries: 0
+//	overrides:
+//	  - service: keyvalue
+//	    procedure: KeyValue::setValue
+//	    with: none
+type Config struct {
+	// Default names the policy used for requests that match no override.
+	// No requests are retried by default if this is empty.
+	Default string `config:"default"`
+
+	// Policies are the named policies available to Default and Overrides.
+	Policies map[string]PolicyConfig `config:"policies"`
+
+	// Overrides select a named policy for a service or a single procedure
+	// of a service.
+	Overrides []OverrideConfig `config:"overrides"`
+}
+
+// PolicyConfig describes a single retry policy.
+type PolicyConfig struct {
+	// Retries is the number of times a request may be retried after its
+	// first attempt.
+	Retries uint `config:"retries"`
+
+	// MaxTimeout bounds the duration of a single attempt.
+	MaxTimeout time.Duration `config:"maxTimeout"`
+
+	// Codes lists the error codes that may be retried, using the names
+	// understood by yarpcerrors.Code.UnmarshalText. Defaults to unavailable
+	// and resource-exhausted.
+	Codes []string `config:"codes"`
+
+	// Backoff configures the delay between attempts.
+	Backoff BackoffConfig `config:"backoff"`
+}
+
+// BackoffConfig configures the delay between attempts. It accepts the same
+// options as yarpcconfig.Backoff: the only supported strategy is
+// "exponential" with full jitter.
+//
+//	exponential:
+//	  first: 10ms
+//	  max: 100ms
+type BackoffConfig struct {
+	Exponential ExponentialBackoffConfig `config:"exponential"`
+}
+
+// ExponentialBackoffConfig configures exponential backoff with full jitter.
+// "first" bounds the delay before the first retry and the bound doubles
+// with each attempt, up to "max".
+type ExponentialBackoffConfig struct {
+	First time.Duration `config:"first"`
+	Max   time.Duration `config:"max"`
+}
+
+// Strategy builds the backoff strategy described by the configuration.
+func (c BackoffConfig) Strategy() (backoffapi.Strategy, error) {
+	var opts []backoff.ExponentialOption
+	if c.Exponential.First > 0 {
+		opts = append(opts, backoff.FirstBackoff(c.Exponential.First))
+	}
+	if c.Exponential.Max > 0 {
+		opts = append(opts, backoff.MaxBackoff(c.Exponential.Max))
+	}
+	return backoff.NewExponential(opts...)
+}
+
+// OverrideConfig selects a named policy for a service, or for a procedure
+// if one is specified.
+type OverrideConfig struct {
+	Service   string `config:"service"`
+	Procedure string `config:"procedure"`
+	With      string `config:"with"`
+}
+
+// NewUnaryMiddlewareFromConfig decodes a Config from the given data, which
+// must be a map[string]interface{} or map[interface{}]interface{} as
+// produced by YAML parsers, and builds a retry middleware from it.
+func NewUnaryMiddlewareFromConfig(src interface{}, opts ...MiddlewareOption) (*OutboundMiddleware, error) {
+	var cfg Config
+	if err := config.DecodeInto(&cfg, src); err != nil {
+		return nil, fmt.Errorf("failed to decode retry configuration: %v", err)
+	}
+
+	provider, err := cfg.PolicyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUnaryMiddleware(append([]MiddlewareOption{WithPolicyProvider(provider)}, opts...)...), nil
+}
+
+// PolicyProvider builds a ProcedurePolicyProvider from the configuration.
+func (c Config) PolicyProvider() (*ProcedurePolicyProvider, error) {
+	policies := make(map[string]*Policy, len(c.Policies))
+	for name, pc := range c.Policies {
+		pol, err := pc.policy()
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry policy %q: %v", name, err)
+		}
+		policies[name] = pol
+	}
+
+	lookup := func(name string) (*Policy, error) {
+		pol, ok := policies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown retry policy %q", name)
+		}
+		return pol, nil
+	}
+
+	provider := NewProcedurePolicyProvider()
+	if c.Default != "" {
+		pol, err := lookup(c.Default)
+		if err != nil {
+			return nil, err
+		}
+		provider.SetDefault(pol)
+	}
+
+	for i, o := range c.Overrides {
+		if o.Service == "" {
+			return nil, fmt.Errorf("retry override %d must specify a service", i)
+		}
+		pol, err := lookup(o.With)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry override %d: %v", i, err)
+		}
+		if o.Procedure == "" {
+			provider.RegisterService(o.Service, pol)
+		} else {
+			provider.RegisterServiceProcedure(o.Service, o.Procedure, pol)
+		}
+	}
+
+	return provider, nil
+}
+
+func (c PolicyConfig) policy() (*Policy, error) {
+	strategy, err := c.Backoff.Strategy()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []PolicyOption{
+		Retries(c.Retries),
+		MaxRequestTimeout(c.MaxTimeout),
+		BackoffStrategy(strategy),
+	}
+
+	if len(c.Codes) > 0 {
+		codes := make([]yarpcerrors.Code, len(c.Codes))
+		for i, name := range c.Codes {
+			if err := codes[i].UnmarshalText([]byte(name)); err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, RetryableCodes(codes...))
+	}
+
+	return NewPolicy(opts...), nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+	"gopkg.in/yaml.v2"
+)
+
+func TestNewUnaryMiddlewareFromConfig(t *testing.T) {
+	var data map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(`
+default: fast
+policies:
+  fast:
+    retries: 2
+    maxTimeout: 50ms
+    codes: [unavailable, internal]
+    backoff:
+      exponential:
+        first: 10ms
+        max: 100ms
+  none:
+    retries: 0
+overrides:
+  - service: keyvalue
+    with: none
+  - service: keyvalue
+    procedure: get
+    with: fast
+`), &data))
+
+	mw, err := NewUnaryMiddlewareFromConfig(data)
+	require.NoError(t, err)
+
+	policy := func(service, procedure string) *Policy {
+		return mw.provider.Policy(context.Background(), &transport.Request{Service: service, Procedure: procedure})
+	}
+
+	fast := policy("other", "proc")
+	require.NotNil(t, fast)
+	assert.Equal(t, uint(2), fast.opts.retries)
+	assert.Equal(t, 50*time.Millisecond, fast.opts.maxRequestTimeout)
+	assert.True(t, fast.retryable(yarpcerrors.InternalErrorf("")))
+	assert.False(t, fast.retryable(yarpcerrors.ResourceExhaustedErrorf("")))
+
+	assert.Equal(t, uint(0), policy("keyvalue", "set").opts.retries)
+	assert.True(t, fast == policy("keyvalue", "get"))
+}
+
+func TestNewUnaryMiddlewareFromConfigErrors(t *testing.T) {
+	tests := []struct {
+		msg     string
+		give    map[string]interface{}
+		wantErr string
+	}{
+		{
+			msg:     "undecodable",
+			give:    map[string]interface{}{"policies": "nope"},
+			wantErr: "failed to decode retry configuration",
+		},
+		{
+			msg:     "unknown default",
+			give:    map[string]interface{}{"default": "missing"},
+			wantErr: `unknown retry policy "missing"`,
+		},
+		{
+			msg: "unknown code",
+			give: map[string]interface{}{
+				"policies": map[string]interface{}{
+					"p": map[string]interface{}{"codes": []interface{}{"sadness"}},
+				},
+			},
+			wantErr: `invalid retry policy "p"`,
+		},
+		{
+			msg: "override without service",
+			give: map[string]interface{}{
+				"overrides": []interface{}{
+					map[string]interface{}{"procedure": "get", "with": "p"},
+				},
+			},
+			wantErr: "retry override 0 must specify a service",
+		},
+		{
+			msg: "override with unknown policy",
+			give: map[string]interface{}{
+				"overrides": []interface{}{
+					map[string]interface{}{"service": "keyvalue", "with": "p"},
+				},
+			},
+			wantErr: `invalid retry override 0: unknown retry policy "p"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			_, err := NewUnaryMiddlewareFromConfig(tt.give)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
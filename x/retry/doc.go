@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package retry provides a unary outbound middleware that retries failed
+// calls according to per-procedure policies.
+//
+// Whether a failed call is retried depends on the yarpcerrors.Code of the
+// error it returned; by default only CodeUnavailable and
+// CodeResourceExhausted are considered retryable. Delays between attempts
+// come from an "go.uber.org/yarpc/api/backoff".Strategy and no attempt is
+// made once the remaining context deadline cannot accommodate the delay.
+//
+// The middleware reads the request body once and gives every attempt its
+// own reader over that copy, so transports may consume or close the body of
+// an attempt without affecting the next one.
+//
+//	provider := retry.NewProcedurePolicyProvider()
+//	provider.SetDefault(retry.NewPolicy(retry.Retries(2)))
+//	mw := retry.NewUnaryMiddleware(retry.WithPolicyProvider(provider))
+//
+// Policies for an outbound are usually configured with the 'retry' key of
+// the outbound in yarpcconfig, which applies this middleware to the unary
+// outbound it builds. See Config for the accepted options.
+// NewUnaryMiddlewareFromConfig builds the middleware from the same
+// configuration for outbounds constructed by hand.
+package retry
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"go.uber.org/yarpc/api/middleware"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var _ middleware.UnaryOutbound = (*OutboundMiddleware)(nil)
+
+// MiddlewareOption customizes the behavior of a retry middleware.
+type MiddlewareOption func(*middlewareOptions)
+
+type middlewareOptions struct {
+	policyProvider PolicyProvider
+}
+
+// WithPolicyProvider sets the PolicyProvider the middleware consults for
+// every request.
+//
+// Defaults to a provider that retries every request once with the default
+// Policy.
+func WithPolicyProvider(provider PolicyProvider) MiddlewareOption {
+	return func(opts *middlewareOptions) {
+		opts.policyProvider = provider
+	}
+}
+
+// OutboundMiddleware is a unary outbound middleware that retries requests
+// which fail with a retryable error.
+type OutboundMiddleware struct {
+	provider PolicyProvider
+}
+
+// NewUnaryMiddleware builds a new retry middleware.
+func NewUnaryMiddleware(opts ...MiddlewareOption) *OutboundMiddleware {
+	var options middlewareOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	provider := options.policyProvider
+	if provider == nil {
+		p := NewProcedurePolicyProvider()
+		p.SetDefault(NewPolicy())
+		provider = p
+	}
+
+	return &OutboundMiddleware{provider: provider}
+}
+
+// Call implements middleware.UnaryOutbound.
+func (r *OutboundMiddleware) Call(ctx context.Context, req *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	policy := r.provider.Policy(ctx, req)
+	if policy == nil || policy.opts.retries == 0 {
+		return out.Call(ctx, req)
+	}
+
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, yarpcerrors.Newf(yarpcerrors.CodeInternal,
+			"retry middleware failed to read request body for %q: %v", req.Procedure, err)
+	}
+
+	boff := policy.opts.backoffStrategy.Backoff()
+	for attempt := uint(0); ; attempt++ {
+		res, err := callAttempt(ctx, req, body, out, policy.opts.maxRequestTimeout)
+		if err == nil || attempt >= policy.opts.retries || ctx.Err() != nil || !policy.retryable(err) {
+			return res, err
+		}
+		if res != nil && res.Body != nil {
+			_ = res.Body.Close()
+		}
+		if !wait(ctx, boff.Duration(attempt)) {
+			return nil, err
+		}
+	}
+}
+
+// callAttempt makes a single attempt with its own copy of the request and
+// body.
+func callAttempt(
+	ctx context.Context,
+	req *transport.Request,
+	body []byte,
+	out transport.UnaryOutbound,
+	timeout time.Duration,
+) (*transport.Response, error) {
+	attemptReq := *req
+	if req.Body != nil {
+		attemptReq.Body = bytes.NewReader(body)
+		attemptReq.BodySize = len(body)
+	}
+
+	if timeout <= 0 {
+		return out.Call(ctx, &attemptReq)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	res, err := out.Call(ctx, &attemptReq)
+	if err != nil || res == nil || res.Body == nil {
+		cancel()
+		return res, err
+	}
+	// Transports may stream the response body under the attempt context so
+	// it must stay alive until the caller is done with the body.
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// wait blocks for the given backoff duration. It returns false without
+// waiting if the context deadline would expire before the next attempt.
+func wait(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+		return false
+	}
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func readBody(body io.Reader) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	return io.ReadAll(body)
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
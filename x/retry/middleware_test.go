@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/yarpc/api/backoff"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/internal/testtime"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+func newRequest() *transport.Request {
+	return &transport.Request{
+		Caller:    "caller",
+		Service:   "service",
+		Encoding:  "raw",
+		Procedure: "procedure",
+		Body:      bytes.NewReader([]byte("body")),
+	}
+}
+
+func TestMiddlewareRetries(t *testing.T) {
+	tests := []struct {
+		msg       string
+		policy    *Policy
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			msg:       "success on first attempt",
+			policy:    NewPolicy(Retries(2), BackoffStrategy(backoff.None)),
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			msg:    "success after retries",
+			policy: NewPolicy(Retries(2), BackoffStrategy(backoff.None)),
+			errs: []error{
+				yarpcerrors.UnavailableErrorf("unavailable"),
+				yarpcerrors.ResourceExhaustedErrorf("exhausted"),
+				nil,
+			},
+			wantCalls: 3,
+		},
+		{
+			msg:    "retries exhausted",
+			policy: NewPolicy(Retries(1), BackoffStrategy(backoff.None)),
+			errs: []error{
+				yarpcerrors.UnavailableErrorf("first"),
+				yarpcerrors.UnavailableErrorf("second"),
+			},
+			wantCalls: 2,
+			wantErr:   yarpcerrors.UnavailableErrorf("second"),
+		},
+		{
+			msg:       "non-retryable code",
+			policy:    NewPolicy(Retries(3), BackoffStrategy(backoff.None)),
+			errs:      []error{yarpcerrors.InvalidArgumentErrorf("bad")},
+			wantCalls: 1,
+			wantErr:   yarpcerrors.InvalidArgumentErrorf("bad"),
+		},
+		{
+			msg:       "non-yarpc error",
+			policy:    NewPolicy(Retries(3), BackoffStrategy(backoff.None)),
+			errs:      []error{errors.New("great sadness")},
+			wantCalls: 1,
+			wantErr:   errors.New("great sadness"),
+		},
+		{
+			msg: "custom retryable codes",
+			policy: NewPolicy(
+				Retries(3),
+				BackoffStrategy(backoff.None),
+				RetryableCodes(yarpcerrors.CodeInternal),
+			),
+			errs: []error{
+				yarpcerrors.InternalErrorf("internal"),
+				yarpcerrors.UnavailableErrorf("unavailable"),
+			},
+			wantCalls: 2,
+			wantErr:   yarpcerrors.UnavailableErrorf("unavailable"),
+		},
+		{
+			msg:       "nil policy",
+			errs:      []error{yarpcerrors.UnavailableErrorf("unavailable")},
+			wantCalls: 1,
+			wantErr:   yarpcerrors.UnavailableErrorf("unavailable"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			provider := NewProcedurePolicyProvider()
+			provider.SetDefault(tt.policy)
+			mw := NewUnaryMiddleware(WithPolicyProvider(provider))
+
+			out := transporttest.NewMockUnaryOutbound(mockCtrl)
+			calls := 0
+			out.EXPECT().Call(gomock.Any(), gomock.Any()).Times(tt.wantCalls).DoAndReturn(
+				func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					assert.Equal(t, "body", string(body), "every attempt must see the full body")
+
+					err = tt.errs[calls]
+					calls++
+					if err != nil {
+						return nil, err
+					}
+					return &transport.Response{Body: io.NopCloser(bytes.NewReader([]byte("ok")))}, nil
+				})
+
+			ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+			defer cancel()
+
+			res, err := mw.Call(ctx, newRequest(), out)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "ok", string(body))
+			assert.NoError(t, res.Body.Close())
+		})
+	}
+}
+
+func TestMiddlewarePerAttemptTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	provider := NewProcedurePolicyProvider()
+	provider.SetDefault(NewPolicy(
+		Retries(1),
+		MaxRequestTimeout(10*time.Millisecond),
+		BackoffStrategy(backoff.None),
+	))
+	mw := NewUnaryMiddleware(WithPolicyProvider(provider))
+
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	gomock.InOrder(
+		out.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+				<-ctx.Done()
+				return nil, yarpcerrors.DeadlineExceededErrorf("timed out")
+			}),
+		out.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+				return &transport.Response{Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	res, err := mw.Call(ctx, newRequest(), out)
+	require.NoError(t, err)
+	assert.NoError(t, res.Body.Close())
+}
+
+func TestMiddlewareRespectsDeadline(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	provider := NewProcedurePolicyProvider()
+	provider.SetDefault(NewPolicy(Retries(5), BackoffStrategy(fixedBackoff(time.Hour))))
+	mw := NewUnaryMiddleware(WithPolicyProvider(provider))
+
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).Return(nil, yarpcerrors.UnavailableErrorf("unavailable"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testtime.Second)
+	defer cancel()
+
+	_, err := mw.Call(ctx, newRequest(), out)
+	assert.True(t, yarpcerrors.IsUnavailable(err), "backoff beyond the deadline must not wait")
+}
+
+func TestMiddlewareNilBody(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mw := NewUnaryMiddleware()
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+	out.EXPECT().Call(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+			assert.Nil(t, req.Body)
+			return &transport.Response{}, nil
+		})
+
+	req := newRequest()
+	req.Body = nil
+	_, err := mw.Call(context.Background(), req, out)
+	assert.NoError(t, err)
+}
+
+func TestMiddlewareBodyReadError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mw := NewUnaryMiddleware()
+	out := transporttest.NewMockUnaryOutbound(mockCtrl)
+
+	req := newRequest()
+	req.Body = io.MultiReader(bytes.NewReader([]byte("a")), errReader{})
+	_, err := mw.Call(context.Background(), req, out)
+	assert.True(t, yarpcerrors.IsInternal(err))
+}
+
+func TestProcedurePolicyProvider(t *testing.T) {
+	def := NewPolicy()
+	svc := NewPolicy()
+	proc := NewPolicy()
+
+	p := NewProcedurePolicyProvider()
+	assert.Nil(t, p.Policy(context.Background(), newRequest()))
+
+	p.SetDefault(def)
+	p.RegisterService("service", svc)
+	p.RegisterServiceProcedure("service", "procedure", proc)
+
+	req := func(service, procedure string) *transport.Request {
+		return &transport.Request{Service: service, Procedure: procedure}
+	}
+
+	assert.True(t, proc == p.Policy(context.Background(), req("service", "procedure")))
+	assert.True(t, svc == p.Policy(context.Background(), req("service", "other")))
+	assert.True(t, def == p.Policy(context.Background(), req("other", "procedure")))
+}
+
+type fixedBackoff time.Duration
+
+func (f fixedBackoff) Backoff() backoff.Backoff    { return f }
+func (f fixedBackoff) Duration(uint) time.Duration { return time.Duration(f) }
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"time"
+
+	"go.uber.org/yarpc/api/backoff"
+	intbackoff "go.uber.org/yarpc/internal/backoff"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+var defaultRetryableCodes = []yarpcerrors.Code{
+	yarpcerrors.CodeUnavailable,
+	yarpcerrors.CodeResourceExhausted,
+}
+
+// PolicyOption customizes the behavior of a retry policy.
+type PolicyOption func(*policyOptions)
+
+type policyOptions struct {
+	retries           uint
+	maxRequestTimeout time.Duration
+	backoffStrategy   backoff.Strategy
+	retryableCodes    map[yarpcerrors.Code]struct{}
+}
+
+func newPolicyOptions() policyOptions {
+	return policyOptions{
+		retries:         1,
+		backoffStrategy: intbackoff.DefaultExponential,
+		retryableCodes:  codeSet(defaultRetryableCodes),
+	}
+}
+
+// Retries is the number of times a request may be retried after the first
+// attempt fails.
+//
+// Defaults to 1.
+func Retries(retries uint) PolicyOption {
+	return func(opts *policyOptions) {
+		opts.retries = retries
+	}
+}
+
+// MaxRequestTimeout bounds the time a single attempt may take. Attempts that
+// exceed this timeout fail with CodeDeadlineExceeded and are retried if the
+// overall context deadline allows it.
+//
+// Defaults to no per-attempt timeout; every attempt may use the remainder of
+// the context deadline.
+func MaxRequestTimeout(timeout time.Duration) PolicyOption {
+	return func(opts *policyOptions) {
+		opts.maxRequestTimeout = timeout
+	}
+}
+
+// BackoffStrategy sets the strategy used to determine how long to wait
+// between attempts.
+//
+// Defaults to an exponential backoff with full jitter.
+func BackoffStrategy(strategy backoff.Strategy) PolicyOption {
+	return func(opts *policyOptions) {
+		opts.backoffStrategy = strategy
+	}
+}
+
+// RetryableCodes replaces the set of error codes that are considered
+// retryable.
+//
+// Defaults to CodeUnavailable and CodeResourceExhausted.
+func RetryableCodes(codes ...yarpcerrors.Code) PolicyOption {
+	return func(opts *policyOptions) {
+		opts.retryableCodes = codeSet(codes)
+	}
+}
+
+// Policy describes how a failed request should be retried.
+type Policy struct {
+	opts policyOptions
+}
+
+// NewPolicy creates a new retry Policy.
+func NewPolicy(opts ...PolicyOption) *Policy {
+	options := newPolicyOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Policy{opts: options}
+}
+
+// retryable reports whether a failed attempt may be retried. The caller is
+// responsible for verifying that the outer context is still live.
+func (p *Policy) retryable(err error) bool {
+	code := yarpcerrors.FromError(err).Code()
+	if _, ok := p.opts.retryableCodes[code]; ok {
+		return true
+	}
+	// A per-attempt timeout fired but the caller still has time left.
+	return code == yarpcerrors.CodeDeadlineExceeded && p.opts.maxRequestTimeout > 0
+}
+
+func codeSet(codes []yarpcerrors.Code) map[yarpcerrors.Code]struct{} {
+	set := make(map[yarpcerrors.Code]struct{}, len(codes))
+	for _, code := range codes {
+		set[code] = struct{}{}
+	}
+	return set
+}
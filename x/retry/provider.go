@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"context"
+
+	"go.uber.org/yarpc/api/transport"
+)
+
+// PolicyProvider returns the retry policy for a request. A nil Policy
+// disables retries for that request.
+type PolicyProvider interface {
+	Policy(context.Context, *transport.Request) *Policy
+}
+
+type serviceProcedure struct {
+	service   string
+	procedure string
+}
+
+// ProcedurePolicyProvider is a PolicyProvider that selects policies by
+// service and procedure name.
+//
+// Policies are looked up from most to least specific: a policy registered
+// for the service and procedure, then one registered for the service, then
+// the default policy.
+//
+// Registration is not thread-safe; all policies must be registered before
+// the provider is used by a middleware.
+type ProcedurePolicyProvider struct {
+	defaultPolicy            *Policy
+	serviceToPolicy          map[string]*Policy
+	serviceProcedureToPolicy map[serviceProcedure]*Policy
+}
+
+var _ PolicyProvider = (*ProcedurePolicyProvider)(nil)
+
+// NewProcedurePolicyProvider creates a new ProcedurePolicyProvider with no
+// policies. Until a policy is registered, no requests will be retried.
+func NewProcedurePolicyProvider() *ProcedurePolicyProvider {
+	return &ProcedurePolicyProvider{
+		serviceToPolicy:          make(map[string]*Policy),
+		serviceProcedureToPolicy: make(map[serviceProcedure]*Policy),
+	}
+}
+
+// SetDefault sets the policy used for requests that match no other
+// registered policy.
+func (p *ProcedurePolicyProvider) SetDefault(pol *Policy) {
+	p.defaultPolicy = pol
+}
+
+// RegisterService registers a policy for all procedures of a service.
+func (p *ProcedurePolicyProvider) RegisterService(service string, pol *Policy) {
+	p.serviceToPolicy[service] = pol
+}
+
+// RegisterServiceProcedure registers a policy for a single procedure of a
+// service.
+func (p *ProcedurePolicyProvider) RegisterServiceProcedure(service, procedure string, pol *Policy) {
+	p.serviceProcedureToPolicy[serviceProcedure{service: service, procedure: procedure}] = pol
+}
+
+// Policy returns the policy for the given request.
+func (p *ProcedurePolicyProvider) Policy(_ context.Context, req *transport.Request) *Policy {
+	key := serviceProcedure{service: req.Service, procedure: req.Procedure}
+	if pol, ok := p.serviceProcedureToPolicy[key]; ok {
+		return pol
+	}
+	if pol, ok := p.serviceToPolicy[req.Service]; ok {
+		return pol
+	}
+	return p.defaultPolicy
+}
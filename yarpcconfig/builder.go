@@ -27,16 +27,23 @@ import (
 	"github.com/uber-go/mapdecode"
 	"go.uber.org/multierr"
 	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/middleware"
 	"go.uber.org/yarpc/api/transport"
 	yarpctls "go.uber.org/yarpc/api/transport/tls"
+	"go.uber.org/yarpc/bearertoken"
 	"go.uber.org/yarpc/internal/config"
+	"go.uber.org/yarpc/internal/ratelimit"
+	"go.uber.org/yarpc/x/retry"
 )
 
 type buildableOutbounds struct {
-	Service string
-	Unary   *buildableOutbound
-	Oneway  *buildableOutbound
-	Stream  *buildableOutbound
+	Service     string
+	Unary       *buildableOutbound
+	Oneway      *buildableOutbound
+	Stream      *buildableOutbound
+	RateLimit   *ratelimit.Config
+	BearerToken bearertoken.TokenSource
+	Retry       retry.PolicyProvider
 }
 
 type buildableInbound struct {
@@ -138,6 +145,41 @@ func (b *builder) Build() (yarpc.Config, error) {
 			}
 		}
 
+		// Rate limits are applied outside of bearer token middleware so that
+		// requests rejected by them do not need a token.
+		if src := c.BearerToken; src != nil {
+			mw := bearertoken.NewOutboundMiddleware(src)
+			if ob.Unary != nil {
+				ob.Unary = middleware.ApplyUnaryOutbound(ob.Unary, mw)
+			}
+			if ob.Oneway != nil {
+				ob.Oneway = middleware.ApplyOnewayOutbound(ob.Oneway, mw)
+			}
+			if ob.Stream != nil {
+				ob.Stream = middleware.ApplyStreamOutbound(ob.Stream, mw)
+			}
+		}
+
+		if rc := c.RateLimit; rc != nil {
+			mw := ratelimit.New(ratelimit.Params{Service: c.Service, Config: *rc})
+			if ob.Unary != nil {
+				ob.Unary = middleware.ApplyUnaryOutbound(ob.Unary, mw)
+			}
+			if ob.Oneway != nil {
+				ob.Oneway = middleware.ApplyOnewayOutbound(ob.Oneway, mw)
+			}
+			if ob.Stream != nil {
+				ob.Stream = middleware.ApplyStreamOutbound(ob.Stream, mw)
+			}
+		}
+
+		// Retries are applied last so that every attempt is subject to the
+		// rate limit and carries a fresh token.
+		if p := c.Retry; p != nil && ob.Unary != nil {
+			mw := retry.NewUnaryMiddleware(retry.WithPolicyProvider(p))
+			ob.Unary = middleware.ApplyUnaryOutbound(ob.Unary, mw)
+		}
+
 		outbounds[ccname] = ob
 	}
 	if len(outbounds) > 0 {
@@ -327,6 +369,32 @@ func (b *builder) AddStreamOutbound(
 	return nil
 }
 
+// SetOutboundRateLimit limits the rate of requests made through the outbound
+// with the given key. The outbound must have been added already.
+func (b *builder) SetOutboundRateLimit(outboundKey string, cfg ratelimit.Config) {
+	if cc, ok := b.clients[outboundKey]; ok {
+		cc.RateLimit = &cfg
+	}
+}
+
+// SetOutboundBearerToken attaches tokens from the given source to requests
+// made through the outbound with the given key. The outbound must have been
+// added already.
+func (b *builder) SetOutboundBearerToken(outboundKey string, src bearertoken.TokenSource) {
+	if cc, ok := b.clients[outboundKey]; ok {
+		cc.BearerToken = src
+	}
+}
+
+// SetOutboundRetry retries unary requests made through the outbound with the
+// given key according to the policies from the given provider. The outbound
+// must have been added already.
+func (b *builder) SetOutboundRetry(outboundKey string, p retry.PolicyProvider) {
+	if cc, ok := b.clients[outboundKey]; ok {
+		cc.Retry = p
+	}
+}
+
 func (b *builder) needTransport(spec *compiledTransportSpec) {
 	b.needTransports[spec.Name] = spec
 }
@@ -30,8 +30,11 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/internal/authorization"
+	"go.uber.org/yarpc/internal/concurrencylimiter"
 	"go.uber.org/yarpc/internal/config"
 	"go.uber.org/yarpc/internal/interpolate"
+	"go.uber.org/yarpc/x/retry"
 	"gopkg.in/yaml.v2"
 )
 
@@ -307,6 +310,22 @@ func (c *Configurator) load(serviceName string, cfg *yarpcConfig) (_ yarpc.Confi
 		err = multierr.Append(err, e)
 	}
 
+	if e := concurrencylimiter.Config(cfg.InboundConcurrency).Validate(); e != nil {
+		err = multierr.Append(err, fmt.Errorf("invalid inbound concurrency configuration: %v", e))
+	}
+
+	if e := authorization.Config(cfg.InboundAuthorization).Validate(); e != nil {
+		err = multierr.Append(err, fmt.Errorf("invalid inbound authorization configuration: %v", e))
+	}
+
+	if cfg.Drain.GracePeriod < 0 {
+		err = multierr.Append(err, fmt.Errorf("invalid drain configuration: gracePeriod must not be negative, got %v", cfg.Drain.GracePeriod))
+	}
+
+	if e := cfg.FaultInjection.config().Validate(); e != nil {
+		err = multierr.Append(err, fmt.Errorf("invalid fault injection configuration: %v", e))
+	}
+
 	if err != nil {
 		return yarpc.Config{}, err
 	}
@@ -318,6 +337,12 @@ func (c *Configurator) load(serviceName string, cfg *yarpcConfig) (_ yarpc.Confi
 
 	cfg.Logging.fill(&yc)
 	cfg.Metrics.fill(&yc)
+	cfg.InboundConcurrency.fill(&yc)
+	cfg.InboundAuthorization.fill(&yc)
+	cfg.Drain.fill(&yc)
+	if err := cfg.FaultInjection.fill(&yc); err != nil {
+		return yarpc.Config{}, err
+	}
 	return yc, nil
 }
 
@@ -356,7 +381,9 @@ func (c *Configurator) loadOutboundInto(b *builder, name string, cfg outbounds)
 	}
 
 	if implicit := cfg.Implicit; implicit != nil {
-		return loadUsing(implicit, b.AddImplicitOutbound)
+		if err := loadUsing(implicit, b.AddImplicitOutbound); err != nil {
+			return err
+		}
 	}
 
 	if unary := cfg.Unary; unary != nil {
@@ -377,6 +404,46 @@ func (c *Configurator) loadOutboundInto(b *builder, name string, cfg outbounds)
 		}
 	}
 
+	if rateLimit := cfg.RateLimit; rateLimit != nil {
+		rc := rateLimit.config()
+		if err := rc.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limit for outbound %q: %v", name, err)
+		}
+		b.SetOutboundRateLimit(name, rc)
+	}
+
+	if attrs := cfg.BearerToken; attrs != nil {
+		var bt outboundBearerToken
+		if err := attrs.Decode(&bt, config.InterpolateWith(c.resolver)); err != nil {
+			return fmt.Errorf("failed to decode bearer token for outbound %q: %v", name, err)
+		}
+		src, err := bt.tokenSource()
+		if err != nil {
+			return fmt.Errorf("invalid bearer token for outbound %q: %v", name, err)
+		}
+		b.SetOutboundBearerToken(name, src)
+	}
+
+	if rc := cfg.Retry; rc != nil {
+		// Overrides that do not name a service apply to the service this
+		// outbound sends requests to.
+		overrides := make([]retry.OverrideConfig, len(rc.Overrides))
+		for i, o := range rc.Overrides {
+			if o.Service == "" {
+				o.Service = cfg.Service
+			}
+			overrides[i] = o
+		}
+		retryConfig := *rc
+		retryConfig.Overrides = overrides
+
+		provider, err := retryConfig.PolicyProvider()
+		if err != nil {
+			return fmt.Errorf("invalid retry policies for outbound %q: %v", name, err)
+		}
+		b.SetOutboundRetry(name, provider)
+	}
+
 	return nil
 }
 
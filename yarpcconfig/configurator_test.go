@@ -21,6 +21,9 @@
 package yarpcconfig
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -30,9 +33,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/api/transport/transporttest"
+	"go.uber.org/yarpc/bearertoken"
+	"go.uber.org/yarpc/faultinjection"
 	"go.uber.org/yarpc/internal/interpolate"
 	"go.uber.org/yarpc/internal/whitespace"
+	"go.uber.org/yarpc/yarpcerrors"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/yaml.v2"
 )
@@ -361,6 +368,181 @@ func TestConfigurator(t *testing.T) {
 				return
 			},
 		},
+		{
+			desc: "inbound concurrency limits",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.serviceName = "foo"
+				tt.give = whitespace.Expand(`
+					inboundConcurrency:
+						default:
+							maxConcurrency: 100
+							maxQueue: 50
+							maxQueueWait: 20ms
+						procedures:
+							KeyValue::getValue:
+								maxConcurrency: 20
+								adaptive:
+									minConcurrency: 5
+									maxConcurrency: 200
+									latencyThreshold: 100ms
+									backoffRatio: 0.8
+				`)
+				tt.wantConfig = yarpc.Config{
+					Name: "foo",
+					InboundConcurrency: yarpc.InboundConcurrencyConfig{
+						Default: &yarpc.ConcurrencyLimit{
+							MaxConcurrency: 100,
+							MaxQueue:       50,
+							MaxQueueWait:   20 * time.Millisecond,
+						},
+						Procedures: map[string]yarpc.ConcurrencyLimit{
+							"KeyValue::getValue": {
+								MaxConcurrency: 20,
+								Adaptive: &yarpc.AdaptiveConcurrencyLimit{
+									MinConcurrency:   5,
+									MaxConcurrency:   200,
+									LatencyThreshold: 100 * time.Millisecond,
+									BackoffRatio:     0.8,
+								},
+							},
+						},
+					},
+				}
+				return
+			},
+		},
+		{
+			desc: "inbound concurrency, invalid limit",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					inboundConcurrency:
+						procedures:
+							KeyValue::getValue:
+								maxQueue: 10
+				`)
+				tt.wantErr = []string{
+					"invalid inbound concurrency configuration:",
+					`invalid concurrency limit for procedure "KeyValue::getValue":`,
+					"maxConcurrency must be positive",
+				}
+				return
+			},
+		},
+		{
+			desc: "inbound authorization rules",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.serviceName = "foo"
+				tt.give = whitespace.Expand(`
+					inboundAuthorization:
+						rules:
+							- service: foo
+							  procedures: [KeyValue::*]
+							  spiffeIDs: [spiffe://example.org/ns/prod/*]
+							  dnsNames: [bar.example.org]
+							  subjects: [CN=baz]
+							- procedures: [Meta::introspect]
+							  allowUnauthenticated: true
+				`)
+				tt.wantConfig = yarpc.Config{
+					Name: "foo",
+					InboundAuthorization: yarpc.InboundAuthorizationConfig{
+						Rules: []yarpc.AuthorizationRule{
+							{
+								Service:    "foo",
+								Procedures: []string{"KeyValue::*"},
+								SPIFFEIDs:  []string{"spiffe://example.org/ns/prod/*"},
+								DNSNames:   []string{"bar.example.org"},
+								Subjects:   []string{"CN=baz"},
+							},
+							{
+								Procedures:           []string{"Meta::introspect"},
+								AllowUnauthenticated: true,
+							},
+						},
+					},
+				}
+				return
+			},
+		},
+		{
+			desc: "inbound authorization, invalid rule",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					inboundAuthorization:
+						rules:
+							- service: foo
+				`)
+				tt.wantErr = []string{
+					"invalid inbound authorization configuration:",
+					"invalid authorization rule 0:",
+					"at least one of spiffeIDs, dnsNames, subjects or allowUnauthenticated is required",
+				}
+				return
+			},
+		},
+		{
+			desc: "drain grace period",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.serviceName = "foo"
+				tt.give = whitespace.Expand(`
+					drain:
+						gracePeriod: 10s
+				`)
+				tt.wantConfig = yarpc.Config{
+					Name:  "foo",
+					Drain: yarpc.DrainConfig{GracePeriod: 10 * time.Second},
+				}
+				return
+			},
+		},
+		{
+			desc: "drain, negative grace period",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					drain:
+						gracePeriod: -1s
+				`)
+				tt.wantErr = []string{
+					"invalid drain configuration: gracePeriod must not be negative, got -1s",
+				}
+				return
+			},
+		},
+		{
+			desc: "fault injection, invalid percentage",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					faultInjection:
+						rules:
+							- direction: inbound
+							  percentage: 150
+							  abort: unavailable
+				`)
+				tt.wantErr = []string{
+					"invalid fault injection configuration:",
+					"invalid fault injection rule 0:",
+					"percentage must be greater than 0 and at most 100",
+				}
+				return
+			},
+		},
+		{
+			desc: "fault injection, invalid abort code",
+			test: func(*testing.T, *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					faultInjection:
+						rules:
+							- direction: outbound
+							  percentage: 10
+							  abort: not-a-code
+				`)
+				tt.wantErr = []string{
+					"error decoding 'faultInjection.rules[0].abort':",
+					"could not decode YARPC error code:",
+				}
+				return
+			},
+		},
 		{
 			desc: "application error, invalid type",
 			test: func(*testing.T, *gomock.Controller) (tt testCase) {
@@ -1530,6 +1712,186 @@ func TestConfigurator(t *testing.T) {
 				return
 			},
 		},
+		{
+			desc: "outbound rate limit, invalid limit",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				type outboundConfig struct{ Address string }
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							rateLimit:
+								procedures:
+									KeyValue::getValue:
+										burst: 10
+							tchannel:
+								address: localhost:4040
+				`)
+
+				tchan := mockTransportSpecBuilder{
+					Name:                "tchannel",
+					TransportConfig:     _typeOfEmptyStruct,
+					UnaryOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+				}.Build(mockCtrl)
+
+				tt.specs = []TransportSpec{tchan.Spec()}
+				tt.wantErr = []string{
+					`invalid rate limit for outbound "bar":`,
+					`invalid rate limit for procedure "KeyValue::getValue":`,
+					"rps must be positive",
+				}
+
+				return
+			},
+		},
+		{
+			desc: "outbound rate limit, invalid attributes",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							rateLimit:
+								rps: fast
+							tchannel:
+								address: localhost:4040
+				`)
+				tt.wantErr = []string{
+					"failed to decode rate limit for outbound",
+				}
+
+				return
+			},
+		},
+		{
+			desc: "outbound retry, unknown policy",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				type outboundConfig struct{ Address string }
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							retry:
+								default: fast
+							tchannel:
+								address: localhost:4040
+				`)
+
+				tchan := mockTransportSpecBuilder{
+					Name:                "tchannel",
+					TransportConfig:     _typeOfEmptyStruct,
+					UnaryOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+				}.Build(mockCtrl)
+
+				tt.specs = []TransportSpec{tchan.Spec()}
+				tt.wantErr = []string{
+					`invalid retry policies for outbound "bar":`,
+					`unknown retry policy "fast"`,
+				}
+
+				return
+			},
+		},
+		{
+			desc: "outbound retry, invalid attributes",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							retry:
+								policies: often
+							tchannel:
+								address: localhost:4040
+				`)
+				tt.wantErr = []string{
+					"failed to decode retry policies for outbound",
+				}
+
+				return
+			},
+		},
+		{
+			desc: "outbound bearer token, token and file",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				type outboundConfig struct{ Address string }
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							bearerToken:
+								token: foo
+								file: /var/run/secrets/token
+							tchannel:
+								address: localhost:4040
+				`)
+
+				tchan := mockTransportSpecBuilder{
+					Name:                "tchannel",
+					TransportConfig:     _typeOfEmptyStruct,
+					UnaryOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+				}.Build(mockCtrl)
+
+				tt.specs = []TransportSpec{tchan.Spec()}
+				tt.wantErr = []string{
+					`invalid bearer token for outbound "bar":`,
+					"only one of token and file may be specified",
+				}
+
+				return
+			},
+		},
+		{
+			desc: "outbound bearer token, no token",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				type outboundConfig struct{ Address string }
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							bearerToken:
+								refreshInterval: 1m
+							tchannel:
+								address: localhost:4040
+				`)
+
+				tchan := mockTransportSpecBuilder{
+					Name:                "tchannel",
+					TransportConfig:     _typeOfEmptyStruct,
+					UnaryOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+				}.Build(mockCtrl)
+
+				tt.specs = []TransportSpec{tchan.Spec()}
+				tt.wantErr = []string{
+					`invalid bearer token for outbound "bar":`,
+					"either token or file must be specified",
+				}
+
+				return
+			},
+		},
+		{
+			desc: "outbound bearer token, invalid attributes",
+			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
+				type outboundConfig struct{ Address string }
+				tt.give = whitespace.Expand(`
+					outbounds:
+						bar:
+							bearerToken:
+								file: /var/run/secrets/token
+								refreshInterval: soon
+							tchannel:
+								address: localhost:4040
+				`)
+
+				tchan := mockTransportSpecBuilder{
+					Name:                "tchannel",
+					TransportConfig:     _typeOfEmptyStruct,
+					UnaryOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+				}.Build(mockCtrl)
+
+				tt.specs = []TransportSpec{tchan.Spec()}
+				tt.wantErr = []string{
+					`failed to decode bearer token for outbound "bar"`,
+				}
+
+				return
+			},
+		},
 		{
 			desc: "implicit outbound service name override",
 			test: func(t *testing.T, mockCtrl *gomock.Controller) (tt testCase) {
@@ -1897,3 +2259,241 @@ func mapVariableResolver(m map[string]string) interpolate.VariableResolver {
 		return
 	}
 }
+
+func TestConfiguratorOutboundRateLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	type outboundConfig struct{ Address string }
+	tchan := mockTransportSpecBuilder{
+		Name:                 "tchannel",
+		TransportConfig:      _typeOfEmptyStruct,
+		UnaryOutboundConfig:  reflect.TypeOf(&outboundConfig{}),
+		OnewayOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+	}.Build(mockCtrl)
+
+	trans := transporttest.NewMockTransport(mockCtrl)
+	unary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	oneway := transporttest.NewMockOnewayOutbound(mockCtrl)
+	tchan.EXPECT().BuildTransport(gomock.Any(), gomock.Any()).Return(trans, nil)
+	tchan.EXPECT().BuildUnaryOutbound(gomock.Any(), trans, gomock.Any()).Return(unary, nil)
+	tchan.EXPECT().BuildOnewayOutbound(gomock.Any(), trans, gomock.Any()).Return(oneway, nil)
+
+	cfg := New()
+	require.NoError(t, cfg.RegisterTransport(tchan.Spec()))
+
+	yc, err := cfg.LoadConfigFromYAML("foo", strings.NewReader(whitespace.Expand(`
+		outbounds:
+			bar:
+				rateLimit:
+					rps: 0.001
+					burst: 2
+					procedures:
+						KeyValue::getValue:
+							rps: 0.001
+							burst: 1
+				tchannel:
+					address: localhost:4040
+	`)))
+	require.NoError(t, err)
+
+	ob := yc.Outbounds["bar"]
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+	_, err = ob.Unary.Call(ctx, &transport.Request{Procedure: "KeyValue::getValue"})
+	require.NoError(t, err)
+
+	_, err = ob.Unary.Call(ctx, &transport.Request{Procedure: "KeyValue::getValue"})
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code(),
+		"procedure limit must apply")
+	assert.Contains(t, err.Error(), `procedure "KeyValue::getValue" of service "bar"`)
+
+	oneway.EXPECT().CallOneway(gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, err = ob.Oneway.CallOneway(ctx, &transport.Request{Procedure: "KeyValue::setValue"})
+	require.NoError(t, err)
+
+	_, err = ob.Oneway.CallOneway(ctx, &transport.Request{Procedure: "KeyValue::setValue"})
+	assert.Equal(t, yarpcerrors.CodeResourceExhausted, yarpcerrors.FromError(err).Code(),
+		"outbound limit must be shared by unary and oneway requests")
+	assert.Contains(t, err.Error(), `service "bar"`)
+}
+
+func TestConfiguratorOutboundBearerToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	type outboundConfig struct{ Address string }
+	tchan := mockTransportSpecBuilder{
+		Name:                 "tchannel",
+		TransportConfig:      _typeOfEmptyStruct,
+		UnaryOutboundConfig:  reflect.TypeOf(&outboundConfig{}),
+		OnewayOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+	}.Build(mockCtrl)
+
+	trans := transporttest.NewMockTransport(mockCtrl)
+	unary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	oneway := transporttest.NewMockOnewayOutbound(mockCtrl)
+	tchan.EXPECT().BuildTransport(gomock.Any(), gomock.Any()).Return(trans, nil)
+	tchan.EXPECT().BuildUnaryOutbound(gomock.Any(), trans, gomock.Any()).Return(unary, nil).Times(2)
+	tchan.EXPECT().BuildOnewayOutbound(gomock.Any(), trans, gomock.Any()).Return(oneway, nil).Times(2)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("from-file\n"), 0o600))
+
+	cfg := New(InterpolationResolver(mapVariableResolver(map[string]string{
+		"TOKEN":      "from-env",
+		"TOKEN_FILE": tokenFile,
+	})))
+	require.NoError(t, cfg.RegisterTransport(tchan.Spec()))
+
+	yc, err := cfg.LoadConfigFromYAML("foo", strings.NewReader(whitespace.Expand(`
+		outbounds:
+			bar:
+				bearerToken:
+					token: ${TOKEN}
+				tchannel:
+					address: localhost:4040
+			baz:
+				bearerToken:
+					file: ${TOKEN_FILE}
+					refreshInterval: 5m
+				tchannel:
+					address: localhost:4040
+	`)))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	var gotToken string
+	recordToken := func(_ context.Context, req *transport.Request) {
+		gotToken, _ = req.Headers.Get(bearertoken.AuthorizationHeader)
+	}
+
+	unary.EXPECT().Call(gomock.Any(), gomock.Any()).Do(recordToken).Return(&transport.Response{}, nil)
+	_, err = yc.Outbounds["bar"].Unary.Call(ctx, &transport.Request{Procedure: "KeyValue::getValue"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer from-env", gotToken)
+
+	oneway.EXPECT().CallOneway(gomock.Any(), gomock.Any()).Do(recordToken).Return(nil, nil)
+	_, err = yc.Outbounds["baz"].Oneway.CallOneway(ctx, &transport.Request{Procedure: "KeyValue::setValue"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer from-file", gotToken)
+}
+
+func TestConfiguratorOutboundRetry(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	type outboundConfig struct{ Address string }
+	tchan := mockTransportSpecBuilder{
+		Name:                 "tchannel",
+		TransportConfig:      _typeOfEmptyStruct,
+		UnaryOutboundConfig:  reflect.TypeOf(&outboundConfig{}),
+		OnewayOutboundConfig: reflect.TypeOf(&outboundConfig{}),
+	}.Build(mockCtrl)
+
+	trans := transporttest.NewMockTransport(mockCtrl)
+	unary := transporttest.NewMockUnaryOutbound(mockCtrl)
+	oneway := transporttest.NewMockOnewayOutbound(mockCtrl)
+	tchan.EXPECT().BuildTransport(gomock.Any(), gomock.Any()).Return(trans, nil)
+	tchan.EXPECT().BuildUnaryOutbound(gomock.Any(), trans, gomock.Any()).Return(unary, nil)
+	tchan.EXPECT().BuildOnewayOutbound(gomock.Any(), trans, gomock.Any()).Return(oneway, nil)
+
+	cfg := New()
+	require.NoError(t, cfg.RegisterTransport(tchan.Spec()))
+
+	yc, err := cfg.LoadConfigFromYAML("foo", strings.NewReader(whitespace.Expand(`
+		outbounds:
+			bar:
+				service: keyvalue
+				retry:
+					default: twice
+					policies:
+						twice:
+							retries: 2
+						none:
+							retries: 0
+					overrides:
+						- procedure: KeyValue::setValue
+						  with: none
+				tchannel:
+					address: localhost:4040
+	`)))
+	require.NoError(t, err)
+
+	ob := yc.Outbounds["bar"]
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unavailable := yarpcerrors.UnavailableErrorf("try again")
+
+	unary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(2)
+	unary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(&transport.Response{}, nil)
+	_, err = ob.Unary.Call(ctx, &transport.Request{Service: "keyvalue", Procedure: "KeyValue::getValue"})
+	require.NoError(t, err, "default policy must retry")
+
+	unary.EXPECT().Call(gomock.Any(), gomock.Any()).Return(nil, unavailable)
+	_, err = ob.Unary.Call(ctx, &transport.Request{Service: "keyvalue", Procedure: "KeyValue::setValue"})
+	assert.Equal(t, unavailable, err, "override without a service must apply to the outbound's service")
+
+	oneway.EXPECT().CallOneway(gomock.Any(), gomock.Any()).Return(nil, unavailable)
+	_, err = ob.Oneway.CallOneway(ctx, &transport.Request{Service: "keyvalue", Procedure: "KeyValue::getValue"})
+	assert.Equal(t, unavailable, err, "oneway requests must not be retried")
+}
+
+func TestConfiguratorFaultInjection(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		yc, err := New().LoadConfigFromYAML("foo", strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Nil(t, yc.FaultInjection)
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		yc, err := New().LoadConfigFromYAML("foo", strings.NewReader(whitespace.Expand(`
+			faultInjection:
+				rules:
+					- direction: inbound
+					  procedure: KeyValue::*
+					  caller: bar
+					  percentage: 5
+					  delay: 100ms
+					  abort: unavailable
+					- direction: outbound
+					  service: baz
+					  headers:
+					    x-chaos: "true"
+					  percentage: 100
+					  disconnect: true
+		`)))
+		require.NoError(t, err)
+		require.NotNil(t, yc.FaultInjection)
+
+		assert.False(t, yc.FaultInjection.Enabled(), "fault injection must be opt-in")
+		assert.Equal(t, []faultinjection.Rule{
+			{
+				Direction:  faultinjection.Inbound,
+				Procedure:  "KeyValue::*",
+				Caller:     "bar",
+				Percentage: 5,
+				Delay:      100 * time.Millisecond,
+				Abort:      yarpcerrors.CodeUnavailable,
+			},
+			{
+				Direction:  faultinjection.Outbound,
+				Service:    "baz",
+				Headers:    map[string]string{"x-chaos": "true"},
+				Percentage: 100,
+				Disconnect: true,
+			},
+		}, yc.FaultInjection.Rules())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		yc, err := New().LoadConfigFromYAML("foo", strings.NewReader(whitespace.Expand(`
+			faultInjection:
+				enabled: true
+		`)))
+		require.NoError(t, err)
+		require.NotNil(t, yc.FaultInjection)
+		assert.True(t, yc.FaultInjection.Enabled())
+		assert.Empty(t, yc.FaultInjection.Rules())
+	})
+}
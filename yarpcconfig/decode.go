@@ -23,10 +23,18 @@ package yarpcconfig
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/uber-go/mapdecode"
 	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/bearertoken"
+	"go.uber.org/yarpc/faultinjection"
+	"go.uber.org/yarpc/internal/authorization"
+	"go.uber.org/yarpc/internal/concurrencylimiter"
 	"go.uber.org/yarpc/internal/config"
+	"go.uber.org/yarpc/internal/ratelimit"
+	"go.uber.org/yarpc/x/retry"
+	"go.uber.org/yarpc/yarpcerrors"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -36,6 +44,117 @@ type yarpcConfig struct {
 	Transports map[string]config.AttributeMap `config:"transports"`
 	Logging    logging                        `config:"logging"`
 	Metrics    metrics                        `config:"metrics"`
+
+	InboundConcurrency   inboundConcurrency   `config:"inboundConcurrency"`
+	InboundAuthorization inboundAuthorization `config:"inboundAuthorization"`
+	Drain                drain                `config:"drain"`
+	FaultInjection       faultInjection       `config:"faultInjection"`
+}
+
+// inboundAuthorization allows configuring inbound authorization rules from
+// YAML.
+type inboundAuthorization authorization.Config
+
+// Fills values from this object into the provided YARPC config.
+func (a *inboundAuthorization) fill(cfg *yarpc.Config) {
+	for _, r := range a.Rules {
+		cfg.InboundAuthorization.Rules = append(cfg.InboundAuthorization.Rules, yarpc.AuthorizationRule(r))
+	}
+}
+
+// drain allows configuring how the dispatcher drains its inbounds from YAML.
+type drain struct {
+	GracePeriod time.Duration `config:"gracePeriod"`
+}
+
+// Fills values from this object into the provided YARPC config.
+func (d *drain) fill(cfg *yarpc.Config) {
+	cfg.Drain.GracePeriod = d.GracePeriod
+}
+
+// faultInjection allows configuring fault injection rules from YAML.
+type faultInjection struct {
+	Enabled bool                 `config:"enabled"`
+	Rules   []faultInjectionRule `config:"rules"`
+}
+
+type faultInjectionRule struct {
+	Direction  faultinjection.Direction `config:"direction"`
+	Service    string                   `config:"service"`
+	Procedure  string                   `config:"procedure"`
+	Caller     string                   `config:"caller"`
+	Headers    map[string]string        `config:"headers"`
+	Percentage float64                  `config:"percentage"`
+	Delay      time.Duration            `config:"delay"`
+	Abort      yarpcCode                `config:"abort"`
+	Disconnect bool                     `config:"disconnect"`
+}
+
+func (f *faultInjection) config() faultinjection.Config {
+	cfg := faultinjection.Config{Enabled: f.Enabled}
+	for _, r := range f.Rules {
+		cfg.Rules = append(cfg.Rules, faultinjection.Rule{
+			Direction:  r.Direction,
+			Service:    r.Service,
+			Procedure:  r.Procedure,
+			Caller:     r.Caller,
+			Headers:    r.Headers,
+			Percentage: r.Percentage,
+			Delay:      r.Delay,
+			Abort:      yarpcerrors.Code(r.Abort),
+			Disconnect: r.Disconnect,
+		})
+	}
+	return cfg
+}
+
+// Fills values from this object into the provided YARPC config. The injector
+// is only installed if fault injection is enabled or has rules, so that it
+// may be toggled at runtime with Dispatcher.FaultInjector.
+func (f *faultInjection) fill(cfg *yarpc.Config) error {
+	if !f.Enabled && len(f.Rules) == 0 {
+		return nil
+	}
+	injector, err := faultinjection.New(f.config())
+	if err != nil {
+		return fmt.Errorf("invalid fault injection configuration: %v", err)
+	}
+	cfg.FaultInjection = injector
+	return nil
+}
+
+// inboundConcurrency allows configuring inbound concurrency limits from YAML.
+type inboundConcurrency concurrencylimiter.Config
+
+// Fills values from this object into the provided YARPC config.
+func (c *inboundConcurrency) fill(cfg *yarpc.Config) {
+	if c.Default != nil {
+		l := concurrencyLimit(*c.Default)
+		cfg.InboundConcurrency.Default = &l
+	}
+	if len(c.Procedures) > 0 {
+		cfg.InboundConcurrency.Procedures = make(map[string]yarpc.ConcurrencyLimit, len(c.Procedures))
+		for procedure, l := range c.Procedures {
+			cfg.InboundConcurrency.Procedures[procedure] = concurrencyLimit(l)
+		}
+	}
+}
+
+func concurrencyLimit(l concurrencylimiter.Limit) yarpc.ConcurrencyLimit {
+	limit := yarpc.ConcurrencyLimit{
+		MaxConcurrency: l.MaxConcurrency,
+		MaxQueue:       l.MaxQueue,
+		MaxQueueWait:   l.MaxQueueWait,
+	}
+	if a := l.Adaptive; a != nil {
+		limit.Adaptive = &yarpc.AdaptiveConcurrencyLimit{
+			MinConcurrency:   a.MinConcurrency,
+			MaxConcurrency:   a.MaxConcurrency,
+			LatencyThreshold: a.LatencyThreshold,
+			BackoffRatio:     a.BackoffRatio,
+		}
+	}
+	return limit
 }
 
 // metrics allows configuring the way metrics are emitted from YAML
@@ -92,6 +211,22 @@ func (l *levels) fill(cfg *yarpc.DirectionalLogLevelConfig) {
 	cfg.ClientError = (*zapcore.Level)(l.ClientError)
 }
 
+type yarpcCode yarpcerrors.Code
+
+// mapdecode doesn't support encoding.TextUnmarshaler by default so we have to
+// do this manually.
+func (c *yarpcCode) Decode(into mapdecode.Into) error {
+	var s string
+	if err := into(&s); err != nil {
+		return fmt.Errorf("could not decode YARPC error code: %v", err)
+	}
+
+	if err := (*yarpcerrors.Code)(c).UnmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf("could not decode YARPC error code: %v", err)
+	}
+	return nil
+}
+
 type zapLevel zapcore.Level
 
 // mapdecode doesn't suport encoding.TextMarhsaler by default so we have to do
@@ -171,6 +306,19 @@ func (cc *clientConfigs) Decode(into mapdecode.Into) error {
 type outbounds struct {
 	Service string
 
+	// RateLimit, if set, limits the rate of requests made through the
+	// outbound.
+	RateLimit *outboundRateLimit
+
+	// BearerToken, if set, holds the configuration for the bearer token
+	// attached to requests made through the outbound. It is decoded by the
+	// Configurator so that variables may be interpolated.
+	BearerToken config.AttributeMap
+
+	// Retry, if set, holds the retry policies for unary requests made
+	// through the outbound.
+	Retry *retry.Config
+
 	// Either (Unary and/or Oneway) will be set or Implicit will be set. For
 	// the latter case, we need to only use those configurations that that
 	// transport supports.
@@ -192,6 +340,18 @@ func (o *outbounds) Decode(into mapdecode.Into) error {
 		return fmt.Errorf("failed to read service name for outbound: %v", err)
 	}
 
+	if _, err := attrs.Pop("rateLimit", &o.RateLimit); err != nil {
+		return fmt.Errorf("failed to decode rate limit for outbound: %v", err)
+	}
+
+	if _, err := attrs.Pop("bearerToken", &o.BearerToken); err != nil {
+		return fmt.Errorf("failed to decode bearer token for outbound: %v", err)
+	}
+
+	if _, err := attrs.Pop("retry", &o.Retry); err != nil {
+		return fmt.Errorf("failed to decode retry policies for outbound: %v", err)
+	}
+
 	hasUnary, err := attrs.Pop("unary", &o.Unary)
 	if err != nil {
 		return fmt.Errorf("failed to unary outbound configuration: %v", err)
@@ -223,6 +383,61 @@ func (o *outbounds) Decode(into mapdecode.Into) error {
 	return nil
 }
 
+// outboundRateLimit allows configuring client-side rate limits for an
+// outbound from YAML.
+type outboundRateLimit struct {
+	RPS        float64
+	Burst      int
+	MaxWait    time.Duration
+	Procedures map[string]ratelimit.Limit
+}
+
+func (r *outboundRateLimit) config() ratelimit.Config {
+	cfg := ratelimit.Config{
+		Procedures: r.Procedures,
+		MaxWait:    r.MaxWait,
+	}
+	// A limit shared by all requests is optional if procedures are limited.
+	if r.RPS != 0 || r.Burst != 0 || len(r.Procedures) == 0 {
+		cfg.Outbound = &ratelimit.Limit{RPS: r.RPS, Burst: r.Burst}
+	}
+	return cfg
+}
+
+// _defaultTokenRefreshInterval is how long tokens read from a file are used
+// before the file is read again.
+const _defaultTokenRefreshInterval = time.Minute
+
+// outboundBearerToken allows configuring the bearer token attached to
+// requests made through an outbound from YAML. Exactly one of Token and File
+// must be set.
+type outboundBearerToken struct {
+	Token           string        `config:"token,interpolate"`
+	File            string        `config:"file,interpolate"`
+	RefreshInterval time.Duration `config:"refreshInterval"`
+	RefreshWindow   time.Duration `config:"refreshWindow"`
+}
+
+func (t *outboundBearerToken) tokenSource() (bearertoken.TokenSource, error) {
+	switch {
+	case t.Token != "" && t.File != "":
+		return nil, errors.New("only one of token and file may be specified")
+	case t.Token != "":
+		return bearertoken.StaticTokenSource(t.Token), nil
+	case t.File != "":
+		interval := t.RefreshInterval
+		if interval == 0 {
+			interval = _defaultTokenRefreshInterval
+		}
+		if interval < 0 || t.RefreshWindow < 0 {
+			return nil, errors.New("refresh interval and window must not be negative")
+		}
+		return bearertoken.ReuseTokenSource(bearertoken.FileTokenSource(t.File, interval), t.RefreshWindow), nil
+	default:
+		return nil, errors.New("either token or file must be specified")
+	}
+}
+
 type outbound struct {
 	Type       string
 	Attributes config.AttributeMap
@@ -88,9 +88,18 @@
 //	  # ...
 //	logging:
 //	  # ...
+//	inboundConcurrency:
+//	  # ...
+//	inboundAuthorization:
+//	  # ...
+//	drain:
+//	  # ...
+//	faultInjection:
+//	  # ...
 //
-// See the following sections for details on the logging, transports,
-// inbounds, and outbounds keys in the configuration.
+// See the following sections for details on the logging, inboundConcurrency,
+// inboundAuthorization, drain, faultInjection, transports, inbounds, and
+// outbounds keys in the configuration.
 //
 // # Inbound Configuration
 //
@@ -188,6 +197,73 @@
 //	  oneway:
 //	    # ...
 //
+// The rate of requests made through an outbound may be limited with the
+// 'rateLimit' key. Tokens are added to a bucket at 'rps' requests per second,
+// up to 'burst' tokens (defaulting to 'rps'), and each request takes one.
+// Limits for individual procedures apply in addition to the limit shared by
+// all requests; the shared limit may be omitted if procedures are limited.
+//
+//	keyvalue:
+//	  rateLimit:
+//	    rps: 100
+//	    burst: 20
+//	    maxWait: 50ms
+//	    procedures:
+//	      KeyValue::setValue:
+//	        rps: 10
+//	  http:
+//	    url: http://127.0.0.1:8080/
+//
+// Requests over a limit wait for a token. Requests that would have to wait
+// past their deadline, or longer than 'maxWait' if specified, fail
+// immediately with a ResourceExhausted error.
+//
+// A bearer token may be attached to every request made through an outbound
+// with the 'bearerToken' key. The token is either given inline with 'token',
+// usually through an environment variable, or read from 'file'. Token files
+// are read again every 'refreshInterval' (defaulting to one minute), or
+// earlier if they hold a JSON Web Token that expires sooner; 'refreshWindow'
+// controls how long before expiry the token is replaced.
+//
+//	keyvalue:
+//	  bearerToken:
+//	    file: /var/run/secrets/keyvalue/token
+//	    refreshInterval: 5m
+//	    refreshWindow: 30s
+//	  http:
+//	    url: http://127.0.0.1:8080/
+//
+// See the bearertoken package for verifying tokens on inbound requests.
+//
+// Unary requests made through an outbound may be retried with the 'retry'
+// key. Named policies set the number of retries, the timeout of each attempt,
+// the error codes that may be retried and the backoff between attempts.
+// 'default' names the policy used for all requests, and 'overrides' select
+// another policy for a procedure. Overrides apply to the outbound's service
+// unless they name a different 'service'.
+//
+//	keyvalue:
+//	  retry:
+//	    default: fast
+//	    policies:
+//	      fast:
+//	        retries: 2
+//	        maxTimeout: 50ms
+//	        backoff:
+//	          exponential:
+//	            first: 10ms
+//	            max: 100ms
+//	      none:
+//	        retries: 0
+//	    overrides:
+//	      - procedure: KeyValue::setValue
+//	        with: none
+//	  http:
+//	    url: http://127.0.0.1:8080/
+//
+// Every attempt is subject to the outbound's rate limit, if any. See the
+// retry package for details.
+//
 // # Peer Configuration
 //
 // Transports that support peer management and selection through YARPC accept
@@ -219,6 +295,21 @@
 // different addresses. In case of the HTTP transport, the URL will be used as
 // a template for the HTTP requests made to these hosts.
 //
+// Instead of a static list of peers, the peer list may be kept up to date by
+// any registered peer list updater, like those in go.uber.org/yarpc/peer/dns
+// and go.uber.org/yarpc/peer/file. For example, with the DNS updater
+// registered, the following resolves the SRV records of the given name every
+// 30 seconds.
+//
+//	keyvalue:
+//	  http:
+//	    url: https://host/yarpc
+//	    round-robin:
+//	      dns:
+//	        name: _keyvalue._tcp.example.com
+//	        record: SRV
+//	        refreshInterval: 30s
+//
 // Finally, the TransportSpec for a Transport may include named presets for
 // peer lists in its definition. These may be referenced by name in the config
 // using the `with` key.
@@ -303,6 +394,111 @@
 //	panic
 //	fatal
 //
+// # Inbound Concurrency Configuration
+//
+// The 'inboundConcurrency' attribute limits the number of unary and streaming
+// requests that may be in flight at once for each procedure. Requests over
+// the limit wait in a bounded queue, and requests that find the queue full or
+// wait longer than 'maxQueueWait' fail with a resource-exhausted error.
+//
+//	inboundConcurrency:
+//	  default:
+//	    maxConcurrency: 100
+//	    maxQueue: 50
+//	    maxQueueWait: 20ms
+//	  procedures:
+//	    KeyValue::getValue:
+//	      maxConcurrency: 20
+//	      adaptive:
+//	        minConcurrency: 5
+//	        maxConcurrency: 200
+//	        latencyThreshold: 100ms
+//	        backoffRatio: 0.9
+//
+// The 'default' limit applies separately to each procedure that does not
+// have its own limit under 'procedures'. Procedures are not limited if
+// neither is specified.
+//
+// An 'adaptive' limit starts at 'maxConcurrency' and moves between its
+// 'minConcurrency' and 'maxConcurrency': it grows by one while the limit is in
+// use and requests complete within 'latencyThreshold', and shrinks by
+// 'backoffRatio' (0.9 by default) whenever a request is slower than that or
+// misses its deadline.
+//
+// # Inbound Authorization Configuration
+//
+// The 'inboundAuthorization' attribute authorizes inbound requests based on
+// the identity of the calling peer, as verified by a mutual TLS handshake.
+// Once any rule is specified, a request is allowed only if a rule that covers
+// its service and procedure admits its caller.
+//
+//	inboundAuthorization:
+//	  rules:
+//	    - service: keyvalue
+//	      procedures: [KeyValue::*]
+//	      spiffeIDs: [spiffe://example.org/ns/prod/sa/*]
+//	      dnsNames: [frontend.example.org]
+//	      subjects: [CN=admin,O=Example]
+//	    - procedures: [Meta::*]
+//	      allowUnauthenticated: true
+//
+// A rule without a 'service' covers all services, and a rule without
+// 'procedures' covers all procedures of its service. A rule admits peers
+// whose certificates carry a matching SPIFFE ID, DNS subject alternative
+// name, or subject, or all callers if 'allowUnauthenticated' is set. Patterns
+// use the syntax of Go's path.Match.
+//
+// Requests from callers that did not present a verified client certificate
+// fail with an unauthenticated error, and requests from other callers that no
+// rule admits fail with a permission-denied error. Health checks are not
+// subject to these rules.
+//
+// # Drain Configuration
+//
+// The 'drain' attribute configures how the dispatcher drains its inbounds
+// before it stops them. When it stops, the dispatcher reports its services
+// as not serving to health checkers and continues to accept new requests for
+// the 'gracePeriod', giving load balancers time to move traffic elsewhere,
+// before it rejects new requests with an unavailable error.
+//
+//	drain:
+//	  gracePeriod: 10s
+//
+// The dispatcher does not drain its inbounds if no grace period is
+// specified.
+//
+// # Fault Injection Configuration
+//
+// The 'faultInjection' attribute configures rules that inject latency,
+// errors, or connection failures into a percentage of inbound or outbound
+// requests, for testing how services behave when their dependencies fail.
+//
+//	faultInjection:
+//	  enabled: true
+//	  rules:
+//	    - direction: outbound
+//	      service: keyvalue
+//	      procedure: KeyValue::*
+//	      percentage: 5
+//	      delay: 200ms
+//	      abort: unavailable
+//	    - direction: inbound
+//	      caller: frontend
+//	      headers:
+//	        x-chaos: "true"
+//	      percentage: 100
+//	      disconnect: true
+//
+// A rule selects requests by 'service', 'procedure', and 'caller', which use
+// the syntax of Go's path.Match, and by the values of request 'headers'. The
+// first rule that selects a request injects its faults into 'percentage'
+// percent of those requests: it waits for 'delay', then either fails the
+// request with the error code given by 'abort', or drops the response as if
+// the connection had failed if 'disconnect' is set.
+//
+// Fault injection is disabled unless 'enabled' is set, and may be enabled
+// and disabled at runtime with Dispatcher.FaultInjector.
+//
 // # Customizing Configuration
 //
 // When building your own TransportSpec, PeerListSpec, or PeerListUpdaterSpec,
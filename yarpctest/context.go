@@ -39,6 +39,7 @@ type Call struct {
 	RoutingKey      string
 	RoutingDelegate string
 	CallerProcedure string
+	PeerIdentity    *transport.PeerIdentity
 
 	// If set, this map will be filled with response headers written to
 	// yarpc.Call.
@@ -74,6 +75,8 @@ func (c callMetadata) Procedure() string            { return c.c.Procedure }
 func (c callMetadata) Encoding() transport.Encoding { return c.c.Encoding }
 func (c callMetadata) CallerProcedure() string      { return c.c.CallerProcedure }
 
+func (c callMetadata) PeerIdentity() *transport.PeerIdentity { return c.c.PeerIdentity }
+
 func (c callMetadata) Headers() transport.Headers {
 	return transport.HeadersFromMap(c.c.Headers)
 }
@@ -67,6 +67,7 @@ func TestContextWithCall(t *testing.T) {
 				RoutingDelegate: "routingdelegate",
 				ResponseHeaders: tt.resHeaders,
 				CallerProcedure: "callerProcedure",
+				PeerIdentity:    &transport.PeerIdentity{SPIFFEID: "spiffe://example.org/caller"},
 			})
 			call := yarpc.CallFromContext(ctx)
 
@@ -81,6 +82,7 @@ func TestContextWithCall(t *testing.T) {
 			assert.Equal(t, "routingkey", call.RoutingKey())
 			assert.Equal(t, "routingdelegate", call.RoutingDelegate())
 			assert.Equal(t, "callerProcedure", call.CallerProcedure())
+			assert.Equal(t, "spiffe://example.org/caller", call.PeerIdentity().SPIFFEID)
 
 			assert.NoError(t, call.WriteResponseHeader("baz", "qux"))
 			assert.Equal(t, tt.wantResHeaders, tt.resHeaders)